@@ -1,41 +1,66 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
 	"os"
-	"os/signal"
+	"strings"
 	"syscall"
+	"time"
+	// Kullanıcıların seçtiği IANA saat dilimleri, sistemde tzdata olmayan
+	// imajlarda da çözümlenebilsin.
+	_ "time/tzdata"
 
 	"zatrano/configs"
+	"zatrano/container"
+	"zatrano/health"
+	"zatrano/lifecycle"
+	"zatrano/mailer"
+	"zatrano/metrics"
+	"zatrano/middlewares"
+	"zatrano/repositories"
 	"zatrano/routes"
+	"zatrano/services"
 	"zatrano/utils"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/template/html/v2"
-	"github.com/joho/godotenv"
 	"go.uber.org/zap"
 )
 
 func main() {
-	if err := godotenv.Load(); err != nil {
-		panic("Error loading .env file: " + err.Error())
+	configFile := flag.String("config", "", "YAML veya TOML yapılandırma dosyası (varsayılan: "+configs.ConfigFileEnv+")")
+	flag.Parse()
+
+	cfg := configs.MustLoad(configs.LoadOptions{File: *configFile, DotEnv: []string{".env"}})
+	if args := flag.Args(); len(args) > 0 {
+		os.Exit(runCommand(cfg, args))
 	}
+	os.Exit(run(cfg))
+}
 
-	utils.InitLogger()
-	defer utils.SyncLogger()
+func run(cfg *configs.Config) int {
+	utils.InitLogger(cfg.App.Env, cfg.Log.Level)
+	lc := lifecycle.New(cfg.Shutdown.Timeout)
 
-	utils.SLog.Debugw("Ortam değişkenleri yüklendi ve logger başlatıldı")
+	utils.SLog.Debugw("Yapılandırma yüklendi ve logger başlatıldı")
 
-	configs.InitDB()
-	defer configs.CloseDB()
+	configs.InitDB(cfg.Database)
+	lc.OnShutdown("database", func(context.Context) error { return configs.CloseDB() })
 
-	configs.InitSession()
+	configs.InitSession(cfg)
+	lc.OnShutdown("session storage", func(context.Context) error { return configs.CloseSession() })
 
 	engine := html.New("./views", ".html")
 	engine.AddFunc("getFlashMessages", utils.GetFlashMessages)
 	engine.AddFuncMap(utils.TemplateHelpers())
 
-	app := fiber.New(fiber.Config{
+	fiberConfig := fiber.Config{
 		Views: engine,
+		// Şablonlar PreferencesMiddleware'in yazdığı "locale" ve "prefs" değerlerine erişebilsin.
+		PassLocalsToViews: true,
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
 			code := fiber.StatusInternalServerError
 			message := "Internal Server Error"
@@ -45,7 +70,7 @@ func main() {
 				message = e.Message
 			}
 
-			utils.Log.Error("Fiber request error",
+			utils.LogFrom(c.UserContext()).Error("Fiber request error",
 				zap.Error(err),
 				zap.Int("status_code", code),
 				zap.String("method", c.Method()),
@@ -55,48 +80,128 @@ func main() {
 
 			return c.Status(code).JSON(fiber.Map{"error": message})
 		},
-	})
+	}
+	configs.ApplyTrustedProxies(&fiberConfig, cfg.Security)
+	app := fiber.New(fiberConfig)
 
+	c := container.New(configs.GetDB())
+	if metricsServer := setupMetrics(app, c, cfg.Metrics); metricsServer != nil {
+		lc.OnShutdown("metrics server", metricsServer.Shutdown)
+	}
+	checker := health.NewChecker(
+		health.DatabaseCheck(c.DB),
+		health.TableCheck("session_storage", c.DB, repositories.SessionTable),
+		health.MigrationsCheck(c.DB),
+	)
+	health.Mount(app, checker)
+	startAttendanceSweeper(lc, c, cfg.Attendance)
+	startReportScheduler(lc, c, engine, cfg.Mail, cfg.Reports, cfg.Jobs)
+
+	app.Use(middlewares.RequestLoggerMiddleware())
+	app.Use(middlewares.SecurityHeadersMiddleware(cfg.Security))
+	app.Use(metrics.Middleware())
 	app.Static("/", "./public")
-	app.Use(configs.SetupCSRF())
-	routes.SetupRoutes(app, configs.GetDB())
-
-	startServer(app)
+	routes.SetupAPIRoutes(app, c, cfg.KPI)
+	app.Use(middlewares.PreferencesMiddleware())
+	app.Use(configs.SetupCSRF(cfg.Cookie))
+	routes.SetupRoutes(app, c)
+
+	startServer(lc, app, cfg, checker, c.JobService)
+	// HTTP sunucusundan önce çalışır: açık bildirim akışları kapanmayı
+	// bekletmesin, yeniden bağlanan sekmeler de hemen reddedilsin.
+	lc.OnShutdown("notification streams", func(context.Context) error {
+		c.NotificationService.Close()
+		return nil
+	})
+	return lc.Wait(os.Interrupt, syscall.SIGTERM)
 }
 
-func startServer(app *fiber.App) {
-	shutdown := make(chan os.Signal, 1)
-	signal.Notify(shutdown, os.Interrupt, syscall.SIGTERM)
-
-	go func() {
-		port := os.Getenv("APP_PORT")
-		if port == "" {
-			port = "3000"
+func runCommand(cfg *configs.Config, args []string) int {
+	switch strings.Join(args, " ") {
+	case "config print":
+		if err := cfg.Print(os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
 		}
-		address := ":" + port
-		fullAddress := "http://localhost" + address
-
-		utils.Log.Info("Uygulama başlatılıyor",
-			zap.String("address", fullAddress),
-			zap.String("port", port),
-		)
+		return 0
+	default:
+		fmt.Fprintf(os.Stderr, "bilinmeyen komut: %s\nkullanım: zatrano [-config dosya] [config print]\n", strings.Join(args, " "))
+		return 2
+	}
+}
 
-		if err := app.Listen(address); err != nil {
-			utils.Log.Fatal("Sunucu dinlenemedi",
-				zap.String("address", address),
-				zap.Error(err),
-			)
+func startAttendanceSweeper(lc *lifecycle.Manager, c *container.Container, attendance configs.AttendanceConfig) {
+	if attendance.SweepInterval <= 0 {
+		utils.Log.Warn("ATTENDANCE_SWEEP_INTERVAL 0, açık kalan devam kayıtları otomatik kapatılmayacak")
+		return
+	}
+	lc.Go("attendance auto-close", func(ctx context.Context) error {
+		ticker := time.NewTicker(attendance.SweepInterval)
+		defer ticker.Stop()
+		for {
+			// Hatalar servis tarafından loglanır; bir sonraki turda yeniden denenir.
+			_, _ = c.AttendanceService.CloseStaleSessions(ctx, time.Now().UTC(), attendance.AutoCloseAfter)
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
 		}
-	}()
+	})
+}
 
-	<-shutdown
-	utils.Log.Info("Kapatma sinyali alındı, uygulama kapatılıyor...")
+func startReportScheduler(lc *lifecycle.Manager, c *container.Container, renderer services.ReportRenderer, mailConfig configs.MailConfig, reports configs.ReportsConfig, jobs configs.JobsConfig) {
+	m, err := mailer.New(mailConfig)
+	if err != nil {
+		utils.Log.Error("Mailer kurulamadı, zamanlanmış raporlar gönderilmeyecek", zap.Error(err))
+		return
+	}
+	delivery := services.NewReportDeliveryService(c.ReportService, c.PreferenceService, c.JobService, renderer, m)
+	// Zamanlayıcı kapalı olsa da kuyrukta bekleyen e-postalar gönderilsin.
+	c.JobRegistry.Register(services.ReportEmailJob, jobs.MaxAttempts, services.TypedJobHandler(delivery.Deliver))
+	if reports.CheckInterval <= 0 {
+		utils.Log.Warn("REPORTS_CHECK_INTERVAL 0, zamanlanmış raporlar gönderilmeyecek")
+		return
+	}
+	lc.Go("report scheduler", func(ctx context.Context) error {
+		ticker := time.NewTicker(reports.CheckInterval)
+		defer ticker.Stop()
+		for {
+			// Hatalar servis tarafından loglanır; bir sonraki turda yeniden denenir.
+			_, _ = delivery.RunDue(ctx, time.Now().UTC())
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	})
+}
 
-	if err := app.Shutdown(); err != nil {
-		utils.Log.Error("Sunucu kapatılırken hata oluştu", zap.Error(err))
-	} else {
-		utils.Log.Info("Sunucu başarıyla kapatıldı")
+func setupMetrics(app *fiber.App, c *container.Container, metricsConfig configs.MetricsConfig) *http.Server {
+	if err := metrics.RegisterStatsCollector(metrics.StatsSources{
+		Users:    c.UserRepository,
+		Teams:    c.TeamRepository,
+		Sessions: c.SessionRepository,
+	}); err != nil {
+		utils.Log.Error("Metrik collector'ı kaydedilemedi", zap.Error(err))
+	}
+	if sqlDB, err := c.DB.DB(); err != nil {
+		utils.Log.Error("Veritabanı havuzu metrikleri için sql.DB alınamadı", zap.Error(err))
+	} else if err := metrics.RegisterDBStats(sqlDB); err != nil {
+		utils.Log.Error("Veritabanı havuzu metrikleri kaydedilemedi", zap.Error(err))
 	}
 
-	utils.Log.Info("Uygulama başarıyla sonlandırıldı.")
+	if addr := metricsConfig.Addr; addr != "" {
+		server := metrics.NewInternalServer(addr)
+		metrics.StartInternalServer(server)
+		return server
+	}
+	if token := metricsConfig.Token; token != "" {
+		metrics.Mount(app, token)
+		utils.Log.Info("Metrikler token korumasıyla sunuluyor", zap.String("path", metrics.Path))
+		return nil
+	}
+	utils.Log.Warn("METRICS_ADDR veya METRICS_TOKEN tanımlı değil, /metrics devre dışı")
+	return nil
 }
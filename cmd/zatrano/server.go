@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"zatrano/configs"
+	"zatrano/health"
+	"zatrano/lifecycle"
+	"zatrano/services"
+	"zatrano/tlscert"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+func startServer(lc *lifecycle.Manager, app *fiber.App, cfg *configs.Config, checker *health.Checker, jobs services.IJobService) {
+	address := ":" + strconv.Itoa(cfg.App.Port)
+	listener, scheme, err := newListener(lc, address, cfg.TLS)
+	if err != nil {
+		lc.Fail(fmt.Errorf("sunucu %s adresinde dinlenemedi: %w", address, err))
+		return
+	}
+
+	if cfg.TLS.RedirectPort != 0 {
+		redirectServer := tlscert.NewRedirectServer(":"+strconv.Itoa(cfg.TLS.RedirectPort), cfg.App.Port)
+		go func() {
+			utils.Log.Info("HTTP->HTTPS yönlendirme sunucusu başlatılıyor", zap.String("address", redirectServer.Addr))
+			if err := redirectServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				lc.Fail(fmt.Errorf("yönlendirme sunucusu %s adresinde dinlenemedi: %w", redirectServer.Addr, err))
+			}
+		}()
+		lc.OnShutdown("redirect server", redirectServer.Shutdown)
+	}
+
+	go func() {
+		utils.Log.Info("Uygulama başlatılıyor",
+			zap.String("address", scheme+"://localhost"+address),
+			zap.Int("port", cfg.App.Port),
+		)
+		if err := app.Listener(listener); err != nil {
+			lc.Fail(fmt.Errorf("sunucu %s adresinde çalışmayı durdurdu: %w", address, err))
+		}
+	}()
+
+	// İşçiler HTTP sunucusundan önce kaydedilir ki kapanışta sunucudan sonra
+	// dursunlar; boşaltma sırasında biten istekler de iş kuyruğa alabilir.
+	startJobWorkers(lc, jobs, cfg.Jobs)
+
+	lc.OnShutdown("http server", func(ctx context.Context) error {
+		return drainHTTP(ctx, app, checker, cfg.Shutdown.ReadinessDelay)
+	})
+}
+
+const jobMaintenanceInterval = time.Minute
+
+func startJobWorkers(lc *lifecycle.Manager, jobs services.IJobService, cfg configs.JobsConfig) {
+	if cfg.Workers == 0 {
+		utils.Log.Warn("JOBS_WORKERS 0, bu sunucu arka plan işlerini çalıştırmayacak")
+		return
+	}
+	host, err := os.Hostname()
+	if err != nil {
+		host = "zatrano"
+	}
+	for i := 1; i <= cfg.Workers; i++ {
+		workerID := fmt.Sprintf("%s-%d-%d", host, os.Getpid(), i)
+		lc.Go("job worker "+strconv.Itoa(i), func(ctx context.Context) error {
+			jobs.Work(ctx, workerID, cfg.PollInterval)
+			return nil
+		})
+	}
+	lc.Go("job maintenance", func(ctx context.Context) error {
+		ticker := time.NewTicker(jobMaintenanceInterval)
+		defer ticker.Stop()
+		for {
+			// Hatalar servis tarafından loglanır; bir sonraki turda yeniden denenir.
+			_, _ = jobs.RequeueStale(ctx, cfg.LockTimeout)
+			_, _ = jobs.PruneSucceeded(ctx, cfg.Retention)
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	})
+	utils.Log.Info("İş işçileri başlatıldı", zap.Int("workers", cfg.Workers), zap.Duration("poll_interval", cfg.PollInterval))
+}
+
+func drainHTTP(ctx context.Context, app *fiber.App, checker *health.Checker, readinessDelay time.Duration) error {
+	checker.SetDraining()
+	if readinessDelay > 0 {
+		utils.Log.Info("Readiness kapatıldı, yük dengeleyicinin fark etmesi bekleniyor", zap.Duration("delay", readinessDelay))
+		select {
+		case <-time.After(readinessDelay):
+		case <-ctx.Done():
+		}
+	}
+
+	if err := app.ShutdownWithContext(ctx); err != nil {
+		if open := app.Server().GetOpenConnectionsCount(); open > 0 {
+			return fmt.Errorf("%d açık bağlantı kapanmadı: %w", open, err)
+		}
+		return err
+	}
+	utils.Log.Info("Sunucu başarıyla kapatıldı")
+	return nil
+}
+
+func newListener(lc *lifecycle.Manager, address string, tlsConfig configs.TLSConfig) (net.Listener, string, error) {
+	ln, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, "", err
+	}
+	if !tlsConfig.Enabled() {
+		return ln, "http", nil
+	}
+
+	reloader, err := tlscert.NewReloader(tlsConfig.CertFile, tlsConfig.KeyFile)
+	if err != nil {
+		_ = ln.Close()
+		return nil, "", err
+	}
+	lc.Go("tls certificate reloader", func(ctx context.Context) error {
+		reloader.Watch(ctx, tlsConfig.ReloadInterval)
+		return nil
+	})
+
+	utils.Log.Info("TLS etkin",
+		zap.String("cert_file", tlsConfig.CertFile),
+		zap.String("min_version", tlsConfig.MinVersion),
+		zap.Duration("reload_interval", tlsConfig.ReloadInterval),
+	)
+	return tls.NewListener(ln, reloader.TLSConfig(tlsConfig.MinTLSVersion())), "https", nil
+}
@@ -0,0 +1,357 @@
+package configs
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	netmail "net/mail"
+	"os"
+	"strings"
+	"time"
+)
+
+type Config struct {
+	App        AppConfig        `yaml:"app"`
+	Log        LogConfig        `yaml:"log"`
+	Database   DatabaseConfig   `yaml:"database"`
+	Session    SessionConfig    `yaml:"session"`
+	Cookie     CookieConfig     `yaml:"cookie"`
+	Security   SecurityConfig   `yaml:"security"`
+	TLS        TLSConfig        `yaml:"tls"`
+	Shutdown   ShutdownConfig   `yaml:"shutdown"`
+	Metrics    MetricsConfig    `yaml:"metrics"`
+	Attendance AttendanceConfig `yaml:"attendance"`
+	KPI        KPIConfig        `yaml:"kpi"`
+	Mail       MailConfig       `yaml:"mail"`
+	Reports    ReportsConfig    `yaml:"reports"`
+	Jobs       JobsConfig       `yaml:"jobs"`
+}
+
+type AppConfig struct {
+	Env  string `yaml:"env" env:"APP_ENV" default:"development"`
+	Port int    `yaml:"port" env:"APP_PORT" default:"3000"`
+}
+
+type LogConfig struct {
+	Level string `yaml:"level" env:"LOG_LEVEL"`
+}
+
+type DatabaseConfig struct {
+	Host     string `yaml:"host" env:"DB_HOST" default:"localhost"`
+	Port     int    `yaml:"port" env:"DB_PORT" default:"5432"`
+	User     string `yaml:"user" env:"DB_USERNAME" default:"postgres"`
+	Password string `yaml:"password" env:"DB_PASSWORD" secret:"true"`
+	Name     string `yaml:"name" env:"DB_DATABASE" default:"myapp"`
+	SSLMode  string `yaml:"sslmode" env:"DB_SSL_MODE" default:"disable"`
+	TimeZone string `yaml:"timezone" env:"DB_TIMEZONE" default:"UTC"`
+
+	MaxIdleConns           int    `yaml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS" default:"10"`
+	MaxOpenConns           int    `yaml:"max_open_conns" env:"DB_MAX_OPEN_CONNS" default:"100"`
+	ConnMaxLifetimeMinutes int    `yaml:"conn_max_lifetime_minutes" env:"DB_CONN_MAX_LIFETIME_MINUTES" default:"60"`
+	LogLevel               string `yaml:"log_level" env:"DB_LOG_LEVEL" default:"info"`
+}
+
+type SessionConfig struct {
+	ExpirationHours int `yaml:"expiration_hours" env:"SESSION_EXPIRATION_HOURS" default:"24"`
+}
+
+type CookieConfig struct {
+	Secure   bool   `yaml:"secure" env:"COOKIE_SECURE"`
+	SameSite string `yaml:"same_site" env:"COOKIE_SAMESITE" default:"Lax"`
+}
+
+type SecurityConfig struct {
+	ContentSecurityPolicy string   `yaml:"csp" env:"SECURITY_CSP"`
+	ReferrerPolicy        string   `yaml:"referrer_policy" env:"SECURITY_REFERRER_POLICY" default:"strict-origin-when-cross-origin"`
+	HSTSMaxAge            int      `yaml:"hsts_max_age" env:"SECURITY_HSTS_MAX_AGE" default:"31536000"`
+	HSTSIncludeSubdomains bool     `yaml:"hsts_include_subdomains" env:"SECURITY_HSTS_INCLUDE_SUBDOMAINS" default:"true"`
+	TrustedProxies        []string `yaml:"trusted_proxies" env:"TRUSTED_PROXIES"`
+	ProxyHeader           string   `yaml:"proxy_header" env:"PROXY_HEADER" default:"X-Real-IP"`
+}
+
+const DefaultContentSecurityPolicy = "default-src 'self'; " +
+	"script-src 'self' 'unsafe-inline' https://cdn.jsdelivr.net; " +
+	"style-src 'self' 'unsafe-inline' https://cdn.jsdelivr.net; " +
+	"font-src 'self' data: https://cdn.jsdelivr.net; " +
+	"img-src 'self' data:; " +
+	"connect-src 'self'; " +
+	"object-src 'none'; " +
+	"base-uri 'self'; " +
+	"form-action 'self'; " +
+	"frame-ancestors 'none'"
+
+type TLSConfig struct {
+	CertFile       string        `yaml:"cert_file" env:"TLS_CERT_FILE"`
+	KeyFile        string        `yaml:"key_file" env:"TLS_KEY_FILE"`
+	MinVersion     string        `yaml:"min_version" env:"TLS_MIN_VERSION" default:"1.2"`
+	RedirectPort   int           `yaml:"redirect_port" env:"TLS_REDIRECT_PORT"`
+	ReloadInterval time.Duration `yaml:"reload_interval" env:"TLS_RELOAD_INTERVAL" default:"30s"`
+}
+
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != "" && c.KeyFile != ""
+}
+
+func (c TLSConfig) MinTLSVersion() uint16 {
+	if c.MinVersion == "1.3" {
+		return tls.VersionTLS13
+	}
+	return tls.VersionTLS12
+}
+
+type ShutdownConfig struct {
+	Timeout        time.Duration `yaml:"timeout" env:"SHUTDOWN_TIMEOUT" default:"30s"`
+	ReadinessDelay time.Duration `yaml:"readiness_delay" env:"SHUTDOWN_READINESS_DELAY" default:"0s"`
+}
+
+type MetricsConfig struct {
+	Addr  string `yaml:"addr" env:"METRICS_ADDR"`
+	Token string `yaml:"token" env:"METRICS_TOKEN" secret:"true"`
+}
+
+type AttendanceConfig struct {
+	AutoCloseAfter time.Duration `yaml:"auto_close_after" env:"ATTENDANCE_AUTO_CLOSE_AFTER" default:"12h"`
+	SweepInterval  time.Duration `yaml:"sweep_interval" env:"ATTENDANCE_SWEEP_INTERVAL" default:"5m"`
+}
+
+type KPIConfig struct {
+	IngestToken string `yaml:"ingest_token" env:"KPI_INGEST_TOKEN" secret:"true"`
+}
+
+const (
+	MailDriverSMTP = "smtp"
+	MailDriverFile = "file"
+)
+
+type MailConfig struct {
+	Driver   string `yaml:"driver" env:"MAIL_DRIVER" default:"file"`
+	From     string `yaml:"from" env:"MAIL_FROM" default:"zatrano@localhost"`
+	Dir      string `yaml:"dir" env:"MAIL_DIR" default:"storage/mail"`
+	Host     string `yaml:"host" env:"MAIL_HOST"`
+	Port     int    `yaml:"port" env:"MAIL_PORT" default:"587"`
+	Username string `yaml:"username" env:"MAIL_USERNAME"`
+	Password string `yaml:"password" env:"MAIL_PASSWORD" secret:"true"`
+}
+
+type ReportsConfig struct {
+	CheckInterval time.Duration `yaml:"check_interval" env:"REPORTS_CHECK_INTERVAL" default:"1m"`
+}
+
+type JobsConfig struct {
+	Workers      int           `yaml:"workers" env:"JOBS_WORKERS" default:"2"`
+	PollInterval time.Duration `yaml:"poll_interval" env:"JOBS_POLL_INTERVAL" default:"1s"`
+	LockTimeout  time.Duration `yaml:"lock_timeout" env:"JOBS_LOCK_TIMEOUT" default:"10m"`
+	MaxAttempts  int           `yaml:"max_attempts" env:"JOBS_MAX_ATTEMPTS" default:"5"`
+	Retention    time.Duration `yaml:"retention" env:"JOBS_RETENTION" default:"168h"`
+}
+
+const (
+	EnvDevelopment = "development"
+	EnvProduction  = "production"
+	EnvTest        = "test"
+)
+
+func (c *Config) IsProduction() bool {
+	return c.App.Env == EnvProduction
+}
+
+func (c DatabaseConfig) DSN() string {
+	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=%s TimeZone=%s",
+		c.Host, c.User, c.Password, c.Name, c.Port, c.SSLMode, c.TimeZone)
+}
+
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "geçersiz yapılandırma:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+func (c *Config) applyDerivedDefaults(provided map[string]bool) {
+	if !provided["cookie.secure"] {
+		c.Cookie.Secure = c.IsProduction()
+	}
+	if c.Security.ContentSecurityPolicy == "" {
+		c.Security.ContentSecurityPolicy = DefaultContentSecurityPolicy
+	}
+}
+
+func (c *Config) validate() []string {
+	var problems []string
+	add := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if !oneOf(c.App.Env, EnvDevelopment, EnvProduction, EnvTest) {
+		add("app.env (APP_ENV) %q olamaz; development, production veya test olmalı", c.App.Env)
+	}
+	if !validPort(c.App.Port) {
+		add("app.port (APP_PORT) 1-65535 aralığında olmalı, %d verildi", c.App.Port)
+	}
+	if c.Log.Level != "" && !oneOf(c.Log.Level, "debug", "info", "warn", "error", "dpanic", "panic", "fatal") {
+		add("log.level (LOG_LEVEL) %q geçerli bir seviye değil", c.Log.Level)
+	}
+
+	db := c.Database
+	if db.Host == "" {
+		add("database.host (DB_HOST) zorunludur")
+	}
+	if !validPort(db.Port) {
+		add("database.port (DB_PORT) 1-65535 aralığında olmalı, %d verildi", db.Port)
+	}
+	if db.User == "" {
+		add("database.user (DB_USERNAME) zorunludur")
+	}
+	if db.Name == "" {
+		add("database.name (DB_DATABASE) zorunludur")
+	}
+	if !oneOf(db.SSLMode, "disable", "allow", "prefer", "require", "verify-ca", "verify-full") {
+		add("database.sslmode (DB_SSL_MODE) %q geçerli bir SSL modu değil", db.SSLMode)
+	}
+	if db.MaxIdleConns < 0 || db.MaxOpenConns < 0 || db.ConnMaxLifetimeMinutes < 0 {
+		add("database havuz ayarları negatif olamaz")
+	}
+	if db.MaxOpenConns > 0 && db.MaxIdleConns > db.MaxOpenConns {
+		add("database.max_idle_conns (%d) database.max_open_conns (%d) değerinden büyük olamaz", db.MaxIdleConns, db.MaxOpenConns)
+	}
+	if !oneOf(db.LogLevel, "silent", "error", "warn", "info") {
+		add("database.log_level (DB_LOG_LEVEL) %q olamaz; silent, error, warn veya info olmalı", db.LogLevel)
+	}
+	if c.IsProduction() && db.Password == "" {
+		add("database.password (DB_PASSWORD) production ortamında zorunludur")
+	}
+
+	if c.Session.ExpirationHours <= 0 {
+		add("session.expiration_hours (SESSION_EXPIRATION_HOURS) pozitif olmalı, %d verildi", c.Session.ExpirationHours)
+	}
+
+	if !oneOf(c.Cookie.SameSite, "Lax", "Strict", "None") {
+		add("cookie.same_site (COOKIE_SAMESITE) %q olamaz; Lax, Strict veya None olmalı", c.Cookie.SameSite)
+	}
+	if c.Cookie.SameSite == "None" && !c.Cookie.Secure {
+		add("cookie.same_site (COOKIE_SAMESITE) None ise cookie.secure (COOKIE_SECURE) true olmalı")
+	}
+
+	if c.Security.HSTSMaxAge < 0 {
+		add("security.hsts_max_age (SECURITY_HSTS_MAX_AGE) negatif olamaz")
+	}
+	for _, proxy := range c.Security.TrustedProxies {
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				add("security.trusted_proxies (TRUSTED_PROXIES) %q geçerli bir IP veya CIDR değil", proxy)
+			}
+		}
+	}
+	if len(c.Security.TrustedProxies) > 0 && c.Security.ProxyHeader == "" {
+		add("security.proxy_header (PROXY_HEADER) trusted_proxies verildiğinde boş olamaz")
+	}
+
+	if (c.TLS.CertFile == "") != (c.TLS.KeyFile == "") {
+		add("tls.cert_file (TLS_CERT_FILE) ve tls.key_file (TLS_KEY_FILE) birlikte verilmeli")
+	}
+	if c.TLS.Enabled() {
+		for _, file := range []string{c.TLS.CertFile, c.TLS.KeyFile} {
+			if _, err := os.Stat(file); err != nil {
+				add("tls dosyası okunamıyor: %v", err)
+			}
+		}
+	}
+	if !oneOf(c.TLS.MinVersion, "1.2", "1.3") {
+		add("tls.min_version (TLS_MIN_VERSION) %q olamaz; 1.2 veya 1.3 olmalı", c.TLS.MinVersion)
+	}
+	if c.TLS.RedirectPort != 0 {
+		if !c.TLS.Enabled() {
+			add("tls.redirect_port (TLS_REDIRECT_PORT) yalnızca TLS açıkken kullanılabilir")
+		}
+		if !validPort(c.TLS.RedirectPort) || c.TLS.RedirectPort == c.App.Port {
+			add("tls.redirect_port (TLS_REDIRECT_PORT) geçerli ve app.port'tan farklı bir port olmalı, %d verildi", c.TLS.RedirectPort)
+		}
+	}
+	if c.TLS.ReloadInterval < 0 {
+		add("tls.reload_interval (TLS_RELOAD_INTERVAL) negatif olamaz")
+	}
+
+	if c.Shutdown.Timeout <= 0 {
+		add("shutdown.timeout (SHUTDOWN_TIMEOUT) pozitif olmalı")
+	}
+	if c.Shutdown.ReadinessDelay < 0 || c.Shutdown.ReadinessDelay >= c.Shutdown.Timeout {
+		add("shutdown.readiness_delay (SHUTDOWN_READINESS_DELAY) 0 ile shutdown.timeout arasında olmalı")
+	}
+
+	if c.Metrics.Addr != "" {
+		if _, _, err := net.SplitHostPort(c.Metrics.Addr); err != nil {
+			add("metrics.addr (METRICS_ADDR) %q host:port biçiminde olmalı", c.Metrics.Addr)
+		}
+	}
+	if c.Metrics.Token != "" && len(c.Metrics.Token) < 16 {
+		add("metrics.token (METRICS_TOKEN) en az 16 karakter olmalı")
+	}
+
+	if c.Attendance.AutoCloseAfter <= 0 {
+		add("attendance.auto_close_after (ATTENDANCE_AUTO_CLOSE_AFTER) pozitif olmalı")
+	}
+	if c.Attendance.SweepInterval < 0 {
+		add("attendance.sweep_interval (ATTENDANCE_SWEEP_INTERVAL) negatif olamaz")
+	}
+
+	if c.KPI.IngestToken != "" && len(c.KPI.IngestToken) < 16 {
+		add("kpi.ingest_token (KPI_INGEST_TOKEN) en az 16 karakter olmalı")
+	}
+
+	mail := c.Mail
+	if !oneOf(mail.Driver, MailDriverSMTP, MailDriverFile) {
+		add("mail.driver (MAIL_DRIVER) %q olamaz; smtp veya file olmalı", mail.Driver)
+	}
+	if _, err := netmail.ParseAddress(mail.From); err != nil {
+		add("mail.from (MAIL_FROM) %q geçerli bir e-posta adresi değil", mail.From)
+	}
+	if mail.Driver == MailDriverSMTP {
+		if mail.Host == "" {
+			add("mail.host (MAIL_HOST) smtp sürücüsünde zorunludur")
+		}
+		if !validPort(mail.Port) {
+			add("mail.port (MAIL_PORT) 1-65535 aralığında olmalı, %d verildi", mail.Port)
+		}
+		if (mail.Username == "") != (mail.Password == "") {
+			add("mail.username (MAIL_USERNAME) ve mail.password (MAIL_PASSWORD) birlikte verilmeli")
+		}
+	}
+	if mail.Driver == MailDriverFile && mail.Dir == "" {
+		add("mail.dir (MAIL_DIR) file sürücüsünde zorunludur")
+	}
+
+	if c.Reports.CheckInterval < 0 {
+		add("reports.check_interval (REPORTS_CHECK_INTERVAL) negatif olamaz")
+	}
+	if c.Jobs.Workers < 0 {
+		add("jobs.workers (JOBS_WORKERS) negatif olamaz")
+	}
+	if c.Jobs.PollInterval <= 0 {
+		add("jobs.poll_interval (JOBS_POLL_INTERVAL) pozitif olmalı")
+	}
+	if c.Jobs.LockTimeout <= 0 {
+		add("jobs.lock_timeout (JOBS_LOCK_TIMEOUT) pozitif olmalı")
+	}
+	if c.Jobs.MaxAttempts < 1 {
+		add("jobs.max_attempts (JOBS_MAX_ATTEMPTS) en az 1 olmalı, %d verildi", c.Jobs.MaxAttempts)
+	}
+	if c.Jobs.Retention <= 0 {
+		add("jobs.retention (JOBS_RETENTION) pozitif olmalı")
+	}
+
+	return problems
+}
+
+func oneOf(value string, allowed ...string) bool {
+	for _, a := range allowed {
+		if value == a {
+			return true
+		}
+	}
+	return false
+}
+
+func validPort(port int) bool {
+	return port > 0 && port <= 65535
+}
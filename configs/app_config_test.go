@@ -0,0 +1,272 @@
+package configs
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func envMap(values map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := values[key]
+		return v, ok
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadDefaults(t *testing.T) {
+	cfg, err := Load(LoadOptions{LookupEnv: envMap(nil)})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.App.Port != 3000 || cfg.Database.Port != 5432 || cfg.Session.ExpirationHours != 24 {
+		t.Fatalf("defaults not applied: %+v", cfg)
+	}
+	if cfg.Cookie.Secure {
+		t.Fatal("cookies are secure by default in development")
+	}
+	if cfg.Security.ContentSecurityPolicy != DefaultContentSecurityPolicy {
+		t.Fatalf("CSP = %q, want the default policy", cfg.Security.ContentSecurityPolicy)
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	yamlFile := writeFile(t, "config.yaml", `
+app:
+  port: 8080
+database:
+  host: db.internal
+  name: zatrano
+  max_open_conns: 20
+`)
+	tomlFile := writeFile(t, "config.toml", `
+# yorum
+[app]
+port = 8080
+
+[database]
+host = "db.internal" # satır sonu yorumu
+name = 'zatrano'
+max_open_conns = 20
+`)
+
+	for _, file := range []string{yamlFile, tomlFile} {
+		t.Run(filepath.Ext(file), func(t *testing.T) {
+			cfg, err := Load(LoadOptions{File: file, LookupEnv: envMap(map[string]string{
+				"DB_HOST": "db.override",
+				"DB_PORT": "",
+			})})
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.App.Port != 8080 || cfg.Database.Name != "zatrano" || cfg.Database.MaxOpenConns != 20 {
+				t.Fatalf("file values not applied: %+v", cfg)
+			}
+			if cfg.Database.Host != "db.override" {
+				t.Fatalf("Database.Host = %q, env should win over the file", cfg.Database.Host)
+			}
+			if cfg.Database.Port != 5432 {
+				t.Fatalf("Database.Port = %d, empty env should keep the default", cfg.Database.Port)
+			}
+		})
+	}
+}
+
+func TestLoadEmptyFileValueKeepsDefault(t *testing.T) {
+	for _, file := range []string{
+		writeFile(t, "config.yaml", "app:\n  port:\ndatabase:\n  host: \"\"\n"),
+		writeFile(t, "config.toml", "[app]\nport = \"\"\n\n[database]\nhost = \"\"\n"),
+	} {
+		t.Run(filepath.Ext(file), func(t *testing.T) {
+			cfg, err := Load(LoadOptions{File: file, LookupEnv: envMap(nil)})
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.App.Port != 3000 || cfg.Database.Host != "localhost" {
+				t.Fatalf("App.Port = %d, Database.Host = %q; empty file values should keep the defaults", cfg.App.Port, cfg.Database.Host)
+			}
+		})
+	}
+}
+
+func TestLoadReportsAllProblems(t *testing.T) {
+	file := writeFile(t, "config.yaml", "database:\n  hots: typo\n")
+	_, err := Load(LoadOptions{File: file, LookupEnv: envMap(map[string]string{
+		"APP_ENV":         "production",
+		"APP_PORT":        "abc",
+		"DB_SSL_MODE":     "sometimes",
+		"METRICS_ADDR":    "9100",
+		"TRUSTED_PROXIES": "10.0.0.0/8, proxy.local",
+		"TLS_CERT_FILE":   "/yok/cert.pem",
+		"TLS_MIN_VERSION": "1.0",
+	})})
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Load() error = %v, want *ValidationError", err)
+	}
+	for _, want := range []string{"app.port (APP_PORT)", "database.hots", "database.sslmode", "database.password", "metrics.addr", `"proxy.local"`, "tls.key_file", "tls.min_version"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error report does not mention %s:\n%v", want, err)
+		}
+	}
+}
+
+func TestCookieSecureDefaultsToProduction(t *testing.T) {
+	env := map[string]string{"APP_ENV": "production", "DB_PASSWORD": "secret"}
+	cfg, err := Load(LoadOptions{LookupEnv: envMap(env)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Cookie.Secure {
+		t.Fatal("cookies are not secure in production")
+	}
+
+	env["COOKIE_SECURE"] = "false"
+	cfg, err = Load(LoadOptions{LookupEnv: envMap(env)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Cookie.Secure {
+		t.Fatal("explicit COOKIE_SECURE=false was ignored")
+	}
+
+	env["COOKIE_SAMESITE"] = "None"
+	if _, err := Load(LoadOptions{LookupEnv: envMap(env)}); err == nil || !strings.Contains(err.Error(), "cookie.same_site") {
+		t.Fatalf("SameSite=None without Secure error = %v", err)
+	}
+}
+
+func TestMailConfig(t *testing.T) {
+	cfg, err := Load(LoadOptions{LookupEnv: envMap(nil)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Mail.Driver != MailDriverFile || cfg.Reports.CheckInterval != time.Minute {
+		t.Fatalf("mail defaults = %+v, reports = %+v", cfg.Mail, cfg.Reports)
+	}
+
+	_, err = Load(LoadOptions{LookupEnv: envMap(map[string]string{
+		"MAIL_DRIVER":   "smtp",
+		"MAIL_FROM":     "rapor",
+		"MAIL_PORT":     "0",
+		"MAIL_USERNAME": "rapor@example.com",
+	})})
+	for _, want := range []string{"mail.from", "mail.host", "mail.port", "mail.password"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("error report does not mention %s:\n%v", want, err)
+		}
+	}
+
+	if _, err := Load(LoadOptions{LookupEnv: envMap(map[string]string{"MAIL_DRIVER": "sendmail"})}); err == nil || !strings.Contains(err.Error(), "mail.driver") {
+		t.Fatalf("unknown driver error = %v", err)
+	}
+}
+
+func TestJobsConfig(t *testing.T) {
+	cfg, err := Load(LoadOptions{LookupEnv: envMap(nil)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Jobs.Workers != 2 || cfg.Jobs.PollInterval != time.Second || cfg.Jobs.MaxAttempts != 5 || cfg.Jobs.Retention != 7*24*time.Hour {
+		t.Fatalf("jobs defaults = %+v", cfg.Jobs)
+	}
+
+	_, err = Load(LoadOptions{LookupEnv: envMap(map[string]string{
+		"JOBS_WORKERS":       "-1",
+		"JOBS_POLL_INTERVAL": "0s",
+		"JOBS_MAX_ATTEMPTS":  "0",
+	})})
+	for _, want := range []string{"jobs.workers", "jobs.poll_interval", "jobs.max_attempts"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("error report does not mention %s:\n%v", want, err)
+		}
+	}
+}
+
+func TestPrintRedactsSecrets(t *testing.T) {
+	cfg, err := Load(LoadOptions{LookupEnv: envMap(map[string]string{
+		"DB_PASSWORD":   "cok-gizli",
+		"METRICS_TOKEN": "0123456789abcdef-token",
+	})})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := cfg.Print(&out); err != nil {
+		t.Fatal(err)
+	}
+	printed := out.String()
+	if strings.Contains(printed, "cok-gizli") || strings.Contains(printed, "0123456789abcdef-token") {
+		t.Fatalf("secrets leaked:\n%s", printed)
+	}
+	if !strings.Contains(printed, "password: '"+redacted+"'") || !strings.Contains(printed, "port: 5432 # DB_PORT") {
+		t.Fatalf("unexpected output:\n%s", printed)
+	}
+}
+
+func TestPrintOutputLoadsBack(t *testing.T) {
+	cfg, err := Load(LoadOptions{LookupEnv: envMap(map[string]string{"APP_PORT": "8081"})})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if err := cfg.Print(&out); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := Load(LoadOptions{File: writeFile(t, "printed.yaml", out.String()), LookupEnv: envMap(nil)})
+	if err != nil {
+		t.Fatalf("printed config cannot be loaded back: %v", err)
+	}
+	if !reflect.DeepEqual(reloaded, cfg) {
+		t.Fatalf("reloaded config = %+v, want %+v", reloaded, cfg)
+	}
+}
+
+func TestReadConfigFileTOML(t *testing.T) {
+	values, err := readConfigFile(writeFile(t, "config.toml", `
+app = { port = 8080, name = """
+zatrano""" }
+
+[security]
+trusted_proxies = [
+  "10.0.0.1",
+  "10.0.0.2",
+]
+`))
+	if err != nil {
+		t.Fatalf("readConfigFile() error = %v", err)
+	}
+	if values["app.port"] != "8080" || values["app.name"] != "zatrano" || values["security.trusted_proxies"] != "10.0.0.1,10.0.0.2" {
+		t.Fatalf("readConfigFile() = %v", values)
+	}
+}
+
+func TestReadConfigFileTOMLErrors(t *testing.T) {
+	tests := []string{
+		"[app\nport = 1",
+		"port",
+		"[app]\nport = 1\nport = 2",
+		"name = \"unterminated",
+	}
+	for _, input := range tests {
+		if _, err := readConfigFile(writeFile(t, "config.toml", input)); err == nil {
+			t.Errorf("readConfigFile(%q) succeeded, want error", input)
+		}
+	}
+}
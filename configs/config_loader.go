@@ -0,0 +1,243 @@
+package configs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+const ConfigFileEnv = "CONFIG_FILE"
+
+type LoadOptions struct {
+	File      string
+	DotEnv    []string
+	LookupEnv func(key string) (string, bool)
+}
+
+func Load(opts LoadOptions) (*Config, error) {
+	for _, path := range opts.DotEnv {
+		if err := godotenv.Load(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%s okunamadı: %w", path, err)
+		}
+	}
+
+	lookup := opts.LookupEnv
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+
+	file := opts.File
+	if file == "" {
+		file, _ = lookup(ConfigFileEnv)
+	}
+	fileValues := map[string]string{}
+	if file != "" {
+		var err error
+		if fileValues, err = readConfigFile(file); err != nil {
+			return nil, err
+		}
+	}
+
+	cfg := &Config{}
+	provided := map[string]bool{}
+	var problems []string
+	failed := map[string]bool{}
+
+	known := map[string]bool{}
+	for _, f := range configFields(cfg) {
+		known[f.key] = true
+
+		raw, source := f.def, ""
+		if v, ok := fileValues[f.key]; ok && v != "" {
+			raw, source = v, file
+			provided[f.key] = true
+		}
+		if f.env != "" {
+			if v, ok := lookup(f.env); ok && v != "" {
+				raw, source = v, f.env
+				provided[f.key] = true
+			}
+		}
+		if raw == "" {
+			continue
+		}
+		if err := setField(f.value, raw); err != nil {
+			problems = append(problems, fmt.Sprintf("%s (%s) ayrıştırılamadı: %v", f.key, source, err))
+			failed[f.key] = true
+		}
+	}
+
+	for _, key := range sortedKeys(fileValues) {
+		if !known[key] {
+			problems = append(problems, fmt.Sprintf("%s: bilinmeyen anahtar %q", file, key))
+		}
+	}
+
+	cfg.applyDerivedDefaults(provided)
+	for _, problem := range cfg.validate() {
+		// Ayrıştırılamayan alanın sıfır değeri için ikinci bir hata üretme.
+		key, _, _ := strings.Cut(problem, " ")
+		if !failed[key] {
+			problems = append(problems, problem)
+		}
+	}
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
+	}
+	return cfg, nil
+}
+
+type configField struct {
+	key    string
+	env    string
+	def    string
+	secret bool
+	value  reflect.Value
+}
+
+func configFields(cfg *Config) []configField {
+	var fields []configField
+	var walk func(v reflect.Value, prefix string)
+	walk = func(v reflect.Value, prefix string) {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			key := sf.Tag.Get("yaml")
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			fv := v.Field(i)
+			if sf.Type.Kind() == reflect.Struct {
+				walk(fv, key)
+				continue
+			}
+			fields = append(fields, configField{
+				key:    key,
+				env:    sf.Tag.Get("env"),
+				def:    sf.Tag.Get("default"),
+				secret: sf.Tag.Get("secret") == "true",
+				value:  fv,
+			})
+		}
+	}
+	walk(reflect.ValueOf(cfg).Elem(), "")
+	return fields
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func setField(v reflect.Value, raw string) error {
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("%q tam sayı değil", raw)
+		}
+		v.SetInt(int64(n))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("%q true/false değil", raw)
+		}
+		v.SetBool(b)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("desteklenmeyen liste tipi %s", v.Type())
+		}
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("desteklenmeyen alan tipi %s", v.Type())
+	}
+	return nil
+}
+
+func readConfigFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("yapılandırma dosyası okunamadı: %w", err)
+	}
+
+	var tree map[string]interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &tree)
+	case ".toml":
+		_, err = toml.Decode(string(data), &tree)
+	default:
+		return nil, fmt.Errorf("%s: desteklenmeyen yapılandırma dosyası uzantısı (yaml, yml veya toml olmalı)", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s ayrıştırılamadı: %w", path, err)
+	}
+
+	values := map[string]string{}
+	flatten(tree, "", values)
+	return values, nil
+}
+
+func flatten(tree map[string]interface{}, prefix string, out map[string]string) {
+	for key, value := range tree {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case map[string]interface{}:
+			flatten(v, key, out)
+		case []interface{}:
+			items := make([]string, len(v))
+			for i, item := range v {
+				items[i] = fmt.Sprint(item)
+			}
+			out[key] = strings.Join(items, ",")
+		case nil:
+			out[key] = ""
+		default:
+			out[key] = fmt.Sprint(v)
+		}
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func MustLoad(opts LoadOptions) *Config {
+	cfg, err := Load(opts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	return cfg
+}
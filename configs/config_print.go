@@ -0,0 +1,58 @@
+package configs
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "******"
+
+func (c *Config) Print(w io.Writer) error {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	sections := map[string]*yaml.Node{}
+
+	for _, f := range configFields(c) {
+		section, key, _ := strings.Cut(f.key, ".")
+		node, ok := sections[section]
+		if !ok {
+			node = &yaml.Node{Kind: yaml.MappingNode}
+			sections[section] = node
+			root.Content = append(root.Content, scalarNode(section, "!!str"), node)
+		}
+
+		value := fmt.Sprint(f.value.Interface())
+		tag := "!!str"
+		switch {
+		case f.secret && value != "":
+			value = redacted
+		case f.value.Type() == durationType:
+		case f.value.Kind() == reflect.Int || f.value.Kind() == reflect.Int64:
+			tag = "!!int"
+		case f.value.Kind() == reflect.Bool:
+			tag = "!!bool"
+		case f.value.Kind() == reflect.Slice:
+			value = strings.Join(f.value.Interface().([]string), ",")
+		}
+
+		valueNode := scalarNode(value, tag)
+		if f.env != "" {
+			valueNode.LineComment = f.env
+		}
+		node.Content = append(node.Content, scalarNode(key, "!!str"), valueNode)
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(root); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+func scalarNode(value, tag string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value}
+}
@@ -10,28 +10,28 @@ import (
 	"go.uber.org/zap"
 )
 
-func SetupCSRF() fiber.Handler {
+func SetupCSRF(cookieConfig CookieConfig) fiber.Handler {
 	config := csrf.Config{
 		KeyLookup:      "form:csrf_token",
 		CookieName:     "csrf_",
 		CookieHTTPOnly: true,
-		CookieSecure:   false,
-		CookieSameSite: "Lax",
+		CookieSecure:   cookieConfig.Secure,
+		CookieSameSite: cookieConfig.SameSite,
 		Expiration:     1 * time.Hour,
 		KeyGenerator:   fiberUtils.UUID,
 		ContextKey:     "csrf",
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
-			utils.Log.Warn("CSRF validation failed",
+			utils.LogFrom(c.UserContext()).Warn("CSRF validation failed",
 				zap.Error(err),
 				zap.String("ip", c.IP()),
 				zap.String("path", c.Path()),
 				zap.String("method", c.Method()),
 			)
-			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz işlem. Lütfen sayfayı yenileyin.")
+			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "errors.csrf_invalid")
 			return c.RedirectBack("/auth/login")
 		},
 	}
 
-	utils.SLog.Info("CSRF middleware yapılandırıldı")
+	utils.SLog.Infow("CSRF middleware yapılandırıldı", "cookie_secure", cookieConfig.Secure, "cookie_samesite", cookieConfig.SameSite)
 	return csrf.New(config)
 }
@@ -1,13 +1,10 @@
 package configs
 
 import (
-	"os"
-	"strconv"
 	"time"
 
 	"zatrano/utils"
 
-	"github.com/joho/godotenv"
 	"go.uber.org/zap"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -16,43 +13,7 @@ import (
 
 var DB *gorm.DB
 
-type DatabaseConfig struct {
-	Host     string
-	Port     int
-	User     string
-	Password string
-	Name     string
-	SSLMode  string
-	TimeZone string
-}
-
-func InitDB() {
-	err := godotenv.Load()
-	if err != nil {
-		utils.SLog.Warnw(".env dosyası yüklenemedi, sistem ortam değişkenleri kullanılacak (eğer varsa)", "error", err)
-	} else {
-		utils.SLog.Info(".env dosyası başarıyla yüklendi")
-	}
-
-	portStr := utils.GetEnvWithDefault("DB_PORT", "5432")
-	port, err := strconv.Atoi(portStr)
-	if err != nil {
-		utils.SLog.Fatalw("Invalid DB_PORT environment variable",
-			"value", portStr,
-			"error", err,
-		)
-	}
-
-	dbConfig := DatabaseConfig{
-		Host:     utils.GetEnvWithDefault("DB_HOST", "localhost"),
-		Port:     port,
-		User:     utils.GetEnvWithDefault("DB_USERNAME", "postgres"),
-		Password: utils.GetEnvWithDefault("DB_PASSWORD", ""),
-		Name:     utils.GetEnvWithDefault("DB_DATABASE", "myapp"),
-		SSLMode:  utils.GetEnvWithDefault("DB_SSL_MODE", "disable"),
-		TimeZone: utils.GetEnvWithDefault("DB_TIMEZONE", "UTC"),
-	}
-
+func InitDB(dbConfig DatabaseConfig) {
 	utils.Log.Info("Database configuration loaded",
 		zap.String("host", dbConfig.Host),
 		zap.Int("port", dbConfig.Port),
@@ -62,17 +23,9 @@ func InitDB() {
 		zap.String("timezone", dbConfig.TimeZone),
 	)
 
-	dsn := "host=" + dbConfig.Host +
-		" user=" + dbConfig.User +
-		" password=" + dbConfig.Password +
-		" dbname=" + dbConfig.Name +
-		" port=" + strconv.Itoa(dbConfig.Port) +
-		" sslmode=" + dbConfig.SSLMode +
-		" TimeZone=" + dbConfig.TimeZone
-
 	var gormerr error
-	DB, gormerr = gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(getGormLogLevel()),
+	DB, gormerr = gorm.Open(postgres.Open(dbConfig.DSN()), &gorm.Config{
+		Logger: logger.Default.LogMode(getGormLogLevel(dbConfig.LogLevel)),
 		NowFunc: func() time.Time {
 			return time.Now().UTC()
 		},
@@ -93,23 +46,19 @@ func InitDB() {
 		utils.Log.Fatal("Failed to get underlying sql.DB instance", zap.Error(err))
 	}
 
-	maxIdleConns := utils.GetEnvAsInt("DB_MAX_IDLE_CONNS", 10)
-	maxOpenConns := utils.GetEnvAsInt("DB_MAX_OPEN_CONNS", 100)
-	connMaxLifetimeMinutes := utils.GetEnvAsInt("DB_CONN_MAX_LIFETIME_MINUTES", 60)
-
-	sqlDB.SetMaxIdleConns(maxIdleConns)
-	sqlDB.SetMaxOpenConns(maxOpenConns)
-	sqlDB.SetConnMaxLifetime(time.Duration(connMaxLifetimeMinutes) * time.Minute)
+	sqlDB.SetMaxIdleConns(dbConfig.MaxIdleConns)
+	sqlDB.SetMaxOpenConns(dbConfig.MaxOpenConns)
+	sqlDB.SetConnMaxLifetime(time.Duration(dbConfig.ConnMaxLifetimeMinutes) * time.Minute)
 
 	utils.Log.Info("Database connection established successfully",
-		zap.Int("max_idle_conns", maxIdleConns),
-		zap.Int("max_open_conns", maxOpenConns),
-		zap.Int("conn_max_lifetime_minutes", connMaxLifetimeMinutes),
+		zap.Int("max_idle_conns", dbConfig.MaxIdleConns),
+		zap.Int("max_open_conns", dbConfig.MaxOpenConns),
+		zap.Int("conn_max_lifetime_minutes", dbConfig.ConnMaxLifetimeMinutes),
 	)
 }
 
-func getGormLogLevel() logger.LogLevel {
-	switch os.Getenv("DB_LOG_LEVEL") {
+func getGormLogLevel(level string) logger.LogLevel {
+	switch level {
 	case "silent":
 		return logger.Silent
 	case "error":
@@ -0,0 +1,24 @@
+package configs
+
+import (
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func ApplyTrustedProxies(config *fiber.Config, security SecurityConfig) {
+	if len(security.TrustedProxies) == 0 {
+		utils.SLog.Info("Güvenilen proxy tanımlı değil, istemci IP'si doğrudan bağlantıdan alınacak")
+		return
+	}
+
+	config.EnableTrustedProxyCheck = true
+	config.TrustedProxies = security.TrustedProxies
+	config.ProxyHeader = security.ProxyHeader
+	config.EnableIPValidation = true
+
+	utils.SLog.Infow("Güvenilen proxy ayarları uygulandı",
+		"trusted_proxies", security.TrustedProxies,
+		"proxy_header", security.ProxyHeader,
+	)
+}
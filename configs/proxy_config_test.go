@@ -0,0 +1,49 @@
+package configs
+
+import (
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestMain(m *testing.M) {
+	utils.InitLogger(EnvTest, "error")
+	os.Exit(m.Run())
+}
+
+func TestApplyTrustedProxies(t *testing.T) {
+	tests := []struct {
+		name    string
+		proxies []string
+		want    string
+	}{
+		{name: "no trusted proxies ignores the header", want: "0.0.0.0"},
+		{name: "untrusted peer ignores the header", proxies: []string{"10.0.0.0/8"}, want: "0.0.0.0"},
+		{name: "trusted peer uses the header", proxies: []string{"0.0.0.0"}, want: "203.0.113.7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := fiber.Config{}
+			ApplyTrustedProxies(&config, SecurityConfig{TrustedProxies: tt.proxies, ProxyHeader: "X-Real-IP"})
+			app := fiber.New(config)
+			app.Get("/", func(c *fiber.Ctx) error { return c.SendString(c.IP()) })
+
+			req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			req.Header.Set("X-Real-IP", "203.0.113.7")
+			resp, err := app.Test(req, -1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			body := make([]byte, 64)
+			n, _ := resp.Body.Read(body)
+			if got := string(body[:n]); got != tt.want {
+				t.Fatalf("c.IP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,61 +2,51 @@ package configs
 
 import (
 	"encoding/gob"
-	"strconv"
 	"time"
 
 	"zatrano/models"
+	"zatrano/repositories"
 	"zatrano/utils"
 
+	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/session"
 	"github.com/gofiber/storage/postgres/v3"
 	"go.uber.org/zap"
 )
 
-type sessionDatabaseConfig struct {
-	Host     string
-	Port     int
-	User     string
-	Password string
-	Name     string
-	SSLMode  string
-}
-
 var Session *session.Store
 
-func InitSession() {
-	Session = createSessionStore()
+func InitSession(cfg *Config) {
+	UseSessionStore(createSessionStore(cfg.Database, cfg.Session, cfg.Cookie))
+}
+
+func UseSessionStore(store *session.Store) {
+	registerGobTypes()
+	Session = store
 	utils.InitializeSessionStore(Session)
 	utils.SLog.Info("Session store initialized and registered in utils")
 }
 
 func SetupSession() *session.Store {
 	if Session == nil {
-		utils.SLog.Warn("Session store requested but not initialized, initializing now.")
-		InitSession()
+		utils.SLog.Fatal("Session store requested but not initialized. Call InitSession() first.")
 	}
 	return Session
 }
 
-func createSessionStore() *session.Store {
-	portStr := utils.GetEnvWithDefault("DB_PORT", "5432")
-	port, err := strconv.Atoi(portStr)
-	if err != nil {
-		utils.SLog.Fatalw("Invalid DB_PORT environment variable for session store",
-			"value", portStr,
-			"error", err,
-		)
+func CloseSession() error {
+	if Session == nil || Session.Storage == nil {
+		return nil
 	}
-
-	dbConfig := sessionDatabaseConfig{
-		Host:     utils.GetEnvWithDefault("DB_HOST", "localhost"),
-		Port:     port,
-		User:     utils.GetEnvWithDefault("DB_USERNAME", "postgres"),
-		Password: utils.GetEnvWithDefault("DB_PASSWORD", ""),
-		Name:     utils.GetEnvWithDefault("DB_DATABASE", "myapp"),
-		SSLMode:  utils.GetEnvWithDefault("DB_SSL_MODE", "disable"),
+	if err := Session.Storage.Close(); err != nil {
+		utils.Log.Error("Session storage kapatılırken hata oluştu", zap.Error(err))
+		return err
 	}
+	utils.SLog.Info("Session storage closed successfully.")
+	return nil
+}
 
+func createSessionStore(dbConfig DatabaseConfig, sessionConfig SessionConfig, cookieConfig CookieConfig) *session.Store {
 	utils.Log.Info("Configuring session storage",
 		zap.String("storage_type", "postgres"),
 		zap.String("host", dbConfig.Host),
@@ -64,7 +54,7 @@ func createSessionStore() *session.Store {
 		zap.String("user", dbConfig.User),
 		zap.String("database", dbConfig.Name),
 		zap.String("sslmode", dbConfig.SSLMode),
-		zap.String("table", "sessions"),
+		zap.String("table", repositories.SessionTable),
 	)
 
 	storage := postgres.New(postgres.Config{
@@ -75,19 +65,27 @@ func createSessionStore() *session.Store {
 		Database:   dbConfig.Name,
 		SSLMode:    dbConfig.SSLMode,
 		Reset:      false,
-		Table:      "sessions",
+		Table:      repositories.SessionTable,
 		GCInterval: 10 * time.Second,
 	})
 
-	sessionExpirationHours := utils.GetEnvAsInt("SESSION_EXPIRATION_HOURS", 24)
+	return NewSessionStore(storage, sessionConfig, cookieConfig)
+}
+
+func NewSessionStore(storage fiber.Storage, sessionConfig SessionConfig, cookieConfig CookieConfig) *session.Store {
 	store := session.New(session.Config{
-		Storage:    storage,
-		Expiration: time.Duration(sessionExpirationHours) * time.Hour,
+		Storage:        storage,
+		Expiration:     time.Duration(sessionConfig.ExpirationHours) * time.Hour,
+		CookieSecure:   cookieConfig.Secure,
+		CookieHTTPOnly: true,
+		CookieSameSite: cookieConfig.SameSite,
 	})
 
-	utils.SLog.Infof("Session store configured with %d hour expiration", sessionExpirationHours)
-
-	registerGobTypes()
+	utils.SLog.Infow("Session store configured",
+		"expiration_hours", sessionConfig.ExpirationHours,
+		"cookie_secure", cookieConfig.Secure,
+		"cookie_samesite", cookieConfig.SameSite,
+	)
 
 	return store
 }
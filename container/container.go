@@ -0,0 +1,113 @@
+package container
+
+import (
+	"zatrano/repositories"
+	"zatrano/services"
+
+	"gorm.io/gorm"
+)
+
+type Container struct {
+	DB *gorm.DB
+
+	UserRepository         repositories.IUserRepository
+	TeamRepository         repositories.ITeamRepository
+	AuthRepository         repositories.IAuthRepository
+	PreferenceRepository   repositories.IUserPreferenceRepository
+	SearchRepository       repositories.ISearchRepository
+	AnnouncementRepository repositories.IAnnouncementRepository
+	NotificationRepository repositories.INotificationRepository
+	ShiftRepository        repositories.IShiftRepository
+	AttendanceRepository   repositories.IAttendanceRepository
+	LeaveRepository        repositories.ILeaveRepository
+	TaskRepository         repositories.ITaskRepository
+	KPIRepository          repositories.IKPIRepository
+	ReportingRepository    repositories.IReportingRepository
+	ReportRepository       repositories.IReportRepository
+	JobRepository          repositories.IJobRepository
+	SessionRepository      repositories.ISessionRepository
+
+	UserService         services.IUserService
+	TeamService         services.ITeamService
+	AuthService         services.IAuthService
+	PreferenceService   services.IUserPreferenceService
+	SearchService       services.ISearchService
+	AnnouncementService services.IAnnouncementService
+	NotificationService services.INotificationService
+	ShiftService        services.IShiftService
+	AttendanceService   services.IAttendanceService
+	LeaveService        services.ILeaveService
+	TaskService         services.ITaskService
+	KPIService          services.IKPIService
+	ReportingService    services.IReportingService
+	ReportService       services.IReportService
+	JobService          services.IJobService
+	JobRegistry         *services.JobRegistry
+}
+
+func New(db *gorm.DB) *Container {
+	c := &Container{
+		DB: db,
+
+		UserRepository:         repositories.NewUserRepository(db),
+		TeamRepository:         repositories.NewTeamRepository(db),
+		AuthRepository:         repositories.NewAuthRepository(db),
+		PreferenceRepository:   repositories.NewUserPreferenceRepository(db),
+		SearchRepository:       repositories.NewSearchRepository(db),
+		AnnouncementRepository: repositories.NewAnnouncementRepository(db),
+		NotificationRepository: repositories.NewNotificationRepository(db),
+		ShiftRepository:        repositories.NewShiftRepository(db),
+		AttendanceRepository:   repositories.NewAttendanceRepository(db),
+		LeaveRepository:        repositories.NewLeaveRepository(db),
+		TaskRepository:         repositories.NewTaskRepository(db),
+		KPIRepository:          repositories.NewKPIRepository(db),
+		ReportingRepository:    repositories.NewReportingRepository(db),
+		ReportRepository:       repositories.NewReportRepository(db),
+		JobRepository:          repositories.NewJobRepository(db),
+		SessionRepository:      repositories.NewSessionRepository(db),
+	}
+	c.initServices()
+	return c
+}
+
+func NewInMemory() *Container {
+	store := repositories.NewMemoryStore()
+	c := &Container{
+		UserRepository:         repositories.NewMemoryUserRepository(store),
+		TeamRepository:         repositories.NewMemoryTeamRepository(store),
+		AuthRepository:         repositories.NewMemoryAuthRepository(store),
+		PreferenceRepository:   repositories.NewMemoryUserPreferenceRepository(store),
+		SearchRepository:       repositories.NewMemorySearchRepository(store),
+		AnnouncementRepository: repositories.NewMemoryAnnouncementRepository(store),
+		NotificationRepository: repositories.NewMemoryNotificationRepository(store),
+		ShiftRepository:        repositories.NewMemoryShiftRepository(store),
+		AttendanceRepository:   repositories.NewMemoryAttendanceRepository(store),
+		LeaveRepository:        repositories.NewMemoryLeaveRepository(store),
+		TaskRepository:         repositories.NewMemoryTaskRepository(store),
+		KPIRepository:          repositories.NewMemoryKPIRepository(store),
+		ReportingRepository:    repositories.NewMemoryReportingRepository(store),
+		ReportRepository:       repositories.NewMemoryReportRepository(store),
+		JobRepository:          repositories.NewMemoryJobRepository(store),
+	}
+	c.initServices()
+	return c
+}
+
+func (c *Container) initServices() {
+	c.NotificationService = services.NewNotificationService(c.NotificationRepository)
+	c.UserService = services.NewUserService(c.UserRepository, c.NotificationService)
+	c.TeamService = services.NewTeamService(c.TeamRepository)
+	c.AuthService = services.NewAuthService(c.AuthRepository)
+	c.PreferenceService = services.NewUserPreferenceService(c.PreferenceRepository)
+	c.SearchService = services.NewSearchService(c.SearchRepository)
+	c.AnnouncementService = services.NewAnnouncementService(c.AnnouncementRepository)
+	c.ShiftService = services.NewShiftService(c.ShiftRepository, c.UserRepository)
+	c.AttendanceService = services.NewAttendanceService(c.AttendanceRepository, c.ShiftRepository, c.UserRepository)
+	c.LeaveService = services.NewLeaveService(c.LeaveRepository, c.NotificationService)
+	c.TaskService = services.NewTaskService(c.TaskRepository, c.UserRepository, c.NotificationService)
+	c.KPIService = services.NewKPIService(c.KPIRepository, c.UserRepository)
+	c.ReportingService = services.NewReportingService(c.ReportingRepository)
+	c.ReportService = services.NewReportService(c.ReportRepository, c.ReportingRepository)
+	c.JobRegistry = services.NewJobRegistry()
+	c.JobService = services.NewJobService(c.JobRepository, c.JobRegistry)
+}
@@ -9,13 +9,16 @@ import (
 )
 
 func main() {
-	utils.InitLogger()
-	defer utils.SyncLogger()
+	configFile := flag.String("config", "", "YAML veya TOML yapılandırma dosyası (varsayılan: "+configs.ConfigFileEnv+")")
 	migrateFlag := flag.Bool("migrate", false, "Veritabanı başlatma işlemini çalıştır (migrasyonları içerir)")
 	seedFlag := flag.Bool("seed", false, "Veritabanı başlatma işlemini çalıştır (seederları içerir)")
 	flag.Parse()
 
-	configs.InitDB()
+	cfg := configs.MustLoad(configs.LoadOptions{File: *configFile, DotEnv: []string{".env"}})
+	utils.InitLogger(cfg.App.Env, cfg.Log.Level)
+	defer utils.SyncLogger()
+
+	configs.InitDB(cfg.Database)
 	defer configs.CloseDB()
 
 	db := configs.GetDB()
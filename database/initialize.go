@@ -61,19 +61,14 @@ func Initialize(db *gorm.DB, migrate bool, seed bool) {
 }
 
 func RunMigrationsInOrder(db *gorm.DB) error {
-	utils.SLog.Info(" -> Team migrasyonları çalıştırılıyor...")
-	if err := migrations.MigrateTeamsTable(db); err != nil {
-		utils.Log.Error("Teams tablosu migrasyonu başarısız oldu", zap.Error(err))
-		return err
-	}
-	utils.SLog.Info(" -> Team migrasyonları tamamlandı.")
-
-	utils.SLog.Info(" -> User migrasyonları çalıştırılıyor...")
-	if err := migrations.MigrateUsersTable(db); err != nil {
-		utils.Log.Error("Users tablosu migrasyonu başarısız oldu", zap.Error(err))
-		return err
+	for _, m := range migrations.All() {
+		utils.SLog.Infof(" -> %s migrasyonları çalıştırılıyor...", m.Name)
+		if err := m.Up(db); err != nil {
+			utils.Log.Error("Migrasyon başarısız oldu", zap.String("migration", m.Name), zap.Error(err))
+			return err
+		}
+		utils.SLog.Infof(" -> %s migrasyonları tamamlandı.", m.Name)
 	}
-	utils.SLog.Info(" -> User migrasyonları tamamlandı.")
 
 	utils.SLog.Info("Tüm migrasyonlar başarıyla çalıştırıldı.")
 	return nil
@@ -106,5 +101,14 @@ func CheckAndRunSeeders(db *gorm.DB) error {
 		}
 
 	}
+
+	if err := seeders.SeedKPIDefinitions(db); err != nil {
+		utils.Log.Error("Varsayılan KPI tanımları seed edilemedi", zap.Error(err))
+		return err
+	}
+	if err := seeders.SeedReportSchedules(db); err != nil {
+		utils.Log.Error("Varsayılan rapor zamanlaması seed edilemedi", zap.Error(err))
+		return err
+	}
 	return nil
 }
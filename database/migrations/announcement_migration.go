@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"zatrano/models"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func MigrateAnnouncementsTables(db *gorm.DB) error {
+	err := db.AutoMigrate(&models.Announcement{}, &models.AnnouncementReceipt{})
+	if err != nil {
+		utils.Log.Error("Failed to migrate announcements tables", zap.Error(err))
+		return err
+	}
+
+	utils.SLog.Info("Announcements tables migrated successfully")
+	return nil
+}
+
+func announcementsTablesApplied(db *gorm.DB) (bool, error) {
+	for _, model := range []interface{}{&models.Announcement{}, &models.AnnouncementReceipt{}} {
+		applied, err := modelApplied(db, model)
+		if err != nil || !applied {
+			return applied, err
+		}
+	}
+	return true, nil
+}
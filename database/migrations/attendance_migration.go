@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"zatrano/models"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func MigrateAttendanceTables(db *gorm.DB) error {
+	err := db.AutoMigrate(&models.AttendanceSession{}, &models.AttendanceBreak{})
+	if err != nil {
+		utils.Log.Error("Failed to migrate attendance tables", zap.Error(err))
+		return err
+	}
+
+	utils.SLog.Info("Attendance tables migrated successfully")
+	return nil
+}
+
+func attendanceTablesApplied(db *gorm.DB) (bool, error) {
+	for _, model := range []interface{}{&models.AttendanceSession{}, &models.AttendanceBreak{}} {
+		applied, err := modelApplied(db, model)
+		if err != nil || !applied {
+			return applied, err
+		}
+	}
+	return true, nil
+}
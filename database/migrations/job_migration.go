@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"zatrano/models"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func MigrateJobsTable(db *gorm.DB) error {
+	err := db.AutoMigrate(&models.Job{})
+	if err != nil {
+		utils.Log.Error("Failed to migrate jobs table", zap.Error(err))
+		return err
+	}
+
+	utils.SLog.Info("Jobs table migrated successfully")
+	return nil
+}
+
+func jobsTableApplied(db *gorm.DB) (bool, error) {
+	return modelApplied(db, &models.Job{})
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"zatrano/models"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func MigrateKPITables(db *gorm.DB) error {
+	err := db.AutoMigrate(&models.KPIDefinition{}, &models.KPIValue{})
+	if err != nil {
+		utils.Log.Error("Failed to migrate KPI tables", zap.Error(err))
+		return err
+	}
+
+	utils.SLog.Info("KPI tables migrated successfully")
+	return nil
+}
+
+func kpiTablesApplied(db *gorm.DB) (bool, error) {
+	for _, model := range []interface{}{&models.KPIDefinition{}, &models.KPIValue{}} {
+		applied, err := modelApplied(db, model)
+		if err != nil || !applied {
+			return applied, err
+		}
+	}
+	return true, nil
+}
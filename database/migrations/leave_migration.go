@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"zatrano/models"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func MigrateLeaveTables(db *gorm.DB) error {
+	err := db.AutoMigrate(&models.LeaveRequest{}, &models.LeaveBalance{})
+	if err != nil {
+		utils.Log.Error("Failed to migrate leave tables", zap.Error(err))
+		return err
+	}
+
+	utils.SLog.Info("Leave tables migrated successfully")
+	return nil
+}
+
+func leaveTablesApplied(db *gorm.DB) (bool, error) {
+	for _, model := range []interface{}{&models.LeaveRequest{}, &models.LeaveBalance{}} {
+		applied, err := modelApplied(db, model)
+		if err != nil || !applied {
+			return applied, err
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"zatrano/models"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func MigrateLoginEventsTable(db *gorm.DB) error {
+	err := db.AutoMigrate(&models.LoginEvent{})
+	if err != nil {
+		utils.Log.Error("Failed to migrate login_events table", zap.Error(err))
+		return err
+	}
+
+	utils.SLog.Info("Login events table migrated successfully")
+	return nil
+}
+
+func loginEventsTableApplied(db *gorm.DB) (bool, error) {
+	return modelApplied(db, &models.LoginEvent{})
+}
@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"zatrano/models"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func MigrateNotificationsTable(db *gorm.DB) error {
+	err := db.AutoMigrate(&models.Notification{})
+	if err != nil {
+		utils.Log.Error("Failed to migrate notifications table", zap.Error(err))
+		return err
+	}
+
+	utils.SLog.Info("Notifications table migrated successfully")
+	return nil
+}
+
+func notificationsTableApplied(db *gorm.DB) (bool, error) {
+	return modelApplied(db, &models.Notification{})
+}
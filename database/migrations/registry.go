@@ -0,0 +1,66 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+)
+
+type Migration struct {
+	Name    string
+	Up      func(db *gorm.DB) error
+	Applied func(db *gorm.DB) (bool, error)
+}
+
+func All() []Migration {
+	return []Migration{
+		{Name: "teams", Up: MigrateTeamsTable, Applied: teamsTableApplied},
+		{Name: "users", Up: MigrateUsersTable, Applied: usersTableApplied},
+		{Name: "user_preferences", Up: MigrateUserPreferencesTable, Applied: userPreferencesTableApplied},
+		{Name: "search_indexes", Up: MigrateSearchIndexes, Applied: searchIndexesApplied},
+		{Name: "announcements", Up: MigrateAnnouncementsTables, Applied: announcementsTablesApplied},
+		{Name: "notifications", Up: MigrateNotificationsTable, Applied: notificationsTableApplied},
+		{Name: "shifts", Up: MigrateShiftsTables, Applied: shiftsTablesApplied},
+		{Name: "attendance", Up: MigrateAttendanceTables, Applied: attendanceTablesApplied},
+		{Name: "leaves", Up: MigrateLeaveTables, Applied: leaveTablesApplied},
+		{Name: "tasks", Up: MigrateTaskTables, Applied: taskTablesApplied},
+		{Name: "kpis", Up: MigrateKPITables, Applied: kpiTablesApplied},
+		{Name: "login_events", Up: MigrateLoginEventsTable, Applied: loginEventsTableApplied},
+		{Name: "team_membership_changes", Up: MigrateTeamMembershipChangesTable, Applied: teamMembershipChangesTableApplied},
+		{Name: "reports", Up: MigrateReportTables, Applied: reportTablesApplied},
+		{Name: "jobs", Up: MigrateJobsTable, Applied: jobsTableApplied},
+	}
+}
+
+func Pending(db *gorm.DB) ([]string, error) {
+	var pending []string
+	for _, m := range All() {
+		applied, err := m.Applied(db)
+		if err != nil {
+			return nil, err
+		}
+		if !applied {
+			pending = append(pending, m.Name)
+		}
+	}
+	return pending, nil
+}
+
+func modelApplied(db *gorm.DB, model interface{}) (bool, error) {
+	migrator := db.Migrator()
+	if !migrator.HasTable(model) {
+		return false, nil
+	}
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return false, err
+	}
+	for _, field := range stmt.Schema.Fields {
+		// "-:migration" etiketli alanlar (ör. hesaplanan sütunlar) tabloda yoktur.
+		if field.DBName == "" || field.IgnoreMigration {
+			continue
+		}
+		if !migrator.HasColumn(model, field.DBName) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
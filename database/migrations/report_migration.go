@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"zatrano/models"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func MigrateReportTables(db *gorm.DB) error {
+	err := db.AutoMigrate(&models.ReportSchedule{}, &models.ReportSubscription{})
+	if err != nil {
+		utils.Log.Error("Failed to migrate report tables", zap.Error(err))
+		return err
+	}
+
+	utils.SLog.Info("Report tables migrated successfully")
+	return nil
+}
+
+func reportTablesApplied(db *gorm.DB) (bool, error) {
+	for _, model := range []interface{}{&models.ReportSchedule{}, &models.ReportSubscription{}} {
+		applied, err := modelApplied(db, model)
+		if err != nil || !applied {
+			return applied, err
+		}
+	}
+	return true, nil
+}
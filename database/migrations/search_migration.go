@@ -0,0 +1,142 @@
+package migrations
+
+import (
+	"slices"
+	"unicode"
+
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// searchFoldFunction, utils.FoldSearch'ün SQL karşılığıdır. Türkçe "I"/"İ"
+// ve utils.SearchFoldSpecial'daki harfler (büyük/küçük tüm halleriyle)
+// unaccent'ten önce elle çevrilir; unaccent bu harflerin bir kısmını
+// bırakır ve lower() ASCII dışı harfleri veritabanı locale'ine göre
+// küçültür. Latin harflerinde iki taraf aynı sonucu üretir; diğer
+// alfabelerde sonuç veritabanının LC_CTYPE ayarına bağlıdır.
+var searchFoldFunction = `CREATE OR REPLACE FUNCTION search_fold(text) RETURNS text
+	LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT
+	AS $$ SELECT lower(immutable_unaccent(` + foldSpecialSQL(`translate($1, 'İIı', 'iii')`) + `)) $$`
+
+func foldSpecialSQL(expr string) string {
+	letters := make([]rune, 0, len(utils.SearchFoldSpecial))
+	for r := range utils.SearchFoldSpecial {
+		letters = append(letters, r)
+	}
+	slices.Sort(letters)
+	for _, r := range letters {
+		variant := r
+		for {
+			expr = "replace(" + expr + ", '" + string(variant) + "', '" + utils.SearchFoldSpecial[r] + "')"
+			if variant = unicode.SimpleFold(variant); variant == r {
+				break
+			}
+		}
+	}
+	return expr
+}
+
+var searchColumns = []struct{ table, column, source string }{
+	{"users", "name_folded", "name"},
+	{"users", "account_folded", "account"},
+	{"teams", "name_folded", "name"},
+}
+
+var searchIndexes = map[string]string{
+	"idx_users_name_folded_trgm":    `CREATE INDEX IF NOT EXISTS idx_users_name_folded_trgm ON users USING gin (name_folded gin_trgm_ops)`,
+	"idx_users_account_folded_trgm": `CREATE INDEX IF NOT EXISTS idx_users_account_folded_trgm ON users USING gin (account_folded gin_trgm_ops)`,
+	"idx_teams_name_folded_trgm":    `CREATE INDEX IF NOT EXISTS idx_teams_name_folded_trgm ON teams USING gin (name_folded gin_trgm_ops)`,
+}
+
+var obsoleteSearchIndexes = []string{"idx_users_name_trgm", "idx_users_account_trgm", "idx_teams_name_trgm"}
+
+// MigrateSearchIndexes, unaccent ve pg_trgm eklentilerini etkinleştirir,
+// index ifadelerinde kullanılabilen immutable_unaccent ve search_fold
+// fonksiyonlarını, katlanmış generated sütunları ve trigram index'lerini
+// oluşturur. unaccent() STABLE olduğundan doğrudan index veya generated sütun
+// ifadesinde kullanılamaz; sözlüğü sabitleyen IMMUTABLE bir sarmalayıcı gerekir.
+func MigrateSearchIndexes(db *gorm.DB) error {
+	if db.Dialector.Name() != "postgres" {
+		utils.SLog.Info("Search indexes are only created on PostgreSQL, skipping")
+		return nil
+	}
+
+	if err := createSearchFunctions(db); err != nil {
+		return err
+	}
+
+	for _, c := range searchColumns {
+		stmt := `ALTER TABLE ` + c.table + ` ADD COLUMN IF NOT EXISTS ` + c.column +
+			` text GENERATED ALWAYS AS (search_fold(` + c.source + `)) STORED`
+		if err := db.Exec(stmt).Error; err != nil {
+			utils.Log.Error("Failed to add folded search column", zap.String("table", c.table), zap.String("column", c.column), zap.Error(err))
+			return err
+		}
+	}
+
+	for _, name := range obsoleteSearchIndexes {
+		if err := db.Exec(`DROP INDEX IF EXISTS ` + name).Error; err != nil {
+			utils.Log.Error("Failed to drop obsolete search index", zap.String("index", name), zap.Error(err))
+			return err
+		}
+	}
+	for name, stmt := range searchIndexes {
+		if err := db.Exec(stmt).Error; err != nil {
+			utils.Log.Error("Failed to create search index", zap.String("index", name), zap.Error(err))
+			return err
+		}
+	}
+
+	utils.SLog.Info("Search indexes migrated successfully")
+	return nil
+}
+
+func createSearchFunctions(db *gorm.DB) error {
+	statements := []string{
+		`CREATE EXTENSION IF NOT EXISTS unaccent`,
+		`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+		`CREATE OR REPLACE FUNCTION immutable_unaccent(text) RETURNS text
+			LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT
+			AS $$ SELECT public.unaccent('public.unaccent'::regdictionary, $1) $$`,
+		searchFoldFunction,
+	}
+	for _, stmt := range statements {
+		if err := db.Exec(stmt).Error; err != nil {
+			utils.Log.Error("Failed to prepare search extensions", zap.Error(err))
+			return err
+		}
+	}
+	utils.SLog.Debug("Checked/created unaccent, pg_trgm, immutable_unaccent and search_fold")
+	return nil
+}
+
+func searchIndexesApplied(db *gorm.DB) (bool, error) {
+	if db.Dialector.Name() != "postgres" {
+		return true, nil
+	}
+
+	var hasFunction bool
+	if err := db.Raw(`SELECT to_regprocedure('search_fold(text)') IS NOT NULL`).Scan(&hasFunction).Error; err != nil {
+		return false, err
+	}
+	if !hasFunction {
+		return false, nil
+	}
+	for _, c := range searchColumns {
+		if !db.Migrator().HasColumn(c.table, c.column) {
+			return false, nil
+		}
+	}
+
+	names := make([]string, 0, len(searchIndexes))
+	for name := range searchIndexes {
+		names = append(names, name)
+	}
+	var count int64
+	if err := db.Raw(`SELECT count(*) FROM pg_indexes WHERE indexname IN ?`, names).Scan(&count).Error; err != nil {
+		return false, err
+	}
+	return count == int64(len(searchIndexes)), nil
+}
@@ -0,0 +1,54 @@
+package migrations
+
+import (
+	"os"
+	"testing"
+
+	"zatrano/utils"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestSearchFoldMatchesFoldSearch(t *testing.T) {
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN ayarlanmamış")
+	}
+	utils.InitLogger("test", "error")
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("PostgreSQL bağlantısı kurulamadı: %v", err)
+	}
+	if err := createSearchFunctions(db); err != nil {
+		t.Fatalf("createSearchFunctions() error = %v", err)
+	}
+
+	tests := map[string]string{
+		"ISPARTA":       "isparta",
+		"İSTANBUL":      "istanbul",
+		"ışık":          "isik",
+		"ŞÇĞÖÜ şçğöü":   "scgou scgou",
+		"Crème Brûlée":  "creme brulee",
+		"Straße STRAẞE": "strasse strasse",
+		"Æsir æ":        "aesir ae",
+		"Œuvre œ":       "oeuvre oe",
+		"Øre ø":         "ore o",
+		"Łódź ł":        "lodz l",
+		"Đorđe":         "dorde",
+		"ñandú":         "nandu",
+	}
+	for input, want := range tests {
+		if got := utils.FoldSearch(input); got != want {
+			t.Errorf("FoldSearch(%q) = %q, want %q", input, got, want)
+		}
+		var got string
+		if err := db.Raw("SELECT search_fold(?)", input).Scan(&got).Error; err != nil {
+			t.Fatalf("search_fold(%q) error = %v", input, err)
+		}
+		if got != want {
+			t.Errorf("search_fold(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
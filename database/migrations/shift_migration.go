@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"zatrano/models"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func MigrateShiftsTables(db *gorm.DB) error {
+	err := db.AutoMigrate(&models.Shift{}, &models.ShiftAssignment{})
+	if err != nil {
+		utils.Log.Error("Failed to migrate shifts tables", zap.Error(err))
+		return err
+	}
+
+	utils.SLog.Info("Shifts tables migrated successfully")
+	return nil
+}
+
+func shiftsTablesApplied(db *gorm.DB) (bool, error) {
+	for _, model := range []interface{}{&models.Shift{}, &models.ShiftAssignment{}} {
+		applied, err := modelApplied(db, model)
+		if err != nil || !applied {
+			return applied, err
+		}
+	}
+	return true, nil
+}
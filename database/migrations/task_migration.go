@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"zatrano/models"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func MigrateTaskTables(db *gorm.DB) error {
+	err := db.AutoMigrate(&models.Task{}, &models.TaskComment{})
+	if err != nil {
+		utils.Log.Error("Failed to migrate task tables", zap.Error(err))
+		return err
+	}
+
+	utils.SLog.Info("Task tables migrated successfully")
+	return nil
+}
+
+func taskTablesApplied(db *gorm.DB) (bool, error) {
+	for _, model := range []interface{}{&models.Task{}, &models.TaskComment{}} {
+		applied, err := modelApplied(db, model)
+		if err != nil || !applied {
+			return applied, err
+		}
+	}
+	return true, nil
+}
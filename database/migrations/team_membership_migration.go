@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"zatrano/models"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func MigrateTeamMembershipChangesTable(db *gorm.DB) error {
+	err := db.AutoMigrate(&models.TeamMembershipChange{})
+	if err != nil {
+		utils.Log.Error("Failed to migrate team_membership_changes table", zap.Error(err))
+		return err
+	}
+
+	utils.SLog.Info("Team membership changes table migrated successfully")
+	return nil
+}
+
+func teamMembershipChangesTableApplied(db *gorm.DB) (bool, error) {
+	return modelApplied(db, &models.TeamMembershipChange{})
+}
@@ -18,3 +18,7 @@ func MigrateTeamsTable(db *gorm.DB) error {
 	utils.SLog.Info("Teams table migrated successfully")
 	return nil
 }
+
+func teamsTableApplied(db *gorm.DB) (bool, error) {
+	return modelApplied(db, &models.Team{})
+}
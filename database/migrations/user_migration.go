@@ -54,3 +54,11 @@ func MigrateUsersTable(db *gorm.DB) error {
 
 	return nil
 }
+
+func usersTableApplied(db *gorm.DB) (bool, error) {
+	applied, err := modelApplied(db, &models.User{})
+	if err != nil || !applied {
+		return applied, err
+	}
+	return db.Migrator().HasConstraint(&models.User{}, "fk_users_team"), nil
+}
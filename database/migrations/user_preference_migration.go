@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"zatrano/models"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func MigrateUserPreferencesTable(db *gorm.DB) error {
+	err := db.AutoMigrate(&models.UserPreference{})
+	if err != nil {
+		utils.Log.Error("Failed to migrate user_preferences table", zap.Error(err))
+		return err
+	}
+
+	utils.SLog.Info("User preferences table migrated successfully")
+	return nil
+}
+
+func userPreferencesTableApplied(db *gorm.DB) (bool, error) {
+	return modelApplied(db, &models.UserPreference{})
+}
@@ -0,0 +1,36 @@
+package seeders
+
+import (
+	"zatrano/models"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func DefaultKPIDefinitions() []models.KPIDefinition {
+	return []models.KPIDefinition{
+		{Key: "calls_handled", Name: "Karşılanan çağrı", Unit: models.KPIUnitCount, Aggregation: models.KPIAggregationSum, HigherIsBetter: true, Status: true},
+		{Key: "avg_handle_time", Name: "Ortalama görüşme süresi", Unit: models.KPIUnitSeconds, Aggregation: models.KPIAggregationAvg, HigherIsBetter: false, Status: true},
+		{Key: "quality_score", Name: "Kalite puanı", Unit: models.KPIUnitPercent, Aggregation: models.KPIAggregationAvg, HigherIsBetter: true, Status: true},
+	}
+}
+
+func SeedKPIDefinitions(db *gorm.DB) error {
+	for _, definition := range DefaultKPIDefinitions() {
+		var count int64
+		if err := db.Model(&models.KPIDefinition{}).Where(&models.KPIDefinition{Key: definition.Key}).Count(&count).Error; err != nil {
+			utils.Log.Error("KPI tanımı kontrol edilirken veritabanı hatası", zap.String("key", definition.Key), zap.Error(err))
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+		if err := db.Create(&definition).Error; err != nil {
+			utils.Log.Error("KPI tanımı oluşturulamadı", zap.String("key", definition.Key), zap.Error(err))
+			return err
+		}
+		utils.SLog.Infof("KPI tanımı oluşturuldu: %s", definition.Key)
+	}
+	return nil
+}
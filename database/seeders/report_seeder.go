@@ -0,0 +1,46 @@
+package seeders
+
+import (
+	"time"
+
+	"zatrano/models"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func DefaultReportSchedule() models.ReportSchedule {
+	return models.ReportSchedule{
+		Name:     "Haftalık özet",
+		Template: models.ReportTemplateWeeklySummary,
+		Cron:     "0 8 * * 1",
+		Timezone: utils.DefaultTimezone,
+		Status:   true,
+	}
+}
+
+func SeedReportSchedules(db *gorm.DB) error {
+	schedule := DefaultReportSchedule()
+	var count int64
+	if err := db.Model(&models.ReportSchedule{}).Where("name = ?", schedule.Name).Count(&count).Error; err != nil {
+		utils.Log.Error("Rapor zamanlaması kontrol edilirken veritabanı hatası", zap.String("name", schedule.Name), zap.Error(err))
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	cron, err := utils.ParseCron(schedule.Cron)
+	if err != nil {
+		return err
+	}
+	next := cron.Next(time.Now().UTC())
+	schedule.NextRunAt = &next
+	if err := db.Create(&schedule).Error; err != nil {
+		utils.Log.Error("Rapor zamanlaması oluşturulamadı", zap.String("name", schedule.Name), zap.Error(err))
+		return err
+	}
+	utils.SLog.Infof("Rapor zamanlaması oluşturuldu: %s", schedule.Name)
+	return nil
+}
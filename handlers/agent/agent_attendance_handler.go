@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"context"
+
+	"zatrano/i18n"
+	"zatrano/models"
+	"zatrano/services"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type AttendanceHandler struct {
+	service services.IAttendanceService
+}
+
+func NewAttendanceHandler(service services.IAttendanceService) *AttendanceHandler {
+	return &AttendanceHandler{service: service}
+}
+
+func (h *AttendanceHandler) record(c *fiber.Ctx, successKey, fallbackKey string, action func(ctx context.Context, agent *models.User) error) error {
+	agent, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	if err := action(c.UserContext(), agent); err != nil {
+		utils.LogFrom(c.UserContext()).Warn("Devam işlemi başarısız", zap.String("path", c.Path()), zap.Error(err))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, i18n.CodeOf(err, fallbackKey))
+		return c.Redirect("/agent/home", fiber.StatusSeeOther)
+	}
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, successKey)
+	return c.Redirect("/agent/home", fiber.StatusFound)
+}
+
+func (h *AttendanceHandler) ClockIn(c *fiber.Ctx) error {
+	return h.record(c, "attendance.clock_in.success", "errors.attendance.clock_in_failed", func(ctx context.Context, agent *models.User) error {
+		return h.service.ClockIn(ctx, agent, c.IP())
+	})
+}
+
+func (h *AttendanceHandler) ClockOut(c *fiber.Ctx) error {
+	return h.record(c, "attendance.clock_out.success", "errors.attendance.clock_out_failed", func(ctx context.Context, agent *models.User) error {
+		return h.service.ClockOut(ctx, agent, c.IP())
+	})
+}
+
+func (h *AttendanceHandler) StartBreak(c *fiber.Ctx) error {
+	return h.record(c, "attendance.break_start.success", "errors.attendance.break_failed", h.service.StartBreak)
+}
+
+func (h *AttendanceHandler) EndBreak(c *fiber.Ctx) error {
+	return h.record(c, "attendance.break_end.success", "errors.attendance.break_failed", h.service.EndBreak)
+}
@@ -1,11 +1,94 @@
 package handlers
 
 import (
+	"time"
+
+	"zatrano/i18n"
+	"zatrano/models"
+	"zatrano/services"
+	"zatrano/utils"
+
 	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
 )
 
-func AgentHomeHandler(c *fiber.Ctx) error {
-	return c.Render("agent/home/agent_home", fiber.Map{
-		"Title": "Aracı Ana Sayfa",
-	}, "layouts/agent_layout")
+type HomeHandler struct {
+	announcementService services.IAnnouncementService
+	shiftService        services.IShiftService
+	attendanceService   services.IAttendanceService
+	taskService         services.ITaskService
+}
+
+func NewHomeHandler(announcementService services.IAnnouncementService, shiftService services.IShiftService, attendanceService services.IAttendanceService, taskService services.ITaskService) *HomeHandler {
+	return &HomeHandler{announcementService: announcementService, shiftService: shiftService, attendanceService: attendanceService, taskService: taskService}
+}
+
+func (h *HomeHandler) HomePage(c *fiber.Ctx) error {
+	agent, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	flashData, flashErr := utils.GetFlashMessages(c)
+	if flashErr != nil {
+		utils.LogFrom(c.UserContext()).Warn("Ajan anasayfa: Flash mesajları alınamadı", zap.Error(flashErr))
+	}
+
+	announcements, err := h.announcementService.PendingForUser(c.UserContext(), agent)
+	renderData := fiber.Map{
+		"Title":         utils.T(c, "agent.home.title"),
+		"CsrfToken":     c.Locals("csrf"),
+		"Announcements": announcements,
+		"Success":       flashData.Success,
+		"Error":         flashData.Error,
+	}
+	if err != nil {
+		utils.LogFrom(c.UserContext()).Error("Ajan anasayfa: Duyurular alınamadı", zap.Error(err))
+		renderData["Error"] = utils.T(c, "announcements.list.load_failed")
+	}
+
+	prefs := utils.Prefs(c)
+	attendance, err := h.attendanceService.Status(c.UserContext(), agent, prefs.Location, time.Now())
+	renderData["Attendance"] = attendance
+	if err != nil {
+		utils.LogFrom(c.UserContext()).Error("Ajan anasayfa: Devam durumu alınamadı", zap.Error(err))
+		renderData["Error"] = utils.T(c, "attendance.status.load_failed")
+	}
+
+	today := models.CivilDate(prefs.Now())
+	shifts, err := h.shiftService.UpcomingShifts(c.UserContext(), agent, today)
+	renderData["Shifts"] = shifts
+	renderData["Today"] = today
+	if err != nil {
+		utils.LogFrom(c.UserContext()).Error("Ajan anasayfa: Vardiyalar alınamadı", zap.Error(err))
+		renderData["Error"] = utils.T(c, "shifts.upcoming.load_failed")
+	}
+
+	tasks, err := h.taskService.AgentTasks(c.UserContext(), agent)
+	renderData["Tasks"] = tasks
+	if err != nil {
+		utils.LogFrom(c.UserContext()).Error("Ajan anasayfa: Görevler alınamadı", zap.Error(err))
+		renderData["Error"] = utils.T(c, "tasks.list.load_failed")
+	}
+	return c.Render("agent/home/agent_home", renderData, "layouts/agent_layout")
+}
+
+func (h *HomeHandler) AcknowledgeAnnouncement(c *fiber.Ctx) error {
+	agent, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "announcements.invalid_id")
+		return c.Redirect("/agent/home", fiber.StatusSeeOther)
+	}
+
+	if err := h.announcementService.Acknowledge(c.UserContext(), agent, uint(id)); err != nil {
+		utils.LogFrom(c.UserContext()).Warn("Duyuru onaylanamadı", zap.Int("announcement_id", id), zap.Error(err))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, i18n.CodeOf(err, "errors.announcement.acknowledge_failed"))
+		return c.Redirect("/agent/home", fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "announcements.acknowledge.success")
+	return c.Redirect("/agent/home", fiber.StatusFound)
 }
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"zatrano/services"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type KPIHandler struct {
+	service services.IKPIService
+}
+
+func NewKPIHandler(service services.IKPIService) *KPIHandler {
+	return &KPIHandler{service: service}
+}
+
+func (h *KPIHandler) ShowScorecard(c *fiber.Ctx) error {
+	agent, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+
+	weeks := c.QueryInt("weeks", services.KPIScorecardDefaultWeeks)
+	scorecard, err := h.service.AgentScorecard(c.UserContext(), agent, utils.Prefs(c).Now(), weeks)
+	if err != nil {
+		utils.LogFrom(c.UserContext()).Error("Ajan karnesi alınamadı", zap.Uint("user_id", agent.ID), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).Render("agent/kpis/agent_kpis_scorecard", fiber.Map{
+			"Title": utils.T(c, "kpis.scorecard.agent_title"),
+			"Error": utils.T(c, "kpis.scorecard.load_failed"),
+		}, "layouts/agent_layout")
+	}
+
+	return c.Render("agent/kpis/agent_kpis_scorecard", fiber.Map{
+		"Title":       utils.T(c, "kpis.scorecard.agent_title"),
+		"Scorecard":   scorecard,
+		"Weeks":       len(scorecard.Weeks),
+		"WeekOptions": services.KPIScorecardWeekOptions,
+	}, "layouts/agent_layout")
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+
+	"zatrano/i18n"
+	"zatrano/models"
+	"zatrano/services"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type LeaveHandler struct {
+	service services.ILeaveService
+}
+
+func NewLeaveHandler(service services.ILeaveService) *LeaveHandler {
+	return &LeaveHandler{service: service}
+}
+
+type leaveForm struct {
+	Type      string `form:"type"`
+	StartDate string `form:"start_date"`
+	EndDate   string `form:"end_date"`
+	Reason    string `form:"reason"`
+}
+
+func (h *LeaveHandler) ShowLeaves(c *fiber.Ctx) error {
+	agent, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	flashData, flashErr := utils.GetFlashMessages(c)
+	if flashErr != nil {
+		utils.LogFrom(c.UserContext()).Warn("İzinler: Flash mesajları alınamadı", zap.Error(flashErr))
+	}
+
+	today := models.CivilDate(utils.Prefs(c).Now())
+	year := today.Year()
+	if y, err := strconv.Atoi(c.Query("year")); err == nil && y > 0 {
+		year = y
+	}
+	overview, err := h.service.Overview(c.UserContext(), agent, year)
+	renderData := fiber.Map{
+		"Title":      utils.T(c, "leaves.agent.title"),
+		"CsrfToken":  c.Locals("csrf"),
+		"Overview":   overview,
+		"Year":       year,
+		"LeaveTypes": models.LeaveTypes,
+		"FormData":   leaveForm{Type: string(models.LeaveAnnual), StartDate: today.Format(utils.DateInputLayout), EndDate: today.Format(utils.DateInputLayout)},
+		"Success":    flashData.Success,
+		"Error":      flashData.Error,
+	}
+	if err != nil {
+		utils.LogFrom(c.UserContext()).Error("İzin özeti alınamadı", zap.Error(err))
+		renderData["Error"] = utils.T(c, "leaves.agent.load_failed")
+	}
+	return c.Render("agent/leaves/agent_leaves", renderData, "layouts/agent_layout")
+}
+
+func (h *LeaveHandler) RequestLeave(c *fiber.Ctx) error {
+	agent, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	var req leaveForm
+	if err := c.BodyParser(&req); err != nil {
+		utils.SLogFrom(c.UserContext()).Warnf("İzin talebi isteği ayrıştırılamadı: %v", err)
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "form.invalid")
+		return c.Redirect("/agent/leaves", fiber.StatusSeeOther)
+	}
+
+	startDate, startErr := time.Parse(utils.DateInputLayout, req.StartDate)
+	endDate, endErr := time.Parse(utils.DateInputLayout, req.EndDate)
+	if startErr != nil || endErr != nil {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "errors.leave.invalid_date_range")
+		return c.Redirect("/agent/leaves", fiber.StatusSeeOther)
+	}
+
+	request := models.LeaveRequest{Type: models.LeaveType(req.Type), StartDate: startDate, EndDate: endDate, Reason: req.Reason}
+	if err := h.service.RequestLeave(c.UserContext(), agent, &request); err != nil {
+		utils.LogFrom(c.UserContext()).Warn("İzin talebi oluşturulamadı", zap.Uint("user_id", agent.ID), zap.Error(err))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, i18n.CodeOf(err, "errors.leave.creation_failed"))
+		return c.Redirect("/agent/leaves", fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "leaves.request.success")
+	return c.Redirect("/agent/leaves", fiber.StatusFound)
+}
+
+func (h *LeaveHandler) CancelLeave(c *fiber.Ctx) error {
+	agent, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "errors.leave.not_found")
+		return c.Redirect("/agent/leaves", fiber.StatusSeeOther)
+	}
+
+	if err := h.service.CancelLeave(c.UserContext(), agent, uint(id)); err != nil {
+		utils.LogFrom(c.UserContext()).Warn("İzin talebi geri çekilemedi", zap.Int("leave_id", id), zap.Error(err))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, i18n.CodeOf(err, "errors.leave.update_failed"))
+		return c.Redirect("/agent/leaves", fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "leaves.cancel.success")
+	return c.Redirect("/agent/leaves", fiber.StatusFound)
+}
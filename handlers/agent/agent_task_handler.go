@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"strconv"
+
+	"zatrano/i18n"
+	"zatrano/models"
+	"zatrano/services"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type TaskHandler struct {
+	service services.ITaskService
+}
+
+func NewTaskHandler(service services.ITaskService) *TaskHandler {
+	return &TaskHandler{service: service}
+}
+
+func taskURL(id int) string {
+	return "/agent/tasks/" + strconv.Itoa(id)
+}
+
+func (h *TaskHandler) ShowTask(c *fiber.Ctx) error {
+	agent, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "errors.task.not_found")
+		return c.Redirect("/agent/home", fiber.StatusSeeOther)
+	}
+	flashData, flashErr := utils.GetFlashMessages(c)
+	if flashErr != nil {
+		utils.LogFrom(c.UserContext()).Warn("Görev ayrıntısı: Flash mesajları alınamadı", zap.Error(flashErr))
+	}
+
+	detail, err := h.service.GetTask(c.UserContext(), agent, uint(id))
+	if err != nil {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, i18n.CodeOf(err, "errors.task.not_found"))
+		return c.Redirect("/agent/home", fiber.StatusSeeOther)
+	}
+
+	return c.Render("agent/tasks/agent_task_detail", fiber.Map{
+		"Title":     detail.Task.Title,
+		"CsrfToken": c.Locals("csrf"),
+		"Detail":    detail,
+		"Today":     models.CivilDate(utils.Prefs(c).Now()),
+		"Success":   flashData.Success,
+		"Error":     flashData.Error,
+	}, "layouts/agent_layout")
+}
+
+func (h *TaskHandler) ChangeStatus(c *fiber.Ctx) error {
+	agent, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "errors.task.not_found")
+		return c.Redirect("/agent/home", fiber.StatusSeeOther)
+	}
+
+	status := models.TaskStatus(c.FormValue("status"))
+	if err := h.service.ChangeStatus(c.UserContext(), agent, uint(id), status); err != nil {
+		utils.LogFrom(c.UserContext()).Warn("Görev durumu değiştirilemedi", zap.Int("task_id", id), zap.String("status", string(status)), zap.Error(err))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, i18n.CodeOf(err, "errors.task.update_failed"))
+		return c.Redirect(taskURL(id), fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "tasks.status_changed")
+	return c.Redirect(taskURL(id), fiber.StatusFound)
+}
+
+func (h *TaskHandler) AddComment(c *fiber.Ctx) error {
+	agent, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "errors.task.not_found")
+		return c.Redirect("/agent/home", fiber.StatusSeeOther)
+	}
+
+	if err := h.service.AddComment(c.UserContext(), agent, uint(id), c.FormValue("body")); err != nil {
+		utils.LogFrom(c.UserContext()).Warn("Görev yorumu eklenemedi", zap.Int("task_id", id), zap.Error(err))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, i18n.CodeOf(err, "errors.task.comment_failed"))
+		return c.Redirect(taskURL(id), fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "tasks.comments.added")
+	return c.Redirect(taskURL(id), fiber.StatusFound)
+}
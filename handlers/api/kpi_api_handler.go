@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"strconv"
+
+	"zatrano/services"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type KPIHandler struct {
+	service services.IKPIService
+}
+
+func NewKPIHandler(service services.IKPIService) *KPIHandler {
+	return &KPIHandler{service: service}
+}
+
+type kpiValueItem struct {
+	Metric  string   `json:"metric"`
+	Account string   `json:"account"`
+	Date    string   `json:"date"`
+	Value   *float64 `json:"value"`
+}
+
+type kpiValuesRequest struct {
+	Values []kpiValueItem `json:"values"`
+}
+
+type kpiRowErrorItem struct {
+	Line    int    `json:"line"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (h *KPIHandler) IngestValues(c *fiber.Ctx) error {
+	var req kpiValuesRequest
+	if err := c.BodyParser(&req); err != nil {
+		utils.SLogFrom(c.UserContext()).Warnf("KPI API isteği ayrıştırılamadı: %v", err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": utils.T(c, "form.invalid")})
+	}
+
+	inputs := make([]services.KPIInput, len(req.Values))
+	for i, item := range req.Values {
+		inputs[i] = services.KPIInput{Line: i + 1, Metric: item.Metric, Account: item.Account, Date: item.Date}
+		if item.Value != nil {
+			inputs[i].Value = strconv.FormatFloat(*item.Value, 'f', -1, 64)
+		}
+	}
+
+	result, err := h.service.Ingest(c.UserContext(), inputs)
+	if err == services.ErrKPIImportInvalidRows {
+		items := make([]kpiRowErrorItem, len(result.Errors))
+		for i, e := range result.Errors {
+			items[i] = kpiRowErrorItem{Line: e.Line, Code: e.Code, Message: e.Message(utils.Locale(c))}
+		}
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"error": utils.TError(c, err), "rows": items})
+	}
+	if err != nil {
+		utils.LogFrom(c.UserContext()).Warn("KPI değerleri API ile yüklenemedi", zap.Int("count", len(inputs)), zap.Error(err))
+		status := fiber.StatusBadRequest
+		if err == services.ErrKPIImportFailed {
+			status = fiber.StatusInternalServerError
+		}
+		return c.Status(status).JSON(fiber.Map{"error": utils.TError(c, err)})
+	}
+	return c.JSON(fiber.Map{"imported": result.Imported})
+}
@@ -1,6 +1,11 @@
 package handlers
 
 import (
+	"strconv"
+	"time"
+
+	"zatrano/i18n"
+	"zatrano/metrics"
 	"zatrano/models"
 	"zatrano/services"
 	"zatrano/utils"
@@ -10,21 +15,23 @@ import (
 )
 
 type AuthHandler struct {
-	service services.IAuthService
+	service     services.IAuthService
+	preferences services.IUserPreferenceService
+	reports     services.IReportService
 }
 
-func NewAuthHandler() *AuthHandler {
-	return &AuthHandler{service: services.NewAuthService()}
+func NewAuthHandler(service services.IAuthService, preferences services.IUserPreferenceService, reports services.IReportService) *AuthHandler {
+	return &AuthHandler{service: service, preferences: preferences, reports: reports}
 }
 
 func (h *AuthHandler) ShowLogin(c *fiber.Ctx) error {
 	flashData, err := utils.GetFlashMessages(c)
 	if err != nil {
-		utils.Log.Warn("Giriş sayfası: Flash mesajları alınamadı", zap.Error(err))
+		utils.LogFrom(c.UserContext()).Warn("Giriş sayfası: Flash mesajları alınamadı", zap.Error(err))
 	}
 
 	return c.Render("auth/auth_login", fiber.Map{
-		"Title":     "Giriş",
+		"Title":     utils.T(c, "auth.login.title"),
 		"CsrfToken": c.Locals("csrf"),
 		"Success":   flashData.Success,
 		"Error":     flashData.Error,
@@ -38,95 +45,114 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 	}
 
 	if err := c.BodyParser(&request); err != nil {
-		utils.SLog.Warnf("Login isteği ayrıştırılamadı: %v", err)
-		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Lütfen hesap adı ve şifre alanlarını doldurun.")
+		utils.SLogFrom(c.UserContext()).Warnf("Login isteği ayrıştırılamadı: %v", err)
+		metrics.ObserveLogin(metrics.LoginResultFailure, metrics.LoginReasonMissingFields)
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "auth.login.missing_fields")
 		return c.Redirect("/auth/login", fiber.StatusSeeOther)
 	}
 
 	if request.Account == "" || request.Password == "" {
-		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Lütfen hesap adı ve şifre alanlarını doldurun.")
+		metrics.ObserveLogin(metrics.LoginResultFailure, metrics.LoginReasonMissingFields)
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "auth.login.missing_fields")
 		return c.Redirect("/auth/login", fiber.StatusSeeOther)
 	}
 
-	user, err := h.service.Authenticate(request.Account, request.Password)
+	user, err := h.service.Authenticate(c.UserContext(), request.Account, request.Password)
 	if err != nil {
-		var errMsg string
+		var errMsg, reason string
 		switch err {
 		case services.ErrInvalidCredentials:
-			errMsg = "Kullanıcı adı veya şifre hatalı."
+			errMsg = "auth.login.invalid_credentials"
+			reason = metrics.LoginReasonInvalidCredentials
 		case services.ErrUserInactive:
-			errMsg = "Hesabınız aktif değil. Lütfen yöneticinizle iletişime geçin."
+			errMsg = "auth.login.inactive"
+			reason = metrics.LoginReasonUserInactive
 		default:
-			errMsg = "Giriş işlemi sırasında bir sorun oluştu. Lütfen tekrar deneyin."
-			utils.Log.Error("Kimlik doğrulama servisinde beklenmeyen hata",
+			errMsg = "auth.login.failed"
+			reason = metrics.LoginReasonError
+			utils.LogFrom(c.UserContext()).Error("Kimlik doğrulama servisinde beklenmeyen hata",
 				zap.String("account", request.Account),
 				zap.Error(err),
 			)
 		}
+		metrics.ObserveLogin(metrics.LoginResultFailure, reason)
 		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, errMsg)
 		return c.Redirect("/auth/login", fiber.StatusSeeOther)
 	}
 
 	sess, sessionErr := utils.SessionStart(c)
 	if sessionErr != nil {
-		utils.Log.Error("Oturum başlatılamadı (Login)",
+		utils.LogFrom(c.UserContext()).Error("Oturum başlatılamadı (Login)",
 			zap.Uint("user_id", user.ID),
 			zap.String("account", user.Account),
 			zap.Error(sessionErr),
 		)
-		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Oturum başlatılamadı. Lütfen tekrar deneyin.")
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "auth.session.start_failed")
 		return c.Redirect("/auth/login", fiber.StatusSeeOther)
 	}
 
 	sess.Set("user_id", user.ID)
-	sess.Set("user_type", string(user.Type))
+	sess.Set("user_type", user.Type)
 	sess.Set("user_status", user.Status)
 	sess.Set("user_name", user.Name)
 
+	pref, prefErr := h.preferences.GetPreferences(c.UserContext(), user.ID)
+	if prefErr != nil {
+		utils.LogFrom(c.UserContext()).Warn("Kullanıcı tercihleri alınamadı, varsayılanlar kullanılıyor (Login)",
+			zap.Uint("user_id", user.ID),
+			zap.Error(prefErr),
+		)
+		pref = services.DefaultUserPreference(user.ID)
+	}
+	utils.StorePreferencesInSession(sess, pref)
+
 	if saveErr := sess.Save(); saveErr != nil {
-		utils.Log.Error("Oturum kaydedilemedi (Login)",
+		utils.LogFrom(c.UserContext()).Error("Oturum kaydedilemedi (Login)",
 			zap.Uint("user_id", user.ID),
 			zap.String("account", user.Account),
 			zap.Error(saveErr),
 		)
-		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Oturum bilgileri kaydedilemedi.")
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "auth.session.save_failed")
 		return c.Redirect("/auth/login", fiber.StatusSeeOther)
 	}
 
 	var redirectURL string
 	switch user.Type {
-	case models.Panel:
-		redirectURL = "/panel/home"
+	case models.Manager:
+		redirectURL = "/manager/home"
+	case models.Agent:
+		redirectURL = "/agent/home"
 	case models.System:
 		redirectURL = "/dashboard/home"
 	default:
-		utils.Log.Error("Geçersiz kullanıcı tipi (Login sonrası yönlendirme)",
+		utils.LogFrom(c.UserContext()).Error("Geçersiz kullanıcı tipi (Login sonrası yönlendirme)",
 			zap.Uint("user_id", user.ID),
 			zap.String("account", user.Account),
 			zap.String("type", string(user.Type)),
 		)
 		_ = sess.Destroy()
-		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Hesabınız için tanımlanmış bir rol bulunamadı.")
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "auth.login.no_role")
 		return c.Redirect("/auth/login", fiber.StatusSeeOther)
 	}
 
-	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Başarıyla giriş yapıldı.")
+	metrics.ObserveLogin(metrics.LoginResultSuccess, metrics.LoginReasonNone)
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "auth.login.success")
 	return c.Redirect(redirectURL, fiber.StatusFound)
 }
 
 func (h *AuthHandler) Profile(c *fiber.Ctx) error {
 	flashData, flashErr := utils.GetFlashMessages(c)
 	if flashErr != nil {
-		utils.Log.Warn("Profil: Flash mesajları alınamadı", zap.Error(flashErr))
+		utils.LogFrom(c.UserContext()).Warn("Profil: Flash mesajları alınamadı", zap.Error(flashErr))
 	}
 
 	userID, ok := c.Locals("userID").(uint)
 	if !ok {
-		utils.SLog.Debug("Profil: UserID locals'ta bulunamadı, session kontrol ediliyor.")
+		utils.SLogFrom(c.UserContext()).Debug("Profil: UserID locals'ta bulunamadı, session kontrol ediliyor.")
 		sess, sessionErr := utils.SessionStart(c)
 		if sessionErr != nil {
-			utils.Log.Error("Profil: Oturum başlatılamadı (locals'ta ID yok)", zap.Error(sessionErr))
-			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Oturum hatası, lütfen tekrar giriş yapın.")
+			utils.LogFrom(c.UserContext()).Error("Profil: Oturum başlatılamadı (locals'ta ID yok)", zap.Error(sessionErr))
+			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "auth.session.error")
 			return c.Redirect("/auth/login", fiber.StatusSeeOther)
 		}
 		userIDValue := sess.Get("user_id")
@@ -144,69 +170,156 @@ func (h *AuthHandler) Profile(c *fiber.Ctx) error {
 			ok = false
 		}
 		if !ok {
-			utils.Log.Warn("Profil: Session'da geçersiz veya eksik user_id", zap.Any("value", userIDValue))
+			utils.LogFrom(c.UserContext()).Warn("Profil: Session'da geçersiz veya eksik user_id", zap.Any("value", userIDValue))
 			_ = sess.Destroy()
-			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz oturum bilgisi, lütfen tekrar giriş yapın.")
+			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "auth.session.invalid")
 			return c.Redirect("/auth/login", fiber.StatusSeeOther)
 		}
-		utils.SLog.Debugf("Profil: UserID session'dan alındı: %d", userID)
+		utils.SLogFrom(c.UserContext()).Debugf("Profil: UserID session'dan alındı: %d", userID)
 	}
 
-	user, err := h.service.GetUserProfile(userID)
+	user, err := h.service.GetUserProfile(c.UserContext(), userID)
 	if err != nil {
 		var errMsg string
 		if err == services.ErrUserNotFound {
-			errMsg = "Profil bilgileri bulunamadı, lütfen tekrar giriş yapın."
-			utils.Log.Warn("Profil: Kullanıcı bulunamadı", zap.Uint("user_id", userID))
+			errMsg = "auth.profile.not_found"
+			utils.LogFrom(c.UserContext()).Warn("Profil: Kullanıcı bulunamadı", zap.Uint("user_id", userID))
 			sess, _ := utils.SessionStart(c)
 			if sess != nil {
 				_ = sess.Destroy()
 			}
 		} else {
-			errMsg = "Profil bilgileri alınırken bir hata oluştu."
-			utils.Log.Error("Profil: Kullanıcı profili alınırken hata", zap.Uint("user_id", userID), zap.Error(err))
+			errMsg = "auth.profile.load_failed"
+			utils.LogFrom(c.UserContext()).Error("Profil: Kullanıcı profili alınırken hata", zap.Uint("user_id", userID), zap.Error(err))
 		}
 		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, errMsg)
 		return c.Redirect("/auth/login", fiber.StatusSeeOther)
 	}
 
+	pref, prefErr := h.preferences.GetPreferences(c.UserContext(), userID)
+	if prefErr != nil {
+		utils.LogFrom(c.UserContext()).Warn("Profil: Kullanıcı tercihleri alınamadı, varsayılanlar gösteriliyor", zap.Uint("user_id", userID), zap.Error(prefErr))
+		pref = services.DefaultUserPreference(userID)
+	}
+
+	reportOptions, reportErr := h.reports.SubscriptionOptions(c.UserContext(), user)
+	if reportErr != nil {
+		utils.LogFrom(c.UserContext()).Warn("Profil: Rapor abonelikleri alınamadı", zap.Uint("user_id", userID), zap.Error(reportErr))
+	}
+
 	return c.Render("auth/auth_profile", fiber.Map{
-		"Title":     "Profilim",
-		"User":      user,
-		"CsrfToken": c.Locals("csrf"),
-		"Success":   flashData.Success,
-		"Error":     flashData.Error,
+		"Title":         utils.T(c, "auth.profile.title"),
+		"User":          user,
+		"Preference":    pref,
+		"ReportOptions": reportOptions,
+		"Timezones":     utils.CommonTimezones,
+		"ExampleDate":   time.Now(),
+		"CsrfToken":     c.Locals("csrf"),
+		"Success":       flashData.Success,
+		"Error":         flashData.Error,
 	}, "layouts/auth_layout")
 }
 
 func (h *AuthHandler) Logout(c *fiber.Ctx) error {
 	sess, err := utils.SessionStart(c)
 	if err != nil {
-		utils.Log.Warn("Çıkış: Oturum başlatılamadı (muhtemelen zaten yok)", zap.Error(err))
-		_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Çıkış yapıldı.")
+		utils.LogFrom(c.UserContext()).Warn("Çıkış: Oturum başlatılamadı (muhtemelen zaten yok)", zap.Error(err))
+		_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "auth.logout.done")
 		return c.Redirect("/auth/login", fiber.StatusFound)
 	}
 
-	flashMsg := "Başarıyla çıkış yapıldı."
+	flashMsg := "auth.logout.success"
 	if destroyErr := sess.Destroy(); destroyErr != nil {
-		utils.Log.Error("Çıkış: Oturum yok edilemedi", zap.Error(destroyErr))
-		flashMsg = "Çıkış yapıldı (ancak oturum temizlenirken bir sorun oluştu)."
+		utils.LogFrom(c.UserContext()).Error("Çıkış: Oturum yok edilemedi", zap.Error(destroyErr))
+		flashMsg = "auth.logout.partial"
 	}
 
 	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, flashMsg)
 	return c.Redirect("/auth/login", fiber.StatusFound)
 }
 
+func (h *AuthHandler) UpdatePreferences(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		utils.LogFrom(c.UserContext()).Warn("Tercih Güncelleme: Locals'ta geçersiz veya eksik user_id", zap.Any("value", c.Locals("userID")))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "auth.session.invalid")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	var request struct {
+		Locale     string `form:"locale"`
+		Timezone   string `form:"timezone"`
+		DateFormat string `form:"date_format"`
+		PerPage    int    `form:"per_page"`
+	}
+	if err := c.BodyParser(&request); err != nil {
+		utils.SLogFrom(c.UserContext()).Warnf("Tercih güncelleme isteği ayrıştırılamadı: %v", err)
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "auth.preferences.update_failed")
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+
+	pref := &models.UserPreference{
+		Locale:     request.Locale,
+		Timezone:   request.Timezone,
+		DateFormat: request.DateFormat,
+		PerPage:    request.PerPage,
+	}
+	if err := h.preferences.UpdatePreferences(c.UserContext(), userID, pref); err != nil {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, i18n.CodeOf(err, "auth.preferences.update_failed"))
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+
+	sess, sessionErr := utils.SessionStart(c)
+	if sessionErr != nil {
+		utils.LogFrom(c.UserContext()).Error("Tercihler kaydedildi ancak oturum alınamadı", zap.Uint("user_id", userID), zap.Error(sessionErr))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "auth.session.error")
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+	utils.StorePreferencesInSession(sess, pref)
+	if saveErr := sess.Save(); saveErr != nil {
+		utils.LogFrom(c.UserContext()).Error("Tercihler kaydedildi ancak oturum güncellenemedi", zap.Uint("user_id", userID), zap.Error(saveErr))
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "auth.preferences.updated")
+	return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+}
+
+func (h *AuthHandler) UpdateReportSubscriptions(c *fiber.Ctx) error {
+	user, ok := utils.CurrentUser(c)
+	if !ok {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "auth.session.invalid")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	var scheduleIDs []uint
+	for _, value := range c.Request().PostArgs().PeekMulti("schedule_ids") {
+		id, err := strconv.ParseUint(string(value), 10, 32)
+		if err != nil || id == 0 {
+			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "errors.report.unknown_subscription")
+			return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+		}
+		scheduleIDs = append(scheduleIDs, uint(id))
+	}
+
+	if err := h.reports.UpdateSubscriptions(c.UserContext(), user, scheduleIDs); err != nil {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, i18n.CodeOf(err, "errors.report.subscription_failed"))
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "auth.reports.updated")
+	return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+}
+
 func (h *AuthHandler) UpdatePassword(c *fiber.Ctx) error {
 	userID, ok := c.Locals("userID").(uint)
 	if !ok {
 		if !ok {
-			utils.Log.Warn("Parola Güncelleme: Session'da geçersiz veya eksik user_id", zap.Any("value", c.Locals("userID")))
+			utils.LogFrom(c.UserContext()).Warn("Parola Güncelleme: Session'da geçersiz veya eksik user_id", zap.Any("value", c.Locals("userID")))
 			sess, _ := utils.SessionStart(c)
 			if sess != nil {
 				_ = sess.Destroy()
 			}
-			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz oturum bilgisi, lütfen tekrar giriş yapın.")
+			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "auth.session.invalid")
 			return c.Redirect("/auth/login", fiber.StatusSeeOther)
 		}
 	}
@@ -217,20 +330,20 @@ func (h *AuthHandler) UpdatePassword(c *fiber.Ctx) error {
 		ConfirmPassword string `form:"confirm_password"`
 	}
 	if err := c.BodyParser(&request); err != nil {
-		utils.SLog.Warnf("Parola güncelleme isteği ayrıştırılamadı: %v", err)
-		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Lütfen tüm şifre alanlarını doldurun.")
+		utils.SLogFrom(c.UserContext()).Warnf("Parola güncelleme isteği ayrıştırılamadı: %v", err)
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "auth.password.missing_fields")
 		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
 	}
 	if request.CurrentPassword == "" || request.NewPassword == "" || request.ConfirmPassword == "" {
-		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Lütfen tüm şifre alanlarını doldurun.")
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "auth.password.missing_fields")
 		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
 	}
 	if request.NewPassword != request.ConfirmPassword {
-		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Yeni şifreler uyuşmuyor.")
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "auth.password.mismatch")
 		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
 	}
 
-	err := h.service.UpdatePassword(userID, request.CurrentPassword, request.NewPassword)
+	err := h.service.UpdatePassword(c.UserContext(), userID, request.CurrentPassword, request.NewPassword)
 	if err != nil {
 		var errMsg string
 		flashKey := utils.FlashErrorKey
@@ -238,20 +351,16 @@ func (h *AuthHandler) UpdatePassword(c *fiber.Ctx) error {
 		logoutUser := false
 
 		switch err {
-		case services.ErrCurrentPasswordIncorrect:
-			errMsg = "Mevcut şifreniz hatalı."
-		case services.ErrPasswordTooShort:
-			errMsg = err.Error()
-		case services.ErrPasswordSameAsOld:
-			errMsg = err.Error()
+		case services.ErrCurrentPasswordIncorrect, services.ErrPasswordTooShort, services.ErrPasswordSameAsOld:
+			errMsg = i18n.CodeOf(err, "auth.password.update_failed")
 		case services.ErrUserNotFound:
-			errMsg = "Kullanıcı bulunamadı, lütfen tekrar giriş yapın."
+			errMsg = "auth.password.user_not_found"
 			logoutUser = true
 			redirectTarget = "/auth/login"
-			utils.Log.Warn("Parola Güncelleme: Kullanıcı bulunamadı (servis hatası)", zap.Uint("user_id", userID))
+			utils.LogFrom(c.UserContext()).Warn("Parola Güncelleme: Kullanıcı bulunamadı (servis hatası)", zap.Uint("user_id", userID))
 		default:
-			errMsg = "Şifre güncellenirken bilinmeyen bir hata oluştu."
-			utils.Log.Error("Parola güncelleme servisinde beklenmeyen hata", zap.Uint("user_id", userID), zap.Error(err))
+			errMsg = "auth.password.update_failed"
+			utils.LogFrom(c.UserContext()).Error("Parola güncelleme servisinde beklenmeyen hata", zap.Uint("user_id", userID), zap.Error(err))
 		}
 
 		if logoutUser {
@@ -265,18 +374,18 @@ func (h *AuthHandler) UpdatePassword(c *fiber.Ctx) error {
 		return c.Redirect(redirectTarget, fiber.StatusSeeOther)
 	}
 
-	flashMsg := "Şifre başarıyla güncellendi. Lütfen yeni şifrenizle tekrar giriş yapın."
+	flashMsg := "auth.password.updated"
 	sess, sessionErr := utils.SessionStart(c)
 	if sess != nil {
 		if destroyErr := sess.Destroy(); destroyErr != nil {
-			utils.Log.Error("Parola güncellendi ancak oturum yok edilemedi",
+			utils.LogFrom(c.UserContext()).Error("Parola güncellendi ancak oturum yok edilemedi",
 				zap.Uint("user_id", userID),
 				zap.Error(destroyErr),
 			)
-			flashMsg = "Şifre başarıyla güncellendi (ancak mevcut oturum sonlandırılamadı). Lütfen tekrar giriş yapın."
+			flashMsg = "auth.password.updated_session_kept"
 		}
 	} else if sessionErr != nil {
-		utils.Log.Error("Parola güncellendi ancak oturum başlatılamadı/alınamadı", zap.Uint("user_id", userID), zap.Error(sessionErr))
+		utils.LogFrom(c.UserContext()).Error("Parola güncellendi ancak oturum başlatılamadı/alınamadı", zap.Uint("user_id", userID), zap.Error(sessionErr))
 	}
 
 	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, flashMsg)
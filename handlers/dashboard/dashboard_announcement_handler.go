@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"strings"
+
+	"zatrano/i18n"
+	"zatrano/models"
+	"zatrano/services"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type AnnouncementHandler struct {
+	service services.IAnnouncementService
+}
+
+func NewAnnouncementHandler(service services.IAnnouncementService) *AnnouncementHandler {
+	return &AnnouncementHandler{service: service}
+}
+
+type announcementForm struct {
+	Title     string `form:"title"`
+	Body      string `form:"body"`
+	Priority  string `form:"priority"`
+	ExpiresAt string `form:"expires_at"`
+}
+
+func (h *AnnouncementHandler) ListAnnouncements(c *fiber.Ctx) error {
+	actor, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	flashData, flashErr := utils.GetFlashMessages(c)
+	if flashErr != nil {
+		utils.LogFrom(c.UserContext()).Warn("Duyuru listesi: Flash mesajları alınamadı", zap.Error(flashErr))
+	}
+
+	items, err := h.service.ListAnnouncements(c.UserContext(), actor)
+	renderData := fiber.Map{
+		"Title":         utils.T(c, "announcements.list.title"),
+		"CsrfToken":     c.Locals("csrf"),
+		"Announcements": items,
+		"Success":       flashData.Success,
+		"Error":         flashData.Error,
+	}
+	if err != nil {
+		utils.LogFrom(c.UserContext()).Error("Duyuru listesi alınamadı", zap.Error(err))
+		renderData["Error"] = utils.T(c, "announcements.list.load_failed")
+	}
+	return c.Render("dashboard/announcements/dashboard_announcements_list", renderData, "layouts/dashboard_layout")
+}
+
+func (h *AnnouncementHandler) ShowCreateAnnouncement(c *fiber.Ctx) error {
+	return c.Render("dashboard/announcements/dashboard_announcements_create", fiber.Map{
+		"Title":      utils.T(c, "announcements.create.title"),
+		"CsrfToken":  c.Locals("csrf"),
+		"Priorities": models.AnnouncementPriorities,
+		"FormData":   announcementForm{Priority: string(models.PriorityNormal)},
+	}, "layouts/dashboard_layout")
+}
+
+func (h *AnnouncementHandler) CreateAnnouncement(c *fiber.Ctx) error {
+	actor, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	var req announcementForm
+
+	renderError := func(errorMsg string, statusCode int) error {
+		return c.Status(statusCode).Render("dashboard/announcements/dashboard_announcements_create", fiber.Map{
+			"Title":      utils.T(c, "announcements.create.title"),
+			"CsrfToken":  c.Locals("csrf"),
+			"Priorities": models.AnnouncementPriorities,
+			"Error":      errorMsg,
+			"FormData":   req,
+		}, "layouts/dashboard_layout")
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		utils.SLogFrom(c.UserContext()).Warnf("Duyuru oluşturma isteği ayrıştırılamadı: %v", err)
+		return renderError(utils.T(c, "form.invalid"), fiber.StatusBadRequest)
+	}
+
+	announcement := models.Announcement{Title: req.Title, Body: req.Body, Priority: models.AnnouncementPriority(req.Priority)}
+	if value := strings.TrimSpace(req.ExpiresAt); value != "" {
+		expiresAt, err := utils.Prefs(c).ParseDateTimeLocal(value)
+		if err != nil {
+			return renderError(utils.T(c, "announcements.form.invalid_expiry"), fiber.StatusBadRequest)
+		}
+		announcement.ExpiresAt = &expiresAt
+	}
+
+	if err := h.service.CreateAnnouncement(c.UserContext(), actor, &announcement); err != nil {
+		utils.LogFrom(c.UserContext()).Warn("Duyuru oluşturulamadı", zap.Error(err))
+		return renderError(utils.TError(c, err), fiber.StatusBadRequest)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "announcements.create.success")
+	return c.Redirect("/dashboard/announcements", fiber.StatusFound)
+}
+
+func (h *AnnouncementHandler) ShowAnnouncement(c *fiber.Ctx) error {
+	actor, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "announcements.invalid_id")
+		return c.Redirect("/dashboard/announcements", fiber.StatusSeeOther)
+	}
+
+	announcement, recipients, err := h.service.GetAnnouncementStatus(c.UserContext(), actor, uint(id))
+	if err != nil {
+		if err != services.ErrAnnouncementNotFound {
+			utils.LogFrom(c.UserContext()).Error("Duyuru durumu alınamadı", zap.Int("announcement_id", id), zap.Error(err))
+		}
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "announcements.not_found")
+		return c.Redirect("/dashboard/announcements", fiber.StatusSeeOther)
+	}
+
+	return c.Render("dashboard/announcements/dashboard_announcements_detail", fiber.Map{
+		"Title":        utils.T(c, "announcements.detail.title"),
+		"CsrfToken":    c.Locals("csrf"),
+		"Announcement": announcement,
+		"Recipients":   recipients,
+	}, "layouts/dashboard_layout")
+}
+
+func (h *AnnouncementHandler) DeleteAnnouncement(c *fiber.Ctx) error {
+	actor, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "announcements.invalid_id")
+		return c.Redirect("/dashboard/announcements", fiber.StatusSeeOther)
+	}
+
+	if err := h.service.DeleteAnnouncement(c.UserContext(), actor, uint(id)); err != nil {
+		utils.LogFrom(c.UserContext()).Warn("Duyuru silinemedi", zap.Int("announcement_id", id), zap.Error(err))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, i18n.CodeOf(err, "announcements.delete.failed"))
+		return c.Redirect("/dashboard/announcements", fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "announcements.delete.success")
+	return c.Redirect("/dashboard/announcements", fiber.StatusFound)
+}
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"zatrano/i18n"
+	"zatrano/models"
 	"zatrano/services"
 	"zatrano/utils"
 
@@ -9,42 +11,184 @@ import (
 )
 
 type HomeHandler struct {
-	userService services.IUserService
-	teamService services.ITeamService
+	reportingService services.IReportingService
 }
 
-func NewHomeHandler() *HomeHandler {
-	return &HomeHandler{
-		userService: services.NewUserService(),
-		teamService: services.NewTeamService(),
-	}
+func NewHomeHandler(reportingService services.IReportingService) *HomeHandler {
+	return &HomeHandler{reportingService: reportingService}
 }
 
 func (h *HomeHandler) HomePage(c *fiber.Ctx) error {
 	flashData, err := utils.GetFlashMessages(c)
 	if err != nil {
-		utils.Log.Warn("Anasayfa: Flash mesajları alınamadı", zap.Error(err))
+		utils.LogFrom(c.UserContext()).Warn("Anasayfa: Flash mesajları alınamadı", zap.Error(err))
 	}
 
-	userCount, userErr := h.userService.GetUserCount()
-	if userErr != nil {
-		utils.Log.Error("Anasayfa: Kullanıcı sayısı alınamadı", zap.Error(userErr))
-		userCount = 0
+	mapData := fiber.Map{
+		"Title":       utils.T(c, "dashboard.home.title"),
+		"Success":     flashData.Success,
+		"Error":       flashData.Error,
+		"LoginDays":   services.ReportingLoginDays,
+		"Weeks":       services.ReportingWeeks,
+		"SpikeMin":    services.ReportingFailedLoginSpikeMin,
+		"SpikeFactor": services.ReportingFailedLoginSpikeFactor,
 	}
 
-	teamCount, teamErr := h.teamService.GetTeamCount()
-	if teamErr != nil {
-		utils.Log.Error("Anasayfa: Takım sayısı alınamadı", zap.Error(teamErr))
-		teamCount = 0
+	overview, err := h.reportingService.DashboardOverview(c.UserContext(), utils.Prefs(c).Now())
+	if err != nil {
+		mapData["Error"] = utils.TError(c, err)
+		overview = &services.DashboardOverview{}
 	}
+	mapData["Overview"] = overview
 
-	mapData := fiber.Map{
-		"Title":     "Dashboard",
-		"Success":   flashData.Success,
-		"Error":     flashData.Error,
-		"UserCount": userCount,
-		"TeamCount": teamCount,
+	return c.Render("dashboard/home/dashboard_home", mapData, "layouts/dashboard_layout")
+}
+
+type analyticsSeries struct {
+	Dates  []string `json:"dates"`
+	Labels []string `json:"labels"`
+	Counts []int64  `json:"counts"`
+}
+
+type analyticsUserType struct {
+	Type     models.UserType `json:"type"`
+	Label    string          `json:"label"`
+	Active   int64           `json:"active"`
+	Inactive int64           `json:"inactive"`
+}
+
+type analyticsTeam struct {
+	ID       uint   `json:"id"`
+	Name     string `json:"name"`
+	Status   bool   `json:"status"`
+	Managers int64  `json:"managers"`
+	Agents   int64  `json:"agents"`
+	Inactive int64  `json:"inactive"`
+}
+
+type analyticsLogins struct {
+	Dates     []string `json:"dates"`
+	Labels    []string `json:"labels"`
+	Succeeded []int64  `json:"succeeded"`
+	Failed    []int64  `json:"failed"`
+	Spikes    []bool   `json:"spikes"`
+}
+
+type analyticsRecentLogin struct {
+	UserID  *uint  `json:"user_id"`
+	Name    string `json:"name"`
+	Account string `json:"account"`
+	At      string `json:"at"`
+}
+
+type analyticsFailedAccount struct {
+	Account string `json:"account"`
+	Count   int64  `json:"count"`
+}
+
+type analyticsResponse struct {
+	GeneratedAt string `json:"generated_at"`
+	Users       struct {
+		Total    int64               `json:"total"`
+		Active   int64               `json:"active"`
+		Inactive int64               `json:"inactive"`
+		ByType   []analyticsUserType `json:"by_type"`
+	} `json:"users"`
+	Teams struct {
+		Total          int             `json:"total"`
+		Active         int             `json:"active"`
+		WithoutManager int             `json:"without_manager"`
+		Empty          int             `json:"empty"`
+		Sizes          []analyticsTeam `json:"sizes"`
+	} `json:"teams"`
+	NewUsers       analyticsSeries          `json:"new_users"`
+	Deactivations  analyticsSeries          `json:"deactivations"`
+	Logins         analyticsLogins          `json:"logins"`
+	LoginSpikes    int                      `json:"login_spikes"`
+	RecentLogins   []analyticsRecentLogin   `json:"recent_logins"`
+	FailedAccounts []analyticsFailedAccount `json:"failed_accounts"`
+}
+
+func newAnalyticsSeries(counts []services.WeeklyCount, prefs utils.Preferences) analyticsSeries {
+	series := analyticsSeries{
+		Dates:  make([]string, len(counts)),
+		Labels: make([]string, len(counts)),
+		Counts: make([]int64, len(counts)),
+	}
+	for i, week := range counts {
+		series.Dates[i] = week.Week.Format(utils.DateInputLayout)
+		series.Labels[i] = prefs.FormatCivilDate(week.Week)
+		series.Counts[i] = week.Count
 	}
+	return series
+}
 
-	return c.Render("dashboard/home/dashboard_home", mapData, "layouts/dashboard_layout")
+func (h *HomeHandler) Analytics(c *fiber.Ctx) error {
+	prefs := utils.Prefs(c)
+	locale := utils.Locale(c)
+	overview, err := h.reportingService.DashboardOverview(c.UserContext(), prefs.Now())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": utils.TError(c, err)})
+	}
+
+	var resp analyticsResponse
+	resp.GeneratedAt = prefs.FormatDateTime(overview.GeneratedAt)
+
+	resp.Users.Total = overview.Users.Total
+	resp.Users.Active = overview.Users.Active
+	resp.Users.Inactive = overview.Users.Inactive
+	resp.Users.ByType = make([]analyticsUserType, len(overview.Users.ByType))
+	for i, t := range overview.Users.ByType {
+		resp.Users.ByType[i] = analyticsUserType{
+			Type: t.Type, Label: i18n.T(locale, "users.type."+string(t.Type)),
+			Active: t.Active, Inactive: t.Inactive,
+		}
+	}
+
+	resp.Teams.Total = overview.Teams.Total
+	resp.Teams.Active = overview.Teams.Active
+	resp.Teams.WithoutManager = overview.Teams.WithoutManager
+	resp.Teams.Empty = overview.Teams.Empty
+	resp.Teams.Sizes = make([]analyticsTeam, len(overview.Teams.Sizes))
+	for i, t := range overview.Teams.Sizes {
+		resp.Teams.Sizes[i] = analyticsTeam{
+			ID: t.TeamID, Name: t.Name, Status: t.Status,
+			Managers: t.Managers, Agents: t.Agents, Inactive: t.Inactive,
+		}
+	}
+
+	resp.NewUsers = newAnalyticsSeries(overview.NewUsers, prefs)
+	resp.Deactivations = newAnalyticsSeries(overview.Deactivations, prefs)
+
+	logins := overview.Logins
+	resp.Logins = analyticsLogins{
+		Dates:     make([]string, len(logins)),
+		Labels:    make([]string, len(logins)),
+		Succeeded: make([]int64, len(logins)),
+		Failed:    make([]int64, len(logins)),
+		Spikes:    make([]bool, len(logins)),
+	}
+	for i, day := range logins {
+		resp.Logins.Dates[i] = day.Day.Format(utils.DateInputLayout)
+		resp.Logins.Labels[i] = prefs.FormatCivilDate(day.Day)
+		resp.Logins.Succeeded[i] = day.Succeeded
+		resp.Logins.Failed[i] = day.Failed
+		resp.Logins.Spikes[i] = day.Spike
+	}
+	resp.LoginSpikes = overview.LoginSpikes
+
+	resp.RecentLogins = make([]analyticsRecentLogin, len(overview.RecentLogins))
+	for i, event := range overview.RecentLogins {
+		item := analyticsRecentLogin{UserID: event.UserID, Account: event.Account, At: prefs.FormatDateTime(event.CreatedAt)}
+		if event.User != nil {
+			item.Name = event.User.Name
+		}
+		resp.RecentLogins[i] = item
+	}
+	resp.FailedAccounts = make([]analyticsFailedAccount, len(overview.FailedAccounts))
+	for i, account := range overview.FailedAccounts {
+		resp.FailedAccounts[i] = analyticsFailedAccount{Account: account.Account, Count: account.Count}
+	}
+
+	return c.JSON(resp)
 }
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"zatrano/i18n"
+	"zatrano/models"
+	"zatrano/services"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type JobHandler struct {
+	service  services.IJobService
+	registry *services.JobRegistry
+}
+
+func NewJobHandler(service services.IJobService, registry *services.JobRegistry) *JobHandler {
+	return &JobHandler{service: service, registry: registry}
+}
+
+func (h *JobHandler) ListJobs(c *fiber.Ctx) error {
+	flashData, flashErr := utils.GetFlashMessages(c)
+	if flashErr != nil {
+		utils.LogFrom(c.UserContext()).Warn("İş listesi: Flash mesajları alınamadı", zap.Error(flashErr))
+	}
+
+	defaultPerPage := utils.Prefs(c).PerPage
+	var params utils.ListParams
+	if err := c.QueryParser(&params); err != nil {
+		utils.LogFrom(c.UserContext()).Warn("İş listesi: Query parametreleri parse edilemedi, varsayılanlar kullanılıyor.", zap.Error(err))
+		params = utils.ListParams{
+			Page:    utils.DefaultPage,
+			PerPage: defaultPerPage,
+			SortBy:  "id",
+			OrderBy: utils.DefaultOrderBy,
+		}
+	}
+	if params.Page <= 0 {
+		params.Page = utils.DefaultPage
+	}
+	if params.PerPage <= 0 || params.PerPage > utils.MaxPerPage {
+		params.PerPage = defaultPerPage
+	}
+	if params.SortBy == "" {
+		params.SortBy = "id"
+	}
+	if params.OrderBy == "" {
+		params.OrderBy = utils.DefaultOrderBy
+	}
+
+	if err := utils.ParseListQuery(c, &params, services.JobListSpec); err != nil {
+		utils.LogFrom(c.UserContext()).Warn("İş listesi: Geçersiz filtre veya sıralama parametreleri yok sayıldı", zap.Error(err))
+	}
+
+	paginatedResult, err := h.service.ListJobs(c.UserContext(), params)
+	counts, countsErr := h.service.StatusCounts(c.UserContext())
+	var total int64
+	for _, n := range counts {
+		total += n
+	}
+
+	renderData := fiber.Map{
+		"Title":     utils.T(c, "jobs.list.title"),
+		"CsrfToken": c.Locals("csrf"),
+		"Result":    paginatedResult,
+		"Params":    params,
+		"Statuses":  models.JobStatuses,
+		"Counts":    counts,
+		"Total":     total,
+		"Types":     h.registry.Types(),
+		"Success":   flashData.Success,
+		"Error":     flashData.Error,
+	}
+	if err != nil || countsErr != nil {
+		renderData["Error"] = utils.T(c, "jobs.list.load_failed")
+		renderData["Result"] = &utils.PaginatedResult{
+			Data: []models.Job{},
+			Meta: utils.PaginationMeta{CurrentPage: params.Page, PerPage: params.PerPage},
+		}
+	}
+	return c.Render("dashboard/jobs/dashboard_jobs_list", renderData, "layouts/dashboard_layout")
+}
+
+func (h *JobHandler) RetryJob(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "errors.job.not_found")
+		return c.Redirect("/dashboard/jobs", fiber.StatusSeeOther)
+	}
+	if err := h.service.RetryJob(c.UserContext(), uint(id)); err != nil {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, i18n.CodeOf(err, "errors.job.retry_failed"))
+		return c.Redirect("/dashboard/jobs", fiber.StatusSeeOther)
+	}
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "jobs.retry.success")
+	return c.Redirect("/dashboard/jobs", fiber.StatusFound)
+}
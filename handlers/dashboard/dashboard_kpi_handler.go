@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"zatrano/i18n"
+	"zatrano/models"
+	"zatrano/services"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+const kpiImportMaxShownErrors = 50
+
+type KPIHandler struct {
+	service services.IKPIService
+}
+
+func NewKPIHandler(service services.IKPIService) *KPIHandler {
+	return &KPIHandler{service: service}
+}
+
+type kpiForm struct {
+	Key            string `form:"key"`
+	Name           string `form:"name"`
+	Unit           string `form:"unit"`
+	Aggregation    string `form:"aggregation"`
+	HigherIsBetter string `form:"higher_is_better"`
+	Status         string `form:"status"`
+}
+
+func (f kpiForm) definition() models.KPIDefinition {
+	return models.KPIDefinition{
+		Key:            f.Key,
+		Name:           f.Name,
+		Unit:           models.KPIUnit(f.Unit),
+		Aggregation:    models.KPIAggregation(f.Aggregation),
+		HigherIsBetter: f.HigherIsBetter == "true",
+		Status:         f.Status == "true",
+	}
+}
+
+func kpiFormData(c *fiber.Ctx, data fiber.Map) fiber.Map {
+	data["CsrfToken"] = c.Locals("csrf")
+	data["Units"] = models.KPIUnits
+	data["Aggregations"] = models.KPIAggregations
+	return data
+}
+
+func (h *KPIHandler) renderList(c *fiber.Ctx, status int, data fiber.Map) error {
+	definitions, err := h.service.ListDefinitions(c.UserContext())
+	if err != nil {
+		data["Error"] = utils.T(c, "kpis.list.load_failed")
+	}
+	data["Title"] = utils.T(c, "kpis.list.title")
+	data["CsrfToken"] = c.Locals("csrf")
+	data["Definitions"] = definitions
+	data["Header"] = services.KPICSVHeader
+	data["MaxRows"] = services.KPIImportMaxRows
+	return c.Status(status).Render("dashboard/kpis/dashboard_kpis_list", data, "layouts/dashboard_layout")
+}
+
+func (h *KPIHandler) ListDefinitions(c *fiber.Ctx) error {
+	flashData, flashErr := utils.GetFlashMessages(c)
+	if flashErr != nil {
+		utils.LogFrom(c.UserContext()).Warn("KPI listesi: Flash mesajları alınamadı", zap.Error(flashErr))
+	}
+	return h.renderList(c, fiber.StatusOK, fiber.Map{
+		"Success": flashData.Success,
+		"Error":   flashData.Error,
+	})
+}
+
+func (h *KPIHandler) ShowCreateDefinition(c *fiber.Ctx) error {
+	return c.Render("dashboard/kpis/dashboard_kpis_create", kpiFormData(c, fiber.Map{
+		"Title":      utils.T(c, "kpis.create.title"),
+		"Definition": models.KPIDefinition{Unit: models.KPIUnitCount, Aggregation: models.KPIAggregationSum, HigherIsBetter: true, Status: true},
+	}), "layouts/dashboard_layout")
+}
+
+func (h *KPIHandler) CreateDefinition(c *fiber.Ctx) error {
+	var req kpiForm
+	renderError := func(errorMsg string, statusCode int) error {
+		return c.Status(statusCode).Render("dashboard/kpis/dashboard_kpis_create", kpiFormData(c, fiber.Map{
+			"Title":      utils.T(c, "kpis.create.title"),
+			"Error":      errorMsg,
+			"Definition": req.definition(),
+		}), "layouts/dashboard_layout")
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		utils.SLogFrom(c.UserContext()).Warnf("KPI tanımı isteği ayrıştırılamadı: %v", err)
+		return renderError(utils.T(c, "form.invalid"), fiber.StatusBadRequest)
+	}
+
+	definition := req.definition()
+	if err := h.service.CreateDefinition(c.UserContext(), &definition); err != nil {
+		utils.LogFrom(c.UserContext()).Warn("KPI tanımı oluşturulamadı", zap.String("key", definition.Key), zap.Error(err))
+		return renderError(utils.TError(c, err), fiber.StatusUnprocessableEntity)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "kpis.create.success")
+	return c.Redirect("/dashboard/kpis", fiber.StatusFound)
+}
+
+func (h *KPIHandler) ShowUpdateDefinition(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "errors.kpi.not_found")
+		return c.Redirect("/dashboard/kpis", fiber.StatusSeeOther)
+	}
+	definition, err := h.service.GetDefinition(c.UserContext(), uint(id))
+	if err != nil {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, i18n.CodeOf(err, "kpis.update.load_failed"))
+		return c.Redirect("/dashboard/kpis", fiber.StatusSeeOther)
+	}
+	return c.Render("dashboard/kpis/dashboard_kpis_update", kpiFormData(c, fiber.Map{
+		"Title":      utils.T(c, "kpis.update.title"),
+		"Definition": definition,
+	}), "layouts/dashboard_layout")
+}
+
+func (h *KPIHandler) UpdateDefinition(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "errors.kpi.not_found")
+		return c.Redirect("/dashboard/kpis", fiber.StatusSeeOther)
+	}
+	var req kpiForm
+	renderError := func(errorMsg string, statusCode int) error {
+		definition := req.definition()
+		definition.ID = uint(id)
+		if existing, err := h.service.GetDefinition(c.UserContext(), uint(id)); err == nil {
+			definition.Key = existing.Key
+		}
+		return c.Status(statusCode).Render("dashboard/kpis/dashboard_kpis_update", kpiFormData(c, fiber.Map{
+			"Title":      utils.T(c, "kpis.update.title"),
+			"Error":      errorMsg,
+			"Definition": definition,
+		}), "layouts/dashboard_layout")
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		utils.SLogFrom(c.UserContext()).Warnf("KPI tanımı güncelleme isteği ayrıştırılamadı: %v", err)
+		return renderError(utils.T(c, "form.invalid"), fiber.StatusBadRequest)
+	}
+
+	definition := req.definition()
+	if err := h.service.UpdateDefinition(c.UserContext(), uint(id), &definition); err != nil {
+		if err == services.ErrKPINotFound {
+			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, i18n.CodeOf(err, ""))
+			return c.Redirect("/dashboard/kpis", fiber.StatusSeeOther)
+		}
+		utils.LogFrom(c.UserContext()).Warn("KPI tanımı güncellenemedi", zap.Int("kpi_id", id), zap.Error(err))
+		return renderError(utils.TError(c, err), fiber.StatusUnprocessableEntity)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "kpis.update.success")
+	return c.Redirect("/dashboard/kpis", fiber.StatusFound)
+}
+
+func (h *KPIHandler) ImportValues(c *fiber.Ctx) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "kpis.import.file_required")
+		return c.Redirect("/dashboard/kpis", fiber.StatusSeeOther)
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.LogFrom(c.UserContext()).Error("KPI dosyası açılamadı", zap.String("file", fileHeader.Filename), zap.Error(err))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "errors.kpi.import_failed")
+		return c.Redirect("/dashboard/kpis", fiber.StatusSeeOther)
+	}
+	defer file.Close()
+
+	inputs, err := h.service.ParseCSV(file)
+	if err != nil {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, i18n.CodeOf(err, "errors.kpi.import_invalid_csv"))
+		return c.Redirect("/dashboard/kpis", fiber.StatusSeeOther)
+	}
+	result, err := h.service.Ingest(c.UserContext(), inputs)
+	if err == services.ErrKPIImportInvalidRows {
+		rowErrors := result.Errors
+		hidden := 0
+		if len(rowErrors) > kpiImportMaxShownErrors {
+			hidden = len(rowErrors) - kpiImportMaxShownErrors
+			rowErrors = rowErrors[:kpiImportMaxShownErrors]
+		}
+		return h.renderList(c, fiber.StatusUnprocessableEntity, fiber.Map{
+			"Error":        utils.TError(c, err),
+			"ImportFile":   fileHeader.Filename,
+			"RowErrors":    rowErrors,
+			"HiddenErrors": hidden,
+		})
+	}
+	if err != nil {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, i18n.CodeOf(err, "errors.kpi.import_failed"))
+		return c.Redirect("/dashboard/kpis", fiber.StatusSeeOther)
+	}
+
+	utils.SLogFrom(c.UserContext()).Infof("KPI dosyası yüklendi: %s (%d satır)", fileHeader.Filename, result.Imported)
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "kpis.import.success")
+	return c.Redirect("/dashboard/kpis", fiber.StatusFound)
+}
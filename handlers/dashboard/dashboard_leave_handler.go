@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"strconv"
+
+	"zatrano/i18n"
+	"zatrano/models"
+	"zatrano/services"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type LeaveBalanceHandler struct {
+	service services.ILeaveService
+}
+
+func NewLeaveBalanceHandler(service services.ILeaveService) *LeaveBalanceHandler {
+	return &LeaveBalanceHandler{service: service}
+}
+
+type balanceForm struct {
+	Year        int    `form:"year"`
+	Type        string `form:"type"`
+	Entitled    int    `form:"entitled"`
+	CarriedOver int    `form:"carried_over"`
+}
+
+func balancesURL(year int) string {
+	if year > 0 {
+		return "/dashboard/leave-balances?year=" + strconv.Itoa(year)
+	}
+	return "/dashboard/leave-balances"
+}
+
+func (h *LeaveBalanceHandler) ListBalances(c *fiber.Ctx) error {
+	actor, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	flashData, flashErr := utils.GetFlashMessages(c)
+	if flashErr != nil {
+		utils.LogFrom(c.UserContext()).Warn("İzin bakiyeleri: Flash mesajları alınamadı", zap.Error(flashErr))
+	}
+
+	year := utils.Prefs(c).Now().Year()
+	if y, err := strconv.Atoi(c.Query("year")); err == nil && y > 0 {
+		year = y
+	}
+	rows, err := h.service.Balances(c.UserContext(), actor, year)
+	renderData := fiber.Map{
+		"Title":         utils.T(c, "leaves.balances.title"),
+		"CsrfToken":     c.Locals("csrf"),
+		"Rows":          rows,
+		"Year":          year,
+		"AnnualAccrual": services.AnnualLeaveDaysPerYear,
+		"SickAccrual":   services.SickLeaveDaysPerYear,
+		"MaxCarryOver":  services.LeaveMaxCarryOverDays,
+		"Success":       flashData.Success,
+		"Error":         flashData.Error,
+	}
+	if err != nil {
+		utils.LogFrom(c.UserContext()).Error("İzin bakiyeleri alınamadı", zap.Error(err))
+		renderData["Error"] = utils.T(c, "leaves.balances.load_failed")
+	}
+	return c.Render("dashboard/leaves/dashboard_leave_balances", renderData, "layouts/dashboard_layout")
+}
+
+func (h *LeaveBalanceHandler) UpdateBalance(c *fiber.Ctx) error {
+	actor, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	var req balanceForm
+	if err := c.BodyParser(&req); err != nil {
+		utils.SLogFrom(c.UserContext()).Warnf("İzin bakiyesi isteği ayrıştırılamadı: %v", err)
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "form.invalid")
+		return c.Redirect(balancesURL(req.Year), fiber.StatusSeeOther)
+	}
+	userID, err := c.ParamsInt("id")
+	if err != nil || userID <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "errors.user.not_found")
+		return c.Redirect(balancesURL(req.Year), fiber.StatusSeeOther)
+	}
+
+	balance := models.LeaveBalance{UserID: uint(userID), Year: req.Year, Type: models.LeaveType(req.Type), Entitled: req.Entitled, CarriedOver: req.CarriedOver}
+	if err := h.service.AdjustBalance(c.UserContext(), actor, &balance); err != nil {
+		utils.LogFrom(c.UserContext()).Warn("İzin bakiyesi güncellenemedi", zap.Int("user_id", userID), zap.Error(err))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, i18n.CodeOf(err, "errors.leave.balance_failed"))
+		return c.Redirect(balancesURL(req.Year), fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "leaves.balances.update_success")
+	return c.Redirect(balancesURL(req.Year), fiber.StatusFound)
+}
@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"bytes"
+	"time"
+
+	"zatrano/i18n"
+	"zatrano/models"
+	"zatrano/services"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type ReportHandler struct {
+	service services.IReportService
+}
+
+func NewReportHandler(service services.IReportService) *ReportHandler {
+	return &ReportHandler{service: service}
+}
+
+type reportForm struct {
+	Name     string `form:"name"`
+	Template string `form:"template"`
+	Cron     string `form:"cron"`
+	Timezone string `form:"timezone"`
+	Status   string `form:"status"`
+}
+
+func (f reportForm) schedule() models.ReportSchedule {
+	return models.ReportSchedule{
+		Name:     f.Name,
+		Template: models.ReportTemplate(f.Template),
+		Cron:     f.Cron,
+		Timezone: f.Timezone,
+		Status:   f.Status == "true",
+	}
+}
+
+func reportFormData(c *fiber.Ctx, data fiber.Map) fiber.Map {
+	data["CsrfToken"] = c.Locals("csrf")
+	data["Templates"] = models.ReportTemplates
+	data["Timezones"] = utils.CommonTimezones
+	return data
+}
+
+func (h *ReportHandler) ListSchedules(c *fiber.Ctx) error {
+	flashData, flashErr := utils.GetFlashMessages(c)
+	if flashErr != nil {
+		utils.LogFrom(c.UserContext()).Warn("Rapor listesi: Flash mesajları alınamadı", zap.Error(flashErr))
+	}
+	data := fiber.Map{
+		"Title":     utils.T(c, "reports.list.title"),
+		"CsrfToken": c.Locals("csrf"),
+		"Success":   flashData.Success,
+		"Error":     flashData.Error,
+		"Templates": models.ReportTemplates,
+	}
+	schedules, err := h.service.ListSchedules(c.UserContext())
+	if err != nil {
+		data["Error"] = utils.T(c, "reports.list.load_failed")
+	}
+	data["Schedules"] = schedules
+	return c.Render("dashboard/reports/dashboard_reports_list", data, "layouts/dashboard_layout")
+}
+
+func (h *ReportHandler) ShowCreateSchedule(c *fiber.Ctx) error {
+	return c.Render("dashboard/reports/dashboard_reports_create", reportFormData(c, fiber.Map{
+		"Title":    utils.T(c, "reports.create.title"),
+		"Schedule": models.ReportSchedule{Template: models.ReportTemplateWeeklySummary, Cron: "0 8 * * 1", Timezone: utils.DefaultTimezone, Status: true},
+	}), "layouts/dashboard_layout")
+}
+
+func (h *ReportHandler) CreateSchedule(c *fiber.Ctx) error {
+	var req reportForm
+	renderError := func(errorMsg string, statusCode int) error {
+		return c.Status(statusCode).Render("dashboard/reports/dashboard_reports_create", reportFormData(c, fiber.Map{
+			"Title":    utils.T(c, "reports.create.title"),
+			"Error":    errorMsg,
+			"Schedule": req.schedule(),
+		}), "layouts/dashboard_layout")
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		utils.SLogFrom(c.UserContext()).Warnf("Rapor zamanlaması isteği ayrıştırılamadı: %v", err)
+		return renderError(utils.T(c, "form.invalid"), fiber.StatusBadRequest)
+	}
+
+	schedule := req.schedule()
+	if err := h.service.CreateSchedule(c.UserContext(), &schedule); err != nil {
+		utils.LogFrom(c.UserContext()).Warn("Rapor zamanlaması oluşturulamadı", zap.String("name", schedule.Name), zap.Error(err))
+		return renderError(utils.TError(c, err), fiber.StatusUnprocessableEntity)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "reports.create.success")
+	return c.Redirect("/dashboard/reports", fiber.StatusFound)
+}
+
+func (h *ReportHandler) ShowUpdateSchedule(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "errors.report.not_found")
+		return c.Redirect("/dashboard/reports", fiber.StatusSeeOther)
+	}
+	schedule, err := h.service.GetSchedule(c.UserContext(), uint(id))
+	if err != nil {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, i18n.CodeOf(err, "reports.update.load_failed"))
+		return c.Redirect("/dashboard/reports", fiber.StatusSeeOther)
+	}
+	return c.Render("dashboard/reports/dashboard_reports_update", reportFormData(c, fiber.Map{
+		"Title":    utils.T(c, "reports.update.title"),
+		"Schedule": schedule,
+	}), "layouts/dashboard_layout")
+}
+
+func (h *ReportHandler) UpdateSchedule(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "errors.report.not_found")
+		return c.Redirect("/dashboard/reports", fiber.StatusSeeOther)
+	}
+	var req reportForm
+	renderError := func(errorMsg string, statusCode int) error {
+		schedule := req.schedule()
+		schedule.ID = uint(id)
+		return c.Status(statusCode).Render("dashboard/reports/dashboard_reports_update", reportFormData(c, fiber.Map{
+			"Title":    utils.T(c, "reports.update.title"),
+			"Error":    errorMsg,
+			"Schedule": schedule,
+		}), "layouts/dashboard_layout")
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		utils.SLogFrom(c.UserContext()).Warnf("Rapor zamanlaması güncelleme isteği ayrıştırılamadı: %v", err)
+		return renderError(utils.T(c, "form.invalid"), fiber.StatusBadRequest)
+	}
+
+	schedule := req.schedule()
+	if err := h.service.UpdateSchedule(c.UserContext(), uint(id), &schedule); err != nil {
+		if err == services.ErrReportNotFound {
+			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, i18n.CodeOf(err, ""))
+			return c.Redirect("/dashboard/reports", fiber.StatusSeeOther)
+		}
+		utils.LogFrom(c.UserContext()).Warn("Rapor zamanlaması güncellenemedi", zap.Int("report_id", id), zap.Error(err))
+		return renderError(utils.TError(c, err), fiber.StatusUnprocessableEntity)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "reports.update.success")
+	return c.Redirect("/dashboard/reports", fiber.StatusFound)
+}
+
+func (h *ReportHandler) DeleteSchedule(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "errors.report.not_found")
+		return c.Redirect("/dashboard/reports", fiber.StatusSeeOther)
+	}
+	if err := h.service.DeleteSchedule(c.UserContext(), uint(id)); err != nil {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, i18n.CodeOf(err, "errors.report.deletion_failed"))
+		return c.Redirect("/dashboard/reports", fiber.StatusSeeOther)
+	}
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "reports.delete.success")
+	return c.Redirect("/dashboard/reports", fiber.StatusFound)
+}
+
+func (h *ReportHandler) PreviewReport(c *fiber.Ctx) error {
+	user, ok := utils.CurrentUser(c)
+	if !ok {
+		return fiber.ErrUnauthorized
+	}
+	prefs := utils.Prefs(c)
+	template := models.ReportTemplate(c.Params("template"))
+	report, err := h.service.BuildReport(c.UserContext(), template, user, time.Now().In(prefs.Location))
+	if err != nil {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, i18n.CodeOf(err, "errors.report.build_failed"))
+		return c.Redirect("/dashboard/reports", fiber.StatusSeeOther)
+	}
+
+	if c.Query("format") == "csv" {
+		var out bytes.Buffer
+		if err := services.WriteReportCSV(&out, report); err != nil {
+			utils.LogFrom(c.UserContext()).Error("Rapor CSV'si yazılamadı", zap.Error(err))
+			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "errors.report.build_failed")
+			return c.Redirect("/dashboard/reports", fiber.StatusSeeOther)
+		}
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+services.ReportCSVFilename(report)+`"`)
+		return c.Send(out.Bytes())
+	}
+
+	locale := utils.Locale(c)
+	title := i18n.T(locale, "reports.template."+string(template))
+	return c.Render(services.ReportViewName, services.ReportView(title, report, locale, prefs))
+}
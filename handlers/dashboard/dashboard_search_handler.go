@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"zatrano/repositories"
+	"zatrano/services"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type SearchHandler struct {
+	searchService services.ISearchService
+}
+
+func NewSearchHandler(searchService services.ISearchService) *SearchHandler {
+	return &SearchHandler{searchService: searchService}
+}
+
+type searchResult struct {
+	Kind     repositories.SearchKind
+	Title    string
+	Subtitle string
+	URL      string
+	Match    int
+}
+
+func newSearchResult(hit repositories.SearchHit) searchResult {
+	result := searchResult{Kind: hit.Kind, Title: hit.Title, Subtitle: hit.Subtitle, Match: int(hit.Score*100 + 0.5)}
+	switch hit.Kind {
+	case repositories.SearchKindUser:
+		result.URL = fmt.Sprintf("/dashboard/users/update/%d", hit.ID)
+	case repositories.SearchKindTeam:
+		result.URL = fmt.Sprintf("/dashboard/teams/update/%d", hit.ID)
+	}
+	return result
+}
+
+func (h *SearchHandler) Search(c *fiber.Ctx) error {
+	term := strings.TrimSpace(c.Query("q"))
+
+	renderData := fiber.Map{
+		"Title":     utils.T(c, "search.title"),
+		"Query":     term,
+		"MinLength": services.SearchMinLength,
+		"Searched":  utf8.RuneCountInString(term) >= services.SearchMinLength,
+		"Results":   []searchResult{},
+	}
+
+	hits, err := h.searchService.Search(c.UserContext(), term)
+	if err != nil {
+		utils.LogFrom(c.UserContext()).Error("Genel arama: Servis hatası", zap.Error(err))
+		renderData["Error"] = utils.TError(c, err)
+		return c.Render("dashboard/search/dashboard_search", renderData, "layouts/dashboard_layout")
+	}
+
+	results := make([]searchResult, 0, len(hits))
+	for _, hit := range hits {
+		results = append(results, newSearchResult(hit))
+	}
+	renderData["Results"] = results
+	return c.Render("dashboard/search/dashboard_search", renderData, "layouts/dashboard_layout")
+}
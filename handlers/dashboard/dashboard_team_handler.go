@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"strconv"
+	"zatrano/i18n"
 	"zatrano/models"
 	"zatrano/services"
 	"zatrano/utils"
@@ -14,22 +15,25 @@ type TeamHandler struct {
 	service services.ITeamService
 }
 
-func NewTeamHandler() *TeamHandler {
-	return &TeamHandler{service: services.NewTeamService()}
+func NewTeamHandler(service services.ITeamService) *TeamHandler {
+	return &TeamHandler{service: service}
 }
 
 func (h *TeamHandler) ListTeams(c *fiber.Ctx) error {
 	flashData, flashErr := utils.GetFlashMessages(c)
 	if flashErr != nil {
-		utils.Log.Warn("Takım listesi: Flash mesajları alınamadı", zap.Error(flashErr))
+		utils.LogFrom(c.UserContext()).Warn("Takım listesi: Flash mesajları alınamadı", zap.Error(flashErr))
 	}
 
+	// Sayfa boyutu verilmemişse kullanıcının tercih ettiği boyut kullanılır.
+	defaultPerPage := utils.Prefs(c).PerPage
+
 	var params utils.ListParams
 	if err := c.QueryParser(&params); err != nil {
-		utils.Log.Warn("Takım listesi: Query parametreleri parse edilemedi, varsayılanlar kullanılıyor.", zap.Error(err))
+		utils.LogFrom(c.UserContext()).Warn("Takım listesi: Query parametreleri parse edilemedi, varsayılanlar kullanılıyor.", zap.Error(err))
 		params = utils.ListParams{
 			Page:    utils.DefaultPage,
-			PerPage: utils.DefaultPerPage,
+			PerPage: defaultPerPage,
 			SortBy:  "id",
 			OrderBy: utils.DefaultOrderBy,
 		}
@@ -39,11 +43,11 @@ func (h *TeamHandler) ListTeams(c *fiber.Ctx) error {
 		params.Page = utils.DefaultPage
 	}
 	if params.PerPage <= 0 {
-		params.PerPage = utils.DefaultPerPage
+		params.PerPage = defaultPerPage
 	} else if params.PerPage > utils.MaxPerPage {
-		utils.Log.Warn("Sayfa başına istenen kayıt sayısı limiti aştı, varsayılana çekildi.",
-			zap.Int("requested", params.PerPage), zap.Int("max", utils.MaxPerPage), zap.Int("default", utils.DefaultPerPage))
-		params.PerPage = utils.DefaultPerPage
+		utils.LogFrom(c.UserContext()).Warn("Sayfa başına istenen kayıt sayısı limiti aştı, varsayılana çekildi.",
+			zap.Int("requested", params.PerPage), zap.Int("max", utils.MaxPerPage), zap.Int("default", defaultPerPage))
+		params.PerPage = defaultPerPage
 	}
 	if params.SortBy == "" {
 		params.SortBy = "id"
@@ -52,10 +56,14 @@ func (h *TeamHandler) ListTeams(c *fiber.Ctx) error {
 		params.OrderBy = utils.DefaultOrderBy
 	}
 
-	paginatedResult, dbErr := h.service.GetAllTeamsPaginated(params)
+	if err := utils.ParseListQuery(c, &params, services.TeamListSpec); err != nil {
+		utils.LogFrom(c.UserContext()).Warn("Takım listesi: Geçersiz filtre veya sıralama parametreleri yok sayıldı", zap.Error(err))
+	}
+
+	paginatedResult, dbErr := h.service.GetAllTeamsPaginated(c.UserContext(), params)
 
 	renderData := fiber.Map{
-		"Title":     "Takımlar",
+		"Title":     utils.T(c, "teams.list.title"),
 		"CsrfToken": c.Locals("csrf"),
 		"Result":    paginatedResult,
 		"Params":    params,
@@ -64,8 +72,8 @@ func (h *TeamHandler) ListTeams(c *fiber.Ctx) error {
 	}
 
 	if dbErr != nil {
-		dbErrMsg := "Takımlar getirilirken bir hata oluştu."
-		utils.Log.Error("Takım listesi DB Hatası", zap.Error(dbErr))
+		dbErrMsg := utils.T(c, "teams.list.load_failed")
+		utils.LogFrom(c.UserContext()).Error("Takım listesi DB Hatası", zap.Error(dbErr))
 		if existingErr, ok := renderData["Error"].(string); ok && existingErr != "" {
 			renderData["Error"] = existingErr + " | " + dbErrMsg
 		} else {
@@ -83,10 +91,10 @@ func (h *TeamHandler) ListTeams(c *fiber.Ctx) error {
 func (h *TeamHandler) ShowCreateTeam(c *fiber.Ctx) error {
 	flashData, flashErr := utils.GetFlashMessages(c)
 	if flashErr != nil {
-		utils.Log.Warn("Takım oluşturma formu: Flash mesajları alınamadı", zap.Error(flashErr))
+		utils.LogFrom(c.UserContext()).Warn("Takım oluşturma formu: Flash mesajları alınamadı", zap.Error(flashErr))
 	}
 	return c.Render("dashboard/teams/dashboard_teams_create", fiber.Map{
-		"Title":     "Yeni Takım Ekle",
+		"Title":     utils.T(c, "teams.create.title"),
 		"CsrfToken": c.Locals("csrf"),
 		"Success":   flashData.Success,
 		"Error":     flashData.Error,
@@ -102,7 +110,7 @@ func (h *TeamHandler) CreateTeam(c *fiber.Ctx) error {
 
 	renderError := func(errorMsg string, statusCode int, formData Request) error {
 		return c.Status(statusCode).Render("dashboard/teams/dashboard_teams_create", fiber.Map{
-			"Title":     "Yeni Takım Ekle",
+			"Title":     utils.T(c, "teams.create.title"),
 			"CsrfToken": c.Locals("csrf"),
 			"Error":     errorMsg,
 			"FormData":  formData,
@@ -110,44 +118,44 @@ func (h *TeamHandler) CreateTeam(c *fiber.Ctx) error {
 	}
 
 	if err := c.BodyParser(&req); err != nil {
-		utils.SLog.Warnf("Takım oluşturma isteği ayrıştırılamadı: %v", err)
-		return renderError("Geçersiz form verisi.", fiber.StatusBadRequest, req)
+		utils.SLogFrom(c.UserContext()).Warnf("Takım oluşturma isteği ayrıştırılamadı: %v", err)
+		return renderError(utils.T(c, "form.invalid"), fiber.StatusBadRequest, req)
 	}
 
 	if req.Name == "" {
-		return renderError("Takım adı boş olamaz.", fiber.StatusBadRequest, req)
+		return renderError(utils.T(c, "teams.form.name_required"), fiber.StatusBadRequest, req)
 	}
 
 	status := req.Status == "true"
 	team := models.Team{Name: req.Name, Status: status}
 
-	if err := h.service.CreateTeam(&team); err != nil {
-		utils.Log.Error("Takım oluşturulamadı (Servis Hatası)", zap.String("team_name", team.Name), zap.Error(err))
-		return renderError("Takım oluşturulamadı: "+err.Error(), fiber.StatusInternalServerError, req)
+	if err := h.service.CreateTeam(c.UserContext(), &team); err != nil {
+		utils.LogFrom(c.UserContext()).Error("Takım oluşturulamadı (Servis Hatası)", zap.String("team_name", team.Name), zap.Error(err))
+		return renderError(utils.T(c, "teams.create.failed", utils.TError(c, err)), fiber.StatusInternalServerError, req)
 	}
 
-	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Takım başarıyla oluşturuldu.")
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "teams.create.success")
 	return c.Redirect("/dashboard/teams", fiber.StatusFound)
 }
 
 func (h *TeamHandler) ShowUpdateTeam(c *fiber.Ctx) error {
 	id, err := c.ParamsInt("id")
 	if err != nil || id <= 0 {
-		utils.Log.Warn("Takım güncelleme formu: Geçersiz ID parametresi", zap.String("param", c.Params("id")))
-		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz takım ID'si.")
+		utils.LogFrom(c.UserContext()).Warn("Takım güncelleme formu: Geçersiz ID parametresi", zap.String("param", c.Params("id")))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "teams.invalid_id")
 		return c.Redirect("/dashboard/teams", fiber.StatusSeeOther)
 	}
 	teamID := uint(id)
 
-	team, err := h.service.GetTeamByID(teamID)
+	team, err := h.service.GetTeamByID(c.UserContext(), teamID)
 	if err != nil {
 		var errMsg string
 		if err == services.ErrTeamNotFound {
-			errMsg = "Düzenlenecek takım bulunamadı."
-			utils.Log.Warn("Takım güncelleme formu: Takım bulunamadı", zap.Uint("team_id", teamID))
+			errMsg = "teams.update.not_found"
+			utils.LogFrom(c.UserContext()).Warn("Takım güncelleme formu: Takım bulunamadı", zap.Uint("team_id", teamID))
 		} else {
-			errMsg = "Takım bilgileri getirilirken bir hata oluştu."
-			utils.Log.Error("Takım güncelleme formu: Takım alınamadı (Servis Hatası)", zap.Uint("team_id", teamID), zap.Error(err))
+			errMsg = "teams.update.load_failed"
+			utils.LogFrom(c.UserContext()).Error("Takım güncelleme formu: Takım alınamadı (Servis Hatası)", zap.Uint("team_id", teamID), zap.Error(err))
 		}
 		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, errMsg)
 		return c.Redirect("/dashboard/teams", fiber.StatusSeeOther)
@@ -155,11 +163,11 @@ func (h *TeamHandler) ShowUpdateTeam(c *fiber.Ctx) error {
 
 	flashData, flashErr := utils.GetFlashMessages(c)
 	if flashErr != nil {
-		utils.Log.Warn("Takım güncelleme formu: Flash mesajları alınamadı", zap.Uint("team_id", teamID), zap.Error(flashErr))
+		utils.LogFrom(c.UserContext()).Warn("Takım güncelleme formu: Flash mesajları alınamadı", zap.Uint("team_id", teamID), zap.Error(flashErr))
 	}
 
 	return c.Render("dashboard/teams/dashboard_teams_update", fiber.Map{
-		"Title":     "Takım Düzenle",
+		"Title":     utils.T(c, "teams.update.title"),
 		"Team":      team,
 		"CsrfToken": c.Locals("csrf"),
 		"Success":   flashData.Success,
@@ -170,8 +178,8 @@ func (h *TeamHandler) ShowUpdateTeam(c *fiber.Ctx) error {
 func (h *TeamHandler) UpdateTeam(c *fiber.Ctx) error {
 	id, err := c.ParamsInt("id")
 	if err != nil || id <= 0 {
-		utils.Log.Warn("Takım güncelleme: Geçersiz ID parametresi", zap.String("param", c.Params("id")))
-		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz takım ID'si.")
+		utils.LogFrom(c.UserContext()).Warn("Takım güncelleme: Geçersiz ID parametresi", zap.String("param", c.Params("id")))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "teams.invalid_id")
 		return c.Redirect("/dashboard/teams", fiber.StatusSeeOther)
 	}
 	teamID := uint(id)
@@ -185,9 +193,9 @@ func (h *TeamHandler) UpdateTeam(c *fiber.Ctx) error {
 	var req Request
 
 	renderError := func(errorMsg string, statusCode int, formData Request) error {
-		team, _ := h.service.GetTeamByID(teamID)
+		team, _ := h.service.GetTeamByID(c.UserContext(), teamID)
 		return c.Status(statusCode).Render("dashboard/teams/dashboard_teams_update", fiber.Map{
-			"Title":     "Takım Düzenle",
+			"Title":     utils.T(c, "teams.update.title"),
 			"CsrfToken": c.Locals("csrf"),
 			"Error":     errorMsg,
 			"Team":      team,
@@ -196,13 +204,13 @@ func (h *TeamHandler) UpdateTeam(c *fiber.Ctx) error {
 	}
 
 	if err := c.BodyParser(&req); err != nil {
-		utils.Log.Warn("Takım güncelleme: Form verileri okunamadı", zap.Uint("team_id", teamID), zap.Error(err))
-		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Form verileri okunamadı.")
+		utils.LogFrom(c.UserContext()).Warn("Takım güncelleme: Form verileri okunamadı", zap.Uint("team_id", teamID), zap.Error(err))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "form.unreadable")
 		return c.Redirect(redirectPathOnError, fiber.StatusSeeOther)
 	}
 
 	if req.Name == "" {
-		return renderError("Takım adı boş olamaz.", fiber.StatusBadRequest, req)
+		return renderError(utils.T(c, "teams.form.name_required"), fiber.StatusBadRequest, req)
 	}
 
 	newStatus := req.Status == "true"
@@ -211,48 +219,48 @@ func (h *TeamHandler) UpdateTeam(c *fiber.Ctx) error {
 		Status: newStatus,
 	}
 
-	if err := h.service.UpdateTeam(teamID, teamToUpdate); err != nil {
+	if err := h.service.UpdateTeam(c.UserContext(), teamID, teamToUpdate); err != nil {
 		var errMsg string
 		statusCode := fiber.StatusInternalServerError
 
 		if err == services.ErrTeamNotFound {
-			utils.Log.Warn("Takım güncelleme: Takım bulunamadı (Servis hatası)", zap.Uint("team_id", teamID))
-			errMsg = "Güncellenecek takım bulunamadı."
+			utils.LogFrom(c.UserContext()).Warn("Takım güncelleme: Takım bulunamadı (Servis hatası)", zap.Uint("team_id", teamID))
+			errMsg = "teams.update.not_found"
 			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, errMsg)
 			return c.Redirect(redirectPathOnSuccess, fiber.StatusSeeOther)
 		} else {
-			utils.Log.Error("Takım güncelleme: Servis hatası", zap.Uint("team_id", teamID), zap.Error(err))
-			errMsg = "Takım güncellenemedi: " + err.Error()
+			utils.LogFrom(c.UserContext()).Error("Takım güncelleme: Servis hatası", zap.Uint("team_id", teamID), zap.Error(err))
+			errMsg = utils.T(c, "teams.update.failed", utils.TError(c, err))
 		}
 		return renderError(errMsg, statusCode, req)
 	}
 
-	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Takım başarıyla güncellendi.")
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "teams.update.success")
 	return c.Redirect(redirectPathOnSuccess, fiber.StatusFound)
 }
 
 func (h *TeamHandler) DeleteTeam(c *fiber.Ctx) error {
 	id, err := c.ParamsInt("id")
 	if err != nil || id <= 0 {
-		utils.Log.Warn("Takım silme: Geçersiz ID parametresi", zap.String("param", c.Params("id")))
-		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz takım ID'si.")
+		utils.LogFrom(c.UserContext()).Warn("Takım silme: Geçersiz ID parametresi", zap.String("param", c.Params("id")))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "teams.invalid_id")
 		return c.Redirect("/dashboard/teams", fiber.StatusSeeOther)
 	}
 	teamID := uint(id)
 
-	if err := h.service.DeleteTeam(teamID); err != nil {
+	if err := h.service.DeleteTeam(c.UserContext(), teamID); err != nil {
 		var errMsg string
 		if err == services.ErrTeamNotFound {
-			errMsg = "Silinecek takım bulunamadı."
-			utils.Log.Warn("Takım silme: Takım bulunamadı", zap.Uint("team_id", teamID))
+			errMsg = "teams.delete.not_found"
+			utils.LogFrom(c.UserContext()).Warn("Takım silme: Takım bulunamadı", zap.Uint("team_id", teamID))
 		} else {
-			errMsg = "Takım silinemedi: " + err.Error()
-			utils.Log.Error("Takım silme: Servis hatası", zap.Uint("team_id", teamID), zap.Error(err))
+			errMsg = i18n.CodeOf(err, "teams.delete.failed")
+			utils.LogFrom(c.UserContext()).Error("Takım silme: Servis hatası", zap.Uint("team_id", teamID), zap.Error(err))
 		}
 		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, errMsg)
 		return c.Redirect("/dashboard/teams", fiber.StatusSeeOther)
 	}
 
-	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Takım başarıyla silindi.")
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "teams.delete.success")
 	return c.Redirect("/dashboard/teams", fiber.StatusFound)
 }
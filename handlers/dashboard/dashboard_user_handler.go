@@ -1,7 +1,11 @@
 package handlers // veya handlers/dashboard
 
 import (
+	"bufio"
+	"encoding/csv"
 	"strconv"
+	"time"
+	"zatrano/i18n"
 	"zatrano/models"
 	"zatrano/services"
 	"zatrano/utils"
@@ -15,25 +19,28 @@ type UserHandler struct {
 	teamService services.ITeamService
 }
 
-func NewUserHandler() *UserHandler {
+func NewUserHandler(userService services.IUserService, teamService services.ITeamService) *UserHandler {
 	return &UserHandler{
-		userService: services.NewUserService(),
-		teamService: services.NewTeamService(),
+		userService: userService,
+		teamService: teamService,
 	}
 }
 
 func (h *UserHandler) ListUsers(c *fiber.Ctx) error {
 	flashData, flashErr := utils.GetFlashMessages(c)
 	if flashErr != nil {
-		utils.Log.Warn("Kullanıcı listesi: Flash mesajları alınamadı", zap.Error(flashErr))
+		utils.LogFrom(c.UserContext()).Warn("Kullanıcı listesi: Flash mesajları alınamadı", zap.Error(flashErr))
 	}
 
+	// Sayfa boyutu verilmemişse kullanıcının tercih ettiği boyut kullanılır.
+	defaultPerPage := utils.Prefs(c).PerPage
+
 	var params utils.ListParams
 	// QueryParser'a pointer (&) iletilmeli
 	if err := c.QueryParser(&params); err != nil {
-		utils.Log.Warn("Kullanıcı listesi: Query parametreleri parse edilemedi, varsayılanlar kullanılıyor.", zap.Error(err))
+		utils.LogFrom(c.UserContext()).Warn("Kullanıcı listesi: Query parametreleri parse edilemedi, varsayılanlar kullanılıyor.", zap.Error(err))
 		params = utils.ListParams{
-			Page: utils.DefaultPage, PerPage: utils.DefaultPerPage,
+			Page: utils.DefaultPage, PerPage: defaultPerPage,
 			SortBy: utils.DefaultSortBy, OrderBy: utils.DefaultOrderBy,
 		}
 	}
@@ -42,11 +49,11 @@ func (h *UserHandler) ListUsers(c *fiber.Ctx) error {
 		params.Page = utils.DefaultPage
 	}
 	if params.PerPage <= 0 {
-		params.PerPage = utils.DefaultPerPage
+		params.PerPage = defaultPerPage
 	} else if params.PerPage > utils.MaxPerPage {
-		utils.Log.Warn("Sayfa başına istenen kayıt sayısı limiti aştı, varsayılana çekildi.",
-			zap.Int("requested", params.PerPage), zap.Int("max", utils.MaxPerPage), zap.Int("default", utils.DefaultPerPage))
-		params.PerPage = utils.DefaultPerPage
+		utils.LogFrom(c.UserContext()).Warn("Sayfa başına istenen kayıt sayısı limiti aştı, varsayılana çekildi.",
+			zap.Int("requested", params.PerPage), zap.Int("max", utils.MaxPerPage), zap.Int("default", defaultPerPage))
+		params.PerPage = defaultPerPage
 	}
 	if params.SortBy == "" {
 		params.SortBy = utils.DefaultSortBy
@@ -55,20 +62,33 @@ func (h *UserHandler) ListUsers(c *fiber.Ctx) error {
 		params.OrderBy = utils.DefaultOrderBy
 	}
 
-	paginatedResult, dbErr := h.userService.GetAllUsersPaginated(params)
+	if err := utils.ParseListQuery(c, &params, services.UserListSpec); err != nil {
+		utils.LogFrom(c.UserContext()).Warn("Kullanıcı listesi: Geçersiz filtre veya sıralama parametreleri yok sayıldı", zap.Error(err))
+	}
+
+	paginatedResult, dbErr := h.userService.GetAllUsersPaginated(c.UserContext(), params)
+
+	// Takım filtresi için; alınamazsa filtre yalnızca "Tümü" seçeneğiyle gösterilir.
+	teams, teamErr := h.teamService.GetAllTeams(c.UserContext())
+	if teamErr != nil {
+		utils.LogFrom(c.UserContext()).Warn("Kullanıcı listesi: Takım filtresi için takımlar alınamadı", zap.Error(teamErr))
+		teams = []models.Team{}
+	}
 
 	renderData := fiber.Map{
-		"Title":     "Kullanıcılar",
+		"Title":     utils.T(c, "users.list.title"),
 		"CsrfToken": c.Locals("csrf"),
 		"Result":    paginatedResult,
 		"Params":    params,
+		"Teams":     teams,
+		"UserTypes": []models.UserType{models.System, models.Manager, models.Agent},
 		"Success":   flashData.Success,
 		"Error":     flashData.Error,
 	}
 
 	if dbErr != nil {
-		dbErrMsg := "Kullanıcılar getirilirken bir hata oluştu."
-		utils.Log.Error("Kullanıcı listesi DB Hatası", zap.Error(dbErr))
+		dbErrMsg := utils.T(c, "users.list.load_failed")
+		utils.LogFrom(c.UserContext()).Error("Kullanıcı listesi DB Hatası", zap.Error(dbErr))
 		if existingErr, ok := renderData["Error"].(string); ok && existingErr != "" {
 			renderData["Error"] = existingErr + " | " + dbErrMsg
 		} else {
@@ -86,21 +106,21 @@ func (h *UserHandler) ListUsers(c *fiber.Ctx) error {
 }
 
 func (h *UserHandler) ShowCreateUser(c *fiber.Ctx) error {
-	teams, teamErr := h.teamService.GetAllTeams()
+	teams, teamErr := h.teamService.GetAllTeams(c.UserContext())
 	currentError := ""
 	if teamErr != nil {
-		utils.Log.Error("Kullanıcı oluşturma formu: Takımlar alınamadı", zap.Error(teamErr))
-		currentError = "Takım listesi yüklenemedi, ancak kullanıcı ekleyebilirsiniz."
+		utils.LogFrom(c.UserContext()).Error("Kullanıcı oluşturma formu: Takımlar alınamadı", zap.Error(teamErr))
+		currentError = utils.T(c, "users.form.teams_load_failed_create")
 		teams = []models.Team{}
 	}
 
 	flashData, flashErr := utils.GetFlashMessages(c)
 	if flashErr != nil {
-		utils.Log.Warn("Kullanıcı oluşturma formu: Flash mesajları alınamadı", zap.Error(flashErr))
+		utils.LogFrom(c.UserContext()).Warn("Kullanıcı oluşturma formu: Flash mesajları alınamadı", zap.Error(flashErr))
 	}
 
 	mapData := fiber.Map{
-		"Title":     "Yeni Kullanıcı Ekle",
+		"Title":     utils.T(c, "users.create.title"),
 		"CsrfToken": c.Locals("csrf"),
 		"Teams":     teams,
 		"Success":   flashData.Success,
@@ -133,16 +153,16 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 	var req Request
 
 	renderError := func(errorMsg string, statusCode int, formData Request) error {
-		teams, teamErr := h.teamService.GetAllTeams()
+		teams, teamErr := h.teamService.GetAllTeams(c.UserContext())
 		mapData := fiber.Map{
-			"Title":     "Yeni Kullanıcı Ekle",
+			"Title":     utils.T(c, "users.create.title"),
 			"CsrfToken": c.Locals("csrf"),
 			"Error":     errorMsg,
 			"FormData":  formData,
 		}
 		if teamErr != nil {
-			utils.Log.Error("Kullanıcı oluşturma formu (hata render): Takımlar alınamadı", zap.Error(teamErr))
-			mapData["Error"] = errorMsg + " (Ayrıca takım listesi yüklenemedi.)"
+			utils.LogFrom(c.UserContext()).Error("Kullanıcı oluşturma formu (hata render): Takımlar alınamadı", zap.Error(teamErr))
+			mapData["Error"] = errorMsg + " " + utils.T(c, "users.form.teams_load_failed_also")
 			mapData["Teams"] = []models.Team{}
 		} else {
 			mapData["Teams"] = teams
@@ -151,12 +171,12 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 	}
 
 	if err := c.BodyParser(&req); err != nil {
-		utils.SLog.Warnf("Kullanıcı oluşturma isteği ayrıştırılamadı: %v", err)
-		return renderError("Geçersiz veri formatı veya eksik alanlar.", fiber.StatusBadRequest, req)
+		utils.SLogFrom(c.UserContext()).Warnf("Kullanıcı oluşturma isteği ayrıştırılamadı: %v", err)
+		return renderError(utils.T(c, "form.invalid"), fiber.StatusBadRequest, req)
 	}
 
 	if req.Name == "" || req.Account == "" || req.Password == "" || req.Type == "" {
-		return renderError("Ad, Hesap Adı, Şifre ve Kullanıcı Tipi alanları zorunludur.", fiber.StatusBadRequest, req)
+		return renderError(utils.T(c, "users.create.required_fields"), fiber.StatusBadRequest, req)
 	}
 
 	status := req.Status == "true"
@@ -164,16 +184,16 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 	if req.TeamID != "" {
 		id, err := strconv.ParseUint(req.TeamID, 10, 32)
 		if err != nil {
-			return renderError("Geçersiz takım ID formatı.", fiber.StatusBadRequest, req)
+			return renderError(utils.T(c, "users.form.invalid_team_id"), fiber.StatusBadRequest, req)
 		}
 		uintVal := uint(id)
-		_, err = h.teamService.GetTeamByID(uintVal)
+		_, err = h.teamService.GetTeamByID(c.UserContext(), uintVal)
 		if err != nil {
 			if err == services.ErrTeamNotFound {
-				return renderError("Seçilen takım bulunamadı.", fiber.StatusBadRequest, req)
+				return renderError(utils.T(c, "users.form.team_not_found"), fiber.StatusBadRequest, req)
 			}
-			utils.Log.Error("Kullanıcı oluşturma: Takım ID kontrol hatası", zap.Uint("team_id", uintVal), zap.Error(err))
-			return renderError("Takım bilgisi kontrol edilirken hata oluştu.", fiber.StatusInternalServerError, req)
+			utils.LogFrom(c.UserContext()).Error("Kullanıcı oluşturma: Takım ID kontrol hatası", zap.Uint("team_id", uintVal), zap.Error(err))
+			return renderError(utils.T(c, "users.form.team_check_failed"), fiber.StatusInternalServerError, req)
 		}
 		teamID = &uintVal
 	}
@@ -187,53 +207,53 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 		TeamID:   teamID,
 	}
 
-	if err := h.userService.CreateUser(&user); err != nil {
-		utils.Log.Error("Kullanıcı oluşturulamadı (Servis Hatası)", zap.String("account", req.Account), zap.Error(err))
-		return renderError("Kullanıcı oluşturulamadı: "+err.Error(), fiber.StatusInternalServerError, req)
+	if err := h.userService.CreateUser(c.UserContext(), &user); err != nil {
+		utils.LogFrom(c.UserContext()).Error("Kullanıcı oluşturulamadı (Servis Hatası)", zap.String("account", req.Account), zap.Error(err))
+		return renderError(utils.T(c, "users.create.failed", utils.TError(c, err)), fiber.StatusInternalServerError, req)
 	}
 
-	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Kullanıcı başarıyla oluşturuldu.")
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "users.create.success")
 	return c.Redirect("/dashboard/users", fiber.StatusFound)
 }
 
 func (h *UserHandler) ShowUpdateUser(c *fiber.Ctx) error {
 	id, err := c.ParamsInt("id")
 	if err != nil || id <= 0 {
-		utils.Log.Warn("Kullanıcı güncelleme formu: Geçersiz ID parametresi", zap.String("param", c.Params("id")))
-		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz kullanıcı ID'si.")
+		utils.LogFrom(c.UserContext()).Warn("Kullanıcı güncelleme formu: Geçersiz ID parametresi", zap.String("param", c.Params("id")))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "users.invalid_id")
 		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
 	}
 	userID := uint(id)
 
-	user, err := h.userService.GetUserByID(userID)
+	user, err := h.userService.GetUserByID(c.UserContext(), userID)
 	if err != nil {
 		var errMsg string
 		if err == services.ErrUserServiceUserNotFound {
-			utils.Log.Warn("Kullanıcı güncelleme formu: Kullanıcı bulunamadı", zap.Uint("user_id", userID))
-			errMsg = "Düzenlenecek kullanıcı bulunamadı."
+			utils.LogFrom(c.UserContext()).Warn("Kullanıcı güncelleme formu: Kullanıcı bulunamadı", zap.Uint("user_id", userID))
+			errMsg = "users.update.not_found"
 		} else {
-			utils.Log.Error("Kullanıcı güncelleme formu: Kullanıcı alınamadı (Servis Hatası)", zap.Uint("user_id", userID), zap.Error(err))
-			errMsg = "Kullanıcı bilgileri alınırken hata oluştu."
+			utils.LogFrom(c.UserContext()).Error("Kullanıcı güncelleme formu: Kullanıcı alınamadı (Servis Hatası)", zap.Uint("user_id", userID), zap.Error(err))
+			errMsg = "users.update.load_failed"
 		}
 		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, errMsg)
 		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
 	}
 
-	teams, teamErr := h.teamService.GetAllTeams()
+	teams, teamErr := h.teamService.GetAllTeams(c.UserContext())
 	currentError := ""
 	if teamErr != nil {
-		utils.Log.Error("Kullanıcı güncelleme formu: Takımlar alınamadı", zap.Uint("user_id", userID), zap.Error(teamErr))
-		currentError = "Takım listesi yüklenemedi."
+		utils.LogFrom(c.UserContext()).Error("Kullanıcı güncelleme formu: Takımlar alınamadı", zap.Uint("user_id", userID), zap.Error(teamErr))
+		currentError = utils.T(c, "users.form.teams_load_failed")
 		teams = []models.Team{}
 	}
 
 	flashData, flashErr := utils.GetFlashMessages(c)
 	if flashErr != nil {
-		utils.Log.Warn("Kullanıcı güncelleme formu: Flash mesajları alınamadı", zap.Uint("user_id", userID), zap.Error(flashErr))
+		utils.LogFrom(c.UserContext()).Warn("Kullanıcı güncelleme formu: Flash mesajları alınamadı", zap.Uint("user_id", userID), zap.Error(flashErr))
 	}
 
 	mapData := fiber.Map{
-		"Title":     "Kullanıcı Düzenle",
+		"Title":     utils.T(c, "users.update.title"),
 		"User":      user,
 		"Teams":     teams,
 		"CsrfToken": c.Locals("csrf"),
@@ -264,8 +284,8 @@ func (h *UserHandler) ShowUpdateUser(c *fiber.Ctx) error {
 func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 	id, err := c.ParamsInt("id")
 	if err != nil || id <= 0 {
-		utils.Log.Warn("Kullanıcı güncelleme: Geçersiz ID parametresi", zap.String("param", c.Params("id")))
-		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz kullanıcı ID'si.")
+		utils.LogFrom(c.UserContext()).Warn("Kullanıcı güncelleme: Geçersiz ID parametresi", zap.String("param", c.Params("id")))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "users.invalid_id")
 		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
 	}
 	userID := uint(id)
@@ -282,10 +302,10 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 	var req Request
 
 	renderError := func(errorMsg string, statusCode int, formData Request) error {
-		user, _ := h.userService.GetUserByID(userID)
-		teams, teamErr := h.teamService.GetAllTeams()
+		user, _ := h.userService.GetUserByID(c.UserContext(), userID)
+		teams, teamErr := h.teamService.GetAllTeams(c.UserContext())
 		mapData := fiber.Map{
-			"Title":     "Kullanıcı Düzenle",
+			"Title":     utils.T(c, "users.update.title"),
 			"CsrfToken": c.Locals("csrf"),
 			"Error":     errorMsg,
 			"User":      user,
@@ -303,8 +323,8 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 		mapData["SelectedTeamID"] = selectedTeamID
 
 		if teamErr != nil {
-			utils.Log.Error("Kullanıcı güncelleme formu (hata render): Takımlar alınamadı", zap.Error(teamErr))
-			mapData["Error"] = errorMsg + " (Ayrıca takım listesi yüklenemedi.)"
+			utils.LogFrom(c.UserContext()).Error("Kullanıcı güncelleme formu (hata render): Takımlar alınamadı", zap.Error(teamErr))
+			mapData["Error"] = errorMsg + " " + utils.T(c, "users.form.teams_load_failed_also")
 			mapData["Teams"] = []models.Team{}
 		} else {
 			mapData["Teams"] = teams
@@ -313,12 +333,12 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 	}
 
 	if err := c.BodyParser(&req); err != nil {
-		utils.Log.Warn("Kullanıcı güncelleme: Form verileri okunamadı", zap.Uint("user_id", userID), zap.Error(err))
-		return renderError("Form verileri okunamadı veya eksik.", fiber.StatusBadRequest, req)
+		utils.LogFrom(c.UserContext()).Warn("Kullanıcı güncelleme: Form verileri okunamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return renderError(utils.T(c, "form.unreadable"), fiber.StatusBadRequest, req)
 	}
 
 	if req.Name == "" || req.Account == "" || req.Type == "" {
-		return renderError("Ad, Hesap Adı ve Kullanıcı Tipi alanları zorunludur.", fiber.StatusBadRequest, req)
+		return renderError(utils.T(c, "users.update.required_fields"), fiber.StatusBadRequest, req)
 	}
 
 	status := req.Status == "true"
@@ -326,16 +346,16 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 	if req.TeamID != "" {
 		tid, err := strconv.ParseUint(req.TeamID, 10, 32)
 		if err != nil {
-			return renderError("Geçersiz takım ID formatı.", fiber.StatusBadRequest, req)
+			return renderError(utils.T(c, "users.form.invalid_team_id"), fiber.StatusBadRequest, req)
 		}
 		uintVal := uint(tid)
-		_, err = h.teamService.GetTeamByID(uintVal)
+		_, err = h.teamService.GetTeamByID(c.UserContext(), uintVal)
 		if err != nil {
 			if err == services.ErrTeamNotFound {
-				return renderError("Seçilen takım bulunamadı.", fiber.StatusBadRequest, req)
+				return renderError(utils.T(c, "users.form.team_not_found"), fiber.StatusBadRequest, req)
 			}
-			utils.Log.Error("Kullanıcı güncelleme: Takım ID kontrol hatası", zap.Uint("user_id", userID), zap.Uint("team_id", uintVal), zap.Error(err))
-			return renderError("Takım bilgisi kontrol edilirken hata oluştu.", fiber.StatusInternalServerError, req)
+			utils.LogFrom(c.UserContext()).Error("Kullanıcı güncelleme: Takım ID kontrol hatası", zap.Uint("user_id", userID), zap.Uint("team_id", uintVal), zap.Error(err))
+			return renderError(utils.T(c, "users.form.team_check_failed"), fiber.StatusInternalServerError, req)
 		}
 		teamID = &uintVal
 	}
@@ -351,14 +371,13 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 		userUpdateData.Password = req.Password
 	}
 
-	if err := h.userService.UpdateUser(userID, userUpdateData); err != nil {
-		errMsg := "Kullanıcı güncellenemedi: " + err.Error()
+	if err := h.userService.UpdateUser(c.UserContext(), userID, userUpdateData); err != nil {
+		errMsg := utils.T(c, "users.update.failed", utils.TError(c, err))
 		statusCode := fiber.StatusInternalServerError
 
 		if err == services.ErrUserServiceUserNotFound {
-			utils.Log.Warn("Kullanıcı güncelleme: Kullanıcı bulunamadı (Servis hatası)", zap.Uint("user_id", userID))
-			errMsg = "Güncellenecek kullanıcı bulunamadı."
-			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, errMsg)
+			utils.LogFrom(c.UserContext()).Warn("Kullanıcı güncelleme: Kullanıcı bulunamadı (Servis hatası)", zap.Uint("user_id", userID))
+			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "users.update.not_found")
 			return c.Redirect(redirectPathOnSuccess, fiber.StatusSeeOther)
 		} else if _, ok := err.(models.ModelError); ok {
 			statusCode = fiber.StatusBadRequest
@@ -366,36 +385,169 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 			statusCode = fiber.StatusBadRequest
 		}
 
-		utils.Log.Error("Kullanıcı güncelleme: Handler'da servis hatası yakalandı", zap.Uint("user_id", userID), zap.Error(err))
+		utils.LogFrom(c.UserContext()).Error("Kullanıcı güncelleme: Handler'da servis hatası yakalandı", zap.Uint("user_id", userID), zap.Error(err))
 		return renderError(errMsg, statusCode, req)
 	}
 
-	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Kullanıcı başarıyla güncellendi.")
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "users.update.success")
 	return c.Redirect(redirectPathOnSuccess, fiber.StatusFound)
 }
 
 func (h *UserHandler) DeleteUser(c *fiber.Ctx) error {
 	id, err := c.ParamsInt("id")
 	if err != nil || id <= 0 {
-		utils.Log.Warn("Kullanıcı silme: Geçersiz ID parametresi", zap.String("param", c.Params("id")))
-		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz kullanıcı ID'si.")
+		utils.LogFrom(c.UserContext()).Warn("Kullanıcı silme: Geçersiz ID parametresi", zap.String("param", c.Params("id")))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "users.invalid_id")
 		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
 	}
 	userID := uint(id)
 
-	if err := h.userService.DeleteUser(userID); err != nil {
+	if err := h.userService.DeleteUser(c.UserContext(), userID); err != nil {
 		var errMsg string
 		if err == services.ErrUserServiceUserNotFound {
-			utils.Log.Warn("Kullanıcı silme: Kullanıcı bulunamadı", zap.Uint("user_id", userID))
-			errMsg = "Silinecek kullanıcı bulunamadı."
+			utils.LogFrom(c.UserContext()).Warn("Kullanıcı silme: Kullanıcı bulunamadı", zap.Uint("user_id", userID))
+			errMsg = "users.delete.not_found"
 		} else {
-			utils.Log.Error("Kullanıcı silme: Servis hatası", zap.Uint("user_id", userID), zap.Error(err))
-			errMsg = "Kullanıcı silinemedi: " + err.Error()
+			utils.LogFrom(c.UserContext()).Error("Kullanıcı silme: Servis hatası", zap.Uint("user_id", userID), zap.Error(err))
+			errMsg = i18n.CodeOf(err, "users.delete.failed")
 		}
 		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, errMsg)
 		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
 	}
 
-	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Kullanıcı başarıyla silindi.")
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "users.delete.success")
 	return c.Redirect("/dashboard/users", fiber.StatusFound)
 }
+
+type userAPIItem struct {
+	ID        uint            `json:"id"`
+	Name      string          `json:"name"`
+	Account   string          `json:"account"`
+	Type      models.UserType `json:"type"`
+	Status    bool            `json:"status"`
+	TeamID    *uint           `json:"team_id"`
+	TeamName  string          `json:"team_name,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+func newUserAPIItem(u models.User) userAPIItem {
+	item := userAPIItem{
+		ID: u.ID, Name: u.Name, Account: u.Account, Type: u.Type,
+		Status: u.Status, TeamID: u.TeamID, CreatedAt: u.CreatedAt,
+	}
+	if u.Team != nil {
+		item.TeamName = u.Team.Name
+	}
+	return item
+}
+
+func cursorListParams(c *fiber.Ctx) (utils.ListParams, utils.CursorParams) {
+	var params utils.ListParams
+	if err := c.QueryParser(&params); err != nil {
+		utils.LogFrom(c.UserContext()).Warn("Kullanıcı API: Query parametreleri parse edilemedi, varsayılanlar kullanılıyor.", zap.Error(err))
+		params = utils.ListParams{}
+	}
+	if err := utils.ParseListQuery(c, &params, services.UserListSpec); err != nil {
+		utils.LogFrom(c.UserContext()).Warn("Kullanıcı API: Geçersiz filtre veya sıralama parametreleri yok sayıldı", zap.Error(err))
+	}
+
+	var cursor utils.CursorParams
+	if err := c.QueryParser(&cursor); err != nil {
+		utils.LogFrom(c.UserContext()).Warn("Kullanıcı API: İmleç parametreleri parse edilemedi", zap.Error(err))
+		cursor = utils.CursorParams{}
+	}
+	return params, cursor
+}
+
+func (h *UserHandler) ListUsersAPI(c *fiber.Ctx) error {
+	params, cursor := cursorListParams(c)
+
+	result, err := h.userService.GetUsersByCursor(c.UserContext(), params, cursor)
+	if err != nil {
+		if err == services.ErrUserInvalidCursor {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": utils.TError(c, err)})
+		}
+		utils.LogFrom(c.UserContext()).Error("Kullanıcı API DB Hatası", zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": utils.T(c, "users.list.load_failed")})
+	}
+
+	users, _ := result.Data.([]models.User)
+	items := make([]userAPIItem, 0, len(users))
+	for _, u := range users {
+		items = append(items, newUserAPIItem(u))
+	}
+	result.Data = items
+	return c.JSON(result)
+}
+
+func (h *UserHandler) ExportUsers(c *fiber.Ctx) error {
+	params, _ := cursorListParams(c)
+	ctx := c.UserContext()
+	prefs := utils.Prefs(c)
+	locale := utils.Locale(c)
+	header := []string{
+		i18n.T(locale, "common.id"),
+		i18n.T(locale, "users.field.name"),
+		i18n.T(locale, "users.field.account"),
+		i18n.T(locale, "users.field.type"),
+		i18n.T(locale, "users.field.team"),
+		i18n.T(locale, "common.status"),
+		i18n.T(locale, "common.created_at"),
+	}
+
+	// İlk sayfa akış başlamadan okunur; böylece veritabanı hataları yarım
+	// bir dosya yerine hata yanıtı olarak döner.
+	cursor := utils.CursorParams{Limit: utils.MaxCursorLimit}
+	first, err := h.userService.GetUsersByCursor(ctx, params, cursor)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Kullanıcı dışa aktarma DB Hatası", zap.Error(err))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "users.list.load_failed")
+		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
+	}
+
+	filename := "users-" + time.Now().In(prefs.Location).Format("20060102-150405") + ".csv"
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+filename+`"`)
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		out := csv.NewWriter(w)
+		_ = out.Write(header)
+
+		result := first
+		for {
+			users, _ := result.Data.([]models.User)
+			for _, u := range users {
+				item := newUserAPIItem(u)
+				status := i18n.T(locale, "common.active")
+				if !item.Status {
+					status = i18n.T(locale, "common.passive")
+				}
+				_ = out.Write(utils.CSVSafeRecord([]string{
+					strconv.FormatUint(uint64(item.ID), 10),
+					item.Name,
+					item.Account,
+					i18n.T(locale, "users.type."+string(item.Type)),
+					item.TeamName,
+					status,
+					prefs.FormatDateTime(item.CreatedAt),
+				}))
+			}
+			out.Flush()
+			if err := w.Flush(); err != nil {
+				utils.LogFrom(ctx).Warn("Kullanıcı dışa aktarma: İstemci bağlantısı kapandı", zap.Error(err))
+				return
+			}
+			if result.Meta.NextCursor == "" {
+				return
+			}
+
+			cursor.Cursor = result.Meta.NextCursor
+			result, err = h.userService.GetUsersByCursor(ctx, params, cursor)
+			if err != nil {
+				utils.LogFrom(ctx).Error("Kullanıcı dışa aktarma yarıda kesildi", zap.Error(err))
+				return
+			}
+		}
+	})
+	return nil
+}
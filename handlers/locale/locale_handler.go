@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"zatrano/i18n"
+	"zatrano/services"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+const localeCookieMaxAge = 365 * 24 * time.Hour
+
+type LocaleHandler struct {
+	preferences services.IUserPreferenceService
+}
+
+func NewLocaleHandler(preferences services.IUserPreferenceService) *LocaleHandler {
+	return &LocaleHandler{preferences: preferences}
+}
+
+func (h *LocaleHandler) SetLocale(c *fiber.Ctx) error {
+	locale := i18n.Normalize(c.Params("locale"))
+	if locale == "" {
+		return c.Redirect(backURL(c), fiber.StatusSeeOther)
+	}
+
+	h.savePreference(c, locale)
+
+	c.Cookie(&fiber.Cookie{
+		Name:     utils.LocaleCookieName,
+		Value:    locale,
+		Path:     "/",
+		Expires:  time.Now().Add(localeCookieMaxAge),
+		Secure:   c.Protocol() == "https",
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+	return c.Redirect(backURL(c), fiber.StatusSeeOther)
+}
+
+func (h *LocaleHandler) savePreference(c *fiber.Ctx, locale string) {
+	sess, err := utils.SessionStart(c)
+	if err != nil {
+		return
+	}
+	userID, err := utils.GetUserIDFromSession(sess)
+	if err != nil {
+		return
+	}
+
+	pref, err := h.preferences.GetPreferences(c.UserContext(), userID)
+	if err != nil {
+		return
+	}
+	pref.Locale = locale
+	if err := h.preferences.UpdatePreferences(c.UserContext(), userID, pref); err != nil {
+		utils.LogFrom(c.UserContext()).Warn("Dil tercihi kaydedilemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return
+	}
+
+	sess.Set(utils.SessionLocaleKey, locale)
+	if err := sess.Save(); err != nil {
+		utils.LogFrom(c.UserContext()).Warn("Dil tercihi session'a yazılamadı", zap.Uint("user_id", userID), zap.Error(err))
+	}
+}
+
+// backURL, Referer aynı host'u gösteriyorsa onun yolunu, aksi halde "/" döner.
+// Başka sitelere açık yönlendirme yapılmaması için yalnızca yol kullanılır.
+func backURL(c *fiber.Ctx) string {
+	referer, err := url.Parse(c.Get(fiber.HeaderReferer))
+	if err != nil || referer.Host != c.Hostname() || !strings.HasPrefix(referer.Path, "/") || strings.HasPrefix(referer.Path, "//") {
+		return "/"
+	}
+	if referer.RawQuery != "" {
+		return referer.Path + "?" + referer.RawQuery
+	}
+	return referer.Path
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"zatrano/i18n"
+	"zatrano/models"
+	"zatrano/services"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type AttendanceHandler struct {
+	service services.IAttendanceService
+}
+
+func NewAttendanceHandler(service services.IAttendanceService) *AttendanceHandler {
+	return &AttendanceHandler{service: service}
+}
+
+func reportQuery(c *fiber.Ctx) services.AttendanceReportQuery {
+	prefs := utils.Prefs(c)
+	query := services.AttendanceReportQuery{
+		Period:   services.AttendancePeriodDay,
+		Date:     models.CivilDate(prefs.Now()),
+		Location: prefs.Location,
+		Now:      time.Now(),
+	}
+	if services.AttendancePeriod(c.Query("view")) == services.AttendancePeriodWeek {
+		query.Period = services.AttendancePeriodWeek
+	}
+	if date, err := time.Parse(utils.DateInputLayout, c.Query("date")); err == nil {
+		query.Date = date
+	}
+	return query
+}
+
+func (h *AttendanceHandler) ShowReport(c *fiber.Ctx) error {
+	manager, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	flashData, flashErr := utils.GetFlashMessages(c)
+	if flashErr != nil {
+		utils.LogFrom(c.UserContext()).Warn("Devam raporu: Flash mesajları alınamadı", zap.Error(flashErr))
+	}
+
+	query := reportQuery(c)
+	report, err := h.service.Report(c.UserContext(), manager, query)
+	if err != nil {
+		utils.LogFrom(c.UserContext()).Error("Devam raporu alınamadı", zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).Render("manager/attendance/manager_attendance_report", fiber.Map{
+			"Title": utils.T(c, "attendance.report.title"),
+			"Error": utils.T(c, "attendance.report.load_failed"),
+		}, "layouts/manager_layout")
+	}
+
+	return c.Render("manager/attendance/manager_attendance_report", fiber.Map{
+		"Title":   utils.T(c, "attendance.report.title"),
+		"Report":  report,
+		"Date":    query.Date.Format(utils.DateInputLayout),
+		"Success": flashData.Success,
+		"Error":   flashData.Error,
+	}, "layouts/manager_layout")
+}
+
+func (h *AttendanceHandler) ExportReport(c *fiber.Ctx) error {
+	manager, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	query := reportQuery(c)
+	report, err := h.service.Report(c.UserContext(), manager, query)
+	if err != nil {
+		utils.LogFrom(c.UserContext()).Error("Devam raporu dışa aktarılamadı", zap.Error(err))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, i18n.CodeOf(err, "attendance.report.load_failed"))
+		return c.Redirect("/manager/attendance", fiber.StatusSeeOther)
+	}
+
+	prefs := utils.Prefs(c)
+	locale := utils.Locale(c)
+	yesNo := func(v bool) string {
+		if v {
+			return i18n.T(locale, "common.yes")
+		}
+		return i18n.T(locale, "common.no")
+	}
+	minutes := func(d time.Duration) string {
+		return strconv.Itoa(int(d.Round(time.Minute) / time.Minute))
+	}
+
+	var out strings.Builder
+	w := csv.NewWriter(&out)
+	_ = w.Write([]string{
+		i18n.T(locale, "attendance.field.date"),
+		i18n.T(locale, "attendance.field.agent"),
+		i18n.T(locale, "users.field.account"),
+		i18n.T(locale, "attendance.field.shifts"),
+		i18n.T(locale, "attendance.field.clock_in"),
+		i18n.T(locale, "attendance.field.clock_out"),
+		i18n.T(locale, "attendance.field.break_minutes"),
+		i18n.T(locale, "attendance.field.worked_minutes"),
+		i18n.T(locale, "attendance.field.late_minutes"),
+		i18n.T(locale, "attendance.field.absent"),
+		i18n.T(locale, "attendance.field.auto_closed"),
+		i18n.T(locale, "attendance.field.ip_addresses"),
+	})
+	for _, row := range report.Rows {
+		for _, day := range row.Days {
+			if len(day.Sessions) == 0 && len(day.Checks) == 0 {
+				continue
+			}
+			var shifts, ips []string
+			var late time.Duration
+			for _, check := range day.Checks {
+				shifts = append(shifts, check.Occurrence.Shift.Name+" "+check.Occurrence.Shift.StartClock()+"-"+check.Occurrence.Shift.EndClock())
+				late += check.LateBy
+			}
+			var clockIn, clockOut string
+			if n := len(day.Sessions); n > 0 {
+				clockIn = prefs.FormatDateTime(day.Sessions[0].ClockInAt)
+				if last := day.Sessions[n-1].ClockOutAt; last != nil {
+					clockOut = prefs.FormatDateTime(*last)
+				}
+			}
+			autoClosed := false
+			for _, session := range day.Sessions {
+				autoClosed = autoClosed || session.AutoClosed
+				for _, ip := range []string{session.ClockInIP, session.ClockOutIP} {
+					if ip != "" && !slices.Contains(ips, ip) {
+						ips = append(ips, ip)
+					}
+				}
+			}
+			_ = w.Write(utils.CSVSafeRecord([]string{
+				day.Date.Format(utils.DateInputLayout),
+				row.Agent.Name,
+				row.Agent.Account,
+				strings.Join(shifts, ", "),
+				clockIn,
+				clockOut,
+				minutes(day.Break),
+				minutes(day.Worked),
+				minutes(late),
+				yesNo(day.Absent()),
+				yesNo(autoClosed),
+				strings.Join(ips, " "),
+			}))
+		}
+	}
+	w.Flush()
+
+	filename := "attendance-" + string(report.Period) + "-" + report.Start.Format(utils.DateInputLayout) + ".csv"
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+filename+`"`)
+	return c.SendString(out.String())
+}
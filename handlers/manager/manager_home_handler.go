@@ -1,11 +1,13 @@
 package handlers
 
 import (
+	"zatrano/utils"
+
 	"github.com/gofiber/fiber/v2"
 )
 
 func ManagerHomeHandler(c *fiber.Ctx) error {
 	return c.Render("manager/home/manager_home", fiber.Map{
-		"Title": "Manager Ana Sayfa",
+		"Title": utils.T(c, "manager.home.title"),
 	}, "layouts/manager_layout")
 }
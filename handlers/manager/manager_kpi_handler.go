@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"strconv"
+
+	"zatrano/i18n"
+	"zatrano/services"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type KPIHandler struct {
+	service services.IKPIService
+}
+
+func NewKPIHandler(service services.IKPIService) *KPIHandler {
+	return &KPIHandler{service: service}
+}
+
+func (h *KPIHandler) ShowScorecard(c *fiber.Ctx) error {
+	manager, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	flashData, flashErr := utils.GetFlashMessages(c)
+	if flashErr != nil {
+		utils.LogFrom(c.UserContext()).Warn("Takım karnesi: Flash mesajları alınamadı", zap.Error(flashErr))
+	}
+
+	definitionID, _ := strconv.ParseUint(c.Query("kpi"), 10, 64)
+	weeks := c.QueryInt("weeks", services.KPIScorecardDefaultWeeks)
+	scorecard, err := h.service.TeamScorecard(c.UserContext(), manager, uint(definitionID), utils.Prefs(c).Now(), weeks)
+	if err != nil {
+		if err == services.ErrKPINotFound {
+			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, i18n.CodeOf(err, ""))
+			return c.Redirect("/manager/scorecard", fiber.StatusSeeOther)
+		}
+		utils.LogFrom(c.UserContext()).Error("Takım karnesi alınamadı", zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).Render("manager/kpis/manager_kpis_scorecard", fiber.Map{
+			"Title": utils.T(c, "kpis.scorecard.team_title"),
+			"Error": utils.T(c, "kpis.scorecard.load_failed"),
+		}, "layouts/manager_layout")
+	}
+
+	return c.Render("manager/kpis/manager_kpis_scorecard", fiber.Map{
+		"Title":       utils.T(c, "kpis.scorecard.team_title"),
+		"Scorecard":   scorecard,
+		"Weeks":       len(scorecard.Weeks),
+		"WeekOptions": services.KPIScorecardWeekOptions,
+		"Success":     flashData.Success,
+		"Error":       flashData.Error,
+	}, "layouts/manager_layout")
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"zatrano/i18n"
+	"zatrano/models"
+	"zatrano/services"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type LeaveHandler struct {
+	service services.ILeaveService
+}
+
+func NewLeaveHandler(service services.ILeaveService) *LeaveHandler {
+	return &LeaveHandler{service: service}
+}
+
+const monthLayout = "2006-01"
+
+func calendarURL(month string) string {
+	if _, err := time.Parse(monthLayout, month); err == nil {
+		return "/manager/leaves?month=" + month
+	}
+	return "/manager/leaves"
+}
+
+func (h *LeaveHandler) ShowCalendar(c *fiber.Ctx) error {
+	manager, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	flashData, flashErr := utils.GetFlashMessages(c)
+	if flashErr != nil {
+		utils.LogFrom(c.UserContext()).Warn("İzin takvimi: Flash mesajları alınamadı", zap.Error(flashErr))
+	}
+
+	today := models.CivilDate(utils.Prefs(c).Now())
+	month := today
+	if m, err := time.Parse(monthLayout, c.Query("month")); err == nil {
+		month = m
+	}
+	calendar, err := h.service.TeamCalendar(c.UserContext(), manager, month)
+	if err != nil {
+		utils.LogFrom(c.UserContext()).Error("İzin takvimi alınamadı", zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).Render("manager/leaves/manager_leaves_calendar", fiber.Map{
+			"Title": utils.T(c, "leaves.manager.title"),
+			"Error": utils.T(c, "leaves.manager.load_failed"),
+		}, "layouts/manager_layout")
+	}
+
+	return c.Render("manager/leaves/manager_leaves_calendar", fiber.Map{
+		"Title":     utils.T(c, "leaves.manager.title"),
+		"CsrfToken": c.Locals("csrf"),
+		"Calendar":  calendar,
+		"Today":     today,
+		"Month":     calendar.Month.Format(monthLayout),
+		"Success":   flashData.Success,
+		"Error":     flashData.Error,
+	}, "layouts/manager_layout")
+}
+
+func (h *LeaveHandler) decide(c *fiber.Ctx, successKey string, action func(ctx context.Context, actor *models.User, id uint, comment string) error) error {
+	manager, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	month := c.FormValue("month")
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "errors.leave.not_found")
+		return c.Redirect(calendarURL(month), fiber.StatusSeeOther)
+	}
+
+	if err := action(c.UserContext(), manager, uint(id), c.FormValue("comment")); err != nil {
+		utils.LogFrom(c.UserContext()).Warn("İzin talebi karara bağlanamadı", zap.Int("leave_id", id), zap.Error(err))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, i18n.CodeOf(err, "errors.leave.update_failed"))
+		return c.Redirect(calendarURL(month), fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, successKey)
+	return c.Redirect(calendarURL(month), fiber.StatusFound)
+}
+
+func (h *LeaveHandler) ApproveLeave(c *fiber.Ctx) error {
+	return h.decide(c, "leaves.approve.success", h.service.ApproveLeave)
+}
+
+func (h *LeaveHandler) RejectLeave(c *fiber.Ctx) error {
+	return h.decide(c, "leaves.reject.success", h.service.RejectLeave)
+}
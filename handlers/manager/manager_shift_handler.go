@@ -0,0 +1,338 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+
+	"zatrano/i18n"
+	"zatrano/models"
+	"zatrano/services"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type ShiftHandler struct {
+	service services.IShiftService
+}
+
+func NewShiftHandler(service services.IShiftService) *ShiftHandler {
+	return &ShiftHandler{service: service}
+}
+
+type shiftForm struct {
+	Name  string `form:"name"`
+	Start string `form:"start"`
+	End   string `form:"end"`
+	Days  []int  `form:"days"`
+}
+
+func newShiftForm(shift *models.Shift) shiftForm {
+	form := shiftForm{Name: shift.Name, Start: shift.StartClock(), End: shift.EndClock()}
+	for _, d := range shift.Days.Weekdays() {
+		form.Days = append(form.Days, int(d))
+	}
+	return form
+}
+
+func (f shiftForm) HasDay(d time.Weekday) bool {
+	for _, day := range f.Days {
+		if day == int(d) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f shiftForm) toShift() (shift models.Shift, ok bool) {
+	start, err := models.ParseClock(f.Start)
+	if err != nil {
+		return shift, false
+	}
+	end, err := models.ParseClock(f.End)
+	if err != nil {
+		return shift, false
+	}
+	var days []time.Weekday
+	for _, d := range f.Days {
+		if d >= 0 && d < 7 {
+			days = append(days, time.Weekday(d))
+		}
+	}
+	return models.Shift{Name: f.Name, StartMinute: start, EndMinute: end, Days: models.NewWeekdaySet(days...)}, true
+}
+
+type assignmentForm struct {
+	UserID    uint   `form:"user_id"`
+	ShiftID   uint   `form:"shift_id"`
+	StartDate string `form:"start_date"`
+	EndDate   string `form:"end_date"`
+	Week      string `form:"week"`
+}
+
+func scheduleURL(week string) string {
+	if date, err := time.Parse(utils.DateInputLayout, week); err == nil {
+		return "/manager/shifts?week=" + models.WeekStart(date).Format(utils.DateInputLayout)
+	}
+	return "/manager/shifts"
+}
+
+func (h *ShiftHandler) ShowSchedule(c *fiber.Ctx) error {
+	manager, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	flashData, flashErr := utils.GetFlashMessages(c)
+	if flashErr != nil {
+		utils.LogFrom(c.UserContext()).Warn("Vardiya takvimi: Flash mesajları alınamadı", zap.Error(flashErr))
+	}
+
+	date := models.CivilDate(utils.Prefs(c).Now())
+	if week, err := time.Parse(utils.DateInputLayout, c.Query("week")); err == nil {
+		date = week
+	}
+	schedule, err := h.service.WeekSchedule(c.UserContext(), manager, date)
+	if err != nil {
+		utils.LogFrom(c.UserContext()).Error("Vardiya takvimi alınamadı", zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).Render("manager/shifts/manager_shifts_schedule", fiber.Map{
+			"Title": utils.T(c, "shifts.schedule.title"),
+			"Error": utils.T(c, "shifts.schedule.load_failed"),
+		}, "layouts/manager_layout")
+	}
+
+	form := assignmentForm{
+		StartDate: schedule.Start.Format(utils.DateInputLayout),
+		EndDate:   schedule.End.Format(utils.DateInputLayout),
+		Week:      schedule.Start.Format(utils.DateInputLayout),
+	}
+	if agentID, err := strconv.ParseUint(c.Query("agent"), 10, 64); err == nil {
+		form.UserID = uint(agentID)
+	}
+	if day, err := time.Parse(utils.DateInputLayout, c.Query("date")); err == nil {
+		form.StartDate = day.Format(utils.DateInputLayout)
+		form.EndDate = form.StartDate
+	}
+
+	return c.Render("manager/shifts/manager_shifts_schedule", fiber.Map{
+		"Title":     utils.T(c, "shifts.schedule.title"),
+		"CsrfToken": c.Locals("csrf"),
+		"Schedule":  schedule,
+		"FormData":  form,
+		"Success":   flashData.Success,
+		"Error":     flashData.Error,
+	}, "layouts/manager_layout")
+}
+
+func (h *ShiftHandler) CreateAssignment(c *fiber.Ctx) error {
+	manager, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	var req assignmentForm
+	if err := c.BodyParser(&req); err != nil {
+		utils.SLogFrom(c.UserContext()).Warnf("Vardiya ataması isteği ayrıştırılamadı: %v", err)
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "form.invalid")
+		return c.Redirect(scheduleURL(req.Week), fiber.StatusSeeOther)
+	}
+
+	startDate, startErr := time.Parse(utils.DateInputLayout, req.StartDate)
+	endDate, endErr := time.Parse(utils.DateInputLayout, req.EndDate)
+	if startErr != nil || endErr != nil {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "errors.shift.invalid_date_range")
+		return c.Redirect(scheduleURL(req.Week), fiber.StatusSeeOther)
+	}
+
+	assignment := models.ShiftAssignment{ShiftID: req.ShiftID, UserID: req.UserID, StartDate: startDate, EndDate: endDate}
+	if err := h.service.AssignShift(c.UserContext(), manager, &assignment); err != nil {
+		utils.LogFrom(c.UserContext()).Warn("Vardiya ataması oluşturulamadı", zap.Uint("user_id", req.UserID), zap.Uint("shift_id", req.ShiftID), zap.Error(err))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, i18n.CodeOf(err, "errors.shift.assignment_failed"))
+		return c.Redirect(scheduleURL(req.Week), fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "shifts.assignment.created")
+	return c.Redirect(scheduleURL(req.Week), fiber.StatusFound)
+}
+
+func (h *ShiftHandler) DeleteAssignment(c *fiber.Ctx) error {
+	manager, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	week := c.FormValue("week")
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "errors.shift.assignment_not_found")
+		return c.Redirect(scheduleURL(week), fiber.StatusSeeOther)
+	}
+
+	if err := h.service.DeleteAssignment(c.UserContext(), manager, uint(id)); err != nil {
+		utils.LogFrom(c.UserContext()).Warn("Vardiya ataması silinemedi", zap.Int("assignment_id", id), zap.Error(err))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, i18n.CodeOf(err, "errors.shift.assignment_deletion_failed"))
+		return c.Redirect(scheduleURL(week), fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "shifts.assignment.deleted")
+	return c.Redirect(scheduleURL(week), fiber.StatusFound)
+}
+
+func (h *ShiftHandler) ListShifts(c *fiber.Ctx) error {
+	manager, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	flashData, flashErr := utils.GetFlashMessages(c)
+	if flashErr != nil {
+		utils.LogFrom(c.UserContext()).Warn("Vardiya listesi: Flash mesajları alınamadı", zap.Error(flashErr))
+	}
+
+	shifts, err := h.service.ListShifts(c.UserContext(), manager)
+	renderData := fiber.Map{
+		"Title":     utils.T(c, "shifts.list.title"),
+		"CsrfToken": c.Locals("csrf"),
+		"Shifts":    shifts,
+		"Success":   flashData.Success,
+		"Error":     flashData.Error,
+	}
+	if err != nil {
+		utils.LogFrom(c.UserContext()).Error("Vardiya listesi alınamadı", zap.Error(err))
+		renderData["Error"] = utils.T(c, "shifts.list.load_failed")
+	}
+	return c.Render("manager/shifts/manager_shifts_list", renderData, "layouts/manager_layout")
+}
+
+func (h *ShiftHandler) ShowCreateShift(c *fiber.Ctx) error {
+	return c.Render("manager/shifts/manager_shifts_create", fiber.Map{
+		"Title":     utils.T(c, "shifts.create.title"),
+		"CsrfToken": c.Locals("csrf"),
+		"Weekdays":  models.ShiftWeekdays,
+		"FormData":  shiftForm{Start: "09:00", End: "18:00", Days: []int{1, 2, 3, 4, 5}},
+	}, "layouts/manager_layout")
+}
+
+func (h *ShiftHandler) CreateShift(c *fiber.Ctx) error {
+	manager, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	var req shiftForm
+
+	renderError := func(errorMsg string, statusCode int) error {
+		return c.Status(statusCode).Render("manager/shifts/manager_shifts_create", fiber.Map{
+			"Title":     utils.T(c, "shifts.create.title"),
+			"CsrfToken": c.Locals("csrf"),
+			"Weekdays":  models.ShiftWeekdays,
+			"Error":     errorMsg,
+			"FormData":  req,
+		}, "layouts/manager_layout")
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		utils.SLogFrom(c.UserContext()).Warnf("Vardiya oluşturma isteği ayrıştırılamadı: %v", err)
+		return renderError(utils.T(c, "form.invalid"), fiber.StatusBadRequest)
+	}
+	shift, ok := req.toShift()
+	if !ok {
+		return renderError(utils.T(c, "errors.shift.invalid_time"), fiber.StatusBadRequest)
+	}
+
+	if err := h.service.CreateShift(c.UserContext(), manager, &shift); err != nil {
+		utils.LogFrom(c.UserContext()).Warn("Vardiya oluşturulamadı", zap.Error(err))
+		return renderError(utils.TError(c, err), fiber.StatusBadRequest)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "shifts.create.success")
+	return c.Redirect("/manager/shifts/definitions", fiber.StatusFound)
+}
+
+func (h *ShiftHandler) ShowUpdateShift(c *fiber.Ctx) error {
+	manager, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "errors.shift.not_found")
+		return c.Redirect("/manager/shifts/definitions", fiber.StatusSeeOther)
+	}
+
+	shift, err := h.service.GetShift(c.UserContext(), manager, uint(id))
+	if err != nil {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, i18n.CodeOf(err, "errors.shift.not_found"))
+		return c.Redirect("/manager/shifts/definitions", fiber.StatusSeeOther)
+	}
+
+	return c.Render("manager/shifts/manager_shifts_update", fiber.Map{
+		"Title":     utils.T(c, "shifts.update.title"),
+		"CsrfToken": c.Locals("csrf"),
+		"ShiftID":   shift.ID,
+		"Weekdays":  models.ShiftWeekdays,
+		"FormData":  newShiftForm(shift),
+	}, "layouts/manager_layout")
+}
+
+func (h *ShiftHandler) UpdateShift(c *fiber.Ctx) error {
+	manager, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "errors.shift.not_found")
+		return c.Redirect("/manager/shifts/definitions", fiber.StatusSeeOther)
+	}
+	var req shiftForm
+
+	renderError := func(errorMsg string, statusCode int) error {
+		return c.Status(statusCode).Render("manager/shifts/manager_shifts_update", fiber.Map{
+			"Title":     utils.T(c, "shifts.update.title"),
+			"CsrfToken": c.Locals("csrf"),
+			"ShiftID":   id,
+			"Weekdays":  models.ShiftWeekdays,
+			"Error":     errorMsg,
+			"FormData":  req,
+		}, "layouts/manager_layout")
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		utils.SLogFrom(c.UserContext()).Warnf("Vardiya güncelleme isteği ayrıştırılamadı: %v", err)
+		return renderError(utils.T(c, "form.invalid"), fiber.StatusBadRequest)
+	}
+	shift, ok := req.toShift()
+	if !ok {
+		return renderError(utils.T(c, "errors.shift.invalid_time"), fiber.StatusBadRequest)
+	}
+
+	if err := h.service.UpdateShift(c.UserContext(), manager, uint(id), &shift); err != nil {
+		if err == services.ErrShiftNotFound {
+			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "errors.shift.not_found")
+			return c.Redirect("/manager/shifts/definitions", fiber.StatusSeeOther)
+		}
+		utils.LogFrom(c.UserContext()).Warn("Vardiya güncellenemedi", zap.Int("shift_id", id), zap.Error(err))
+		return renderError(utils.TError(c, err), fiber.StatusBadRequest)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "shifts.update.success")
+	return c.Redirect("/manager/shifts/definitions", fiber.StatusFound)
+}
+
+func (h *ShiftHandler) DeleteShift(c *fiber.Ctx) error {
+	manager, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "errors.shift.not_found")
+		return c.Redirect("/manager/shifts/definitions", fiber.StatusSeeOther)
+	}
+
+	if err := h.service.DeleteShift(c.UserContext(), manager, uint(id)); err != nil {
+		utils.LogFrom(c.UserContext()).Warn("Vardiya silinemedi", zap.Int("shift_id", id), zap.Error(err))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, i18n.CodeOf(err, "errors.shift.deletion_failed"))
+		return c.Redirect("/manager/shifts/definitions", fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "shifts.delete.success")
+	return c.Redirect("/manager/shifts/definitions", fiber.StatusFound)
+}
@@ -0,0 +1,353 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+
+	"zatrano/i18n"
+	"zatrano/models"
+	"zatrano/services"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type TaskHandler struct {
+	service services.ITaskService
+}
+
+func NewTaskHandler(service services.ITaskService) *TaskHandler {
+	return &TaskHandler{service: service}
+}
+
+type taskForm struct {
+	Title       string `form:"title"`
+	Description string `form:"description"`
+	DueDate     string `form:"due_date"`
+	Priority    string `form:"priority"`
+	AssigneeID  uint   `form:"assignee_id"`
+}
+
+func newTaskForm(task *models.Task) taskForm {
+	form := taskForm{Title: task.Title, Description: task.Description, Priority: string(task.Priority), AssigneeID: task.AssigneeID}
+	if task.DueDate != nil {
+		form.DueDate = task.DueDate.Format(utils.DateInputLayout)
+	}
+	return form
+}
+
+func (f taskForm) toTask() (task models.Task, ok bool) {
+	task = models.Task{Title: f.Title, Description: f.Description, Priority: models.TaskPriority(f.Priority), AssigneeID: f.AssigneeID}
+	if f.DueDate != "" {
+		due, err := time.Parse(utils.DateInputLayout, f.DueDate)
+		if err != nil {
+			return task, false
+		}
+		task.DueDate = &due
+	}
+	return task, true
+}
+
+func taskURL(id int) string {
+	return "/manager/tasks/" + strconv.Itoa(id)
+}
+
+func (h *TaskHandler) ListTasks(c *fiber.Ctx) error {
+	manager, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	flashData, flashErr := utils.GetFlashMessages(c)
+	if flashErr != nil {
+		utils.LogFrom(c.UserContext()).Warn("Görev listesi: Flash mesajları alınamadı", zap.Error(flashErr))
+	}
+
+	prefs := utils.Prefs(c)
+	defaultPerPage := prefs.PerPage
+
+	var params utils.ListParams
+	if err := c.QueryParser(&params); err != nil {
+		utils.LogFrom(c.UserContext()).Warn("Görev listesi: Query parametreleri parse edilemedi, varsayılanlar kullanılıyor.", zap.Error(err))
+		params = utils.ListParams{
+			Page:    utils.DefaultPage,
+			PerPage: defaultPerPage,
+			SortBy:  "id",
+			OrderBy: utils.DefaultOrderBy,
+		}
+	}
+
+	if params.Page <= 0 {
+		params.Page = utils.DefaultPage
+	}
+	if params.PerPage <= 0 || params.PerPage > utils.MaxPerPage {
+		params.PerPage = defaultPerPage
+	}
+	if params.SortBy == "" {
+		params.SortBy = "id"
+	}
+	if params.OrderBy == "" {
+		params.OrderBy = utils.DefaultOrderBy
+	}
+
+	if err := utils.ParseListQuery(c, &params, services.TaskListSpec); err != nil {
+		utils.LogFrom(c.UserContext()).Warn("Görev listesi: Geçersiz filtre veya sıralama parametreleri yok sayıldı", zap.Error(err))
+	}
+
+	today := models.CivilDate(prefs.Now())
+	paginatedResult, err := h.service.ListTeamTasks(c.UserContext(), manager, params, today)
+	agents, agentsErr := h.service.TeamAgents(c.UserContext(), manager)
+
+	renderData := fiber.Map{
+		"Title":      utils.T(c, "tasks.list.title"),
+		"CsrfToken":  c.Locals("csrf"),
+		"Result":     paginatedResult,
+		"Params":     params,
+		"Agents":     agents,
+		"Statuses":   models.TaskStatuses,
+		"Priorities": models.TaskPriorities,
+		"Today":      today,
+		"Success":    flashData.Success,
+		"Error":      flashData.Error,
+	}
+	if err != nil || agentsErr != nil {
+		utils.LogFrom(c.UserContext()).Error("Görev listesi alınamadı", zap.NamedError("tasks", err), zap.NamedError("agents", agentsErr))
+		renderData["Error"] = utils.T(c, "tasks.list.load_failed")
+		renderData["Result"] = &utils.PaginatedResult{
+			Data: []models.Task{},
+			Meta: utils.PaginationMeta{CurrentPage: params.Page, PerPage: params.PerPage},
+		}
+	}
+	return c.Render("manager/tasks/manager_tasks_list", renderData, "layouts/manager_layout")
+}
+
+func (h *TaskHandler) ShowCreateTask(c *fiber.Ctx) error {
+	manager, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	agents, err := h.service.TeamAgents(c.UserContext(), manager)
+	renderData := fiber.Map{
+		"Title":      utils.T(c, "tasks.create.title"),
+		"CsrfToken":  c.Locals("csrf"),
+		"Agents":     agents,
+		"Priorities": models.TaskPriorities,
+		"FormData":   taskForm{Priority: string(models.TaskPriorityNormal)},
+	}
+	if err != nil {
+		utils.LogFrom(c.UserContext()).Error("Görev formu: Takım ajanları alınamadı", zap.Error(err))
+		renderData["Error"] = utils.TError(c, err)
+	}
+	return c.Render("manager/tasks/manager_tasks_create", renderData, "layouts/manager_layout")
+}
+
+func (h *TaskHandler) CreateTask(c *fiber.Ctx) error {
+	manager, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	var req taskForm
+
+	renderError := func(errorMsg string, statusCode int) error {
+		agents, _ := h.service.TeamAgents(c.UserContext(), manager)
+		return c.Status(statusCode).Render("manager/tasks/manager_tasks_create", fiber.Map{
+			"Title":      utils.T(c, "tasks.create.title"),
+			"CsrfToken":  c.Locals("csrf"),
+			"Agents":     agents,
+			"Priorities": models.TaskPriorities,
+			"Error":      errorMsg,
+			"FormData":   req,
+		}, "layouts/manager_layout")
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		utils.SLogFrom(c.UserContext()).Warnf("Görev oluşturma isteği ayrıştırılamadı: %v", err)
+		return renderError(utils.T(c, "form.invalid"), fiber.StatusBadRequest)
+	}
+	task, ok := req.toTask()
+	if !ok {
+		return renderError(utils.T(c, "tasks.invalid_due_date"), fiber.StatusBadRequest)
+	}
+
+	if err := h.service.CreateTask(c.UserContext(), manager, &task); err != nil {
+		utils.LogFrom(c.UserContext()).Warn("Görev oluşturulamadı", zap.Error(err))
+		return renderError(utils.TError(c, err), fiber.StatusBadRequest)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "tasks.create.success")
+	return c.Redirect("/manager/tasks", fiber.StatusFound)
+}
+
+func (h *TaskHandler) ShowUpdateTask(c *fiber.Ctx) error {
+	manager, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "errors.task.not_found")
+		return c.Redirect("/manager/tasks", fiber.StatusSeeOther)
+	}
+
+	detail, err := h.service.GetTask(c.UserContext(), manager, uint(id))
+	if err != nil {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, i18n.CodeOf(err, "errors.task.not_found"))
+		return c.Redirect("/manager/tasks", fiber.StatusSeeOther)
+	}
+	agents, err := h.service.TeamAgents(c.UserContext(), manager)
+	renderData := fiber.Map{
+		"Title":      utils.T(c, "tasks.update.title"),
+		"CsrfToken":  c.Locals("csrf"),
+		"TaskID":     detail.Task.ID,
+		"Agents":     agents,
+		"Priorities": models.TaskPriorities,
+		"FormData":   newTaskForm(detail.Task),
+	}
+	if err != nil {
+		utils.LogFrom(c.UserContext()).Error("Görev formu: Takım ajanları alınamadı", zap.Error(err))
+		renderData["Error"] = utils.TError(c, err)
+	}
+	return c.Render("manager/tasks/manager_tasks_update", renderData, "layouts/manager_layout")
+}
+
+func (h *TaskHandler) UpdateTask(c *fiber.Ctx) error {
+	manager, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "errors.task.not_found")
+		return c.Redirect("/manager/tasks", fiber.StatusSeeOther)
+	}
+	var req taskForm
+
+	renderError := func(errorMsg string, statusCode int) error {
+		agents, _ := h.service.TeamAgents(c.UserContext(), manager)
+		return c.Status(statusCode).Render("manager/tasks/manager_tasks_update", fiber.Map{
+			"Title":      utils.T(c, "tasks.update.title"),
+			"CsrfToken":  c.Locals("csrf"),
+			"TaskID":     id,
+			"Agents":     agents,
+			"Priorities": models.TaskPriorities,
+			"Error":      errorMsg,
+			"FormData":   req,
+		}, "layouts/manager_layout")
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		utils.SLogFrom(c.UserContext()).Warnf("Görev güncelleme isteği ayrıştırılamadı: %v", err)
+		return renderError(utils.T(c, "form.invalid"), fiber.StatusBadRequest)
+	}
+	task, ok := req.toTask()
+	if !ok {
+		return renderError(utils.T(c, "tasks.invalid_due_date"), fiber.StatusBadRequest)
+	}
+
+	if err := h.service.UpdateTask(c.UserContext(), manager, uint(id), &task); err != nil {
+		if err == services.ErrTaskNotFound {
+			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "errors.task.not_found")
+			return c.Redirect("/manager/tasks", fiber.StatusSeeOther)
+		}
+		utils.LogFrom(c.UserContext()).Warn("Görev güncellenemedi", zap.Int("task_id", id), zap.Error(err))
+		return renderError(utils.TError(c, err), fiber.StatusBadRequest)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "tasks.update.success")
+	return c.Redirect(taskURL(id), fiber.StatusFound)
+}
+
+func (h *TaskHandler) ShowTask(c *fiber.Ctx) error {
+	manager, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "errors.task.not_found")
+		return c.Redirect("/manager/tasks", fiber.StatusSeeOther)
+	}
+	flashData, flashErr := utils.GetFlashMessages(c)
+	if flashErr != nil {
+		utils.LogFrom(c.UserContext()).Warn("Görev ayrıntısı: Flash mesajları alınamadı", zap.Error(flashErr))
+	}
+
+	detail, err := h.service.GetTask(c.UserContext(), manager, uint(id))
+	if err != nil {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, i18n.CodeOf(err, "errors.task.not_found"))
+		return c.Redirect("/manager/tasks", fiber.StatusSeeOther)
+	}
+
+	return c.Render("manager/tasks/manager_tasks_detail", fiber.Map{
+		"Title":     detail.Task.Title,
+		"CsrfToken": c.Locals("csrf"),
+		"Detail":    detail,
+		"Today":     models.CivilDate(utils.Prefs(c).Now()),
+		"Success":   flashData.Success,
+		"Error":     flashData.Error,
+	}, "layouts/manager_layout")
+}
+
+func (h *TaskHandler) ChangeStatus(c *fiber.Ctx) error {
+	manager, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "errors.task.not_found")
+		return c.Redirect("/manager/tasks", fiber.StatusSeeOther)
+	}
+
+	status := models.TaskStatus(c.FormValue("status"))
+	if err := h.service.ChangeStatus(c.UserContext(), manager, uint(id), status); err != nil {
+		utils.LogFrom(c.UserContext()).Warn("Görev durumu değiştirilemedi", zap.Int("task_id", id), zap.String("status", string(status)), zap.Error(err))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, i18n.CodeOf(err, "errors.task.update_failed"))
+		return c.Redirect(taskURL(id), fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "tasks.status_changed")
+	return c.Redirect(taskURL(id), fiber.StatusFound)
+}
+
+func (h *TaskHandler) AddComment(c *fiber.Ctx) error {
+	manager, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "errors.task.not_found")
+		return c.Redirect("/manager/tasks", fiber.StatusSeeOther)
+	}
+
+	if err := h.service.AddComment(c.UserContext(), manager, uint(id), c.FormValue("body")); err != nil {
+		utils.LogFrom(c.UserContext()).Warn("Görev yorumu eklenemedi", zap.Int("task_id", id), zap.Error(err))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, i18n.CodeOf(err, "errors.task.comment_failed"))
+		return c.Redirect(taskURL(id), fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "tasks.comments.added")
+	return c.Redirect(taskURL(id), fiber.StatusFound)
+}
+
+func (h *TaskHandler) DeleteTask(c *fiber.Ctx) error {
+	manager, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login")
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "errors.task.not_found")
+		return c.Redirect("/manager/tasks", fiber.StatusSeeOther)
+	}
+
+	if err := h.service.DeleteTask(c.UserContext(), manager, uint(id)); err != nil {
+		utils.LogFrom(c.UserContext()).Warn("Görev silinemedi", zap.Int("task_id", id), zap.Error(err))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, i18n.CodeOf(err, "errors.task.deletion_failed"))
+		return c.Redirect("/manager/tasks", fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "tasks.delete.success")
+	return c.Redirect("/manager/tasks", fiber.StatusFound)
+}
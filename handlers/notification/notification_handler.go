@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"zatrano/models"
+	"zatrano/services"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+const streamHeartbeat = 25 * time.Second
+
+const streamRetryMillis = 5000
+
+type NotificationHandler struct {
+	service services.INotificationService
+}
+
+func NewNotificationHandler(service services.INotificationService) *NotificationHandler {
+	return &NotificationHandler{service: service}
+}
+
+type notificationItem struct {
+	ID        uint   `json:"id"`
+	Message   string `json:"message"`
+	Link      string `json:"link,omitempty"`
+	Read      bool   `json:"read"`
+	CreatedAt string `json:"created_at"`
+}
+
+func newNotificationItem(n models.Notification, locale string, prefs utils.Preferences) notificationItem {
+	return notificationItem{
+		ID:        n.ID,
+		Message:   n.Message(locale),
+		Link:      n.Link,
+		Read:      n.IsRead(),
+		CreatedAt: prefs.FormatDateTime(n.CreatedAt),
+	}
+}
+
+type notificationList struct {
+	Unread int64              `json:"unread"`
+	Items  []notificationItem `json:"items"`
+}
+
+func (h *NotificationHandler) list(c *fiber.Ctx, userID uint) error {
+	notifications, err := h.service.ListRecent(c.UserContext(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": utils.T(c, "notifications.load_failed")})
+	}
+	unread, err := h.service.UnreadCount(c.UserContext(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": utils.T(c, "notifications.load_failed")})
+	}
+
+	locale, prefs := utils.Locale(c), utils.Prefs(c)
+	result := notificationList{Unread: unread, Items: make([]notificationItem, len(notifications))}
+	for i, n := range notifications {
+		result.Items[i] = newNotificationItem(n, locale, prefs)
+	}
+	return c.JSON(result)
+}
+
+func (h *NotificationHandler) ListNotifications(c *fiber.Ctx) error {
+	user, ok := utils.CurrentUser(c)
+	if !ok {
+		return fiber.ErrUnauthorized
+	}
+	return h.list(c, user.ID)
+}
+
+func (h *NotificationHandler) MarkRead(c *fiber.Ctx) error {
+	user, ok := utils.CurrentUser(c)
+	if !ok {
+		return fiber.ErrUnauthorized
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": utils.T(c, "notifications.invalid_id")})
+	}
+	if err := h.service.MarkRead(c.UserContext(), user.ID, uint(id)); err != nil {
+		status := fiber.StatusInternalServerError
+		if err == services.ErrNotificationNotFound {
+			status = fiber.StatusNotFound
+		}
+		return c.Status(status).JSON(fiber.Map{"error": utils.TError(c, err)})
+	}
+	return h.list(c, user.ID)
+}
+
+func (h *NotificationHandler) MarkAllRead(c *fiber.Ctx) error {
+	user, ok := utils.CurrentUser(c)
+	if !ok {
+		return fiber.ErrUnauthorized
+	}
+	if err := h.service.MarkAllRead(c.UserContext(), user.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": utils.TError(c, err)})
+	}
+	return h.list(c, user.ID)
+}
+
+func (h *NotificationHandler) Stream(c *fiber.Ctx) error {
+	user, ok := utils.CurrentUser(c)
+	if !ok {
+		return fiber.ErrUnauthorized
+	}
+
+	// Yazıcı, handler döndükten sonra çalışır; c'ye bağlı değerler önceden alınır.
+	locale, prefs := utils.Locale(c), utils.Prefs(c)
+	logger := utils.LogFrom(c.UserContext())
+	stream, cancel := h.service.Subscribe(user.ID)
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	// nginx gibi proxy'lerin yanıtı tamponlamaması için.
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+		heartbeat := time.NewTicker(streamHeartbeat)
+		defer heartbeat.Stop()
+
+		fmt.Fprintf(w, "retry: %d\n\n", streamRetryMillis)
+		if err := w.Flush(); err != nil {
+			return
+		}
+		for {
+			select {
+			case n, open := <-stream:
+				if !open {
+					return
+				}
+				data, err := json.Marshal(newNotificationItem(n, locale, prefs))
+				if err != nil {
+					logger.Error("Bildirim akışa yazılamadı", zap.Uint("notification_id", n.ID), zap.Error(err))
+					continue
+				}
+				fmt.Fprintf(w, "event: notification\ndata: %s\n\n", data)
+			case <-heartbeat.C:
+				fmt.Fprint(w, ": ping\n\n")
+			}
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+	return nil
+}
@@ -0,0 +1,41 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"zatrano/database/migrations"
+
+	"gorm.io/gorm"
+)
+
+func DatabaseCheck(db *gorm.DB) Check {
+	return Check{Name: "database", Run: func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	}}
+}
+
+func TableCheck(name string, db *gorm.DB, table string) Check {
+	return Check{Name: name, Run: func(ctx context.Context) error {
+		var probe int
+		return db.WithContext(ctx).Table(table).Select("1").Limit(1).Scan(&probe).Error
+	}}
+}
+
+func MigrationsCheck(db *gorm.DB) Check {
+	return Check{Name: "migrations", Run: func(ctx context.Context) error {
+		pending, err := migrations.Pending(db.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		if len(pending) > 0 {
+			return fmt.Errorf("bekleyen migrasyonlar: %s", strings.Join(pending, ", "))
+		}
+		return nil
+	}}
+}
@@ -0,0 +1,134 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func okCheck(name string) Check {
+	return Check{Name: name, Run: func(context.Context) error { return nil }}
+}
+
+func readyz(t *testing.T, checker *Checker) (int, Report) {
+	t.Helper()
+	app := fiber.New()
+	Mount(app, checker)
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, ReadinessPath, nil), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report Report
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, report
+}
+
+func TestLiveness(t *testing.T) {
+	app := fiber.New()
+	Mount(app, NewChecker(Check{Name: "down", Run: func(context.Context) error { return errors.New("down") }}))
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, LivenessPath, nil), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("status = %d, want 200 regardless of dependencies", resp.StatusCode)
+	}
+}
+
+func TestReadiness(t *testing.T) {
+	tests := []struct {
+		name       string
+		checks     []Check
+		draining   bool
+		wantStatus int
+		wantFailed string
+	}{
+		{name: "all checks pass", checks: []Check{okCheck("database"), okCheck("migrations")}, wantStatus: fiber.StatusOK},
+		{
+			name:       "failing check",
+			checks:     []Check{okCheck("database"), {Name: "migrations", Run: func(context.Context) error { return errors.New("bekleyen") }}},
+			wantStatus: fiber.StatusServiceUnavailable,
+			wantFailed: "migrations",
+		},
+		{name: "draining", checks: []Check{okCheck("database")}, draining: true, wantStatus: fiber.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewChecker(tt.checks...)
+			if tt.draining {
+				checker.SetDraining()
+			}
+			status, report := readyz(t, checker)
+			if status != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", status, tt.wantStatus)
+			}
+			if len(report.Checks) != len(tt.checks) {
+				t.Fatalf("got %d check results, want %d", len(report.Checks), len(tt.checks))
+			}
+			if tt.wantFailed != "" && report.Checks[tt.wantFailed].Error == "" {
+				t.Fatalf("%s result has no error: %+v", tt.wantFailed, report.Checks[tt.wantFailed])
+			}
+			if report.Draining != tt.draining {
+				t.Fatalf("draining = %v, want %v", report.Draining, tt.draining)
+			}
+		})
+	}
+}
+
+func TestReadinessCheckTimeout(t *testing.T) {
+	checker := NewChecker(Check{Name: "slow", Run: func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}})
+	checker.timeout = 20 * time.Millisecond
+
+	report := checker.Check(context.Background())
+	if report.Status != StatusFail || report.Checks["slow"].Error == "" {
+		t.Fatalf("slow check did not time out: %+v", report)
+	}
+}
+
+func TestDatabaseChecks(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file:health?mode=memory&cache=shared"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	report := NewChecker(
+		DatabaseCheck(db),
+		TableCheck("session_storage", db, "sessions"),
+		MigrationsCheck(db),
+	).Check(context.Background())
+
+	if report.Checks["database"].Status != StatusOK {
+		t.Fatalf("database check = %+v", report.Checks["database"])
+	}
+	if report.Checks["session_storage"].Status != StatusFail {
+		t.Fatal("session_storage check passed without a sessions table")
+	}
+	if got := report.Checks["migrations"].Error; !strings.Contains(got, "teams") || !strings.Contains(got, "users") {
+		t.Fatalf("migrations error = %q, want pending teams and users", got)
+	}
+
+	if err := db.Exec("CREATE TABLE sessions (k TEXT PRIMARY KEY, v BLOB, e INTEGER)").Error; err != nil {
+		t.Fatal(err)
+	}
+	report = NewChecker(TableCheck("session_storage", db, "sessions")).Check(context.Background())
+	if report.Status != StatusOK {
+		t.Fatalf("session_storage check failed with the table present: %+v", report)
+	}
+}
@@ -0,0 +1,99 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+const (
+	TR = "tr"
+	EN = "en"
+
+	DefaultLocale = TR
+
+	ErrUnexpected = "errors.unexpected"
+)
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+var catalogs = mustLoadCatalogs()
+
+func mustLoadCatalogs() map[string]map[string]string {
+	entries, err := localeFiles.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+	loaded := make(map[string]map[string]string, len(entries))
+	for _, entry := range entries {
+		data, err := localeFiles.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			panic(err)
+		}
+		messages := map[string]string{}
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("i18n: %s ayrıştırılamadı: %v", entry.Name(), err))
+		}
+		loaded[strings.TrimSuffix(entry.Name(), ".json")] = messages
+	}
+	if _, ok := loaded[DefaultLocale]; !ok {
+		panic("i18n: varsayılan dil kataloğu bulunamadı: " + DefaultLocale)
+	}
+	return loaded
+}
+
+func Supported() []string {
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+func IsSupported(locale string) bool {
+	_, ok := catalogs[locale]
+	return ok
+}
+
+func Messages(locale string) map[string]string {
+	messages := make(map[string]string, len(catalogs[locale]))
+	for id, text := range catalogs[locale] {
+		messages[id] = text
+	}
+	return messages
+}
+
+func T(locale, id string, args ...interface{}) string {
+	text, ok := catalogs[locale][id]
+	if !ok {
+		if text, ok = catalogs[DefaultLocale][id]; !ok {
+			text = id
+		}
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(text, args...)
+	}
+	return text
+}
+
+type Coder interface {
+	Code() string
+}
+
+func CodeOf(err error, fallback string) string {
+	var coder Coder
+	if errors.As(err, &coder) {
+		return coder.Code()
+	}
+	return fallback
+}
+
+func Error(locale string, err error) string {
+	return T(locale, CodeOf(err, ErrUnexpected))
+}
@@ -0,0 +1,130 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+)
+
+type codedError string
+
+func (e codedError) Error() string { return string(e) }
+func (e codedError) Code() string  { return string(e) }
+
+func TestCatalogsHaveSameMessages(t *testing.T) {
+	reference := Messages(DefaultLocale)
+	for _, locale := range Supported() {
+		messages := Messages(locale)
+		for id := range reference {
+			if messages[id] == "" {
+				t.Errorf("%s: %q eksik", locale, id)
+			}
+		}
+		for id := range messages {
+			if _, ok := reference[id]; !ok {
+				t.Errorf("%s: %q varsayılan katalogda yok", locale, id)
+			}
+		}
+		for id, text := range messages {
+			if got, want := strings.Count(text, "%"), strings.Count(reference[id], "%"); got != want {
+				t.Errorf("%s: %q biçim parametreleri farklı (%d, varsayılan %d)", locale, id, got, want)
+			}
+		}
+	}
+}
+
+var templateCall = regexp.MustCompile(`\bT\s+[$.\w]+\s+"([^"]+)"`)
+
+func TestTemplateMessagesExist(t *testing.T) {
+	var missing []string
+	err := filepath.WalkDir("../views", func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".html" {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, match := range templateCall.FindAllStringSubmatch(string(data), -1) {
+			if _, ok := catalogs[DefaultLocale][match[1]]; !ok {
+				missing = append(missing, fmt.Sprintf("%s: %s", path, match[1]))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(missing)
+	for _, m := range missing {
+		t.Error("katalogda olmayan mesaj:", m)
+	}
+}
+
+func TestT(t *testing.T) {
+	tests := []struct {
+		name   string
+		locale string
+		id     string
+		args   []interface{}
+		want   string
+	}{
+		{name: "turkish", locale: TR, id: "common.save", want: "Kaydet"},
+		{name: "english", locale: EN, id: "common.save", want: "Save"},
+		{name: "unknown locale falls back to default", locale: "de", id: "common.save", want: "Kaydet"},
+		{name: "unknown id is returned as is", locale: EN, id: "Eski flash mesajı", want: "Eski flash mesajı"},
+		{name: "arguments", locale: EN, id: "list.showing", args: []interface{}{42, 21, 40}, want: "Showing 21 - 40 of 42 records."},
+		{name: "reordered arguments", locale: TR, id: "list.showing", args: []interface{}{42, 21, 40}, want: "Toplam 42 kayıttan 21 - 40 arası gösteriliyor."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := T(tt.locale, tt.id, tt.args...); got != tt.want {
+				t.Fatalf("T() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	coded := codedError("errors.team.not_found")
+	if got := Error(EN, fmt.Errorf("wrapped: %w", coded)); got != "team not found" {
+		t.Fatalf("Error(wrapped coded) = %q", got)
+	}
+	if got := Error(EN, errors.New("pq: connection refused")); got != T(EN, ErrUnexpected) {
+		t.Fatalf("Error(plain) = %q, internal details must not leak", got)
+	}
+	if got := CodeOf(errors.New("x"), "teams.delete.failed"); got != "teams.delete.failed" {
+		t.Fatalf("CodeOf() fallback = %q", got)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name           string
+		preference     string
+		cookie         string
+		acceptLanguage string
+		want           string
+	}{
+		{name: "nothing", want: DefaultLocale},
+		{name: "accept-language", acceptLanguage: "en-US,en;q=0.9", want: EN},
+		{name: "accept-language quality order", acceptLanguage: "de;q=1, tr;q=0.5, en;q=0.8", want: EN},
+		{name: "unsupported accept-language", acceptLanguage: "de-DE, fr;q=0.9", want: DefaultLocale},
+		{name: "zero quality is ignored", acceptLanguage: "en;q=0, tr;q=0.1", want: TR},
+		{name: "cookie beats header", cookie: "tr", acceptLanguage: "en", want: TR},
+		{name: "preference beats cookie", preference: "en", cookie: "tr", acceptLanguage: "tr", want: EN},
+		{name: "invalid preference is skipped", preference: "xx", cookie: "EN_gb", want: EN},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Resolve(tt.preference, tt.cookie, tt.acceptLanguage); got != tt.want {
+				t.Fatalf("Resolve() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func Normalize(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	if IsSupported(tag) {
+		return tag
+	}
+	return ""
+}
+
+func MatchAcceptLanguage(header string) string {
+	type candidate struct {
+		tag string
+		q   float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		if name, value, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.TrimSpace(name) == "q" {
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{tag: tag, q: q})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	for _, c := range candidates {
+		if locale := Normalize(c.tag); locale != "" {
+			return locale
+		}
+	}
+	return ""
+}
+
+func Resolve(preference, cookie, acceptLanguage string) string {
+	for _, tag := range []string{preference, cookie} {
+		if locale := Normalize(tag); locale != "" {
+			return locale
+		}
+	}
+	if locale := MatchAcceptLanguage(acceptLanguage); locale != "" {
+		return locale
+	}
+	return DefaultLocale
+}
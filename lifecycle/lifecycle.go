@@ -0,0 +1,181 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"time"
+
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+)
+
+type Manager struct {
+	timeout time.Duration
+
+	mu       sync.Mutex
+	hooks    []hook
+	running  map[string]struct{}
+	stopping bool
+
+	failed chan error
+}
+
+const lateStepTimeout = time.Second
+
+type hook struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+func New(timeout time.Duration) *Manager {
+	return &Manager{
+		timeout: timeout,
+		running: map[string]struct{}{},
+		failed:  make(chan error, 1),
+	}
+}
+
+func (m *Manager) OnShutdown(name string, fn func(ctx context.Context) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hooks = append(m.hooks, hook{name: name, fn: fn})
+}
+
+func (m *Manager) Go(name string, fn func(ctx context.Context) error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	m.mu.Lock()
+	m.running[name] = struct{}{}
+	m.mu.Unlock()
+
+	go func() {
+		defer close(done)
+		err := fn(ctx)
+
+		m.mu.Lock()
+		delete(m.running, name)
+		stopping := m.stopping
+		m.mu.Unlock()
+
+		if err != nil && !errors.Is(err, context.Canceled) && !stopping {
+			m.Fail(fmt.Errorf("%s beklenmedik şekilde durdu: %w", name, err))
+		}
+	}()
+
+	m.OnShutdown(name, func(shutdownCtx context.Context) error {
+		cancel()
+		select {
+		case <-done:
+			return nil
+		case <-shutdownCtx.Done():
+			return shutdownCtx.Err()
+		}
+	})
+}
+
+func (m *Manager) Fail(err error) {
+	select {
+	case m.failed <- err:
+	default:
+	}
+}
+
+func (m *Manager) Wait(signals ...os.Signal) int {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, signals...)
+	defer signal.Stop(sig)
+
+	exitCode := 0
+	select {
+	case s := <-sig:
+		utils.Log.Info("Kapatma sinyali alındı, uygulama kapatılıyor...", zap.String("signal", s.String()))
+	case err := <-m.failed:
+		utils.Log.Error("Kritik hata, uygulama kapatılıyor...", zap.Error(err))
+		exitCode = 1
+	}
+
+	if err := m.Shutdown(); err != nil {
+		exitCode = 1
+	}
+	return exitCode
+}
+
+func (m *Manager) Shutdown() error {
+	m.mu.Lock()
+	m.stopping = true
+	hooks := append([]hook(nil), m.hooks...)
+	m.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	var stuck []string
+	var errs []error
+	for i := len(hooks) - 1; i >= 0; i-- {
+		h := hooks[i]
+		start := time.Now()
+		finished, err := runHook(ctx, h)
+		switch {
+		case !finished:
+			stuck = append(stuck, h.name)
+		case err != nil:
+			errs = append(errs, fmt.Errorf("%s: %w", h.name, err))
+			utils.Log.Error("Kapanış adımı başarısız oldu", zap.String("step", h.name), zap.Error(err))
+		default:
+			utils.Log.Debug("Kapanış adımı tamamlandı", zap.String("step", h.name), zap.Duration("duration", time.Since(start)))
+		}
+	}
+
+	if len(stuck) > 0 {
+		utils.Log.Warn("Kapanış zaman aşımına uğradı",
+			zap.Duration("timeout", m.timeout),
+			zap.Strings("unfinished_steps", stuck),
+			zap.Strings("running_workers", m.Running()),
+		)
+		errs = append(errs, fmt.Errorf("kapanış zaman aşımı: %v", stuck))
+	}
+	if len(errs) == 0 {
+		utils.Log.Info("Uygulama başarıyla sonlandırıldı.")
+	}
+	utils.SyncLogger()
+	return errors.Join(errs...)
+}
+
+func runHook(ctx context.Context, h hook) (bool, error) {
+	if ctx.Err() != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), lateStepTimeout)
+		defer cancel()
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- h.fn(ctx) }()
+
+	select {
+	case err := <-done:
+		if err != nil && ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+			return false, err
+		}
+		return true, err
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+func (m *Manager) Running() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	names := make([]string, 0, len(m.running))
+	for name := range m.running {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,141 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func observeLogs(t *testing.T) *observer.ObservedLogs {
+	t.Helper()
+	core, logs := observer.New(zapcore.DebugLevel)
+	previous, previousSugar := utils.Log, utils.SLog
+	utils.Log = zap.New(core)
+	utils.SLog = utils.Log.Sugar()
+	t.Cleanup(func() { utils.Log, utils.SLog = previous, previousSugar })
+	return logs
+}
+
+type recorder struct {
+	mu    sync.Mutex
+	steps []string
+}
+
+func (r *recorder) step(name string) func(context.Context) error {
+	return func(context.Context) error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.steps = append(r.steps, name)
+		return nil
+	}
+}
+
+func TestShutdownRunsStepsInReverseOrder(t *testing.T) {
+	observeLogs(t)
+	m := New(time.Second)
+	rec := &recorder{}
+
+	m.OnShutdown("database", rec.step("database"))
+	m.Go("worker", func(ctx context.Context) error {
+		<-ctx.Done()
+		return rec.step("worker")(ctx)
+	})
+	m.OnShutdown("http", rec.step("http"))
+
+	if err := m.Shutdown(); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if want := []string{"http", "worker", "database"}; !reflect.DeepEqual(rec.steps, want) {
+		t.Fatalf("steps = %v, want %v", rec.steps, want)
+	}
+	if running := m.Running(); len(running) != 0 {
+		t.Fatalf("Running() = %v after shutdown", running)
+	}
+}
+
+func TestShutdownTimeoutReportsStuckWork(t *testing.T) {
+	logs := observeLogs(t)
+	m := New(50 * time.Millisecond)
+	rec := &recorder{}
+
+	m.OnShutdown("database", rec.step("database"))
+	m.Go("stubborn worker", func(ctx context.Context) error {
+		time.Sleep(time.Hour)
+		return nil
+	})
+
+	err := m.Shutdown()
+	if err == nil {
+		t.Fatal("Shutdown() succeeded despite a stuck worker")
+	}
+	if !reflect.DeepEqual(rec.steps, []string{"database"}) {
+		t.Fatalf("steps after timeout = %v, database must still be closed", rec.steps)
+	}
+
+	entries := logs.FilterMessage("Kapanış zaman aşımına uğradı").All()
+	if len(entries) != 1 {
+		t.Fatalf("got %d timeout reports, want 1", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	if !reflect.DeepEqual(fields["running_workers"], []interface{}{"stubborn worker"}) {
+		t.Fatalf("running_workers = %v", fields["running_workers"])
+	}
+	if !reflect.DeepEqual(fields["unfinished_steps"], []interface{}{"stubborn worker"}) {
+		t.Fatalf("unfinished_steps = %v", fields["unfinished_steps"])
+	}
+}
+
+func TestWait(t *testing.T) {
+	tests := []struct {
+		name     string
+		trigger  func(m *Manager)
+		wantCode int
+	}{
+		{name: "signal", trigger: func(*Manager) { _ = syscall.Kill(syscall.Getpid(), syscall.SIGUSR1) }, wantCode: 0},
+		{name: "fail", trigger: func(m *Manager) { m.Fail(errors.New("dinlenemedi")) }, wantCode: 1},
+		{name: "worker error", trigger: func(m *Manager) {
+			m.Go("crashing worker", func(context.Context) error { return errors.New("çöktü") })
+		}, wantCode: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observeLogs(t)
+			m := New(time.Second)
+			rec := &recorder{}
+			m.OnShutdown("database", rec.step("database"))
+
+			code := make(chan int, 1)
+			started := make(chan struct{})
+			go func() {
+				close(started)
+				code <- m.Wait(syscall.SIGUSR1)
+			}()
+			<-started
+			time.Sleep(10 * time.Millisecond) // signal.Notify'ın kurulmasını bekle
+			tt.trigger(m)
+
+			select {
+			case got := <-code:
+				if got != tt.wantCode {
+					t.Fatalf("Wait() = %d, want %d", got, tt.wantCode)
+				}
+			case <-time.After(2 * time.Second):
+				t.Fatal("Wait() did not return")
+			}
+			if !reflect.DeepEqual(rec.steps, []string{"database"}) {
+				t.Fatalf("steps = %v, want shutdown to run", rec.steps)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"zatrano/configs"
+)
+
+var ErrNoRecipients = errors.New("mailer: alıcı yok")
+
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+type Message struct {
+	To          []string
+	Subject     string
+	HTML        string
+	Text        string
+	Attachments []Attachment
+}
+
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+func New(cfg configs.MailConfig) (Mailer, error) {
+	switch cfg.Driver {
+	case configs.MailDriverSMTP:
+		return NewSMTPMailer(cfg), nil
+	case configs.MailDriverFile:
+		return NewFileMailer(cfg.Dir, cfg.From)
+	}
+	return nil, fmt.Errorf("mailer: bilinmeyen sürücü %q", cfg.Driver)
+}
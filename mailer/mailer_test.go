@@ -0,0 +1,173 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"zatrano/configs"
+)
+
+var testMessage = Message{
+	To:      []string{"Ayşe Yönetici <ayse@example.com>"},
+	Subject: "Haftalık özet — 12 değişiklik",
+	HTML:    "<p>Merhaba <b>Ayşe</b></p>",
+	Text:    "Merhaba Ayşe",
+	Attachments: []Attachment{
+		{Filename: "ozet.csv", ContentType: "text/csv; charset=utf-8", Data: []byte("section,name\nlogin,Ayşe\n")},
+	},
+}
+
+func parseMessage(t *testing.T, raw []byte) (subject string, bodies map[string]string, attachments map[string]string) {
+	t.Helper()
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("mail.ReadMessage() error = %v", err)
+	}
+	subject, err = new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		t.Fatalf("Subject decode error = %v", err)
+	}
+	bodies, attachments = map[string]string{}, map[string]string{}
+
+	var walk func(r io.Reader, contentType string)
+	walk = func(r io.Reader, contentType string) {
+		mediaType, params, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			t.Fatalf("ParseMediaType(%q) error = %v", contentType, err)
+		}
+		reader := multipart.NewReader(r, params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				t.Fatalf("%s NextPart() error = %v", mediaType, err)
+			}
+			partType := part.Header.Get("Content-Type")
+			if strings.HasPrefix(partType, "multipart/") {
+				walk(part, partType)
+				continue
+			}
+			var body io.Reader = part
+			if part.Header.Get("Content-Transfer-Encoding") == "base64" {
+				body = base64.NewDecoder(base64.StdEncoding, part)
+			}
+			data, err := io.ReadAll(body)
+			if err != nil {
+				t.Fatalf("part read error = %v", err)
+			}
+			if name := part.FileName(); name != "" {
+				attachments[name] = string(data)
+			} else {
+				bodies[strings.SplitN(partType, ";", 2)[0]] = string(data)
+			}
+		}
+	}
+	walk(msg.Body, msg.Header.Get("Content-Type"))
+	return subject, bodies, attachments
+}
+
+func assertTestMessage(t *testing.T, raw []byte) {
+	t.Helper()
+	subject, bodies, attachments := parseMessage(t, raw)
+	if subject != testMessage.Subject {
+		t.Fatalf("Subject = %q", subject)
+	}
+	if bodies["text/plain"] != testMessage.Text || bodies["text/html"] != testMessage.HTML {
+		t.Fatalf("bodies = %q", bodies)
+	}
+	if attachments["ozet.csv"] != string(testMessage.Attachments[0].Data) {
+		t.Fatalf("attachments = %q", attachments)
+	}
+}
+
+func TestFileMailer(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	m, err := New(configs.MailConfig{Driver: configs.MailDriverFile, Dir: dir, From: "Zatrano <rapor@zatrano.local>"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := m.Send(context.Background(), testMessage); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if err := m.Send(context.Background(), Message{Subject: "alıcısız"}); err != ErrNoRecipients {
+		t.Fatalf("Send() without recipients error = %v", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*ayse@example.com.eml"))
+	if len(files) != 1 {
+		t.Fatalf("written files = %v", files)
+	}
+	raw, _ := os.ReadFile(files[0])
+	assertTestMessage(t, raw)
+}
+
+func fakeSMTPServer(t *testing.T) (addr string, received <-chan []byte) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	ch := make(chan []byte, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		tp := textproto.NewConn(conn)
+		_ = tp.PrintfLine("220 fake ESMTP")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+			switch cmd := strings.ToUpper(strings.Fields(line + " ")[0]); cmd {
+			case "EHLO", "HELO":
+				_ = tp.PrintfLine("250 fake")
+			case "DATA":
+				_ = tp.PrintfLine("354 go ahead")
+				data, err := tp.ReadDotBytes()
+				if err != nil {
+					return
+				}
+				ch <- data
+				_ = tp.PrintfLine("250 queued")
+			case "QUIT":
+				_ = tp.PrintfLine("221 bye")
+				return
+			default:
+				_ = tp.PrintfLine("250 ok")
+			}
+		}
+	}()
+	return ln.Addr().String(), ch
+}
+
+func TestSMTPMailer(t *testing.T) {
+	addr, received := fakeSMTPServer(t)
+	host, portText, _ := net.SplitHostPort(addr)
+	port, _ := strconv.Atoi(portText)
+
+	m := NewSMTPMailer(configs.MailConfig{Driver: configs.MailDriverSMTP, Host: host, Port: port, From: "rapor@zatrano.local"})
+	if err := m.Send(context.Background(), testMessage); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	raw := <-received
+	// ReadDotBytes satır sonlarını \n'e çevirir; mail.ReadMessage ikisini de kabul eder.
+	assertTestMessage(t, raw)
+}
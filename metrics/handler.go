@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+	"time"
+
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+)
+
+const Path = "/metrics"
+
+func httpHandler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+func Mount(app *fiber.App, token string) {
+	handler := adaptor.HTTPHandler(httpHandler())
+	app.Get(Path, func(c *fiber.Ctx) error {
+		if !validBearerToken(c.Get(fiber.HeaderAuthorization), token) {
+			c.Set(fiber.HeaderWWWAuthenticate, `Bearer realm="metrics"`)
+			return c.SendStatus(fiber.StatusUnauthorized)
+		}
+		return handler(c)
+	})
+}
+
+func NewInternalServer(addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle(Path, httpHandler())
+	return &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+}
+
+func StartInternalServer(server *http.Server) {
+	go func() {
+		utils.Log.Info("Metrik sunucusu başlatılıyor", zap.String("address", server.Addr))
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			utils.Log.Error("Metrik sunucusu dinlenemedi", zap.String("address", server.Addr), zap.Error(err))
+		}
+	}()
+}
+
+func validBearerToken(header, token string) bool {
+	if token == "" {
+		return false
+	}
+	provided, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(provided), []byte(token)) == 1
+}
@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "zatrano"
+
+const (
+	LoginResultSuccess = "success"
+	LoginResultFailure = "failure"
+
+	LoginReasonNone               = "none"
+	LoginReasonInvalidCredentials = "invalid_credentials"
+	LoginReasonUserInactive       = "user_inactive"
+	LoginReasonMissingFields      = "missing_fields"
+	LoginReasonError              = "error"
+)
+
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "İşlenen HTTP isteklerinin route, method ve status bazında sayısı.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP isteklerinin route, method ve status bazında süresi.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	loginAttemptsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "auth_login_attempts_total",
+		Help:      "Giriş denemelerinin sonuç ve neden bazında sayısı.",
+	}, []string{"result", "reason"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequestsTotal,
+		httpRequestDuration,
+		loginAttemptsTotal,
+	)
+}
+
+func ObserveLogin(result, reason string) {
+	loginAttemptsTotal.WithLabelValues(result, reason).Inc()
+}
@@ -0,0 +1,118 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"zatrano/models"
+	"zatrano/repositories"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMain(m *testing.M) {
+	utils.InitLogger("test", "error")
+	os.Exit(m.Run())
+}
+
+func TestMiddlewareUsesRouteTemplate(t *testing.T) {
+	app := fiber.New()
+	app.Use(Middleware())
+	app.Get("/teams/:id", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) })
+
+	before := testutil.ToFloat64(httpRequestsTotal.WithLabelValues("GET", "/teams/:id", "204"))
+	for _, path := range []string{"/teams/1", "/teams/2"} {
+		if _, err := app.Test(httptest.NewRequest(fiber.MethodGet, path, nil), -1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := testutil.ToFloat64(httpRequestsTotal.WithLabelValues("GET", "/teams/:id", "204")) - before; got != 2 {
+		t.Fatalf("counter delta = %v, want 2", got)
+	}
+
+	unmatchedBefore := testutil.ToFloat64(httpRequestsTotal.WithLabelValues("GET", unmatchedRoute, "404"))
+	if _, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/random/path", nil), -1); err != nil {
+		t.Fatal(err)
+	}
+	if got := testutil.ToFloat64(httpRequestsTotal.WithLabelValues("GET", unmatchedRoute, "404")) - unmatchedBefore; got != 1 {
+		t.Fatalf("unmatched counter delta = %v, want 1", got)
+	}
+}
+
+func TestMountRequiresBearerToken(t *testing.T) {
+	app := fiber.New()
+	Mount(app, "gizli")
+
+	tests := []struct {
+		name       string
+		header     string
+		wantStatus int
+	}{
+		{name: "missing", wantStatus: fiber.StatusUnauthorized},
+		{name: "wrong token", header: "Bearer yanlis", wantStatus: fiber.StatusUnauthorized},
+		{name: "wrong scheme", header: "Basic gizli", wantStatus: fiber.StatusUnauthorized},
+		{name: "valid", header: "Bearer gizli", wantStatus: fiber.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodGet, Path, nil)
+			if tt.header != "" {
+				req.Header.Set(fiber.HeaderAuthorization, tt.header)
+			}
+			resp, err := app.Test(req, -1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestStatsCollector(t *testing.T) {
+	store := repositories.NewMemoryStore()
+	users := repositories.NewMemoryUserRepository(store)
+	teams := repositories.NewMemoryTeamRepository(store)
+
+	team := &models.Team{Name: "Destek", Status: true}
+	if err := teams.Create(team); err != nil {
+		t.Fatal(err)
+	}
+	passive := &models.Team{Name: "Pasif", Status: true}
+	if err := teams.Create(passive); err != nil {
+		t.Fatal(err)
+	}
+	if err := teams.Update(passive.ID, map[string]interface{}{"status": false}); err != nil {
+		t.Fatal(err)
+	}
+	for _, u := range []models.User{
+		{Name: "A", Account: "a@x", Password: "secret", Type: models.Agent, TeamID: &team.ID},
+		{Name: "B", Account: "b@x", Password: "secret", Type: models.Agent, TeamID: &team.ID},
+		{Name: "M", Account: "m@x", Password: "secret", Type: models.Manager, TeamID: &team.ID},
+	} {
+		u := u
+		if err := users.Create(&u); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	collector := &statsCollector{sources: StatsSources{Users: users, Teams: teams}}
+	expected := `
+# HELP zatrano_teams Silinmemiş takımların durum bazında sayısı.
+# TYPE zatrano_teams gauge
+zatrano_teams{status="active"} 1
+zatrano_teams{status="inactive"} 1
+# HELP zatrano_users Silinmemiş kullanıcıların tip ve durum bazında sayısı.
+# TYPE zatrano_users gauge
+zatrano_users{status="active",type="agent"} 2
+zatrano_users{status="active",type="manager"} 1
+`
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(expected)); err != nil {
+		t.Fatal(err)
+	}
+}
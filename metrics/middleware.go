@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const unmatchedRoute = "unmatched"
+
+func Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		chainErr := c.Next()
+
+		status := c.Response().StatusCode()
+		if chainErr != nil {
+			status = fiber.StatusInternalServerError
+			if e, ok := chainErr.(*fiber.Error); ok {
+				status = e.Code
+			}
+		}
+
+		route := unmatchedRoute
+		if r := c.Route(); r != nil && r.Path != "" && status != fiber.StatusNotFound {
+			route = r.Path
+		}
+
+		labels := []string{c.Method(), route, strconv.Itoa(status)}
+		httpRequestsTotal.WithLabelValues(labels...).Inc()
+		httpRequestDuration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+
+		return chainErr
+	}
+}
@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"database/sql"
+
+	"zatrano/repositories"
+	"zatrano/utils"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"go.uber.org/zap"
+)
+
+type StatsSources struct {
+	Users    repositories.IUserRepository
+	Teams    repositories.ITeamRepository
+	Sessions repositories.ISessionRepository
+}
+
+var (
+	usersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "users"),
+		"Silinmemiş kullanıcıların tip ve durum bazında sayısı.",
+		[]string{"type", "status"}, nil,
+	)
+	teamsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "teams"),
+		"Silinmemiş takımların durum bazında sayısı.",
+		[]string{"status"}, nil,
+	)
+	activeSessionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "active_sessions"),
+		"Süresi dolmamış oturum sayısı.",
+		nil, nil,
+	)
+)
+
+type statsCollector struct {
+	sources StatsSources
+}
+
+func RegisterStatsCollector(sources StatsSources) error {
+	return Registry.Register(&statsCollector{sources: sources})
+}
+
+func RegisterDBStats(db *sql.DB) error {
+	return Registry.Register(collectors.NewDBStatsCollector(db, namespace))
+}
+
+func (c *statsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- usersDesc
+	ch <- teamsDesc
+	ch <- activeSessionsDesc
+}
+
+func (c *statsCollector) Collect(ch chan<- prometheus.Metric) {
+	if c.sources.Users != nil {
+		rows, err := c.sources.Users.CountByTypeAndStatus()
+		if err != nil {
+			utils.Log.Error("Metrik: Kullanıcı sayıları alınamadı", zap.Error(err))
+		}
+		for _, row := range rows {
+			ch <- prometheus.MustNewConstMetric(usersDesc, prometheus.GaugeValue,
+				float64(row.Count), string(row.Type), statusLabel(row.Status))
+		}
+	}
+
+	if c.sources.Teams != nil {
+		counts, err := c.sources.Teams.CountByStatus()
+		if err != nil {
+			utils.Log.Error("Metrik: Takım sayıları alınamadı", zap.Error(err))
+		}
+		for status, count := range counts {
+			ch <- prometheus.MustNewConstMetric(teamsDesc, prometheus.GaugeValue,
+				float64(count), statusLabel(status))
+		}
+	}
+
+	if c.sources.Sessions != nil {
+		count, err := c.sources.Sessions.CountActive()
+		if err != nil {
+			utils.Log.Error("Metrik: Aktif oturum sayısı alınamadı", zap.Error(err))
+		} else {
+			ch <- prometheus.MustNewConstMetric(activeSessionsDesc, prometheus.GaugeValue, float64(count))
+		}
+	}
+}
+
+func statusLabel(active bool) string {
+	if active {
+		return "active"
+	}
+	return "inactive"
+}
@@ -5,29 +5,41 @@ import (
 	"zatrano/utils"
 
 	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
 )
 
-func AuthMiddleware(c *fiber.Ctx) error {
-	sess, err := utils.SessionStart(c)
+func AuthMiddleware(authService services.IAuthService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		sess, err := utils.SessionStart(c)
 
-	if err != nil {
-		return c.Redirect("/auth/login")
-	}
+		if err != nil {
+			return c.Redirect("/auth/login")
+		}
 
-	userID, err := utils.GetUserIDFromSession(sess)
+		userID, err := utils.GetUserIDFromSession(sess)
 
-	if err != nil {
-		return c.Redirect("/auth/login")
-	}
+		if err != nil {
+			return c.Redirect("/auth/login")
+		}
 
-	authService := services.NewAuthService()
+		user, err := authService.GetUserProfile(c.UserContext(), userID)
 
-	_, err = authService.GetUserProfile(userID)
+		if err != nil {
+			_ = sess.Destroy()
+			return c.Redirect("/auth/login")
+		}
 
-	if err != nil {
-		_ = sess.Destroy()
-		return c.Redirect("/auth/login")
-	}
+		c.Locals("userID", userID)
+		c.Locals("userType", user.Type)
+		c.Locals(utils.CurrentUserLocalsKey, user)
 
-	return c.Next()
+		// Bu noktadan sonraki servis logları ve erişim logu kullanıcıyı da içerir.
+		logger := utils.LogFrom(c.UserContext()).With(
+			zap.Uint("user_id", userID),
+			zap.String("user_type", string(user.Type)),
+		)
+		c.SetUserContext(utils.WithLogger(c.UserContext(), logger))
+
+		return c.Next()
+	}
 }
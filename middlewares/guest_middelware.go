@@ -8,36 +8,37 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func GuestMiddleware(c *fiber.Ctx) error {
-	sess, err := utils.SessionStart(c)
-	if err != nil {
-		return c.Next()
-	}
+func GuestMiddleware(authService services.IAuthService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		sess, err := utils.SessionStart(c)
+		if err != nil {
+			return c.Next()
+		}
 
-	userID, err := utils.GetUserIDFromSession(sess)
-	if err != nil {
-		return c.Next()
-	}
+		userID, err := utils.GetUserIDFromSession(sess)
+		if err != nil {
+			return c.Next()
+		}
 
-	authService := services.NewAuthService()
-	user, err := authService.GetUserProfile(userID)
-	if err != nil {
-		_ = sess.Destroy()
-		return c.Next()
-	}
+		user, err := authService.GetUserProfile(c.UserContext(), userID)
+		if err != nil {
+			_ = sess.Destroy()
+			return c.Next()
+		}
 
-	var redirectURL string
-	switch user.Type {
-	case models.Manager:
-		redirectURL = "/manager/home"
-	case models.Agent:
-		redirectURL = "/agent/home"
-	case models.System:
-		redirectURL = "/dashboard/home"
-	default:
-		_ = sess.Destroy()
-		return c.Next()
-	}
+		var redirectURL string
+		switch user.Type {
+		case models.Manager:
+			redirectURL = "/manager/home"
+		case models.Agent:
+			redirectURL = "/agent/home"
+		case models.System:
+			redirectURL = "/dashboard/home"
+		default:
+			_ = sess.Destroy()
+			return c.Next()
+		}
 
-	return c.Redirect(redirectURL)
+		return c.Redirect(redirectURL)
+	}
 }
@@ -0,0 +1,26 @@
+package middlewares
+
+import (
+	"zatrano/i18n"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func PreferencesMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var preference string
+		prefs := utils.DefaultPreferences()
+		if sess, err := utils.SessionStart(c); err == nil {
+			preference, _ = sess.Get(utils.SessionLocaleKey).(string)
+			prefs = utils.PreferencesFromSession(sess)
+		}
+
+		locale := i18n.Resolve(preference, c.Cookies(utils.LocaleCookieName), c.Get(fiber.HeaderAcceptLanguage))
+		c.Locals(utils.LocaleLocalsKey, locale)
+		c.Locals(utils.PreferencesLocalsKey, prefs)
+		c.Set(fiber.HeaderContentLanguage, locale)
+		c.Vary(fiber.HeaderAcceptLanguage)
+		return c.Next()
+	}
+}
@@ -0,0 +1,104 @@
+package middlewares
+
+import (
+	"time"
+
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	fiberUtils "github.com/gofiber/fiber/v2/utils"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	RequestIDHeader    = "X-Request-ID"
+	requestIDLocalsKey = "requestID"
+	maxRequestIDLength = 128
+)
+
+func RequestLoggerMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		requestID := c.Get(RequestIDHeader)
+		if !isValidRequestID(requestID) {
+			requestID = fiberUtils.UUIDv4()
+		}
+		c.Set(RequestIDHeader, requestID)
+		c.Locals(requestIDLocalsKey, requestID)
+
+		logger := utils.Log.With(zap.String("request_id", requestID))
+		c.SetUserContext(utils.WithLogger(c.UserContext(), logger))
+
+		chainErr := c.Next()
+		if chainErr != nil {
+			// Hata yanıtını burada üretiyoruz ki loglanan status ve boyut,
+			// istemciye giden yanıtla aynı olsun.
+			if err := c.App().ErrorHandler(c, chainErr); err != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+
+		fields := []zap.Field{
+			zap.String("method", c.Method()),
+			zap.String("path", c.Path()),
+			zap.Int("status", c.Response().StatusCode()),
+			zap.Duration("latency", time.Since(start)),
+			zap.String("ip", c.IP()),
+			zap.String("user_agent", c.Get(fiber.HeaderUserAgent)),
+		}
+		// Akış yanıtlarında (SSE, büyük CSV) Body() çağrısı akışı belleğe
+		// okur ve istemciye hiçbir şey gönderilmeden tüketir; bu yüzden
+		// boyutu yalnızca Content-Length başlığı varsa loglarız.
+		if c.Response().IsBodyStream() {
+			if size := c.Response().Header.ContentLength(); size >= 0 {
+				fields = append(fields, zap.Int("bytes", size))
+			}
+		} else {
+			fields = append(fields, zap.Int("bytes", len(c.Response().Body())))
+		}
+		if chainErr != nil {
+			fields = append(fields, zap.Error(chainErr))
+		}
+
+		// Kullanıcı bilgileri AuthMiddleware tarafından eklenmiş logger'da bulunur.
+		accessLogger := utils.LogFrom(c.UserContext())
+		if ce := accessLogger.Check(accessLogLevel(c.Response().StatusCode()), "HTTP isteği"); ce != nil {
+			ce.Write(fields...)
+		}
+
+		return nil
+	}
+}
+
+func GetRequestID(c *fiber.Ctx) string {
+	requestID, _ := c.Locals(requestIDLocalsKey).(string)
+	return requestID
+}
+
+func accessLogLevel(status int) zapcore.Level {
+	switch {
+	case status >= fiber.StatusInternalServerError:
+		return zapcore.ErrorLevel
+	case status >= fiber.StatusBadRequest:
+		return zapcore.WarnLevel
+	default:
+		return zapcore.InfoLevel
+	}
+}
+
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,215 @@
+package middlewares
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func observeLogs(t *testing.T) *observer.ObservedLogs {
+	t.Helper()
+	core, logs := observer.New(zapcore.DebugLevel)
+	previous, previousSugar := utils.Log, utils.SLog
+	utils.Log = zap.New(core)
+	utils.SLog = utils.Log.Sugar()
+	t.Cleanup(func() { utils.Log, utils.SLog = previous, previousSugar })
+	return logs
+}
+
+func newLoggedApp() *fiber.App {
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Use(RequestLoggerMiddleware())
+	app.Get("/ok", func(c *fiber.Ctx) error {
+		utils.LogFrom(c.UserContext()).Info("servis logu")
+		return c.SendString("merhaba")
+	})
+	app.Get("/user", func(c *fiber.Ctx) error {
+		logger := utils.LogFrom(c.UserContext()).With(zap.Uint("user_id", 7), zap.String("user_type", "agent"))
+		c.SetUserContext(utils.WithLogger(c.UserContext(), logger))
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+	app.Get("/missing", func(c *fiber.Ctx) error {
+		return fiber.ErrNotFound
+	})
+	return app
+}
+
+func TestRequestLoggerRequestID(t *testing.T) {
+	tests := []struct {
+		name     string
+		incoming string
+		keep     bool
+	}{
+		{name: "generated when missing"},
+		{name: "propagated when valid", incoming: "abc-123_DEF.4:5", keep: true},
+		{name: "replaced when it contains spaces", incoming: "bad id"},
+		{name: "replaced when too long", incoming: strings.Repeat("a", maxRequestIDLength+1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs := observeLogs(t)
+			app := newLoggedApp()
+
+			req := httptest.NewRequest(fiber.MethodGet, "/ok", nil)
+			if tt.incoming != "" {
+				req.Header.Set(RequestIDHeader, tt.incoming)
+			}
+			resp, err := app.Test(req, -1)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			requestID := resp.Header.Get(RequestIDHeader)
+			if requestID == "" {
+				t.Fatal("response has no X-Request-ID header")
+			}
+			if tt.keep && requestID != tt.incoming {
+				t.Fatalf("X-Request-ID = %q, want %q", requestID, tt.incoming)
+			}
+			if !tt.keep && requestID == tt.incoming {
+				t.Fatalf("invalid X-Request-ID %q was propagated", tt.incoming)
+			}
+
+			entries := logs.All()
+			if len(entries) != 2 {
+				t.Fatalf("got %d log entries, want service and access log", len(entries))
+			}
+			for _, entry := range entries {
+				if got := entry.ContextMap()["request_id"]; got != requestID {
+					t.Fatalf("%q entry request_id = %v, want %q", entry.Message, got, requestID)
+				}
+			}
+		})
+	}
+}
+
+func TestRequestLoggerAccessLogFields(t *testing.T) {
+	logs := observeLogs(t)
+	app := newLoggedApp()
+
+	if _, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/ok", nil), -1); err != nil {
+		t.Fatal(err)
+	}
+	access := logs.FilterMessage("HTTP isteği").All()
+	if len(access) != 1 {
+		t.Fatalf("got %d access log entries, want 1", len(access))
+	}
+	fields := access[0].ContextMap()
+	if fields["status"] != int64(fiber.StatusOK) || fields["bytes"] != int64(len("merhaba")) {
+		t.Fatalf("status/bytes = %v/%v", fields["status"], fields["bytes"])
+	}
+	if fields["method"] != fiber.MethodGet || fields["path"] != "/ok" {
+		t.Fatalf("method/path = %v/%v", fields["method"], fields["path"])
+	}
+	if _, ok := fields["latency"]; !ok {
+		t.Fatal("access log has no latency field")
+	}
+}
+
+func TestRequestLoggerUserAttribution(t *testing.T) {
+	logs := observeLogs(t)
+	app := newLoggedApp()
+
+	if _, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/user", nil), -1); err != nil {
+		t.Fatal(err)
+	}
+	access := logs.FilterMessage("HTTP isteği").All()
+	if len(access) != 1 {
+		t.Fatalf("got %d access log entries, want 1", len(access))
+	}
+	fields := access[0].ContextMap()
+	if fields["user_id"] != uint64(7) || fields["user_type"] != "agent" {
+		t.Fatalf("user fields = %v/%v", fields["user_id"], fields["user_type"])
+	}
+}
+
+func TestRequestLoggerErrorStatus(t *testing.T) {
+	logs := observeLogs(t)
+	app := newLoggedApp()
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/missing", nil), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Fatalf("status = %d, want 404", resp.StatusCode)
+	}
+	access := logs.FilterMessage("HTTP isteği").All()
+	if len(access) != 1 || access[0].Level != zapcore.WarnLevel {
+		t.Fatalf("access log = %+v, want one warn entry", access)
+	}
+	if access[0].ContextMap()["status"] != int64(fiber.StatusNotFound) {
+		t.Fatalf("logged status = %v, want 404", access[0].ContextMap()["status"])
+	}
+}
+
+func TestRequestLoggerStreamsBody(t *testing.T) {
+	logs := observeLogs(t)
+	app := newLoggedApp()
+
+	release := make(chan struct{})
+	returned := make(chan struct{})
+	app.Get("/stream", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, "text/event-stream")
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			defer close(returned)
+			_, _ = w.WriteString("data: ilk\n\n")
+			_ = w.Flush()
+			<-release
+			_, _ = w.WriteString("data: son\n\n")
+			_ = w.Flush()
+		})
+		return nil
+	})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = app.Listener(ln) }()
+	t.Cleanup(func() { _ = app.Shutdown() })
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get("http://" + ln.Addr().String() + "/stream")
+	if err != nil {
+		close(release)
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	select {
+	case <-returned:
+		t.Fatal("stream writer returned before the first chunk was read")
+	default:
+	}
+	close(release)
+	if err != nil || line != "data: ilk\n" {
+		t.Fatalf("first line = %q, %v", line, err)
+	}
+
+	<-returned
+	deadline := time.Now().Add(5 * time.Second)
+	for logs.FilterMessage("HTTP isteği").Len() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	access := logs.FilterMessage("HTTP isteği").All()
+	if len(access) != 1 {
+		t.Fatalf("got %d access log entries, want 1", len(access))
+	}
+	if _, ok := access[0].ContextMap()["bytes"]; ok {
+		t.Fatal("access log reports bytes for a stream without Content-Length")
+	}
+}
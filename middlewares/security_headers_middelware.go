@@ -0,0 +1,36 @@
+package middlewares
+
+import (
+	"strconv"
+
+	"zatrano/configs"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SecurityHeadersMiddleware, her yanıta CSP, çerçeveleme yasağı, MIME
+// sniffing yasağı ve referrer politikası başlıklarını ekler. HSTS yalnızca
+// istek HTTPS üzerinden geldiğinde (doğrudan TLS ya da güvenilen bir proxy'nin
+// X-Forwarded-Proto başlığı) gönderilir; aksi halde tarayıcı başlığı yok sayar
+// ve yerel geliştirmede yanlışlıkla HTTPS'e kilitlenme riski doğar.
+func SecurityHeadersMiddleware(cfg configs.SecurityConfig) fiber.Handler {
+	hsts := ""
+	if cfg.HSTSMaxAge > 0 {
+		hsts = "max-age=" + strconv.Itoa(cfg.HSTSMaxAge)
+		if cfg.HSTSIncludeSubdomains {
+			hsts += "; includeSubDomains"
+		}
+	}
+
+	return func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentSecurityPolicy, cfg.ContentSecurityPolicy)
+		c.Set(fiber.HeaderXFrameOptions, "DENY")
+		c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+		c.Set(fiber.HeaderReferrerPolicy, cfg.ReferrerPolicy)
+		c.Set("Cross-Origin-Opener-Policy", "same-origin")
+		if hsts != "" && c.Protocol() == "https" {
+			c.Set(fiber.HeaderStrictTransportSecurity, hsts)
+		}
+		return c.Next()
+	}
+}
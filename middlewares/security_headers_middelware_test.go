@@ -0,0 +1,60 @@
+package middlewares
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"zatrano/configs"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestSecurityHeaders(t *testing.T) {
+	cfg := configs.SecurityConfig{
+		ContentSecurityPolicy: configs.DefaultContentSecurityPolicy,
+		ReferrerPolicy:        "no-referrer",
+		HSTSMaxAge:            600,
+		HSTSIncludeSubdomains: true,
+	}
+
+	tests := []struct {
+		name     string
+		cfg      configs.SecurityConfig
+		https    bool
+		wantHSTS string
+	}{
+		{name: "plain http has no HSTS", cfg: cfg},
+		{name: "https behind proxy gets HSTS", cfg: cfg, https: true, wantHSTS: "max-age=600; includeSubDomains"},
+		{name: "HSTS disabled", cfg: configs.SecurityConfig{ContentSecurityPolicy: "default-src 'self'", ReferrerPolicy: "no-referrer"}, https: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(SecurityHeadersMiddleware(tt.cfg))
+			app.Get("/", func(c *fiber.Ctx) error { return c.SendString("ok") })
+
+			req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			if tt.https {
+				req.Header.Set(fiber.HeaderXForwardedProto, "https")
+			}
+			resp, err := app.Test(req, -1)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := map[string]string{
+				fiber.HeaderContentSecurityPolicy:   tt.cfg.ContentSecurityPolicy,
+				fiber.HeaderXFrameOptions:           "DENY",
+				fiber.HeaderXContentTypeOptions:     "nosniff",
+				fiber.HeaderReferrerPolicy:          "no-referrer",
+				fiber.HeaderStrictTransportSecurity: tt.wantHSTS,
+			}
+			for header, value := range want {
+				if got := resp.Header.Get(header); got != value {
+					t.Errorf("%s = %q, want %q", header, got, value)
+				}
+			}
+		})
+	}
+}
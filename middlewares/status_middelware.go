@@ -7,26 +7,27 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func StatusMiddleware(c *fiber.Ctx) error {
-	sess, err := utils.SessionStart(c)
-	if err != nil {
-		return c.Redirect("/auth/login")
-	}
+func StatusMiddleware(authService services.IAuthService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		sess, err := utils.SessionStart(c)
+		if err != nil {
+			return c.Redirect("/auth/login")
+		}
 
-	userID, err := utils.GetUserIDFromSession(sess)
-	if err != nil {
-		return c.Redirect("/auth/login")
-	}
+		userID, err := utils.GetUserIDFromSession(sess)
+		if err != nil {
+			return c.Redirect("/auth/login")
+		}
 
-	authService := services.NewAuthService()
-	user, err := authService.GetUserProfile(userID)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).SendString("Kullanıcı bulunamadı")
-	}
+		user, err := authService.GetUserProfile(c.UserContext(), userID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).SendString(utils.T(c, "errors.session.user_lookup_failed"))
+		}
 
-	if !user.Status {
-		return c.Status(fiber.StatusForbidden).SendString("Kullanıcı aktif değil")
-	}
+		if !user.Status {
+			return c.Status(fiber.StatusForbidden).SendString(utils.T(c, "errors.auth.user_inactive"))
+		}
 
-	return c.Next()
+		return c.Next()
+	}
 }
@@ -8,26 +8,25 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func TypeMiddleware(requiredType models.UserType) fiber.Handler {
+func TypeMiddleware(authService services.IAuthService, requiredType models.UserType) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		sess, err := utils.SessionStart(c)
 		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).SendString("Oturum açılmamış")
+			return c.Status(fiber.StatusUnauthorized).SendString(utils.T(c, "errors.session.not_logged_in"))
 		}
 
 		userID, err := utils.GetUserIDFromSession(sess)
 		if err != nil {
-			return c.Status(fiber.StatusForbidden).SendString("Yetkisiz erişim")
+			return c.Status(fiber.StatusForbidden).SendString(utils.T(c, "errors.session.unauthorized"))
 		}
 
-		authService := services.NewAuthService()
-		user, err := authService.GetUserProfile(userID)
+		user, err := authService.GetUserProfile(c.UserContext(), userID)
 		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).SendString("Kullanıcı bilgileri alınamadı")
+			return c.Status(fiber.StatusInternalServerError).SendString(utils.T(c, "errors.session.user_lookup_failed"))
 		}
 
 		if user.Type != requiredType {
-			return c.Status(fiber.StatusForbidden).SendString("Bu işlem için yetkiniz yok")
+			return c.Status(fiber.StatusForbidden).SendString(utils.T(c, "errors.session.forbidden"))
 		}
 
 		return c.Next()
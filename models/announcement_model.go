@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AnnouncementPriority string
+
+const (
+	PriorityNormal    AnnouncementPriority = "normal"
+	PriorityImportant AnnouncementPriority = "important"
+	PriorityUrgent    AnnouncementPriority = "urgent"
+)
+
+var AnnouncementPriorities = []AnnouncementPriority{PriorityNormal, PriorityImportant, PriorityUrgent}
+
+func (p AnnouncementPriority) IsValid() bool {
+	for _, known := range AnnouncementPriorities {
+		if p == known {
+			return true
+		}
+	}
+	return false
+}
+
+func (p AnnouncementPriority) Rank() int {
+	switch p {
+	case PriorityUrgent:
+		return 0
+	case PriorityImportant:
+		return 1
+	}
+	return 2
+}
+
+type Announcement struct {
+	gorm.Model
+	TeamID    *uint                `gorm:"index"`
+	Team      *Team                `gorm:"foreignKey:TeamID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	AuthorID  uint                 `gorm:"not null;index"`
+	Author    *User                `gorm:"foreignKey:AuthorID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+	Title     string               `gorm:"size:200;not null"`
+	Body      string               `gorm:"type:text;not null"`
+	Priority  AnnouncementPriority `gorm:"size:16;not null;default:'normal'"`
+	ExpiresAt *time.Time           `gorm:"index"`
+}
+
+func (a *Announcement) IsGlobal() bool {
+	return a.TeamID == nil
+}
+
+func (a *Announcement) IsActiveAt(t time.Time) bool {
+	return a.ExpiresAt == nil || a.ExpiresAt.After(t)
+}
+
+type AnnouncementReceipt struct {
+	AnnouncementID uint          `gorm:"primaryKey;autoIncrement:false"`
+	Announcement   *Announcement `gorm:"foreignKey:AnnouncementID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	UserID         uint          `gorm:"primaryKey;autoIncrement:false;index"`
+	User           *User         `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	ReadAt         time.Time     `gorm:"not null"`
+	AcknowledgedAt *time.Time
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"time"
+)
+
+type AttendanceSession struct {
+	ID         uint      `gorm:"primaryKey"`
+	UserID     uint      `gorm:"not null;index:idx_attendance_sessions_user_clock_in,priority:1;uniqueIndex:idx_attendance_sessions_open,where:clock_out_at IS NULL"`
+	User       *User     `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	ClockInAt  time.Time `gorm:"not null;index:idx_attendance_sessions_user_clock_in,priority:2"`
+	ClockInIP  string    `gorm:"size:45;not null;default:''"`
+	ClockOutAt *time.Time
+	ClockOutIP string            `gorm:"size:45;not null;default:''"`
+	AutoClosed bool              `gorm:"not null;default:false"`
+	Breaks     []AttendanceBreak `gorm:"foreignKey:SessionID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type AttendanceBreak struct {
+	ID        uint      `gorm:"primaryKey"`
+	SessionID uint      `gorm:"not null;index;uniqueIndex:idx_attendance_breaks_open,where:ended_at IS NULL"`
+	StartedAt time.Time `gorm:"not null"`
+	EndedAt   *time.Time
+}
+
+func (s *AttendanceSession) IsOpen() bool {
+	return s.ClockOutAt == nil
+}
+
+func (s *AttendanceSession) OpenBreak() *AttendanceBreak {
+	for i := range s.Breaks {
+		if s.Breaks[i].EndedAt == nil {
+			return &s.Breaks[i]
+		}
+	}
+	return nil
+}
+
+func (s *AttendanceSession) end(now time.Time) time.Time {
+	if s.ClockOutAt != nil {
+		return *s.ClockOutAt
+	}
+	return now
+}
+
+func (s *AttendanceSession) BreakDuration(now time.Time) time.Duration {
+	var total time.Duration
+	for _, b := range s.Breaks {
+		end := now
+		if b.EndedAt != nil {
+			end = *b.EndedAt
+		}
+		if end.After(b.StartedAt) {
+			total += end.Sub(b.StartedAt)
+		}
+	}
+	return total
+}
+
+func (s *AttendanceSession) WorkedDuration(now time.Time) time.Duration {
+	worked := s.end(now).Sub(s.ClockInAt) - s.BreakDuration(now)
+	if worked < 0 {
+		return 0
+	}
+	return worked
+}
+
+// WallClock, anı verilen saat dilimindeki duvar saatine çevirir ve UTC olarak
+// döner. Vardiya saatleri saat dilimi taşımadığından (bkz. Shift.Window)
+// giriş ve çıkış anları vardiyalarla bu biçimde karşılaştırılır.
+func WallClock(t time.Time, loc *time.Location) time.Time {
+	local := t.In(loc)
+	return time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), local.Minute(), local.Second(), local.Nanosecond(), time.UTC)
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+type JobStatus string
+
+const (
+	JobPending   JobStatus = "pending"
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobDead      JobStatus = "dead"
+)
+
+var JobStatuses = []JobStatus{JobPending, JobRunning, JobSucceeded, JobDead}
+
+func (s JobStatus) IsValid() bool {
+	switch s {
+	case JobPending, JobRunning, JobSucceeded, JobDead:
+		return true
+	}
+	return false
+}
+
+type Job struct {
+	ID          uint       `gorm:"primaryKey"`
+	Type        string     `gorm:"size:64;not null;index"`
+	Payload     string     `gorm:"type:text;not null"`
+	Status      JobStatus  `gorm:"size:16;not null;index:idx_jobs_claim,priority:1"`
+	Attempts    int        `gorm:"not null"`
+	MaxAttempts int        `gorm:"not null"`
+	RunAt       time.Time  `gorm:"not null;index:idx_jobs_claim,priority:2"`
+	LockedBy    string     `gorm:"size:100"`
+	LockedAt    *time.Time `gorm:"index"`
+	LastError   string     `gorm:"type:text"`
+	FinishedAt  *time.Time
+	CreatedAt   time.Time `gorm:"index"`
+	UpdatedAt   time.Time
+}
+
+func (j *Job) CanRetry() bool {
+	return j.Status == JobDead || (j.Status == JobPending && j.Attempts > 0)
+}
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+type KPIUnit string
+
+const (
+	KPIUnitCount   KPIUnit = "count"
+	KPIUnitSeconds KPIUnit = "seconds"
+	KPIUnitPercent KPIUnit = "percent"
+	KPIUnitScore   KPIUnit = "score"
+)
+
+var KPIUnits = []KPIUnit{KPIUnitCount, KPIUnitSeconds, KPIUnitPercent, KPIUnitScore}
+
+func (u KPIUnit) IsValid() bool {
+	switch u {
+	case KPIUnitCount, KPIUnitSeconds, KPIUnitPercent, KPIUnitScore:
+		return true
+	}
+	return false
+}
+
+type KPIAggregation string
+
+const (
+	KPIAggregationSum KPIAggregation = "sum"
+	KPIAggregationAvg KPIAggregation = "avg"
+)
+
+var KPIAggregations = []KPIAggregation{KPIAggregationSum, KPIAggregationAvg}
+
+func (a KPIAggregation) IsValid() bool {
+	return a == KPIAggregationSum || a == KPIAggregationAvg
+}
+
+func (a KPIAggregation) Aggregate(values []float64) (result float64, ok bool) {
+	if len(values) == 0 {
+		return 0, false
+	}
+	for _, v := range values {
+		result += v
+	}
+	if a == KPIAggregationAvg {
+		result /= float64(len(values))
+	}
+	return result, true
+}
+
+var kpiKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+func ValidKPIKey(key string) bool {
+	return kpiKeyPattern.MatchString(key)
+}
+
+type KPIDefinition struct {
+	ID             uint           `gorm:"primarykey"`
+	Key            string         `gorm:"size:64;not null;uniqueIndex"`
+	Name           string         `gorm:"size:100;not null"`
+	Unit           KPIUnit        `gorm:"size:16;not null;default:'count'"`
+	Aggregation    KPIAggregation `gorm:"size:8;not null;default:'sum'"`
+	HigherIsBetter bool           `gorm:"not null"`
+	Status         bool           `gorm:"not null"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (KPIDefinition) TableName() string {
+	return "kpi_definitions"
+}
+
+func (d KPIDefinition) Format(value float64) string {
+	switch d.Unit {
+	case KPIUnitSeconds:
+		total := int64(math.Round(value))
+		return fmt.Sprintf("%d:%02d", total/60, total%60)
+	case KPIUnitPercent:
+		return strconv.FormatFloat(value, 'f', 1, 64) + "%"
+	case KPIUnitCount:
+		if value == math.Trunc(value) {
+			return strconv.FormatFloat(value, 'f', 0, 64)
+		}
+	}
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
+
+func (d KPIDefinition) Better(a, b float64) bool {
+	if d.HigherIsBetter {
+		return a > b
+	}
+	return a < b
+}
+
+type KPIValue struct {
+	ID           uint           `gorm:"primarykey"`
+	DefinitionID uint           `gorm:"not null;uniqueIndex:idx_kpi_values_key,priority:1"`
+	Definition   *KPIDefinition `gorm:"foreignKey:DefinitionID"`
+	UserID       uint           `gorm:"not null;uniqueIndex:idx_kpi_values_key,priority:2;index"`
+	User         *User          `gorm:"foreignKey:UserID"`
+	Day          time.Time      `gorm:"type:date;not null;uniqueIndex:idx_kpi_values_key,priority:3"`
+	Value        float64        `gorm:"not null"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type LeaveType string
+
+const (
+	LeaveAnnual LeaveType = "annual"
+	LeaveSick   LeaveType = "sick"
+	LeaveUnpaid LeaveType = "unpaid"
+)
+
+var LeaveTypes = []LeaveType{LeaveAnnual, LeaveSick, LeaveUnpaid}
+
+func (t LeaveType) IsValid() bool {
+	switch t {
+	case LeaveAnnual, LeaveSick, LeaveUnpaid:
+		return true
+	}
+	return false
+}
+
+func (t LeaveType) HasBalance() bool {
+	return t == LeaveAnnual || t == LeaveSick
+}
+
+type LeaveStatus string
+
+const (
+	LeavePending   LeaveStatus = "pending"
+	LeaveApproved  LeaveStatus = "approved"
+	LeaveRejected  LeaveStatus = "rejected"
+	LeaveCancelled LeaveStatus = "cancelled"
+)
+
+type LeaveRequest struct {
+	gorm.Model
+	UserID          uint        `gorm:"not null;index"`
+	User            *User       `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	TeamID          uint        `gorm:"not null;index"`
+	ApproverID      *uint       `gorm:"index"`
+	Approver        *User       `gorm:"foreignKey:ApproverID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Type            LeaveType   `gorm:"size:20;not null"`
+	StartDate       time.Time   `gorm:"type:date;not null;index"`
+	EndDate         time.Time   `gorm:"type:date;not null;index"`
+	Days            int         `gorm:"not null"`
+	Reason          string      `gorm:"size:500"`
+	Status          LeaveStatus `gorm:"size:20;not null;default:pending;index"`
+	DecidedByID     *uint
+	DecidedAt       *time.Time
+	DecisionComment string `gorm:"size:500"`
+}
+
+func (r *LeaveRequest) IsPending() bool {
+	return r.Status == LeavePending
+}
+
+func (r *LeaveRequest) IsActive() bool {
+	return r.Status == LeavePending || r.Status == LeaveApproved
+}
+
+func (r *LeaveRequest) Covers(date time.Time) bool {
+	return !date.Before(r.StartDate) && !date.After(r.EndDate)
+}
+
+func LeaveWorkingDays(start, end time.Time) int {
+	days := 0
+	for d := CivilDate(start); !d.After(end); d = d.AddDate(0, 0, 1) {
+		if d.Weekday() != time.Saturday && d.Weekday() != time.Sunday {
+			days++
+		}
+	}
+	return days
+}
+
+type LeaveBalance struct {
+	ID          uint      `gorm:"primarykey"`
+	UserID      uint      `gorm:"not null;uniqueIndex:idx_leave_balances_user_year_type"`
+	User        *User     `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Year        int       `gorm:"not null;uniqueIndex:idx_leave_balances_user_year_type"`
+	Type        LeaveType `gorm:"size:20;not null;uniqueIndex:idx_leave_balances_user_year_type"`
+	Entitled    int       `gorm:"not null"`
+	CarriedOver int       `gorm:"not null;default:0"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
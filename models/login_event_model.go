@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+const LoginEventAccountMaxLength = 100
+
+type LoginEvent struct {
+	ID        uint      `gorm:"primarykey"`
+	UserID    *uint     `gorm:"index"`
+	User      *User     `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Account   string    `gorm:"size:100;not null;index"`
+	Success   bool      `gorm:"not null;index:idx_login_events_outcome,priority:1"`
+	Reason    string    `gorm:"size:100"`
+	CreatedAt time.Time `gorm:"not null;index:idx_login_events_outcome,priority:2"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"zatrano/i18n"
+)
+
+type Notification struct {
+	ID        uint       `gorm:"primaryKey"`
+	UserID    uint       `gorm:"not null;index:idx_notifications_user_created,priority:1"`
+	User      *User      `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	MessageID string     `gorm:"size:100;not null"`
+	Params    []string   `gorm:"serializer:json;type:text"`
+	Link      string     `gorm:"size:255;not null;default:''"`
+	ReadAt    *time.Time `gorm:"index"`
+	CreatedAt time.Time  `gorm:"not null;index:idx_notifications_user_created,priority:2"`
+}
+
+func (n *Notification) Message(locale string) string {
+	args := make([]interface{}, len(n.Params))
+	for i, p := range n.Params {
+		args[i] = p
+	}
+	return i18n.T(locale, n.MessageID, args...)
+}
+
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
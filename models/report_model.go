@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+type ReportTemplate string
+
+const (
+	ReportTemplateDailySummary  ReportTemplate = "daily_summary"
+	ReportTemplateWeeklySummary ReportTemplate = "weekly_summary"
+)
+
+var ReportTemplates = []ReportTemplate{ReportTemplateDailySummary, ReportTemplateWeeklySummary}
+
+func (t ReportTemplate) IsValid() bool {
+	return t == ReportTemplateDailySummary || t == ReportTemplateWeeklySummary
+}
+
+func (t ReportTemplate) PeriodDays() int {
+	if t == ReportTemplateDailySummary {
+		return 1
+	}
+	return 7
+}
+
+type ReportSchedule struct {
+	ID              uint           `gorm:"primarykey"`
+	Name            string         `gorm:"size:100;not null"`
+	Template        ReportTemplate `gorm:"size:32;not null"`
+	Cron            string         `gorm:"size:100;not null"`
+	Timezone        string         `gorm:"size:64;not null;default:'UTC'"`
+	Status          bool           `gorm:"not null"`
+	LastRunAt       *time.Time
+	NextRunAt       *time.Time `gorm:"index"`
+	SubscriberCount int64      `gorm:"->;-:migration"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+type ReportSubscription struct {
+	ScheduleID uint            `gorm:"primaryKey;autoIncrement:false"`
+	Schedule   *ReportSchedule `gorm:"foreignKey:ScheduleID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	UserID     uint            `gorm:"primaryKey;autoIncrement:false;index"`
+	User       *User           `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	CreatedAt  time.Time
+}
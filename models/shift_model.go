@@ -0,0 +1,111 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const MinutesPerDay = 24 * 60
+
+const ClockLayout = "15:04"
+
+type WeekdaySet uint8
+
+var ShiftWeekdays = []time.Weekday{
+	time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday,
+}
+
+func NewWeekdaySet(days ...time.Weekday) WeekdaySet {
+	var set WeekdaySet
+	for _, d := range days {
+		set |= 1 << uint(d)
+	}
+	return set
+}
+
+func (s WeekdaySet) Has(d time.Weekday) bool {
+	return s&(1<<uint(d)) != 0
+}
+
+func (s WeekdaySet) Weekdays() []time.Weekday {
+	days := []time.Weekday{}
+	for _, d := range ShiftWeekdays {
+		if s.Has(d) {
+			days = append(days, d)
+		}
+	}
+	return days
+}
+
+func (s WeekdaySet) IsValid() bool {
+	return s != 0 && s < 1<<7
+}
+
+type Shift struct {
+	gorm.Model
+	TeamID      uint       `gorm:"not null;index"`
+	Team        *Team      `gorm:"foreignKey:TeamID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Name        string     `gorm:"size:100;not null"`
+	StartMinute int        `gorm:"not null"`
+	EndMinute   int        `gorm:"not null"`
+	Days        WeekdaySet `gorm:"not null"`
+}
+
+func (s *Shift) IsOvernight() bool {
+	return s.EndMinute < s.StartMinute
+}
+
+func (s *Shift) StartClock() string {
+	return FormatClock(s.StartMinute)
+}
+
+func (s *Shift) EndClock() string {
+	return FormatClock(s.EndMinute)
+}
+
+func (s *Shift) Window(date time.Time) (time.Time, time.Time) {
+	start := date.Add(time.Duration(s.StartMinute) * time.Minute)
+	end := date.Add(time.Duration(s.EndMinute) * time.Minute)
+	if s.IsOvernight() {
+		end = end.AddDate(0, 0, 1)
+	}
+	return start, end
+}
+
+func FormatClock(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+func ParseClock(value string) (int, error) {
+	t, err := time.Parse(ClockLayout, value)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func CivilDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func WeekStart(date time.Time) time.Time {
+	date = CivilDate(date)
+	offset := (int(date.Weekday()) + 6) % 7
+	return date.AddDate(0, 0, -offset)
+}
+
+type ShiftAssignment struct {
+	gorm.Model
+	ShiftID   uint      `gorm:"not null;index"`
+	Shift     *Shift    `gorm:"foreignKey:ShiftID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	UserID    uint      `gorm:"not null;index"`
+	User      *User     `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	StartDate time.Time `gorm:"type:date;not null;index"`
+	EndDate   time.Time `gorm:"type:date;not null;index"`
+}
+
+func (a *ShiftAssignment) Covers(date time.Time) bool {
+	return !date.Before(a.StartDate) && !date.After(a.EndDate)
+}
@@ -0,0 +1,95 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TaskPriority string
+
+const (
+	TaskPriorityLow    TaskPriority = "low"
+	TaskPriorityNormal TaskPriority = "normal"
+	TaskPriorityHigh   TaskPriority = "high"
+)
+
+var TaskPriorities = []TaskPriority{TaskPriorityLow, TaskPriorityNormal, TaskPriorityHigh}
+
+func (p TaskPriority) IsValid() bool {
+	switch p {
+	case TaskPriorityLow, TaskPriorityNormal, TaskPriorityHigh:
+		return true
+	}
+	return false
+}
+
+type TaskStatus string
+
+const (
+	TaskOpen       TaskStatus = "open"
+	TaskInProgress TaskStatus = "in_progress"
+	TaskDone       TaskStatus = "done"
+)
+
+var TaskStatuses = []TaskStatus{TaskOpen, TaskInProgress, TaskDone}
+
+func (s TaskStatus) IsValid() bool {
+	switch s {
+	case TaskOpen, TaskInProgress, TaskDone:
+		return true
+	}
+	return false
+}
+
+var taskTransitions = map[TaskStatus][]TaskStatus{
+	TaskOpen:       {TaskInProgress, TaskDone},
+	TaskInProgress: {TaskOpen, TaskDone},
+	TaskDone:       {TaskInProgress},
+}
+
+func (s TaskStatus) CanTransitionTo(next TaskStatus) bool {
+	for _, allowed := range taskTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+func (s TaskStatus) Transitions() []TaskStatus {
+	return taskTransitions[s]
+}
+
+type Task struct {
+	gorm.Model
+	TeamID      uint         `gorm:"not null;index"`
+	CreatedByID uint         `gorm:"not null;index"`
+	CreatedBy   *User        `gorm:"foreignKey:CreatedByID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+	AssigneeID  uint         `gorm:"not null;index"`
+	Assignee    *User        `gorm:"foreignKey:AssigneeID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Title       string       `gorm:"size:200;not null"`
+	Description string       `gorm:"type:text"`
+	DueDate     *time.Time   `gorm:"type:date;index"`
+	Priority    TaskPriority `gorm:"size:16;not null;default:'normal'"`
+	Status      TaskStatus   `gorm:"size:20;not null;default:'open';index"`
+	CompletedAt *time.Time
+}
+
+func (t *Task) IsDone() bool {
+	return t.Status == TaskDone
+}
+
+func (t *Task) IsOverdue(today time.Time) bool {
+	return t.DueDate != nil && !t.IsDone() && t.DueDate.Before(today)
+}
+
+type TaskComment struct {
+	ID        uint      `gorm:"primaryKey"`
+	TaskID    uint      `gorm:"not null;index"`
+	Task      *Task     `gorm:"foreignKey:TaskID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	AuthorID  uint      `gorm:"not null;index"`
+	Author    *User     `gorm:"foreignKey:AuthorID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Body      string    `gorm:"type:text;not null"`
+	CreatedAt time.Time `gorm:"not null"`
+}
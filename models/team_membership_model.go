@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+type TeamMembershipChange struct {
+	ID         uint      `gorm:"primarykey"`
+	UserID     uint      `gorm:"not null;index"`
+	User       *User     `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	FromTeamID *uint     `gorm:"index"`
+	FromTeam   *Team     `gorm:"foreignKey:FromTeamID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	ToTeamID   *uint     `gorm:"index"`
+	ToTeam     *Team     `gorm:"foreignKey:ToTeamID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	CreatedAt  time.Time `gorm:"not null;index"`
+}
@@ -6,9 +6,10 @@ import (
 
 type Team struct {
 	gorm.Model
-	Name   string `gorm:"size:100;not null;index"`
-	Status bool   `gorm:"default:true;index"`
-	Agents []User `gorm:"foreignKey:TeamID;references:ID"`
+	Name        string `gorm:"size:100;not null;index"`
+	Status      bool   `gorm:"default:true;index"`
+	Agents      []User `gorm:"foreignKey:TeamID;references:ID"`
+	MemberCount int64  `gorm:"->;-:migration"`
 }
 
 func (t *Team) Manager(db *gorm.DB) (*User, error) {
@@ -1,6 +1,10 @@
 package models
 
 import (
+	"time"
+
+	"zatrano/i18n"
+
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
@@ -9,16 +13,20 @@ import (
 type ModelError string
 
 func (e ModelError) Error() string {
+	return i18n.T(i18n.DefaultLocale, string(e))
+}
+
+func (e ModelError) Code() string {
 	return string(e)
 }
 
 const (
-	ErrSystemUserHasTeam        ModelError = "sistem kullanıcısının (system user) bir takımı olamaz (TeamID NULL olmalı)"
-	ErrUserMissingTeam          ModelError = "yönetici (manager) veya temsilci (agent) kullanıcısının bir takımı olmalı (TeamID boş olamaz)"
-	ErrInvalidUserType          ModelError = "geçersiz kullanıcı tipi (UserType)"
-	ErrPasswordCannotBeEmpty    ModelError = "şifre boş olamaz"
-	ErrInvalidUpdateTypeField   ModelError = "güncelleme verisinde geçersiz 'type' alanı tipi"
-	ErrInvalidUpdateTeamIDField ModelError = "güncelleme verisinde geçersiz 'team_id' alanı tipi"
+	ErrSystemUserHasTeam        ModelError = "errors.model.system_user_has_team"
+	ErrUserMissingTeam          ModelError = "errors.model.user_missing_team"
+	ErrInvalidUserType          ModelError = "errors.model.invalid_user_type"
+	ErrPasswordCannotBeEmpty    ModelError = "errors.model.password_empty"
+	ErrInvalidUpdateTypeField   ModelError = "errors.model.invalid_update_type"
+	ErrInvalidUpdateTeamIDField ModelError = "errors.model.invalid_update_team_id"
 )
 
 type UserType string
@@ -41,13 +49,14 @@ func (UserType) GormDBDataType(db *gorm.DB, field *schema.Field) string {
 
 type User struct {
 	gorm.Model
-	Name     string   `gorm:"size:100;not null;index"`
-	Account  string   `gorm:"size:100;unique;not null"`
-	Password string   `gorm:"size:255;not null"`
-	Status   bool     `gorm:"default:true;index"`
-	Type     UserType `gorm:"type:user_type;not null;default:'agent';index"`
-	TeamID   *uint    `gorm:"index"`
-	Team     *Team    `gorm:"foreignKey:TeamID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Name          string     `gorm:"size:100;not null;index"`
+	Account       string     `gorm:"size:100;unique;not null"`
+	Password      string     `gorm:"size:255;not null"`
+	Status        bool       `gorm:"default:true;index"`
+	Type          UserType   `gorm:"type:user_type;not null;default:'agent';index"`
+	TeamID        *uint      `gorm:"index"`
+	Team          *Team      `gorm:"foreignKey:TeamID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	DeactivatedAt *time.Time `gorm:"index"`
 }
 
 func (u *User) BeforeCreate(tx *gorm.DB) (err error) {
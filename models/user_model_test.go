@@ -0,0 +1,128 @@
+package models
+
+import (
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+func uintPtr(v uint) *uint {
+	return &v
+}
+
+func txWithDest(dest interface{}) *gorm.DB {
+	return &gorm.DB{Statement: &gorm.Statement{Dest: dest}}
+}
+
+func TestUserBeforeCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		user    User
+		wantErr error
+	}{
+		{name: "empty password", user: User{Type: Agent, TeamID: uintPtr(1)}, wantErr: ErrPasswordCannotBeEmpty},
+		{name: "invalid type", user: User{Password: "secret", Type: "admin"}, wantErr: ErrInvalidUserType},
+		{name: "empty type", user: User{Password: "secret"}, wantErr: ErrInvalidUserType},
+		{name: "system user with team", user: User{Password: "secret", Type: System, TeamID: uintPtr(1)}, wantErr: ErrSystemUserHasTeam},
+		{name: "manager without team", user: User{Password: "secret", Type: Manager}, wantErr: ErrUserMissingTeam},
+		{name: "agent without team", user: User{Password: "secret", Type: Agent}, wantErr: ErrUserMissingTeam},
+		{name: "system user", user: User{Password: "secret", Type: System}},
+		{name: "manager with team", user: User{Password: "secret", Type: Manager, TeamID: uintPtr(3)}},
+		{name: "agent with team", user: User{Password: "secret", Type: Agent, TeamID: uintPtr(3)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := tt.user
+			err := user.BeforeCreate(txWithDest(&user))
+			if err != tt.wantErr {
+				t.Fatalf("BeforeCreate() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if user.Password == tt.user.Password {
+				t.Fatal("BeforeCreate() did not hash the password")
+			}
+			if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(tt.user.Password)) != nil {
+				t.Fatal("BeforeCreate() stored a hash that does not match the original password")
+			}
+		})
+	}
+}
+
+func TestUserBeforeUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		current User
+		dest    interface{}
+		wantErr error
+	}{
+		{name: "type as string", dest: map[string]interface{}{"type": "agent", "team_id": uintPtr(1)}},
+		{name: "type as UserType", dest: map[string]interface{}{"type": Manager, "team_id": uint(2)}},
+		{name: "team id as float64", dest: map[string]interface{}{"type": "agent", "team_id": float64(2)}},
+		{name: "nil team id for system", dest: map[string]interface{}{"type": System, "team_id": nil}},
+		{name: "typed nil team id for system", dest: map[string]interface{}{"type": System, "team_id": (*uint)(nil)}},
+		{name: "invalid type value", dest: map[string]interface{}{"type": "admin"}, wantErr: ErrInvalidUserType},
+		{name: "invalid type field", dest: map[string]interface{}{"type": 42}, wantErr: ErrInvalidUpdateTypeField},
+		{name: "invalid team id field", dest: map[string]interface{}{"team_id": "3"}, wantErr: ErrInvalidUpdateTeamIDField},
+		{name: "system with team in map", dest: map[string]interface{}{"type": "system", "team_id": uintPtr(1)}, wantErr: ErrSystemUserHasTeam},
+		{
+			name:    "system type in map keeps current team",
+			current: User{Type: Agent, TeamID: uintPtr(4)},
+			dest:    map[string]interface{}{"type": System},
+			wantErr: ErrSystemUserHasTeam,
+		},
+		{
+			name:    "team change keeps current system type",
+			current: User{Type: System},
+			dest:    map[string]interface{}{"team_id": uint(4)},
+			wantErr: ErrSystemUserHasTeam,
+		},
+		{name: "struct dest uses current values", current: User{Type: System}, dest: &User{}},
+		{name: "nil dest", current: User{Type: Agent, TeamID: uintPtr(1)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := tt.current
+			err := user.BeforeUpdate(txWithDest(tt.dest))
+			if err != tt.wantErr {
+				t.Fatalf("BeforeUpdate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUserPasswordHelpers(t *testing.T) {
+	var user User
+	if err := user.SetPassword(""); err != ErrPasswordCannotBeEmpty {
+		t.Fatalf("SetPassword(\"\") error = %v, want %v", err, ErrPasswordCannotBeEmpty)
+	}
+	if err := user.SetPassword("secret"); err != nil {
+		t.Fatalf("SetPassword() error = %v", err)
+	}
+	if err := user.CheckPassword("secret"); err != nil {
+		t.Fatalf("CheckPassword() with correct password error = %v", err)
+	}
+	if err := user.CheckPassword("wrong"); err == nil {
+		t.Fatal("CheckPassword() with wrong password returned nil")
+	}
+}
+
+func TestUserIsManager(t *testing.T) {
+	tests := []struct {
+		userType UserType
+		want     bool
+	}{
+		{System, false},
+		{Manager, true},
+		{Agent, false},
+	}
+	for _, tt := range tests {
+		if got := (&User{Type: tt.userType}).IsManager(); got != tt.want {
+			t.Errorf("IsManager() for %q = %v, want %v", tt.userType, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+)
+
+type UserPreference struct {
+	UserID     uint   `gorm:"primaryKey;autoIncrement:false"`
+	User       *User  `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Locale     string `gorm:"size:10;not null;default:''"`
+	Timezone   string `gorm:"size:64;not null;default:'UTC'"`
+	DateFormat string `gorm:"size:32;not null;default:'02.01.2006'"`
+	PerPage    int    `gorm:"not null;default:20"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
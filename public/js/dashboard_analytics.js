@@ -0,0 +1,92 @@
+// Yönetim panosu ana sayfasındaki grafikleri çizer: veriyi
+// /dashboard/api/analytics'ten alır ve ApexCharts ile gösterir.
+(function () {
+  'use strict';
+
+  var root = document.getElementById('dashboardAnalytics');
+  if (!root || typeof ApexCharts === 'undefined') {
+    return;
+  }
+
+  var label = function (name) {
+    return root.getAttribute('data-label-' + name) || '';
+  };
+
+  function chart(name, options) {
+    var el = root.querySelector('[data-analytics-chart="' + name + '"]');
+    if (!el) {
+      return;
+    }
+    options.chart = Object.assign({ height: 260, toolbar: { show: false } }, options.chart);
+    options.dataLabels = options.dataLabels || { enabled: false };
+    new ApexCharts(el, options).render();
+  }
+
+  function weeklyChart(name, series, title, color) {
+    chart(name, {
+      chart: { type: 'bar' },
+      series: [{ name: title, data: series.counts }],
+      xaxis: { categories: series.labels },
+      yaxis: { labels: { formatter: function (v) { return Math.round(v); } } },
+      colors: [color]
+    });
+  }
+
+  function render(data) {
+    chart('users', {
+      chart: { type: 'bar', stacked: true, height: 200 },
+      plotOptions: { bar: { horizontal: true } },
+      series: [
+        { name: label('active'), data: data.users.by_type.map(function (t) { return t.active; }) },
+        { name: label('passive'), data: data.users.by_type.map(function (t) { return t.inactive; }) }
+      ],
+      xaxis: { categories: data.users.by_type.map(function (t) { return t.label; }) },
+      colors: ['#198754', '#adb5bd']
+    });
+
+    // Sıçrama olan günler x ekseninde işaretlenir.
+    var spikes = [];
+    data.logins.spikes.forEach(function (spike, i) {
+      if (spike) {
+        spikes.push({
+          x: data.logins.labels[i],
+          borderColor: '#dc3545',
+          label: { text: label('spike'), style: { color: '#fff', background: '#dc3545' } }
+        });
+      }
+    });
+    chart('logins', {
+      chart: { type: 'bar', stacked: true },
+      series: [
+        { name: label('succeeded'), data: data.logins.succeeded },
+        { name: label('failed'), data: data.logins.failed }
+      ],
+      xaxis: { categories: data.logins.labels },
+      annotations: { xaxis: spikes },
+      colors: ['#0d6efd', '#dc3545']
+    });
+
+    weeklyChart('new_users', data.new_users, label('new-users'), '#ffc107');
+    weeklyChart('deactivations', data.deactivations, label('deactivations'), '#6c757d');
+  }
+
+  function showError() {
+    var alert = document.createElement('div');
+    alert.className = 'alert alert-warning';
+    alert.textContent = root.getAttribute('data-load-failed') || '';
+    root.prepend(alert);
+  }
+
+  fetch(root.getAttribute('data-url'), {
+    credentials: 'same-origin',
+    headers: { Accept: 'application/json' }
+  })
+    .then(function (resp) {
+      if (!resp.ok) {
+        throw new Error('HTTP ' + resp.status);
+      }
+      return resp.json();
+    })
+    .then(render)
+    .catch(showError);
+})();
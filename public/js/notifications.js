@@ -0,0 +1,132 @@
+// Üst menüdeki bildirim zilini yönetir: listeyi /notifications'tan yükler,
+// okundu işaretlemelerini gönderir ve yeni bildirimleri SSE akışından alır.
+(function () {
+  'use strict';
+
+  var menu = document.getElementById('notificationMenu');
+  if (!menu) {
+    return;
+  }
+
+  var csrfMeta = document.querySelector('meta[name="csrf_token"]');
+  var csrf = csrfMeta ? csrfMeta.getAttribute('content') : '';
+  var emptyText = menu.getAttribute('data-empty') || '';
+  var badge = menu.querySelector('[data-notification-count]');
+  var list = menu.querySelector('[data-notification-list]');
+  var readAll = menu.querySelector('[data-notification-read-all]');
+  var unread = 0;
+
+  function setUnread(count) {
+    unread = Math.max(0, count);
+    badge.textContent = unread > 99 ? '99+' : String(unread);
+    badge.classList.toggle('d-none', unread === 0);
+  }
+
+  function renderItem(item) {
+    var link = document.createElement('a');
+    link.href = item.link || '#';
+    link.className = 'dropdown-item' + (item.read ? ' text-secondary' : ' fw-semibold');
+    link.setAttribute('data-notification-id', String(item.id));
+    link.setAttribute('data-read', item.read ? '1' : '0');
+
+    var text = document.createElement('div');
+    text.className = 'text-wrap';
+    text.textContent = item.message;
+    link.appendChild(text);
+
+    var time = document.createElement('small');
+    time.className = 'text-secondary';
+    time.textContent = item.created_at;
+    link.appendChild(time);
+    return link;
+  }
+
+  function renderEmpty() {
+    var span = document.createElement('span');
+    span.className = 'dropdown-item text-secondary';
+    span.setAttribute('data-notification-empty', '');
+    span.textContent = emptyText;
+    return span;
+  }
+
+  function render(data) {
+    list.replaceChildren();
+    if (!data.items || data.items.length === 0) {
+      list.appendChild(renderEmpty());
+    } else {
+      data.items.forEach(function (item) {
+        list.appendChild(renderItem(item));
+      });
+    }
+    setUnread(data.unread || 0);
+  }
+
+  function post(url) {
+    return fetch(url, {
+      method: 'POST',
+      credentials: 'same-origin',
+      headers: { Accept: 'application/json' },
+      body: new URLSearchParams({ csrf_token: csrf }),
+    }).then(function (res) {
+      if (!res.ok) {
+        throw new Error(res.status);
+      }
+      return res.json();
+    });
+  }
+
+  function load() {
+    fetch('/notifications', { credentials: 'same-origin', headers: { Accept: 'application/json' } })
+      .then(function (res) {
+        return res.ok ? res.json() : null;
+      })
+      .then(function (data) {
+        if (data) {
+          render(data);
+        }
+      })
+      .catch(function () {});
+  }
+
+  list.addEventListener('click', function (event) {
+    var link = event.target.closest('[data-notification-id]');
+    if (!link) {
+      return;
+    }
+    event.preventDefault();
+    var target = link.getAttribute('href');
+    var follow = function () {
+      if (target && target !== '#') {
+        window.location.href = target;
+      }
+    };
+    if (link.getAttribute('data-read') === '1') {
+      follow();
+      return;
+    }
+    post('/notifications/' + link.getAttribute('data-notification-id') + '/read')
+      .then(render)
+      .catch(function () {})
+      .then(follow);
+  });
+
+  readAll.addEventListener('click', function (event) {
+    event.preventDefault();
+    post('/notifications/read-all').then(render).catch(function () {});
+  });
+
+  load();
+
+  if (window.EventSource) {
+    var source = new EventSource('/notifications/stream');
+    source.addEventListener('notification', function (event) {
+      var item = JSON.parse(event.data);
+      var empty = list.querySelector('[data-notification-empty]');
+      if (empty) {
+        empty.remove();
+      }
+      list.insertBefore(renderItem(item), list.firstChild);
+      setUnread(unread + 1);
+    });
+  }
+})();
@@ -0,0 +1,214 @@
+package repositories
+
+import (
+	"slices"
+	"time"
+
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+type MemoryAnnouncementRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryAnnouncementRepository(store *MemoryStore) IAnnouncementRepository {
+	return &MemoryAnnouncementRepository{store: store}
+}
+
+func (r *MemoryAnnouncementRepository) copyAnnouncement(a *models.Announcement) models.Announcement {
+	out := *a
+	out.Team, out.Author = nil, nil
+	if a.TeamID != nil {
+		if t, ok := r.store.teams[*a.TeamID]; ok && !isSoftDeleted(t.Model) {
+			team := *t
+			team.Agents = nil
+			out.Team = &team
+		}
+	}
+	if u, ok := r.store.users[a.AuthorID]; ok && !isSoftDeleted(u.Model) {
+		author := r.store.copyUser(u, false)
+		out.Author = &author
+	}
+	return out
+}
+
+func (r *MemoryAnnouncementRepository) filter(match func(a *models.Announcement) bool) []models.Announcement {
+	announcements := []models.Announcement{}
+	for _, a := range r.store.announcements {
+		if isSoftDeleted(a.Model) || !match(a) {
+			continue
+		}
+		announcements = append(announcements, r.copyAnnouncement(a))
+	}
+	slices.SortFunc(announcements, func(a, b models.Announcement) int {
+		if c := compareOrdered(a.Priority.Rank(), b.Priority.Rank()); c != 0 {
+			return c
+		}
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return compareOrdered(b.ID, a.ID)
+	})
+	return announcements
+}
+
+func (r *MemoryAnnouncementRepository) FindAll() ([]models.Announcement, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.filter(func(*models.Announcement) bool { return true }), nil
+}
+
+func (r *MemoryAnnouncementRepository) FindByTeam(teamID uint) ([]models.Announcement, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.filter(func(a *models.Announcement) bool {
+		return a.TeamID != nil && *a.TeamID == teamID
+	}), nil
+}
+
+func (r *MemoryAnnouncementRepository) FindByID(id uint) (*models.Announcement, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	a, ok := r.store.announcements[id]
+	if !ok || isSoftDeleted(a.Model) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	announcement := r.copyAnnouncement(a)
+	return &announcement, nil
+}
+
+func (r *MemoryAnnouncementRepository) FindActiveForUser(userID uint, teamID *uint, now time.Time) ([]UserAnnouncement, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	announcements := r.filter(func(a *models.Announcement) bool {
+		if !a.IsActiveAt(now) {
+			return false
+		}
+		return a.TeamID == nil || (teamID != nil && *a.TeamID == *teamID)
+	})
+	var receipts []models.AnnouncementReceipt
+	for _, a := range announcements {
+		if receipt, ok := r.store.receipts[receiptKey{a.ID, userID}]; ok {
+			receipts = append(receipts, *receipt)
+		}
+	}
+	return joinReceipts(announcements, receipts), nil
+}
+
+func (r *MemoryAnnouncementRepository) FindRecipients(announcement *models.Announcement) ([]AnnouncementRecipient, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	users := []models.User{}
+	for _, u := range r.store.users {
+		if isSoftDeleted(u.Model) || u.Type != models.Agent || !u.Status {
+			continue
+		}
+		if announcement.TeamID != nil && (u.TeamID == nil || *u.TeamID != *announcement.TeamID) {
+			continue
+		}
+		users = append(users, r.store.copyUser(u, false))
+	}
+	slices.SortFunc(users, func(a, b models.User) int {
+		if c := compareOrdered(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return compareOrdered(a.ID, b.ID)
+	})
+
+	var receipts []models.AnnouncementReceipt
+	for key, receipt := range r.store.receipts {
+		if key.announcementID == announcement.ID {
+			receipts = append(receipts, *receipt)
+		}
+	}
+	return recipientsWithReceipts(users, receipts), nil
+}
+
+func (r *MemoryAnnouncementRepository) CountReceipts(announcementIDs []uint) (map[uint]ReceiptCount, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	counts := make(map[uint]ReceiptCount, len(announcementIDs))
+	for key, receipt := range r.store.receipts {
+		if !slices.Contains(announcementIDs, key.announcementID) {
+			continue
+		}
+		count := counts[key.announcementID]
+		count.Read++
+		if receipt.AcknowledgedAt != nil {
+			count.Acknowledged++
+		}
+		counts[key.announcementID] = count
+	}
+	return counts, nil
+}
+
+func (r *MemoryAnnouncementRepository) Create(announcement *models.Announcement) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if announcement.Priority == "" {
+		announcement.Priority = models.PriorityNormal
+	}
+	now := memoryNow()
+	announcement.ID = r.store.nextAnnouncementID
+	announcement.CreatedAt = now
+	announcement.UpdatedAt = now
+	r.store.nextAnnouncementID++
+
+	stored := *announcement
+	stored.Team, stored.Author = nil, nil
+	r.store.announcements[announcement.ID] = &stored
+	return nil
+}
+
+func (r *MemoryAnnouncementRepository) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	a, ok := r.store.announcements[id]
+	if !ok || isSoftDeleted(a.Model) {
+		return gorm.ErrRecordNotFound
+	}
+	a.DeletedAt = gorm.DeletedAt{Time: memoryNow(), Valid: true}
+	return nil
+}
+
+func (r *MemoryAnnouncementRepository) MarkRead(announcementIDs []uint, userID uint, at time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.markRead(announcementIDs, userID, at)
+	return nil
+}
+
+func (r *MemoryAnnouncementRepository) markRead(announcementIDs []uint, userID uint, at time.Time) {
+	for _, id := range announcementIDs {
+		key := receiptKey{id, userID}
+		if _, ok := r.store.receipts[key]; !ok {
+			r.store.receipts[key] = &models.AnnouncementReceipt{AnnouncementID: id, UserID: userID, ReadAt: at}
+		}
+	}
+}
+
+func (r *MemoryAnnouncementRepository) Acknowledge(announcementID, userID uint, at time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.markRead([]uint{announcementID}, userID, at)
+	receipt := r.store.receipts[receiptKey{announcementID, userID}]
+	if receipt.AcknowledgedAt == nil {
+		acknowledgedAt := at
+		receipt.AcknowledgedAt = &acknowledgedAt
+	}
+	return nil
+}
+
+var _ IAnnouncementRepository = (*MemoryAnnouncementRepository)(nil)
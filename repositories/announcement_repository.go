@@ -0,0 +1,209 @@
+package repositories
+
+import (
+	"time"
+
+	"zatrano/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IAnnouncementRepository interface {
+	FindAll() ([]models.Announcement, error)
+	FindByTeam(teamID uint) ([]models.Announcement, error)
+	FindByID(id uint) (*models.Announcement, error)
+	FindActiveForUser(userID uint, teamID *uint, now time.Time) ([]UserAnnouncement, error)
+	FindRecipients(announcement *models.Announcement) ([]AnnouncementRecipient, error)
+	CountReceipts(announcementIDs []uint) (map[uint]ReceiptCount, error)
+	Create(announcement *models.Announcement) error
+	Delete(id uint) error
+	MarkRead(announcementIDs []uint, userID uint, at time.Time) error
+	Acknowledge(announcementID, userID uint, at time.Time) error
+}
+
+type UserAnnouncement struct {
+	models.Announcement
+	ReadAt         *time.Time
+	AcknowledgedAt *time.Time
+}
+
+type AnnouncementRecipient struct {
+	User           models.User
+	ReadAt         *time.Time
+	AcknowledgedAt *time.Time
+}
+
+type ReceiptCount struct {
+	Read         int64
+	Acknowledged int64
+}
+
+const announcementOrderSQL = "CASE announcements.priority WHEN 'urgent' THEN 0 WHEN 'important' THEN 1 ELSE 2 END, announcements.created_at DESC, announcements.id DESC"
+
+type AnnouncementRepository struct {
+	db *gorm.DB
+}
+
+func NewAnnouncementRepository(db *gorm.DB) IAnnouncementRepository {
+	return &AnnouncementRepository{db: db}
+}
+
+func (r *AnnouncementRepository) find(query *gorm.DB) ([]models.Announcement, error) {
+	var announcements []models.Announcement
+	err := query.Preload("Team").Preload("Author").Order(announcementOrderSQL).Find(&announcements).Error
+	return announcements, err
+}
+
+func (r *AnnouncementRepository) FindAll() ([]models.Announcement, error) {
+	return r.find(r.db)
+}
+
+func (r *AnnouncementRepository) FindByTeam(teamID uint) ([]models.Announcement, error) {
+	return r.find(r.db.Where("team_id = ?", teamID))
+}
+
+func (r *AnnouncementRepository) FindByID(id uint) (*models.Announcement, error) {
+	var announcement models.Announcement
+	if err := r.db.Preload("Team").Preload("Author").First(&announcement, id).Error; err != nil {
+		return nil, err
+	}
+	return &announcement, nil
+}
+
+func (r *AnnouncementRepository) FindActiveForUser(userID uint, teamID *uint, now time.Time) ([]UserAnnouncement, error) {
+	query := r.db.Where("expires_at IS NULL OR expires_at > ?", now)
+	if teamID != nil {
+		query = query.Where("team_id IS NULL OR team_id = ?", *teamID)
+	} else {
+		query = query.Where("team_id IS NULL")
+	}
+	announcements, err := r.find(query)
+	if err != nil || len(announcements) == 0 {
+		return []UserAnnouncement{}, err
+	}
+
+	ids := make([]uint, len(announcements))
+	for i, a := range announcements {
+		ids[i] = a.ID
+	}
+	var receipts []models.AnnouncementReceipt
+	if err := r.db.Where("user_id = ? AND announcement_id IN ?", userID, ids).Find(&receipts).Error; err != nil {
+		return nil, err
+	}
+	return joinReceipts(announcements, receipts), nil
+}
+
+func (r *AnnouncementRepository) FindRecipients(announcement *models.Announcement) ([]AnnouncementRecipient, error) {
+	query := r.db.Where("type = ? AND status = ?", models.Agent, true)
+	if announcement.TeamID != nil {
+		query = query.Where("team_id = ?", *announcement.TeamID)
+	}
+	var users []models.User
+	if err := query.Order("name ASC, id ASC").Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	var receipts []models.AnnouncementReceipt
+	if err := r.db.Where("announcement_id = ?", announcement.ID).Find(&receipts).Error; err != nil {
+		return nil, err
+	}
+	return recipientsWithReceipts(users, receipts), nil
+}
+
+func (r *AnnouncementRepository) CountReceipts(announcementIDs []uint) (map[uint]ReceiptCount, error) {
+	counts := make(map[uint]ReceiptCount, len(announcementIDs))
+	if len(announcementIDs) == 0 {
+		return counts, nil
+	}
+	var rows []struct {
+		AnnouncementID    uint
+		ReadCount         int64
+		AcknowledgedCount int64
+	}
+	err := r.db.Model(&models.AnnouncementReceipt{}).
+		Select("announcement_id, COUNT(*) AS read_count, COUNT(acknowledged_at) AS acknowledged_count").
+		Where("announcement_id IN ?", announcementIDs).
+		Group("announcement_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.AnnouncementID] = ReceiptCount{Read: row.ReadCount, Acknowledged: row.AcknowledgedCount}
+	}
+	return counts, nil
+}
+
+func (r *AnnouncementRepository) Create(announcement *models.Announcement) error {
+	return r.db.Omit(clause.Associations).Create(announcement).Error
+}
+
+func (r *AnnouncementRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.Announcement{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *AnnouncementRepository) MarkRead(announcementIDs []uint, userID uint, at time.Time) error {
+	if len(announcementIDs) == 0 {
+		return nil
+	}
+	receipts := make([]models.AnnouncementReceipt, len(announcementIDs))
+	for i, id := range announcementIDs {
+		receipts[i] = models.AnnouncementReceipt{AnnouncementID: id, UserID: userID, ReadAt: at}
+	}
+	return r.db.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&receipts).Error
+}
+
+func (r *AnnouncementRepository) Acknowledge(announcementID, userID uint, at time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := NewAnnouncementRepository(tx).MarkRead([]uint{announcementID}, userID, at); err != nil {
+			return err
+		}
+		return tx.Model(&models.AnnouncementReceipt{}).
+			Where("announcement_id = ? AND user_id = ? AND acknowledged_at IS NULL", announcementID, userID).
+			Update("acknowledged_at", at).Error
+	})
+}
+
+func joinReceipts(announcements []models.Announcement, receipts []models.AnnouncementReceipt) []UserAnnouncement {
+	byAnnouncement := make(map[uint]models.AnnouncementReceipt, len(receipts))
+	for _, receipt := range receipts {
+		byAnnouncement[receipt.AnnouncementID] = receipt
+	}
+	out := make([]UserAnnouncement, len(announcements))
+	for i, a := range announcements {
+		out[i] = UserAnnouncement{Announcement: a}
+		if receipt, ok := byAnnouncement[a.ID]; ok {
+			readAt := receipt.ReadAt
+			out[i].ReadAt = &readAt
+			out[i].AcknowledgedAt = receipt.AcknowledgedAt
+		}
+	}
+	return out
+}
+
+func recipientsWithReceipts(users []models.User, receipts []models.AnnouncementReceipt) []AnnouncementRecipient {
+	byUser := make(map[uint]models.AnnouncementReceipt, len(receipts))
+	for _, receipt := range receipts {
+		byUser[receipt.UserID] = receipt
+	}
+	out := make([]AnnouncementRecipient, len(users))
+	for i, u := range users {
+		out[i] = AnnouncementRecipient{User: u}
+		if receipt, ok := byUser[u.ID]; ok {
+			readAt := receipt.ReadAt
+			out[i].ReadAt = &readAt
+			out[i].AcknowledgedAt = receipt.AcknowledgedAt
+		}
+	}
+	return out
+}
+
+var _ IAnnouncementRepository = (*AnnouncementRepository)(nil)
@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"zatrano/models"
+)
+
+func announcementTitles(items []UserAnnouncement) []string {
+	titles := make([]string, len(items))
+	for i, a := range items {
+		titles[i] = a.Title
+	}
+	return titles
+}
+
+func TestAnnouncementRepositoryActiveAndReceipts(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repoSet) {
+		sales := mustCreateTeam(t, repos, "Satış", true)
+		support := mustCreateTeam(t, repos, "Destek", true)
+		system := mustCreateUser(t, repos, models.User{Name: "Sistem", Account: "system@x", Type: models.System})
+		manager := mustCreateUser(t, repos, models.User{Name: "Yönetici", Account: "manager@x", Type: models.Manager, TeamID: &sales.ID})
+		ali := mustCreateUser(t, repos, models.User{Name: "Ali", Account: "ali@x", Type: models.Agent, TeamID: &sales.ID})
+		mustCreateUser(t, repos, models.User{Name: "Ayşe", Account: "ayse@x", Type: models.Agent, TeamID: &sales.ID})
+		mustCreateUser(t, repos, models.User{Name: "Can", Account: "can@x", Type: models.Agent, TeamID: &support.ID})
+
+		now := time.Now().UTC()
+		past := now.Add(-time.Hour)
+		create := func(title string, teamID *uint, author uint, priority models.AnnouncementPriority, expires *time.Time) *models.Announcement {
+			a := &models.Announcement{TeamID: teamID, AuthorID: author, Title: title, Body: title, Priority: priority, ExpiresAt: expires}
+			if err := repos.announcements.Create(a); err != nil {
+				t.Fatalf("duyuru oluşturulamadı: %v", err)
+			}
+			return a
+		}
+		general := create("Genel", nil, system.ID, models.PriorityNormal, nil)
+		urgent := create("Acil", &sales.ID, manager.ID, models.PriorityUrgent, nil)
+		create("Süresi dolmuş", &sales.ID, manager.ID, models.PriorityNormal, &past)
+		create("Destek ekibi", &support.ID, system.ID, models.PriorityNormal, nil)
+
+		active, err := repos.announcements.FindActiveForUser(ali.ID, ali.TeamID, now)
+		if err != nil {
+			t.Fatalf("FindActiveForUser() error = %v", err)
+		}
+		if got, want := announcementTitles(active), []string{"Acil", "Genel"}; !equalStrings(got, want) {
+			t.Fatalf("FindActiveForUser() = %v, want %v", got, want)
+		}
+		if active[0].Author == nil || active[0].Author.Name != "Yönetici" || active[0].ReadAt != nil {
+			t.Fatalf("FindActiveForUser()[0] = %+v, want author and no receipt", active[0])
+		}
+
+		if err := repos.announcements.MarkRead([]uint{urgent.ID, general.ID}, ali.ID, now); err != nil {
+			t.Fatalf("MarkRead() error = %v", err)
+		}
+		if err := repos.announcements.Acknowledge(urgent.ID, ali.ID, now.Add(time.Minute)); err != nil {
+			t.Fatalf("Acknowledge() error = %v", err)
+		}
+		// Tekrarlanan okuma ve onay ilk zamanları değiştirmez.
+		if err := repos.announcements.MarkRead([]uint{urgent.ID}, ali.ID, now.Add(time.Hour)); err != nil {
+			t.Fatalf("MarkRead() again error = %v", err)
+		}
+		if err := repos.announcements.Acknowledge(urgent.ID, ali.ID, now.Add(time.Hour)); err != nil {
+			t.Fatalf("Acknowledge() again error = %v", err)
+		}
+
+		recipients, err := repos.announcements.FindRecipients(urgent)
+		if err != nil {
+			t.Fatalf("FindRecipients() error = %v", err)
+		}
+		if len(recipients) != 2 || recipients[0].User.Name != "Ali" || recipients[1].User.Name != "Ayşe" {
+			t.Fatalf("FindRecipients() = %+v, want Ali and Ayşe", recipients)
+		}
+		if recipients[0].ReadAt == nil || !recipients[0].ReadAt.Equal(now) {
+			t.Fatalf("Ali ReadAt = %v, want %v", recipients[0].ReadAt, now)
+		}
+		if recipients[0].AcknowledgedAt == nil || !recipients[0].AcknowledgedAt.Equal(now.Add(time.Minute)) {
+			t.Fatalf("Ali AcknowledgedAt = %v, want %v", recipients[0].AcknowledgedAt, now.Add(time.Minute))
+		}
+		if recipients[1].ReadAt != nil {
+			t.Fatalf("Ayşe ReadAt = %v, want nil", recipients[1].ReadAt)
+		}
+
+		everyone, err := repos.announcements.FindRecipients(general)
+		if err != nil || len(everyone) != 3 {
+			t.Fatalf("FindRecipients(global) = %d, %v; want 3 agents", len(everyone), err)
+		}
+
+		counts, err := repos.announcements.CountReceipts([]uint{urgent.ID, general.ID})
+		if err != nil {
+			t.Fatalf("CountReceipts() error = %v", err)
+		}
+		if counts[urgent.ID] != (ReceiptCount{Read: 1, Acknowledged: 1}) || counts[general.ID] != (ReceiptCount{Read: 1}) {
+			t.Fatalf("CountReceipts() = %+v", counts)
+		}
+
+		teamList, err := repos.announcements.FindByTeam(sales.ID)
+		if err != nil || len(teamList) != 2 {
+			t.Fatalf("FindByTeam() = %d, %v; want 2", len(teamList), err)
+		}
+
+		if err := repos.announcements.Delete(urgent.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if _, err := repos.announcements.FindByID(urgent.ID); err == nil {
+			t.Fatal("FindByID() after delete error = nil")
+		}
+	})
+}
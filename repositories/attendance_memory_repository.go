@@ -0,0 +1,179 @@
+package repositories
+
+import (
+	"slices"
+	"time"
+
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+type MemoryAttendanceRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryAttendanceRepository(store *MemoryStore) IAttendanceRepository {
+	return &MemoryAttendanceRepository{store: store}
+}
+
+func (r *MemoryAttendanceRepository) copySession(s *models.AttendanceSession, withUser bool) models.AttendanceSession {
+	out := *s
+	out.User = nil
+	out.Breaks = []models.AttendanceBreak{}
+	for _, b := range r.store.attendanceBreaks {
+		if b.SessionID == s.ID {
+			out.Breaks = append(out.Breaks, *b)
+		}
+	}
+	slices.SortFunc(out.Breaks, func(a, b models.AttendanceBreak) int {
+		if c := a.StartedAt.Compare(b.StartedAt); c != 0 {
+			return c
+		}
+		return compareOrdered(a.ID, b.ID)
+	})
+	if withUser {
+		if u, ok := r.store.users[s.UserID]; ok && !isSoftDeleted(u.Model) {
+			user := r.store.copyUser(u, false)
+			out.User = &user
+		}
+	}
+	return out
+}
+
+func (r *MemoryAttendanceRepository) filterSessions(withUser bool, match func(s *models.AttendanceSession) bool) []models.AttendanceSession {
+	sessions := []models.AttendanceSession{}
+	for _, s := range r.store.attendanceSessions {
+		if match(s) {
+			sessions = append(sessions, r.copySession(s, withUser))
+		}
+	}
+	slices.SortFunc(sessions, func(a, b models.AttendanceSession) int {
+		if c := a.ClockInAt.Compare(b.ClockInAt); c != 0 {
+			return c
+		}
+		return compareOrdered(a.ID, b.ID)
+	})
+	return sessions
+}
+
+func sessionOverlaps(s *models.AttendanceSession, from, to time.Time) bool {
+	return s.ClockInAt.Before(to) && (s.ClockOutAt == nil || s.ClockOutAt.After(from))
+}
+
+func (r *MemoryAttendanceRepository) FindOpenByUser(userID uint) (*models.AttendanceSession, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, s := range r.store.attendanceSessions {
+		if s.UserID == userID && s.IsOpen() {
+			session := r.copySession(s, false)
+			return &session, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *MemoryAttendanceRepository) FindByUser(userID uint, from, to time.Time) ([]models.AttendanceSession, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.filterSessions(false, func(s *models.AttendanceSession) bool {
+		return s.UserID == userID && sessionOverlaps(s, from, to)
+	}), nil
+}
+
+func (r *MemoryAttendanceRepository) FindByTeam(teamID uint, from, to time.Time) ([]models.AttendanceSession, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.filterSessions(true, func(s *models.AttendanceSession) bool {
+		u, ok := r.store.users[s.UserID]
+		return ok && !isSoftDeleted(u.Model) && u.TeamID != nil && *u.TeamID == teamID && sessionOverlaps(s, from, to)
+	}), nil
+}
+
+func (r *MemoryAttendanceRepository) FindOpenBefore(before time.Time) ([]models.AttendanceSession, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.filterSessions(false, func(s *models.AttendanceSession) bool {
+		return s.IsOpen() && s.ClockInAt.Before(before)
+	}), nil
+}
+
+func (r *MemoryAttendanceRepository) Create(session *models.AttendanceSession) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// idx_attendance_sessions_open kısıtını taklit eder.
+	for _, s := range r.store.attendanceSessions {
+		if s.UserID == session.UserID && s.IsOpen() {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	now := memoryNow()
+	session.ID = r.store.nextAttendanceSessionID
+	session.CreatedAt = now
+	session.UpdatedAt = now
+	r.store.nextAttendanceSessionID++
+
+	stored := *session
+	stored.User, stored.Breaks = nil, nil
+	r.store.attendanceSessions[session.ID] = &stored
+	return nil
+}
+
+func (r *MemoryAttendanceRepository) Close(id uint, at time.Time, ip string, autoClosed bool) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	s, ok := r.store.attendanceSessions[id]
+	if !ok || !s.IsOpen() {
+		return gorm.ErrRecordNotFound
+	}
+	s.ClockOutAt, s.ClockOutIP, s.AutoClosed = &at, ip, autoClosed
+	s.UpdatedAt = memoryNow()
+	for _, b := range r.store.attendanceBreaks {
+		if b.SessionID == id && b.EndedAt == nil {
+			end := at
+			if b.StartedAt.After(at) {
+				end = b.StartedAt
+			}
+			b.EndedAt = &end
+		}
+	}
+	return nil
+}
+
+func (r *MemoryAttendanceRepository) StartBreak(b *models.AttendanceBreak) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// idx_attendance_breaks_open kısıtını taklit eder.
+	for _, other := range r.store.attendanceBreaks {
+		if other.SessionID == b.SessionID && other.EndedAt == nil {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	b.ID = r.store.nextAttendanceBreakID
+	r.store.nextAttendanceBreakID++
+
+	stored := *b
+	r.store.attendanceBreaks[b.ID] = &stored
+	return nil
+}
+
+func (r *MemoryAttendanceRepository) EndBreak(id uint, at time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	b, ok := r.store.attendanceBreaks[id]
+	if !ok || b.EndedAt != nil {
+		return gorm.ErrRecordNotFound
+	}
+	b.EndedAt = &at
+	return nil
+}
+
+var _ IAttendanceRepository = (*MemoryAttendanceRepository)(nil)
@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"time"
+
+	"zatrano/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IAttendanceRepository interface {
+	FindOpenByUser(userID uint) (*models.AttendanceSession, error)
+	FindByUser(userID uint, from, to time.Time) ([]models.AttendanceSession, error)
+	FindByTeam(teamID uint, from, to time.Time) ([]models.AttendanceSession, error)
+	FindOpenBefore(before time.Time) ([]models.AttendanceSession, error)
+	Create(session *models.AttendanceSession) error
+	Close(id uint, at time.Time, ip string, autoClosed bool) error
+	StartBreak(b *models.AttendanceBreak) error
+	EndBreak(id uint, at time.Time) error
+}
+
+const attendanceOrderSQL = "attendance_sessions.clock_in_at ASC, attendance_sessions.id ASC"
+
+type AttendanceRepository struct {
+	db *gorm.DB
+}
+
+func NewAttendanceRepository(db *gorm.DB) IAttendanceRepository {
+	return &AttendanceRepository{db: db}
+}
+
+func preloadBreaks(db *gorm.DB) *gorm.DB {
+	return db.Order("started_at ASC, id ASC")
+}
+
+func (r *AttendanceRepository) findSessions(query *gorm.DB) ([]models.AttendanceSession, error) {
+	var sessions []models.AttendanceSession
+	err := query.Preload("Breaks", preloadBreaks).Order(attendanceOrderSQL).Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *AttendanceRepository) FindOpenByUser(userID uint) (*models.AttendanceSession, error) {
+	var session models.AttendanceSession
+	err := r.db.Preload("Breaks", preloadBreaks).
+		Where("user_id = ? AND clock_out_at IS NULL", userID).
+		First(&session).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *AttendanceRepository) FindByUser(userID uint, from, to time.Time) ([]models.AttendanceSession, error) {
+	return r.findSessions(r.db.
+		Where("user_id = ? AND clock_in_at < ? AND (clock_out_at IS NULL OR clock_out_at > ?)", userID, to, from))
+}
+
+func (r *AttendanceRepository) FindByTeam(teamID uint, from, to time.Time) ([]models.AttendanceSession, error) {
+	return r.findSessions(r.db.Preload("User").
+		Joins("JOIN users ON users.id = attendance_sessions.user_id AND users.deleted_at IS NULL").
+		Where("users.team_id = ? AND attendance_sessions.clock_in_at < ? AND (attendance_sessions.clock_out_at IS NULL OR attendance_sessions.clock_out_at > ?)", teamID, to, from))
+}
+
+func (r *AttendanceRepository) FindOpenBefore(before time.Time) ([]models.AttendanceSession, error) {
+	return r.findSessions(r.db.Where("clock_out_at IS NULL AND clock_in_at < ?", before))
+}
+
+func (r *AttendanceRepository) Create(session *models.AttendanceSession) error {
+	return r.db.Omit(clause.Associations).Create(session).Error
+}
+
+func (r *AttendanceRepository) Close(id uint, at time.Time, ip string, autoClosed bool) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.AttendanceSession{}).
+			Where("id = ? AND clock_out_at IS NULL", id).
+			Updates(map[string]interface{}{"clock_out_at": at, "clock_out_ip": ip, "auto_closed": autoClosed})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		// Oturum kapanırken süren mola da kapanır; mola girişten sonra
+		// başlamışsa bitişi başlangıcından önce olamaz.
+		return tx.Model(&models.AttendanceBreak{}).
+			Where("session_id = ? AND ended_at IS NULL", id).
+			Update("ended_at", gorm.Expr("CASE WHEN started_at > ? THEN started_at ELSE ? END", at, at)).Error
+	})
+}
+
+func (r *AttendanceRepository) StartBreak(b *models.AttendanceBreak) error {
+	return r.db.Create(b).Error
+}
+
+func (r *AttendanceRepository) EndBreak(id uint, at time.Time) error {
+	result := r.db.Model(&models.AttendanceBreak{}).
+		Where("id = ? AND ended_at IS NULL", id).
+		Update("ended_at", at)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+var _ IAttendanceRepository = (*AttendanceRepository)(nil)
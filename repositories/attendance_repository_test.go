@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+func TestAttendanceRepositorySessions(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repoSet) {
+		sales := mustCreateTeam(t, repos, "Satış", true)
+		support := mustCreateTeam(t, repos, "Destek", true)
+		ali := mustCreateUser(t, repos, models.User{Name: "Ali", Account: "ali@x", Type: models.Agent, TeamID: &sales.ID})
+		can := mustCreateUser(t, repos, models.User{Name: "Can", Account: "can@x", Type: models.Agent, TeamID: &support.ID})
+
+		at := func(day, hour, minute int) time.Time {
+			return time.Date(2026, time.March, day, hour, minute, 0, 0, time.UTC)
+		}
+		yesterday := &models.AttendanceSession{UserID: ali.ID, ClockInAt: at(1, 9, 0), ClockInIP: "10.0.0.1"}
+		if err := repos.attendance.Create(yesterday); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if err := repos.attendance.Close(yesterday.ID, at(1, 17, 0), "10.0.0.2", false); err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+		if err := repos.attendance.Close(yesterday.ID, at(1, 18, 0), "", false); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Fatalf("Close() twice error = %v, want ErrRecordNotFound", err)
+		}
+
+		today := &models.AttendanceSession{UserID: ali.ID, ClockInAt: at(2, 8, 55), ClockInIP: "10.0.0.1"}
+		if err := repos.attendance.Create(today); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if err := repos.attendance.Create(&models.AttendanceSession{UserID: ali.ID, ClockInAt: at(2, 9, 0)}); err == nil {
+			t.Fatal("Create() with an open session error = nil")
+		}
+		other := &models.AttendanceSession{UserID: can.ID, ClockInAt: at(2, 9, 0)}
+		if err := repos.attendance.Create(other); err != nil {
+			t.Fatalf("Create(other team) error = %v", err)
+		}
+
+		lunch := &models.AttendanceBreak{SessionID: today.ID, StartedAt: at(2, 12, 0)}
+		if err := repos.attendance.StartBreak(lunch); err != nil {
+			t.Fatalf("StartBreak() error = %v", err)
+		}
+		if err := repos.attendance.StartBreak(&models.AttendanceBreak{SessionID: today.ID, StartedAt: at(2, 12, 5)}); err == nil {
+			t.Fatal("StartBreak() with an open break error = nil")
+		}
+		if err := repos.attendance.EndBreak(lunch.ID, at(2, 12, 30)); err != nil {
+			t.Fatalf("EndBreak() error = %v", err)
+		}
+		if err := repos.attendance.EndBreak(lunch.ID, at(2, 12, 40)); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Fatalf("EndBreak() twice error = %v, want ErrRecordNotFound", err)
+		}
+		coffee := &models.AttendanceBreak{SessionID: today.ID, StartedAt: at(2, 15, 0)}
+		if err := repos.attendance.StartBreak(coffee); err != nil {
+			t.Fatalf("StartBreak() error = %v", err)
+		}
+
+		open, err := repos.attendance.FindOpenByUser(ali.ID)
+		if err != nil || open.ID != today.ID || len(open.Breaks) != 2 || open.OpenBreak() == nil || open.OpenBreak().ID != coffee.ID {
+			t.Fatalf("FindOpenByUser() = %+v, %v; want today's session with the coffee break open", open, err)
+		}
+
+		// Açık oturum aralığın sonuna kadar sürüyor sayılır.
+		sessions, err := repos.attendance.FindByTeam(sales.ID, at(1, 16, 0), at(3, 0, 0))
+		if err != nil || len(sessions) != 2 || sessions[0].ID != yesterday.ID || sessions[1].ID != today.ID {
+			t.Fatalf("FindByTeam() = %+v, %v; want yesterday and today", sessions, err)
+		}
+		if sessions[1].User == nil || sessions[1].User.Name != "Ali" || sessions[0].ClockOutIP != "10.0.0.2" {
+			t.Fatalf("FindByTeam()[1] = %+v, want User loaded", sessions[1])
+		}
+		if sessions, _ := repos.attendance.FindByUser(ali.ID, at(1, 17, 0), at(2, 8, 55)); len(sessions) != 0 {
+			t.Fatalf("FindByUser() between sessions = %+v, want none", sessions)
+		}
+
+		stale, err := repos.attendance.FindOpenBefore(at(2, 9, 0))
+		if err != nil || len(stale) != 1 || stale[0].ID != today.ID {
+			t.Fatalf("FindOpenBefore() = %+v, %v; want today's session", stale, err)
+		}
+
+		// Kapanış anı süren moladan önceyse mola başladığı anda biter.
+		if err := repos.attendance.Close(today.ID, at(2, 14, 0), "", true); err != nil {
+			t.Fatalf("Close(auto) error = %v", err)
+		}
+		if _, err := repos.attendance.FindOpenByUser(ali.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Fatalf("FindOpenByUser() after close error = %v, want ErrRecordNotFound", err)
+		}
+		closed, _ := repos.attendance.FindByUser(ali.ID, at(2, 0, 0), at(3, 0, 0))
+		if len(closed) != 1 || !closed[0].AutoClosed || closed[0].OpenBreak() != nil || !closed[0].Breaks[1].EndedAt.Equal(coffee.StartedAt) {
+			t.Fatalf("closed session = %+v, want auto closed with every break ended", closed)
+		}
+		if got := closed[0].WorkedDuration(at(3, 0, 0)); got != 4*time.Hour+35*time.Minute {
+			t.Fatalf("WorkedDuration() = %v, want 4h35m", got)
+		}
+	})
+}
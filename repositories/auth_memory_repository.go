@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"strings"
+
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+type MemoryAuthRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryAuthRepository(store *MemoryStore) IAuthRepository {
+	return &MemoryAuthRepository{store: store}
+}
+
+func (r *MemoryAuthRepository) FindUserByAccount(account string) (*models.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, u := range r.store.users {
+		if !isSoftDeleted(u.Model) && u.Account == account {
+			user := r.store.copyUser(u, false)
+			return &user, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *MemoryAuthRepository) FindUserByID(id uint) (*models.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	u, ok := r.store.users[id]
+	if !ok || isSoftDeleted(u.Model) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	user := r.store.copyUser(u, false)
+	return &user, nil
+}
+
+func (r *MemoryAuthRepository) UpdateUser(user *models.User) error {
+	if err := user.BeforeUpdate(&gorm.DB{Statement: &gorm.Statement{Dest: user}}); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.store.accountTaken(user.Account, user.ID) {
+		return gorm.ErrDuplicatedKey
+	}
+
+	now := memoryNow()
+	if user.ID == 0 {
+		// GORM'daki Save gibi, ID'si olmayan kayıt yeni kayıt olarak eklenir.
+		user.ID = r.store.nextUserID
+		user.CreatedAt = now
+		r.store.nextUserID++
+	}
+	user.UpdatedAt = now
+	stored := r.store.copyUser(user, false)
+	r.store.users[user.ID] = &stored
+	return nil
+}
+
+func (r *MemoryAuthRepository) CreateLoginEvent(event *models.LoginEvent) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	event.ID = r.store.nextLoginEventID
+	r.store.nextLoginEventID++
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = memoryNow()
+	}
+	stored := *event
+	stored.User = nil
+	stored.Account = strings.Clone(event.Account)
+	if event.UserID != nil {
+		userID := *event.UserID
+		stored.UserID = &userID
+	}
+	r.store.loginEvents[event.ID] = &stored
+	return nil
+}
+
+var _ IAuthRepository = (*MemoryAuthRepository)(nil)
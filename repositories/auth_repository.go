@@ -1,7 +1,6 @@
 package repositories
 
 import (
-	"zatrano/configs"
 	"zatrano/models"
 
 	"gorm.io/gorm"
@@ -11,14 +10,15 @@ type IAuthRepository interface {
 	FindUserByAccount(account string) (*models.User, error)
 	FindUserByID(id uint) (*models.User, error)
 	UpdateUser(user *models.User) error
+	CreateLoginEvent(event *models.LoginEvent) error
 }
 
 type AuthRepository struct {
 	db *gorm.DB
 }
 
-func NewAuthRepository() IAuthRepository {
-	return &AuthRepository{db: configs.GetDB()}
+func NewAuthRepository(db *gorm.DB) IAuthRepository {
+	return &AuthRepository{db: db}
 }
 
 func (r *AuthRepository) FindUserByAccount(account string) (*models.User, error) {
@@ -43,4 +43,8 @@ func (r *AuthRepository) UpdateUser(user *models.User) error {
 	return r.db.Save(user).Error
 }
 
+func (r *AuthRepository) CreateLoginEvent(event *models.LoginEvent) error {
+	return r.db.Create(event).Error
+}
+
 var _ IAuthRepository = (*AuthRepository)(nil)
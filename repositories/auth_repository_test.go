@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"testing"
+
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+func TestAuthRepository(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repoSet) {
+		created := mustCreateUser(t, repos, models.User{Name: "System", Account: "system@system", Type: models.System})
+
+		user, err := repos.auth.FindUserByAccount("system@system")
+		if err != nil || user.ID != created.ID {
+			t.Fatalf("FindUserByAccount() = %+v, %v", user, err)
+		}
+		if _, err := repos.auth.FindUserByAccount("missing@x"); err != gorm.ErrRecordNotFound {
+			t.Fatalf("FindUserByAccount(missing) error = %v, want ErrRecordNotFound", err)
+		}
+
+		user.Name = "Sistem"
+		if err := repos.auth.UpdateUser(user); err != nil {
+			t.Fatalf("UpdateUser() error = %v", err)
+		}
+		reloaded, err := repos.auth.FindUserByID(created.ID)
+		if err != nil || reloaded.Name != "Sistem" {
+			t.Fatalf("FindUserByID() = %+v, %v; want updated name", reloaded, err)
+		}
+
+		if err := repos.users.Delete(created.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if _, err := repos.auth.FindUserByID(created.ID); err != gorm.ErrRecordNotFound {
+			t.Fatalf("FindUserByID() after delete error = %v, want ErrRecordNotFound", err)
+		}
+	})
+}
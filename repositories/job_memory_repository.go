@@ -0,0 +1,226 @@
+package repositories
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"zatrano/models"
+	"zatrano/utils"
+
+	"gorm.io/gorm"
+)
+
+// MemoryJobRepository, IJobRepository'nin bellek içi uygulamasıdır. Store
+// kilidi Claim'i tek işçiyle sınırladığı için SKIP LOCKED'a gerek yoktur.
+type MemoryJobRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryJobRepository(store *MemoryStore) IJobRepository {
+	return &MemoryJobRepository{store: store}
+}
+
+var jobFields = memoryFields[models.Job]{
+	"id":         func(j models.Job) interface{} { return int64(j.ID) },
+	"type":       func(j models.Job) interface{} { return j.Type },
+	"status":     func(j models.Job) interface{} { return string(j.Status) },
+	"attempts":   func(j models.Job) interface{} { return int64(j.Attempts) },
+	"run_at":     func(j models.Job) interface{} { return j.RunAt },
+	"created_at": func(j models.Job) interface{} { return j.CreatedAt },
+}
+
+func copyJob(j *models.Job) models.Job {
+	out := *j
+	out.LockedAt = toTimePtr(j.LockedAt)
+	out.FinishedAt = toTimePtr(j.FinishedAt)
+	return out
+}
+
+func (r *MemoryJobRepository) FindAndPaginate(params utils.ListParams) ([]models.Job, int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	jobs := []models.Job{}
+	for _, j := range r.store.jobs {
+		if matchFilters(*j, params.Filters, jobFields) {
+			jobs = append(jobs, copyJob(j))
+		}
+	}
+
+	totalCount := int64(len(jobs))
+	if totalCount == 0 {
+		return jobs, 0, nil
+	}
+
+	page := sortAndPage(jobs, params, jobFields, "id", func(j models.Job) uint { return j.ID })
+	return page, totalCount, nil
+}
+
+func (r *MemoryJobRepository) CountByStatus() (map[models.JobStatus]int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	counts := make(map[models.JobStatus]int64)
+	for _, j := range r.store.jobs {
+		counts[j.Status]++
+	}
+	return counts, nil
+}
+
+func (r *MemoryJobRepository) FindByID(id uint) (*models.Job, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	j, ok := r.store.jobs[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	job := copyJob(j)
+	return &job, nil
+}
+
+func (r *MemoryJobRepository) Create(job *models.Job) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	now := memoryNow()
+	job.ID = r.store.nextJobID
+	job.CreatedAt = now
+	job.UpdatedAt = now
+	r.store.nextJobID++
+
+	stored := copyJob(job)
+	stored.Type = strings.Clone(job.Type)
+	stored.Payload = strings.Clone(job.Payload)
+	r.store.jobs[job.ID] = &stored
+	return nil
+}
+
+func (r *MemoryJobRepository) Claim(types []string, workerID string, now time.Time) (*models.Job, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var next *models.Job
+	for _, j := range r.store.jobs {
+		if j.Status != models.JobPending || j.RunAt.After(now) || !slices.Contains(types, j.Type) {
+			continue
+		}
+		if next == nil || j.RunAt.Before(next.RunAt) || (j.RunAt.Equal(next.RunAt) && j.ID < next.ID) {
+			next = j
+		}
+	}
+	if next == nil {
+		return nil, nil
+	}
+
+	lockedAt := now
+	next.Status = models.JobRunning
+	next.Attempts++
+	next.LockedBy = strings.Clone(workerID)
+	next.LockedAt = &lockedAt
+	next.UpdatedAt = memoryNow()
+	job := copyJob(next)
+	return &job, nil
+}
+
+func (r *MemoryJobRepository) Finish(id uint, workerID string, data map[string]interface{}) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	j, ok := r.store.jobs[id]
+	if !ok || j.Status != models.JobRunning || j.LockedBy != workerID {
+		return gorm.ErrRecordNotFound
+	}
+	applyJobUpdates(j, data)
+	return nil
+}
+
+func (r *MemoryJobRepository) Retry(id uint, now time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	j, ok := r.store.jobs[id]
+	if !ok || (j.Status != models.JobPending && j.Status != models.JobDead) {
+		return gorm.ErrRecordNotFound
+	}
+	applyJobUpdates(j, map[string]interface{}{
+		"status":      models.JobPending,
+		"attempts":    0,
+		"run_at":      now,
+		"locked_by":   "",
+		"locked_at":   nil,
+		"finished_at": nil,
+	})
+	return nil
+}
+
+func (r *MemoryJobRepository) RequeueStale(lockedBefore, now time.Time) (requeued, dead int64, err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, j := range r.store.jobs {
+		if j.Status != models.JobRunning || j.LockedAt == nil || !j.LockedAt.Before(lockedBefore) {
+			continue
+		}
+		if j.Attempts >= j.MaxAttempts {
+			applyJobUpdates(j, map[string]interface{}{
+				"status":      models.JobDead,
+				"last_error":  staleJobError,
+				"locked_by":   "",
+				"locked_at":   nil,
+				"finished_at": now,
+			})
+			dead++
+			continue
+		}
+		applyJobUpdates(j, map[string]interface{}{
+			"status":    models.JobPending,
+			"run_at":    now,
+			"locked_by": "",
+			"locked_at": nil,
+		})
+		requeued++
+	}
+	return requeued, dead, nil
+}
+
+func (r *MemoryJobRepository) DeleteSucceeded(before time.Time) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var count int64
+	for id, j := range r.store.jobs {
+		if j.Status == models.JobSucceeded && j.FinishedAt != nil && j.FinishedAt.Before(before) {
+			delete(r.store.jobs, id)
+			count++
+		}
+	}
+	return count, nil
+}
+
+func applyJobUpdates(j *models.Job, data map[string]interface{}) {
+	for key, value := range data {
+		switch key {
+		case "status":
+			j.Status, _ = value.(models.JobStatus)
+		case "attempts":
+			j.Attempts, _ = value.(int)
+		case "run_at":
+			j.RunAt, _ = value.(time.Time)
+		case "locked_by":
+			lockedBy, _ := value.(string)
+			j.LockedBy = strings.Clone(lockedBy)
+		case "locked_at":
+			j.LockedAt = toTimePtr(value)
+		case "last_error":
+			lastError, _ := value.(string)
+			j.LastError = strings.Clone(lastError)
+		case "finished_at":
+			j.FinishedAt = toTimePtr(value)
+		}
+	}
+	j.UpdatedAt = memoryNow()
+}
+
+var _ IJobRepository = (*MemoryJobRepository)(nil)
@@ -0,0 +1,216 @@
+package repositories
+
+import (
+	"time"
+
+	"zatrano/models"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IJobRepository interface {
+	FindAndPaginate(params utils.ListParams) ([]models.Job, int64, error)
+	CountByStatus() (map[models.JobStatus]int64, error)
+	FindByID(id uint) (*models.Job, error)
+	Create(job *models.Job) error
+	Claim(types []string, workerID string, now time.Time) (*models.Job, error)
+	Finish(id uint, workerID string, data map[string]interface{}) error
+	Retry(id uint, now time.Time) error
+	RequeueStale(lockedBefore, now time.Time) (requeued, dead int64, err error)
+	DeleteSucceeded(before time.Time) (int64, error)
+}
+
+const staleJobError = "işçi işi kilit süresi içinde bitirmedi ve deneme hakkı kalmadı"
+
+var jobListColumns = listColumns{
+	"id":         "jobs.id",
+	"type":       "jobs.type",
+	"status":     "jobs.status",
+	"attempts":   "jobs.attempts",
+	"run_at":     "jobs.run_at",
+	"created_at": "jobs.created_at",
+}
+
+type JobRepository struct {
+	db *gorm.DB
+}
+
+func NewJobRepository(db *gorm.DB) IJobRepository {
+	return &JobRepository{db: db}
+}
+
+func (r *JobRepository) FindAndPaginate(params utils.ListParams) ([]models.Job, int64, error) {
+	var jobs []models.Job
+	var totalCount int64
+
+	query := applyFilters(r.db.Model(&models.Job{}), params.Filters, jobListColumns)
+
+	if err := query.Count(&totalCount).Error; err != nil {
+		utils.Log.Error("İş sayısı alınırken hata (FindAndPaginate)", zap.Error(err))
+		return nil, 0, err
+	}
+	if totalCount == 0 {
+		return jobs, 0, nil
+	}
+
+	query = applySort(query, params, jobListColumns, "id")
+	if err := query.Limit(params.PerPage).Offset(params.CalculateOffset()).Find(&jobs).Error; err != nil {
+		utils.Log.Error("İş verisi çekilirken hata (FindAndPaginate)", zap.Error(err))
+		return nil, totalCount, err
+	}
+	return jobs, totalCount, nil
+}
+
+func (r *JobRepository) CountByStatus() (map[models.JobStatus]int64, error) {
+	var rows []struct {
+		Status models.JobStatus
+		Count  int64
+	}
+	err := r.db.Model(&models.Job{}).Select("status, COUNT(*) AS count").Group("status").Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[models.JobStatus]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *JobRepository) FindByID(id uint) (*models.Job, error) {
+	var job models.Job
+	if err := r.db.First(&job, id).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *JobRepository) Create(job *models.Job) error {
+	return r.db.Create(job).Error
+}
+
+func (r *JobRepository) Claim(types []string, workerID string, now time.Time) (*models.Job, error) {
+	if len(types) == 0 {
+		return nil, nil
+	}
+	var claimed *models.Job
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		query := tx.Where("status = ? AND run_at <= ? AND type IN ?", models.JobPending, now, types).
+			Order("run_at ASC, id ASC").
+			Limit(1)
+		// SKIP LOCKED, başka bir işçinin kilitlediği satırı beklemeden
+		// sonrakine geçer; böylece işçiler birbirini bloklamaz. SQLite satır
+		// kilidi desteklemez; orada aşağıdaki koşullu güncelleme yeterlidir.
+		if tx.Dialector.Name() == "postgres" {
+			query = query.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"})
+		}
+		var jobs []models.Job
+		if err := query.Find(&jobs).Error; err != nil {
+			return err
+		}
+		if len(jobs) == 0 {
+			return nil
+		}
+
+		job := jobs[0]
+		result := tx.Model(&models.Job{}).
+			Where("id = ? AND status = ?", job.ID, models.JobPending).
+			Updates(map[string]interface{}{
+				"status":    models.JobRunning,
+				"attempts":  gorm.Expr("attempts + 1"),
+				"locked_by": workerID,
+				"locked_at": now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		job.Status = models.JobRunning
+		job.Attempts++
+		job.LockedBy = workerID
+		job.LockedAt = &now
+		claimed = &job
+		return nil
+	})
+	return claimed, err
+}
+
+func (r *JobRepository) Finish(id uint, workerID string, data map[string]interface{}) error {
+	result := r.db.Model(&models.Job{}).
+		Where("id = ? AND status = ? AND locked_by = ?", id, models.JobRunning, workerID).
+		Updates(data)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *JobRepository) Retry(id uint, now time.Time) error {
+	result := r.db.Model(&models.Job{}).
+		Where("id = ? AND status IN ?", id, []models.JobStatus{models.JobPending, models.JobDead}).
+		Updates(map[string]interface{}{
+			"status":      models.JobPending,
+			"attempts":    0,
+			"run_at":      now,
+			"locked_by":   "",
+			"locked_at":   nil,
+			"finished_at": nil,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *JobRepository) RequeueStale(lockedBefore, now time.Time) (requeued, dead int64, err error) {
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		stale := func() *gorm.DB {
+			return tx.Model(&models.Job{}).Where("status = ? AND locked_at < ?", models.JobRunning, lockedBefore)
+		}
+		result := stale().Where("attempts < max_attempts").Updates(map[string]interface{}{
+			"status":    models.JobPending,
+			"run_at":    now,
+			"locked_by": "",
+			"locked_at": nil,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		requeued = result.RowsAffected
+
+		result = stale().Where("attempts >= max_attempts").Updates(map[string]interface{}{
+			"status":      models.JobDead,
+			"last_error":  staleJobError,
+			"locked_by":   "",
+			"locked_at":   nil,
+			"finished_at": now,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		dead = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return requeued, dead, nil
+}
+
+func (r *JobRepository) DeleteSucceeded(before time.Time) (int64, error) {
+	result := r.db.Where("status = ? AND finished_at < ?", models.JobSucceeded, before).Delete(&models.Job{})
+	return result.RowsAffected, result.Error
+}
+
+var _ IJobRepository = (*JobRepository)(nil)
@@ -0,0 +1,145 @@
+package repositories
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"zatrano/models"
+	"zatrano/utils"
+
+	"gorm.io/gorm"
+)
+
+func TestJobRepositoryClaimAndFinish(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repoSet) {
+		now := time.Now().UTC().Truncate(time.Second)
+		older := &models.Job{Type: "email", Payload: `{}`, Status: models.JobPending, MaxAttempts: 3, RunAt: now.Add(-time.Minute)}
+		newer := &models.Job{Type: "email", Payload: `{}`, Status: models.JobPending, MaxAttempts: 3, RunAt: now}
+		future := &models.Job{Type: "email", Payload: `{}`, Status: models.JobPending, MaxAttempts: 3, RunAt: now.Add(time.Hour)}
+		other := &models.Job{Type: "hash", Payload: `{}`, Status: models.JobPending, MaxAttempts: 3, RunAt: now.Add(-time.Hour)}
+		for _, j := range []*models.Job{newer, older, future, other} {
+			if err := repos.jobs.Create(j); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+		}
+
+		if job, err := repos.jobs.Claim(nil, "w1", now); job != nil || err != nil {
+			t.Fatalf("Claim(no types) = %+v, %v", job, err)
+		}
+		job, err := repos.jobs.Claim([]string{"email"}, "w1", now)
+		if err != nil || job == nil || job.ID != older.ID || job.Status != models.JobRunning || job.Attempts != 1 || job.LockedBy != "w1" {
+			t.Fatalf("Claim() = %+v, %v; want the oldest due email job", job, err)
+		}
+		second, _ := repos.jobs.Claim([]string{"email"}, "w2", now)
+		if second == nil || second.ID != newer.ID {
+			t.Fatalf("second Claim() = %+v; want the next due job", second)
+		}
+		if third, _ := repos.jobs.Claim([]string{"email"}, "w3", now); third != nil {
+			t.Fatalf("third Claim() = %+v; the remaining job is not due", third)
+		}
+
+		finished := now.Add(time.Second)
+		done := map[string]interface{}{"status": models.JobSucceeded, "locked_by": "", "locked_at": nil, "finished_at": finished}
+		if err := repos.jobs.Finish(job.ID, "w2", done); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Fatalf("Finish(wrong worker) error = %v", err)
+		}
+		if err := repos.jobs.Finish(job.ID, "w1", done); err != nil {
+			t.Fatalf("Finish() error = %v", err)
+		}
+		stored, _ := repos.jobs.FindByID(job.ID)
+		if stored.Status != models.JobSucceeded || stored.LockedAt != nil || stored.FinishedAt == nil || stored.Attempts != 1 {
+			t.Fatalf("FindByID() after Finish = %+v", stored)
+		}
+		if err := repos.jobs.Finish(job.ID, "w1", done); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Fatalf("Finish(twice) error = %v", err)
+		}
+
+		counts, err := repos.jobs.CountByStatus()
+		if err != nil || counts[models.JobPending] != 2 || counts[models.JobRunning] != 1 || counts[models.JobSucceeded] != 1 || counts[models.JobDead] != 0 {
+			t.Fatalf("CountByStatus() = %v, %v", counts, err)
+		}
+
+		if n, err := repos.jobs.DeleteSucceeded(finished); n != 0 || err != nil {
+			t.Fatalf("DeleteSucceeded(before finish) = %d, %v", n, err)
+		}
+		if n, err := repos.jobs.DeleteSucceeded(finished.Add(time.Second)); n != 1 || err != nil {
+			t.Fatalf("DeleteSucceeded() = %d, %v", n, err)
+		}
+		if _, err := repos.jobs.FindByID(job.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Fatalf("FindByID(deleted) error = %v", err)
+		}
+	})
+}
+
+func TestJobRepositoryRetryAndRequeue(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repoSet) {
+		now := time.Now().UTC().Truncate(time.Second)
+		job := &models.Job{Type: "email", Payload: `{}`, Status: models.JobPending, MaxAttempts: 2, RunAt: now}
+		if err := repos.jobs.Create(job); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := repos.jobs.Claim([]string{"email"}, "w1", now); err != nil {
+			t.Fatal(err)
+		}
+		if err := repos.jobs.Retry(job.ID, now); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Fatalf("Retry(running) error = %v", err)
+		}
+
+		if n, dead, err := repos.jobs.RequeueStale(now, now); n != 0 || dead != 0 || err != nil {
+			t.Fatalf("RequeueStale(fresh lock) = %d, %d, %v", n, dead, err)
+		}
+		later := now.Add(time.Hour)
+		if n, dead, err := repos.jobs.RequeueStale(now.Add(time.Minute), later); n != 1 || dead != 0 || err != nil {
+			t.Fatalf("RequeueStale() = %d, %d, %v", n, dead, err)
+		}
+		stored, _ := repos.jobs.FindByID(job.ID)
+		if stored.Status != models.JobPending || stored.LockedBy != "" || stored.LockedAt != nil || !stored.RunAt.Equal(later) || stored.Attempts != 1 {
+			t.Fatalf("FindByID() after RequeueStale = %+v", stored)
+		}
+
+		// Son denemesinde takılan iş yeniden kuyruğa alınmaz, ölü olur.
+		if _, err := repos.jobs.Claim([]string{"email"}, "w2", later); err != nil {
+			t.Fatal(err)
+		}
+		if n, dead, err := repos.jobs.RequeueStale(later.Add(time.Minute), later.Add(time.Hour)); n != 0 || dead != 1 || err != nil {
+			t.Fatalf("RequeueStale(no attempts left) = %d, %d, %v", n, dead, err)
+		}
+		stored, _ = repos.jobs.FindByID(job.ID)
+		if stored.Status != models.JobDead || stored.LockedAt != nil || stored.FinishedAt == nil || stored.Attempts != 2 || stored.LastError != staleJobError {
+			t.Fatalf("FindByID() after RequeueStale(no attempts left) = %+v", stored)
+		}
+		if err := repos.jobs.Finish(job.ID, "w2", map[string]interface{}{"status": models.JobSucceeded}); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Fatalf("Finish(dead) error = %v", err)
+		}
+		if err := repos.jobs.Retry(job.ID, now); err != nil {
+			t.Fatalf("Retry() error = %v", err)
+		}
+		stored, _ = repos.jobs.FindByID(job.ID)
+		if stored.Status != models.JobPending || stored.Attempts != 0 || stored.FinishedAt != nil || !stored.RunAt.Equal(now) || stored.LastError == "" {
+			t.Fatalf("FindByID() after Retry = %+v; want a fresh pending job that keeps its last error", stored)
+		}
+		if err := repos.jobs.Retry(999, now); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Fatalf("Retry(missing) error = %v", err)
+		}
+	})
+}
+
+func TestJobRepositoryFindAndPaginate(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repoSet) {
+		now := time.Now().UTC().Truncate(time.Second)
+		for i, status := range []models.JobStatus{models.JobPending, models.JobDead, models.JobDead, models.JobSucceeded} {
+			job := &models.Job{Type: "email", Payload: `{}`, Status: status, MaxAttempts: 3, RunAt: now.Add(time.Duration(i) * time.Minute)}
+			if err := repos.jobs.Create(job); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		params := utils.ListParams{Page: 1, PerPage: 1, SortBy: "run_at", OrderBy: "desc",
+			Filters: []utils.Filter{{Field: "status", Op: utils.OpEq, Value: string(models.JobDead)}}}
+		jobs, total, err := repos.jobs.FindAndPaginate(params)
+		if err != nil || total != 2 || len(jobs) != 1 || jobs[0].Status != models.JobDead || !jobs[0].RunAt.Equal(now.Add(2*time.Minute)) {
+			t.Fatalf("FindAndPaginate() = %+v, %d, %v", jobs, total, err)
+		}
+	})
+}
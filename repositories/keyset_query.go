@@ -0,0 +1,144 @@
+package repositories
+
+import (
+	"reflect"
+	"slices"
+
+	"zatrano/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type keyset struct {
+	field string
+	desc  bool
+	after *utils.Cursor
+	limit int
+}
+
+func newKeyset[T any](params utils.ListParams, cursor utils.CursorParams, sortable func(string) bool, fallback string, fields memoryFields[T]) (keyset, error) {
+	k := keyset{field: fallback, desc: fallbackDesc(params), limit: cursor.NormalizeLimit()}
+	if sorts := params.SortFields(); len(sorts) > 0 && sortable(sorts[0].Field) {
+		k.field, k.desc = sorts[0].Field, sorts[0].Desc
+	}
+	if cursor.Cursor == "" {
+		return k, nil
+	}
+	after, err := utils.DecodeCursor(cursor.Cursor)
+	if err != nil {
+		return keyset{}, err
+	}
+	var zero T
+	if after.Field != k.field || after.Desc != k.desc || reflect.TypeOf(after.Value) != reflect.TypeOf(fields[k.field](zero)) {
+		return keyset{}, utils.ErrInvalidCursor
+	}
+	k.after = &after
+	return k, nil
+}
+
+func (k keyset) backward() bool {
+	return k.after != nil && k.after.Before
+}
+
+func (k keyset) scanDesc() bool {
+	return k.desc != k.backward()
+}
+
+func applyKeyset(query *gorm.DB, k keyset, columns listColumns) *gorm.DB {
+	column, id := columns[k.field], columns["id"]
+	desc := k.scanDesc()
+
+	if k.after != nil {
+		op := ">"
+		if desc {
+			op = "<"
+		}
+		if k.field == "id" {
+			query = query.Where(id+" "+op+" ?", k.after.ID)
+		} else {
+			query = query.Where("("+column+", "+id+") "+op+" (?, ?)", sqlFilterValue(k.after.Value), k.after.ID)
+		}
+	}
+
+	query = query.Order(clause.OrderByColumn{Column: clause.Column{Name: column, Raw: true}, Desc: desc})
+	if k.field != "id" {
+		query = query.Order(clause.OrderByColumn{Column: clause.Column{Name: id, Raw: true}, Desc: desc})
+	}
+	return query.Limit(k.limit + 1)
+}
+
+func keysetSlice[T any](items []T, k keyset, fields memoryFields[T], id func(T) uint) []T {
+	get := fields[k.field]
+	desc := k.scanDesc()
+	compare := func(value interface{}, itemID uint, otherValue interface{}, otherID uint) int {
+		c := compareValues(value, otherValue)
+		if c == 0 {
+			c = compareOrdered(itemID, otherID)
+		}
+		if desc {
+			return -c
+		}
+		return c
+	}
+
+	slices.SortFunc(items, func(a, b T) int {
+		return compare(get(a), id(a), get(b), id(b))
+	})
+	if k.after != nil {
+		items = slices.DeleteFunc(items, func(item T) bool {
+			return compare(get(item), id(item), k.after.Value, k.after.ID) <= 0
+		})
+	}
+	if len(items) > k.limit+1 {
+		items = items[:k.limit+1]
+	}
+	return items
+}
+
+func keysetPage[T any](k keyset, rows []T, value func(T) interface{}, id func(T) uint) ([]T, utils.CursorMeta) {
+	meta := utils.CursorMeta{Limit: k.limit}
+	hasMore := len(rows) > k.limit
+	if hasMore {
+		rows = rows[:k.limit]
+	}
+	if k.backward() {
+		slices.Reverse(rows)
+	}
+	if len(rows) == 0 {
+		return rows, meta
+	}
+
+	cursorAt := func(item T, before bool) string {
+		return utils.EncodeCursor(utils.Cursor{Field: k.field, Desc: k.desc, Value: value(item), ID: id(item), Before: before})
+	}
+	first, last := rows[0], rows[len(rows)-1]
+	if k.backward() {
+		// Önceki sayfaya imleçteki kayıttan gelindiği için sonraki sayfa her zaman vardır.
+		meta.NextCursor = cursorAt(last, false)
+		if hasMore {
+			meta.PrevCursor = cursorAt(first, true)
+		}
+	} else {
+		if hasMore {
+			meta.NextCursor = cursorAt(last, false)
+		}
+		if k.after != nil {
+			meta.PrevCursor = cursorAt(first, true)
+		}
+	}
+	return rows, meta
+}
+
+func estimateCount(db *gorm.DB, model interface{}, table string) (int64, error) {
+	if db.Dialector.Name() == "postgres" {
+		var estimate float64
+		err := db.Raw("SELECT reltuples FROM pg_class WHERE oid = to_regclass(?)", table).Scan(&estimate).Error
+		if err == nil && estimate >= 0 {
+			return int64(estimate), nil
+		}
+	}
+	var count int64
+	err := db.Model(model).Count(&count).Error
+	return count, err
+}
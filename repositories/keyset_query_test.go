@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"errors"
+	"testing"
+
+	"zatrano/models"
+	"zatrano/utils"
+)
+
+func walkCursor(t *testing.T, repos repoSet, params utils.ListParams, cursor utils.CursorParams) ([][]string, utils.CursorMeta) {
+	t.Helper()
+	var pages [][]string
+	var meta utils.CursorMeta
+	for i := 0; ; i++ {
+		if i > 10 {
+			t.Fatal("cursor pagination does not terminate")
+		}
+		users, m, err := repos.users.FindByCursor(params, cursor)
+		if err != nil {
+			t.Fatalf("FindByCursor() error = %v", err)
+		}
+		pages = append(pages, userNames(users))
+		meta = m
+		if m.NextCursor == "" {
+			return pages, meta
+		}
+		cursor.Cursor = m.NextCursor
+	}
+}
+
+func TestUserRepositoryCursorPagination(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repoSet) {
+		team, _ := seedUsers(t, repos)
+		mustCreateUser(t, repos, models.User{Name: "Ahmet", Account: "ahmet2@x", Type: models.Agent, TeamID: &team.ID})
+
+		params := listParams(t, testUserSpec, "sort=name")
+		pages, last := walkCursor(t, repos, params, utils.CursorParams{Limit: 2})
+		want := [][]string{{"Ahmet", "Ahmet"}, {"Mehmet", "Zeynep"}, {"Çağla"}}
+		if len(pages) != len(want) {
+			t.Fatalf("pages = %v, want %v", pages, want)
+		}
+		for i := range want {
+			if !equalStrings(pages[i], want[i]) {
+				t.Fatalf("pages = %v, want %v", pages, want)
+			}
+		}
+		// Tahmin tablonun tamamını sayabilir; listedeki kayıtlardan az olamaz.
+		if last.EstimatedTotal < 5 {
+			t.Fatalf("EstimatedTotal = %d, want at least 5", last.EstimatedTotal)
+		}
+
+		// Son sayfadan geriye doğru ilerlemek aynı sayfaları ters sırada verir.
+		users, meta, err := repos.users.FindByCursor(params, utils.CursorParams{Cursor: last.PrevCursor, Limit: 2})
+		if err != nil {
+			t.Fatalf("FindByCursor(prev) error = %v", err)
+		}
+		if got := userNames(users); !equalStrings(got, want[1]) || meta.NextCursor == "" || meta.PrevCursor == "" {
+			t.Fatalf("prev page = %v (%+v), want %v with both cursors", got, meta, want[1])
+		}
+		users, meta, err = repos.users.FindByCursor(params, utils.CursorParams{Cursor: meta.PrevCursor, Limit: 2})
+		if err != nil {
+			t.Fatalf("FindByCursor(prev) error = %v", err)
+		}
+		if got := userNames(users); !equalStrings(got, want[0]) || meta.PrevCursor != "" {
+			t.Fatalf("first page = %v (%+v), want %v without prev cursor", got, meta, want[0])
+		}
+
+		// Filtreli listelerde tahmini toplam dönmez.
+		filtered := listParams(t, testUserSpec, "filter.status.eq=true&sort=-created_at")
+		pages, last = walkCursor(t, repos, filtered, utils.CursorParams{Limit: 3})
+		if len(pages) != 2 || len(pages[0]) != 3 || len(pages[1]) != 2 || last.EstimatedTotal != 0 {
+			t.Fatalf("filtered pages = %v (%+v)", pages, last)
+		}
+	})
+}
+
+func TestUserRepositoryCursorRejectsForeignCursor(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repoSet) {
+		seedUsers(t, repos)
+
+		_, meta, err := repos.users.FindByCursor(listParams(t, testUserSpec, "sort=name"), utils.CursorParams{Limit: 1})
+		if err != nil || meta.NextCursor == "" {
+			t.Fatalf("FindByCursor() = %+v, %v", meta, err)
+		}
+
+		for name, cursor := range map[string]string{
+			"other sort":  meta.NextCursor,
+			"garbage":     "not-a-cursor",
+			"wrong kind":  utils.EncodeCursor(utils.Cursor{Field: "created_at", Desc: true, Value: "x", ID: 1}),
+			"int as time": utils.EncodeCursor(utils.Cursor{Field: "created_at", Desc: true, Value: int64(5), ID: 1}),
+		} {
+			_, _, err := repos.users.FindByCursor(listParams(t, testUserSpec, "sort=-created_at"), utils.CursorParams{Cursor: cursor})
+			if !errors.Is(err, utils.ErrInvalidCursor) {
+				t.Fatalf("%s: error = %v, want ErrInvalidCursor", name, err)
+			}
+		}
+	})
+}
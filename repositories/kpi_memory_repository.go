@@ -0,0 +1,177 @@
+package repositories
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+type MemoryKPIRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryKPIRepository(store *MemoryStore) IKPIRepository {
+	return &MemoryKPIRepository{store: store}
+}
+
+func (r *MemoryKPIRepository) FindDefinitions() ([]models.KPIDefinition, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	definitions := []models.KPIDefinition{}
+	for _, d := range r.store.kpiDefinitions {
+		definitions = append(definitions, *d)
+	}
+	slices.SortFunc(definitions, func(a, b models.KPIDefinition) int {
+		if c := compareOrdered(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return compareOrdered(a.ID, b.ID)
+	})
+	return definitions, nil
+}
+
+func (r *MemoryKPIRepository) FindDefinitionByID(id uint) (*models.KPIDefinition, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	d, ok := r.store.kpiDefinitions[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	definition := *d
+	return &definition, nil
+}
+
+func (r *MemoryKPIRepository) FindDefinitionByKey(key string) (*models.KPIDefinition, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, d := range r.store.kpiDefinitions {
+		if d.Key == key {
+			definition := *d
+			return &definition, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *MemoryKPIRepository) CreateDefinition(definition *models.KPIDefinition) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, d := range r.store.kpiDefinitions {
+		if d.Key == definition.Key {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	now := memoryNow()
+	definition.ID = r.store.nextKPIDefinitionID
+	definition.CreatedAt = now
+	definition.UpdatedAt = now
+	r.store.nextKPIDefinitionID++
+
+	stored := *definition
+	stored.Key = strings.Clone(definition.Key)
+	stored.Name = strings.Clone(definition.Name)
+	r.store.kpiDefinitions[definition.ID] = &stored
+	return nil
+}
+
+func (r *MemoryKPIRepository) UpdateDefinition(id uint, data map[string]interface{}) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	d, ok := r.store.kpiDefinitions[id]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	for key, value := range data {
+		switch key {
+		case "name":
+			name, _ := value.(string)
+			d.Name = strings.Clone(name)
+		case "unit":
+			d.Unit, _ = value.(models.KPIUnit)
+		case "aggregation":
+			d.Aggregation, _ = value.(models.KPIAggregation)
+		case "higher_is_better":
+			d.HigherIsBetter, _ = value.(bool)
+		case "status":
+			d.Status, _ = value.(bool)
+		}
+	}
+	d.UpdatedAt = memoryNow()
+	return nil
+}
+
+func (r *MemoryKPIRepository) SaveValues(values []models.KPIValue) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	now := memoryNow()
+	for i := range values {
+		v := &values[i]
+		key := kpiValueKey{definitionID: v.DefinitionID, userID: v.UserID, day: models.CivilDate(v.Day)}
+		if existing, ok := r.store.kpiValues[key]; ok {
+			existing.Value = v.Value
+			existing.UpdatedAt = now
+			v.ID, v.CreatedAt, v.UpdatedAt = existing.ID, existing.CreatedAt, now
+			continue
+		}
+		v.ID = r.store.nextKPIValueID
+		v.CreatedAt, v.UpdatedAt = now, now
+		r.store.nextKPIValueID++
+
+		stored := *v
+		stored.Definition, stored.User = nil, nil
+		stored.Day = key.day
+		r.store.kpiValues[key] = &stored
+	}
+	return nil
+}
+
+func (r *MemoryKPIRepository) FindValues(definitionIDs []uint, from, to time.Time, userIDs ...uint) ([]models.KPIValue, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	values := []models.KPIValue{}
+	for _, v := range r.store.kpiValues {
+		if !slices.Contains(definitionIDs, v.DefinitionID) || v.Day.Before(from) || v.Day.After(to) {
+			continue
+		}
+		if len(userIDs) > 0 && !slices.Contains(userIDs, v.UserID) {
+			continue
+		}
+		values = append(values, *v)
+	}
+	slices.SortFunc(values, func(a, b models.KPIValue) int {
+		if c := a.Day.Compare(b.Day); c != 0 {
+			return c
+		}
+		if c := compareOrdered(a.UserID, b.UserID); c != 0 {
+			return c
+		}
+		return compareOrdered(a.DefinitionID, b.DefinitionID)
+	})
+	return values, nil
+}
+
+func (r *MemoryKPIRepository) FindAgentsByAccounts(accounts []string) ([]models.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	users := []models.User{}
+	for _, u := range r.store.users {
+		if !isSoftDeleted(u.Model) && u.Type == models.Agent && slices.Contains(accounts, u.Account) {
+			users = append(users, r.store.copyUser(u, false))
+		}
+	}
+	return users, nil
+}
+
+var _ IKPIRepository = (*MemoryKPIRepository)(nil)
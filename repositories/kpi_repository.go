@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"time"
+
+	"zatrano/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const kpiValueBatchSize = 500
+
+type IKPIRepository interface {
+	FindDefinitions() ([]models.KPIDefinition, error)
+	FindDefinitionByID(id uint) (*models.KPIDefinition, error)
+	FindDefinitionByKey(key string) (*models.KPIDefinition, error)
+	CreateDefinition(definition *models.KPIDefinition) error
+	UpdateDefinition(id uint, data map[string]interface{}) error
+	SaveValues(values []models.KPIValue) error
+	FindValues(definitionIDs []uint, from, to time.Time, userIDs ...uint) ([]models.KPIValue, error)
+	FindAgentsByAccounts(accounts []string) ([]models.User, error)
+}
+
+type KPIRepository struct {
+	db *gorm.DB
+}
+
+func NewKPIRepository(db *gorm.DB) IKPIRepository {
+	return &KPIRepository{db: db}
+}
+
+func (r *KPIRepository) FindDefinitions() ([]models.KPIDefinition, error) {
+	var definitions []models.KPIDefinition
+	err := r.db.Order("name ASC, id ASC").Find(&definitions).Error
+	return definitions, err
+}
+
+func (r *KPIRepository) FindDefinitionByID(id uint) (*models.KPIDefinition, error) {
+	var definition models.KPIDefinition
+	if err := r.db.First(&definition, id).Error; err != nil {
+		return nil, err
+	}
+	return &definition, nil
+}
+
+func (r *KPIRepository) FindDefinitionByKey(key string) (*models.KPIDefinition, error) {
+	var definition models.KPIDefinition
+	if err := r.db.Where(&models.KPIDefinition{Key: key}).First(&definition).Error; err != nil {
+		return nil, err
+	}
+	return &definition, nil
+}
+
+func (r *KPIRepository) CreateDefinition(definition *models.KPIDefinition) error {
+	return r.db.Create(definition).Error
+}
+
+func (r *KPIRepository) UpdateDefinition(id uint, data map[string]interface{}) error {
+	result := r.db.Model(&models.KPIDefinition{}).Where("id = ?", id).Updates(data)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *KPIRepository) SaveValues(values []models.KPIValue) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return tx.Omit(clause.Associations).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "definition_id"}, {Name: "user_id"}, {Name: "day"}},
+			DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+		}).CreateInBatches(&values, kpiValueBatchSize).Error
+	})
+}
+
+func (r *KPIRepository) FindValues(definitionIDs []uint, from, to time.Time, userIDs ...uint) ([]models.KPIValue, error) {
+	query := r.db.Where("definition_id IN ? AND day BETWEEN ? AND ?", definitionIDs, from, to)
+	if len(userIDs) > 0 {
+		query = query.Where("user_id IN ?", userIDs)
+	}
+	var values []models.KPIValue
+	err := query.Order("day ASC, user_id ASC, definition_id ASC").Find(&values).Error
+	return values, err
+}
+
+func (r *KPIRepository) FindAgentsByAccounts(accounts []string) ([]models.User, error) {
+	var users []models.User
+	if len(accounts) == 0 {
+		return users, nil
+	}
+	err := r.db.Where("account IN ? AND type = ?", accounts, models.Agent).Find(&users).Error
+	return users, err
+}
+
+var _ IKPIRepository = (*KPIRepository)(nil)
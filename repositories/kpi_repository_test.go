@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+func TestKPIRepositoryValues(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repoSet) {
+		sales := mustCreateTeam(t, repos, "Satış", true)
+		ali := mustCreateUser(t, repos, models.User{Name: "Ali", Account: "ali@x", Type: models.Agent, TeamID: &sales.ID})
+		veli := mustCreateUser(t, repos, models.User{Name: "Veli", Account: "veli@x", Type: models.Agent, TeamID: &sales.ID})
+		mustCreateUser(t, repos, models.User{Name: "Yönetici", Account: "manager@x", Type: models.Manager, TeamID: &sales.ID})
+
+		calls := &models.KPIDefinition{Key: "calls_handled", Name: "Karşılanan çağrı", Unit: models.KPIUnitCount, Aggregation: models.KPIAggregationSum, HigherIsBetter: true, Status: true}
+		if err := repos.kpis.CreateDefinition(calls); err != nil {
+			t.Fatalf("CreateDefinition() error = %v", err)
+		}
+		if got, err := repos.kpis.FindDefinitionByKey("calls_handled"); err != nil || got.ID != calls.ID {
+			t.Fatalf("FindDefinitionByKey() = %+v, %v", got, err)
+		}
+		// false değerler veritabanı varsayılanıyla ezilmemeli.
+		aht := &models.KPIDefinition{Key: "aht", Name: "Görüşme süresi", Unit: models.KPIUnitSeconds, Aggregation: models.KPIAggregationAvg}
+		if err := repos.kpis.CreateDefinition(aht); err != nil {
+			t.Fatalf("CreateDefinition(aht) error = %v", err)
+		}
+		if got, err := repos.kpis.FindDefinitionByID(aht.ID); err != nil || got.HigherIsBetter || got.Status {
+			t.Fatalf("FindDefinitionByID(aht) = %+v, %v", got, err)
+		}
+		if _, err := repos.kpis.FindDefinitionByKey("missing"); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Fatalf("FindDefinitionByKey(missing) error = %v", err)
+		}
+		if err := repos.kpis.UpdateDefinition(calls.ID, map[string]interface{}{"name": "Çağrılar", "status": false}); err != nil {
+			t.Fatalf("UpdateDefinition() error = %v", err)
+		}
+		if got, _ := repos.kpis.FindDefinitionByID(calls.ID); got.Name != "Çağrılar" || got.Status {
+			t.Fatalf("FindDefinitionByID() = %+v", got)
+		}
+
+		day := func(d int) time.Time { return time.Date(2026, time.March, d, 0, 0, 0, 0, time.UTC) }
+		values := []models.KPIValue{
+			{DefinitionID: calls.ID, UserID: ali.ID, Day: day(2), Value: 40},
+			{DefinitionID: calls.ID, UserID: ali.ID, Day: day(3), Value: 35},
+			{DefinitionID: calls.ID, UserID: veli.ID, Day: day(3), Value: 20},
+		}
+		if err := repos.kpis.SaveValues(values); err != nil {
+			t.Fatalf("SaveValues() error = %v", err)
+		}
+		// Aynı gün için yeniden yükleme değeri günceller.
+		if err := repos.kpis.SaveValues([]models.KPIValue{{DefinitionID: calls.ID, UserID: ali.ID, Day: day(3), Value: 37}}); err != nil {
+			t.Fatalf("SaveValues(update) error = %v", err)
+		}
+
+		got, err := repos.kpis.FindValues([]uint{calls.ID}, day(1), day(3))
+		if err != nil || len(got) != 3 {
+			t.Fatalf("FindValues() = %+v, %v", got, err)
+		}
+		if got[1].UserID != ali.ID || got[1].Value != 37 || !got[1].Day.Equal(day(3)) {
+			t.Fatalf("updated value = %+v", got[1])
+		}
+		if mine, _ := repos.kpis.FindValues([]uint{calls.ID}, day(3), day(3), veli.ID); len(mine) != 1 || mine[0].Value != 20 {
+			t.Fatalf("FindValues(veli) = %+v", mine)
+		}
+
+		agents, err := repos.kpis.FindAgentsByAccounts([]string{"ali@x", "manager@x", "nobody@x"})
+		if err != nil || len(agents) != 1 || agents[0].ID != ali.ID {
+			t.Fatalf("FindAgentsByAccounts() = %+v, %v", agents, err)
+		}
+	})
+}
@@ -0,0 +1,272 @@
+package repositories
+
+import (
+	"slices"
+	"time"
+
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+type MemoryLeaveRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryLeaveRepository(store *MemoryStore) ILeaveRepository {
+	return &MemoryLeaveRepository{store: store}
+}
+
+func (r *MemoryLeaveRepository) copyRequest(l *models.LeaveRequest, withUser bool) models.LeaveRequest {
+	out := *l
+	out.User, out.Approver = nil, nil
+	if withUser {
+		if u, ok := r.store.users[l.UserID]; ok && !isSoftDeleted(u.Model) {
+			user := r.store.copyUser(u, false)
+			out.User = &user
+		}
+	}
+	return out
+}
+
+func (r *MemoryLeaveRepository) filterRequests(withUser bool, match func(l *models.LeaveRequest) bool) []models.LeaveRequest {
+	requests := []models.LeaveRequest{}
+	for _, l := range r.store.leaveRequests {
+		if !isSoftDeleted(l.Model) && match(l) {
+			requests = append(requests, r.copyRequest(l, withUser))
+		}
+	}
+	slices.SortFunc(requests, func(a, b models.LeaveRequest) int {
+		if c := a.StartDate.Compare(b.StartDate); c != 0 {
+			return c
+		}
+		return compareOrdered(a.ID, b.ID)
+	})
+	return requests
+}
+
+func (r *MemoryLeaveRepository) FindByID(id uint) (*models.LeaveRequest, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	l, ok := r.store.leaveRequests[id]
+	if !ok || isSoftDeleted(l.Model) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	request := r.copyRequest(l, true)
+	return &request, nil
+}
+
+func (r *MemoryLeaveRepository) FindByUser(userID uint) ([]models.LeaveRequest, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	requests := r.filterRequests(false, func(l *models.LeaveRequest) bool {
+		return l.UserID == userID
+	})
+	slices.Reverse(requests)
+	return requests, nil
+}
+
+func (r *MemoryLeaveRepository) FindByTeam(teamID uint, from, to time.Time, statuses ...models.LeaveStatus) ([]models.LeaveRequest, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.filterRequests(true, func(l *models.LeaveRequest) bool {
+		return l.TeamID == teamID && !l.StartDate.After(to) && !l.EndDate.Before(from) && slices.Contains(statuses, l.Status)
+	}), nil
+}
+
+func (r *MemoryLeaveRepository) FindPendingByTeam(teamID uint) ([]models.LeaveRequest, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.filterRequests(true, func(l *models.LeaveRequest) bool {
+		return l.TeamID == teamID && l.IsPending()
+	}), nil
+}
+
+func (r *MemoryLeaveRepository) Create(request *models.LeaveRequest) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	now := memoryNow()
+	request.ID = r.store.nextLeaveRequestID
+	request.CreatedAt = now
+	request.UpdatedAt = now
+	if request.Status == "" {
+		request.Status = models.LeavePending
+	}
+	r.store.nextLeaveRequestID++
+
+	stored := *request
+	stored.User, stored.Approver = nil, nil
+	r.store.leaveRequests[request.ID] = &stored
+	return nil
+}
+
+func (r *MemoryLeaveRepository) UpdatePending(id uint, data map[string]interface{}) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	l, ok := r.store.leaveRequests[id]
+	if !ok || isSoftDeleted(l.Model) || !l.IsPending() {
+		return gorm.ErrRecordNotFound
+	}
+	applyLeaveUpdates(l, data)
+	l.UpdatedAt = memoryNow()
+	return nil
+}
+
+func (r *MemoryLeaveRepository) SumDays(year int, userIDs ...uint) ([]LeaveDaysTotal, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	type key struct {
+		userID    uint
+		leaveType models.LeaveType
+		status    models.LeaveStatus
+	}
+	sums := make(map[key]int)
+	for _, l := range r.store.leaveRequests {
+		if isSoftDeleted(l.Model) || !l.IsActive() || l.StartDate.Year() != year {
+			continue
+		}
+		if len(userIDs) > 0 && !slices.Contains(userIDs, l.UserID) {
+			continue
+		}
+		sums[key{l.UserID, l.Type, l.Status}] += l.Days
+	}
+	totals := make([]LeaveDaysTotal, 0, len(sums))
+	for k, days := range sums {
+		totals = append(totals, LeaveDaysTotal{UserID: k.userID, Type: k.leaveType, Status: k.status, Days: days})
+	}
+	return totals, nil
+}
+
+func (r *MemoryLeaveRepository) FindBalances(year int, userIDs ...uint) ([]models.LeaveBalance, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	balances := []models.LeaveBalance{}
+	for _, b := range r.store.leaveBalances {
+		if b.Year != year || (len(userIDs) > 0 && !slices.Contains(userIDs, b.UserID)) {
+			continue
+		}
+		balance := *b
+		balances = append(balances, balance)
+	}
+	slices.SortFunc(balances, func(a, b models.LeaveBalance) int {
+		if c := compareOrdered(a.UserID, b.UserID); c != 0 {
+			return c
+		}
+		return compareOrdered(a.Type, b.Type)
+	})
+	return balances, nil
+}
+
+func (r *MemoryLeaveRepository) findBalance(userID uint, year int, leaveType models.LeaveType) *models.LeaveBalance {
+	for _, b := range r.store.leaveBalances {
+		if b.UserID == userID && b.Year == year && b.Type == leaveType {
+			return b
+		}
+	}
+	return nil
+}
+
+func (r *MemoryLeaveRepository) insertBalance(balance *models.LeaveBalance) {
+	now := memoryNow()
+	balance.ID = r.store.nextLeaveBalanceID
+	balance.CreatedAt = now
+	balance.UpdatedAt = now
+	r.store.nextLeaveBalanceID++
+
+	stored := *balance
+	stored.User = nil
+	r.store.leaveBalances[balance.ID] = &stored
+}
+
+func (r *MemoryLeaveRepository) CreateBalances(balances []models.LeaveBalance) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for i := range balances {
+		if r.findBalance(balances[i].UserID, balances[i].Year, balances[i].Type) == nil {
+			r.insertBalance(&balances[i])
+		}
+	}
+	return nil
+}
+
+func (r *MemoryLeaveRepository) SaveBalance(balance *models.LeaveBalance) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if existing := r.findBalance(balance.UserID, balance.Year, balance.Type); existing != nil {
+		existing.Entitled = balance.Entitled
+		existing.CarriedOver = balance.CarriedOver
+		existing.UpdatedAt = memoryNow()
+		balance.ID, balance.CreatedAt, balance.UpdatedAt = existing.ID, existing.CreatedAt, existing.UpdatedAt
+		return nil
+	}
+	r.insertBalance(balance)
+	return nil
+}
+
+func (r *MemoryLeaveRepository) FindAgents() ([]models.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	users := []models.User{}
+	for _, u := range r.store.users {
+		if !isSoftDeleted(u.Model) && u.Type == models.Agent {
+			users = append(users, r.store.copyUser(u, true))
+		}
+	}
+	slices.SortFunc(users, func(a, b models.User) int {
+		if c := compareOrdered(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return compareOrdered(a.ID, b.ID)
+	})
+	return users, nil
+}
+
+func (r *MemoryLeaveRepository) FindTeamManager(teamID uint) (*models.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var manager *models.User
+	for _, u := range r.store.users {
+		if isSoftDeleted(u.Model) || u.Type != models.Manager || u.TeamID == nil || *u.TeamID != teamID {
+			continue
+		}
+		if manager == nil || u.ID < manager.ID {
+			manager = u
+		}
+	}
+	if manager == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	user := r.store.copyUser(manager, false)
+	return &user, nil
+}
+
+func applyLeaveUpdates(l *models.LeaveRequest, data map[string]interface{}) {
+	for key, value := range data {
+		switch key {
+		case "status":
+			l.Status, _ = value.(models.LeaveStatus)
+		case "decided_by_id":
+			l.DecidedByID = toUintPtr(value)
+		case "decided_at":
+			if at, ok := value.(time.Time); ok {
+				l.DecidedAt = &at
+			}
+		case "decision_comment":
+			l.DecisionComment, _ = value.(string)
+		}
+	}
+}
+
+var _ ILeaveRepository = (*MemoryLeaveRepository)(nil)
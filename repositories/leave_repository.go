@@ -0,0 +1,146 @@
+package repositories
+
+import (
+	"time"
+
+	"zatrano/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LeaveDaysTotal struct {
+	UserID uint
+	Type   models.LeaveType
+	Status models.LeaveStatus
+	Days   int
+}
+
+type ILeaveRepository interface {
+	FindByID(id uint) (*models.LeaveRequest, error)
+	FindByUser(userID uint) ([]models.LeaveRequest, error)
+	FindByTeam(teamID uint, from, to time.Time, statuses ...models.LeaveStatus) ([]models.LeaveRequest, error)
+	FindPendingByTeam(teamID uint) ([]models.LeaveRequest, error)
+	Create(request *models.LeaveRequest) error
+	UpdatePending(id uint, data map[string]interface{}) error
+	SumDays(year int, userIDs ...uint) ([]LeaveDaysTotal, error)
+	FindBalances(year int, userIDs ...uint) ([]models.LeaveBalance, error)
+	CreateBalances(balances []models.LeaveBalance) error
+	SaveBalance(balance *models.LeaveBalance) error
+	FindAgents() ([]models.User, error)
+	FindTeamManager(teamID uint) (*models.User, error)
+}
+
+const leaveOrderSQL = "leave_requests.start_date ASC, leave_requests.id ASC"
+
+type LeaveRepository struct {
+	db *gorm.DB
+}
+
+func NewLeaveRepository(db *gorm.DB) ILeaveRepository {
+	return &LeaveRepository{db: db}
+}
+
+func yearRange(year int) (time.Time, time.Time) {
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(1, 0, 0)
+}
+
+func (r *LeaveRepository) FindByID(id uint) (*models.LeaveRequest, error) {
+	var request models.LeaveRequest
+	if err := r.db.Preload("User").First(&request, id).Error; err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r *LeaveRepository) FindByUser(userID uint) ([]models.LeaveRequest, error) {
+	var requests []models.LeaveRequest
+	err := r.db.Where("user_id = ?", userID).Order("start_date DESC, id DESC").Find(&requests).Error
+	return requests, err
+}
+
+func (r *LeaveRepository) FindByTeam(teamID uint, from, to time.Time, statuses ...models.LeaveStatus) ([]models.LeaveRequest, error) {
+	var requests []models.LeaveRequest
+	err := r.db.Preload("User").
+		Where("team_id = ? AND start_date <= ? AND end_date >= ? AND status IN ?", teamID, to, from, statuses).
+		Order(leaveOrderSQL).
+		Find(&requests).Error
+	return requests, err
+}
+
+func (r *LeaveRepository) FindPendingByTeam(teamID uint) ([]models.LeaveRequest, error) {
+	var requests []models.LeaveRequest
+	err := r.db.Preload("User").
+		Where("team_id = ? AND status = ?", teamID, models.LeavePending).
+		Order(leaveOrderSQL).
+		Find(&requests).Error
+	return requests, err
+}
+
+func (r *LeaveRepository) Create(request *models.LeaveRequest) error {
+	return r.db.Omit(clause.Associations).Create(request).Error
+}
+
+func (r *LeaveRepository) UpdatePending(id uint, data map[string]interface{}) error {
+	result := r.db.Model(&models.LeaveRequest{}).
+		Where("id = ? AND status = ?", id, models.LeavePending).
+		Updates(data)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *LeaveRepository) SumDays(year int, userIDs ...uint) ([]LeaveDaysTotal, error) {
+	start, end := yearRange(year)
+	query := r.db.Model(&models.LeaveRequest{}).
+		Select("user_id, type, status, SUM(days) AS days").
+		Where("start_date >= ? AND start_date < ? AND status IN ?", start, end, []models.LeaveStatus{models.LeavePending, models.LeaveApproved})
+	if len(userIDs) > 0 {
+		query = query.Where("user_id IN ?", userIDs)
+	}
+	var totals []LeaveDaysTotal
+	err := query.Group("user_id, type, status").Scan(&totals).Error
+	return totals, err
+}
+
+func (r *LeaveRepository) FindBalances(year int, userIDs ...uint) ([]models.LeaveBalance, error) {
+	query := r.db.Where("year = ?", year)
+	if len(userIDs) > 0 {
+		query = query.Where("user_id IN ?", userIDs)
+	}
+	var balances []models.LeaveBalance
+	err := query.Order("user_id ASC, type ASC").Find(&balances).Error
+	return balances, err
+}
+
+func (r *LeaveRepository) CreateBalances(balances []models.LeaveBalance) error {
+	if len(balances) == 0 {
+		return nil
+	}
+	return r.db.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&balances).Error
+}
+
+func (r *LeaveRepository) SaveBalance(balance *models.LeaveBalance) error {
+	return r.db.Omit(clause.Associations).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "year"}, {Name: "type"}},
+		DoUpdates: clause.AssignmentColumns([]string{"entitled", "carried_over", "updated_at"}),
+	}).Create(balance).Error
+}
+
+func (r *LeaveRepository) FindAgents() ([]models.User, error) {
+	var users []models.User
+	err := r.db.Preload("Team").Where("type = ?", models.Agent).Order("name ASC, id ASC").Find(&users).Error
+	return users, err
+}
+
+func (r *LeaveRepository) FindTeamManager(teamID uint) (*models.User, error) {
+	team := models.Team{Model: gorm.Model{ID: teamID}}
+	return team.Manager(r.db)
+}
+
+var _ ILeaveRepository = (*LeaveRepository)(nil)
@@ -0,0 +1,118 @@
+package repositories
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+func TestLeaveRepositoryRequestsAndBalances(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repoSet) {
+		sales := mustCreateTeam(t, repos, "Satış", true)
+		support := mustCreateTeam(t, repos, "Destek", true)
+		ali := mustCreateUser(t, repos, models.User{Name: "Ali", Account: "ali@x", Type: models.Agent, TeamID: &sales.ID})
+		can := mustCreateUser(t, repos, models.User{Name: "Can", Account: "can@x", Type: models.Agent, TeamID: &support.ID})
+		manager := mustCreateUser(t, repos, models.User{Name: "Yönetici", Account: "manager@x", Type: models.Manager, TeamID: &sales.ID})
+		mustCreateUser(t, repos, models.User{Name: "İkinci", Account: "second@x", Type: models.Manager, TeamID: &sales.ID})
+
+		found, err := repos.leaves.FindTeamManager(sales.ID)
+		if err != nil || found.ID != manager.ID {
+			t.Fatalf("FindTeamManager() = %+v, %v; want %d", found, err, manager.ID)
+		}
+		if _, err := repos.leaves.FindTeamManager(support.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Fatalf("FindTeamManager(no manager) error = %v, want ErrRecordNotFound", err)
+		}
+
+		date := func(month time.Month, day int) time.Time { return time.Date(2026, month, day, 0, 0, 0, 0, time.UTC) }
+		create := func(user *models.User, leaveType models.LeaveType, start, end time.Time) *models.LeaveRequest {
+			t.Helper()
+			request := &models.LeaveRequest{UserID: user.ID, TeamID: *user.TeamID, Type: leaveType, StartDate: start, EndDate: end,
+				Days: models.LeaveWorkingDays(start, end), Status: models.LeavePending}
+			if err := repos.leaves.Create(request); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			return request
+		}
+		march := create(ali, models.LeaveAnnual, date(time.March, 2), date(time.March, 6))
+		april := create(ali, models.LeaveSick, date(time.April, 1), date(time.April, 2))
+		create(ali, models.LeaveAnnual, date(time.December, 30), date(time.December, 31))
+		create(can, models.LeaveAnnual, date(time.March, 4), date(time.March, 4))
+
+		now := date(time.February, 20)
+		if err := repos.leaves.UpdatePending(march.ID, map[string]interface{}{
+			"status": models.LeaveApproved, "decided_by_id": manager.ID, "decided_at": now, "decision_comment": "iyi tatiller",
+		}); err != nil {
+			t.Fatalf("UpdatePending() error = %v", err)
+		}
+		if err := repos.leaves.UpdatePending(march.ID, map[string]interface{}{"status": models.LeaveRejected}); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Fatalf("UpdatePending() twice error = %v, want ErrRecordNotFound", err)
+		}
+		got, err := repos.leaves.FindByID(march.ID)
+		if err != nil || got.Status != models.LeaveApproved || got.DecidedByID == nil || *got.DecidedByID != manager.ID ||
+			got.DecisionComment != "iyi tatiller" || got.User == nil || got.User.ID != ali.ID {
+			t.Fatalf("FindByID() = %+v, %v", got, err)
+		}
+
+		mine, _ := repos.leaves.FindByUser(ali.ID)
+		if len(mine) != 3 || mine[0].StartDate.Month() != time.December || mine[2].ID != march.ID {
+			t.Fatalf("FindByUser() = %d requests, want newest first", len(mine))
+		}
+		inMarch, _ := repos.leaves.FindByTeam(sales.ID, date(time.March, 1), date(time.March, 31), models.LeavePending, models.LeaveApproved)
+		if len(inMarch) != 1 || inMarch[0].ID != march.ID || inMarch[0].User == nil {
+			t.Fatalf("FindByTeam(March) = %+v", inMarch)
+		}
+		pending, _ := repos.leaves.FindPendingByTeam(sales.ID)
+		if len(pending) != 2 || pending[0].ID != april.ID {
+			t.Fatalf("FindPendingByTeam() = %d requests, want April and December", len(pending))
+		}
+
+		totals, err := repos.leaves.SumDays(2026, ali.ID)
+		if err != nil {
+			t.Fatalf("SumDays() error = %v", err)
+		}
+		sums := map[LeaveDaysTotal]bool{}
+		for _, total := range totals {
+			sums[total] = true
+		}
+		if len(totals) != 3 ||
+			!sums[LeaveDaysTotal{UserID: ali.ID, Type: models.LeaveAnnual, Status: models.LeaveApproved, Days: 5}] ||
+			!sums[LeaveDaysTotal{UserID: ali.ID, Type: models.LeaveAnnual, Status: models.LeavePending, Days: 2}] ||
+			!sums[LeaveDaysTotal{UserID: ali.ID, Type: models.LeaveSick, Status: models.LeavePending, Days: 2}] {
+			t.Fatalf("SumDays() = %+v", totals)
+		}
+
+		if err := repos.leaves.CreateBalances([]models.LeaveBalance{
+			{UserID: ali.ID, Year: 2026, Type: models.LeaveAnnual, Entitled: 14},
+			{UserID: ali.ID, Year: 2026, Type: models.LeaveSick, Entitled: 10},
+		}); err != nil {
+			t.Fatalf("CreateBalances() error = %v", err)
+		}
+		// Var olan bakiyeye dokunulmaz.
+		if err := repos.leaves.CreateBalances([]models.LeaveBalance{
+			{UserID: ali.ID, Year: 2026, Type: models.LeaveAnnual, Entitled: 99},
+			{UserID: can.ID, Year: 2026, Type: models.LeaveAnnual, Entitled: 14},
+		}); err != nil {
+			t.Fatalf("CreateBalances() again error = %v", err)
+		}
+		if err := repos.leaves.SaveBalance(&models.LeaveBalance{UserID: ali.ID, Year: 2026, Type: models.LeaveSick, Entitled: 12, CarriedOver: 1}); err != nil {
+			t.Fatalf("SaveBalance() error = %v", err)
+		}
+		balances, _ := repos.leaves.FindBalances(2026, ali.ID)
+		if len(balances) != 2 || balances[0].Type != models.LeaveAnnual || balances[0].Entitled != 14 ||
+			balances[1].Entitled != 12 || balances[1].CarriedOver != 1 {
+			t.Fatalf("FindBalances() = %+v", balances)
+		}
+		if all, _ := repos.leaves.FindBalances(2026); len(all) != 3 {
+			t.Fatalf("FindBalances(all) = %d balances, want 3", len(all))
+		}
+
+		agents, _ := repos.leaves.FindAgents()
+		if len(agents) != 2 || agents[0].ID != ali.ID || agents[0].Team == nil || agents[0].Team.Name != "Satış" {
+			t.Fatalf("FindAgents() = %+v", agents)
+		}
+	})
+}
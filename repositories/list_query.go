@@ -0,0 +1,150 @@
+package repositories
+
+import (
+	"strings"
+	"time"
+
+	"zatrano/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type listColumns map[string]string
+
+var filterOperators = map[utils.FilterOp]string{
+	utils.OpEq:  "=",
+	utils.OpNe:  "<>",
+	utils.OpGt:  ">",
+	utils.OpGte: ">=",
+	utils.OpLt:  "<",
+	utils.OpLte: "<=",
+	utils.OpIn:  "IN",
+}
+
+func applyFilters(query *gorm.DB, filters []utils.Filter, columns listColumns) *gorm.DB {
+	for _, f := range filters {
+		column, ok := columns[f.Field]
+		operator, known := filterOperators[f.Op]
+		if !ok || !known {
+			continue
+		}
+		query = query.Where(column+" "+operator+" ?", sqlFilterValue(f.Value))
+	}
+	return query
+}
+
+func sqlFilterValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case time.Time:
+		return v.UTC()
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = sqlFilterValue(item)
+		}
+		return out
+	}
+	return value
+}
+
+func applySort(query *gorm.DB, params utils.ListParams, columns listColumns, fallback string) *gorm.DB {
+	var orders []clause.OrderByColumn
+	hasID := false
+	for _, s := range params.SortFields() {
+		column, ok := columns[s.Field]
+		if !ok {
+			continue
+		}
+		hasID = hasID || s.Field == "id"
+		orders = append(orders, clause.OrderByColumn{Column: clause.Column{Name: column, Raw: true}, Desc: s.Desc})
+	}
+	if len(orders) == 0 {
+		orders = append(orders, clause.OrderByColumn{Column: clause.Column{Name: columns[fallback], Raw: true}, Desc: fallbackDesc(params)})
+		hasID = fallback == "id"
+	}
+	if !hasID {
+		orders = append(orders, clause.OrderByColumn{Column: clause.Column{Name: columns["id"], Raw: true}})
+	}
+	for _, order := range orders {
+		query = query.Order(order)
+	}
+	return query
+}
+
+func fallbackDesc(params utils.ListParams) bool {
+	return strings.ToLower(params.OrderBy) != "asc"
+}
+
+type memoryFields[T any] map[string]func(T) interface{}
+
+func matchFilters[T any](item T, filters []utils.Filter, fields memoryFields[T]) bool {
+	for _, f := range filters {
+		get, ok := fields[f.Field]
+		if !ok {
+			continue
+		}
+		value := get(item)
+		if value == nil {
+			return false
+		}
+		if !matchFilter(value, f) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchFilter(value interface{}, f utils.Filter) bool {
+	if f.Op == utils.OpIn {
+		list, _ := f.Value.([]interface{})
+		for _, candidate := range list {
+			if compareValues(value, candidate) == 0 {
+				return true
+			}
+		}
+		return false
+	}
+	c := compareValues(value, f.Value)
+	switch f.Op {
+	case utils.OpEq:
+		return c == 0
+	case utils.OpNe:
+		return c != 0
+	case utils.OpGt:
+		return c > 0
+	case utils.OpGte:
+		return c >= 0
+	case utils.OpLt:
+		return c < 0
+	case utils.OpLte:
+		return c <= 0
+	}
+	return false
+}
+
+func compareValues(a, b interface{}) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+	switch x := a.(type) {
+	case int64:
+		y, _ := b.(int64)
+		return compareOrdered(x, y)
+	case string:
+		y, _ := b.(string)
+		return compareOrdered(x, y)
+	case bool:
+		y, _ := b.(bool)
+		return compareBool(x, y)
+	case time.Time:
+		y, _ := b.(time.Time)
+		return x.Compare(y)
+	}
+	return 0
+}
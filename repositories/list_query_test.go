@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"zatrano/models"
+	"zatrano/utils"
+)
+
+var testUserSpec = utils.FilterSpec{
+	"id":         {Type: utils.FieldInt, Sortable: true},
+	"name":       {Type: utils.FieldString, Sortable: true},
+	"team_id":    {Type: utils.FieldInt, Ops: []utils.FilterOp{utils.OpEq, utils.OpIn}, Sortable: true},
+	"type":       {Type: utils.FieldEnum, Ops: []utils.FilterOp{utils.OpEq, utils.OpNe, utils.OpIn}, Values: []string{"system", "manager", "agent"}, Sortable: true},
+	"status":     {Type: utils.FieldBool, Ops: []utils.FilterOp{utils.OpEq}, Sortable: true},
+	"created_at": {Type: utils.FieldDate, Ops: []utils.FilterOp{utils.OpGte, utils.OpLte}, Sortable: true},
+}
+
+var testTeamSpec = utils.FilterSpec{
+	"id":           {Type: utils.FieldInt, Sortable: true},
+	"name":         {Type: utils.FieldString, Sortable: true},
+	"status":       {Type: utils.FieldBool, Ops: []utils.FilterOp{utils.OpEq}, Sortable: true},
+	"member_count": {Type: utils.FieldInt, Ops: []utils.FilterOp{utils.OpEq, utils.OpGte, utils.OpLte}, Sortable: true},
+}
+
+func listParams(t *testing.T, spec utils.FilterSpec, query string) utils.ListParams {
+	t.Helper()
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		t.Fatalf("ParseQuery(%q) error = %v", query, err)
+	}
+	params := utils.ListParams{Page: 1, PerPage: 10}
+	if err := params.ApplyQuery(values, spec, time.UTC); err != nil {
+		t.Fatalf("ApplyQuery(%q) error = %v", query, err)
+	}
+	return params
+}
+
+func TestUserRepositoryFiltersAndMultiSort(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repoSet) {
+		team, _ := seedUsers(t, repos)
+		other := mustCreateTeam(t, repos, "Satış", true)
+		mustCreateUser(t, repos, models.User{Name: "Burak", Account: "burak@x", Type: models.Agent, TeamID: &other.ID})
+		if err := repos.users.Update(4, map[string]interface{}{"status": false}); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		today := time.Now().UTC().Format("2006-01-02")
+		tomorrow := time.Now().UTC().AddDate(0, 0, 1).Format("2006-01-02")
+
+		tests := []struct {
+			name      string
+			query     string
+			wantNames []string
+		}{
+			{name: "type and status", query: "filter.type.eq=agent&filter.status.eq=true&sort=name", wantNames: []string{"Ahmet", "Burak", "Mehmet"}},
+			{name: "type not equal", query: "filter.type.ne=agent", wantNames: []string{"Zeynep"}},
+			{name: "team in list", query: "filter.team_id.in=" + url.QueryEscape(uintString(other.ID)), wantNames: []string{"Burak"}},
+			{name: "team equals", query: "filter.team_id.eq=" + uintString(team.ID) + "&sort=name", wantNames: []string{"Ahmet", "Mehmet", "Zeynep", "Çağla"}},
+			{name: "created today is inclusive", query: "filter.created_at.gte=" + today + "&filter.created_at.lte=" + today + "&filter.type.eq=manager", wantNames: []string{"Zeynep"}},
+			{name: "created from tomorrow", query: "filter.created_at.gte=" + tomorrow, wantNames: []string{}},
+			{name: "multi-column sort", query: "sort=-status,team_id,-name", wantNames: []string{"Zeynep", "Mehmet", "Ahmet", "Burak", "Çağla"}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				users, total, err := repos.users.FindAndPaginate(listParams(t, testUserSpec, tt.query))
+				if err != nil {
+					t.Fatalf("FindAndPaginate() error = %v", err)
+				}
+				if got := userNames(users); !equalStrings(got, tt.wantNames) || total != int64(len(tt.wantNames)) {
+					t.Fatalf("names = %v (total %d), want %v", got, total, tt.wantNames)
+				}
+			})
+		}
+	})
+}
+
+func TestTeamRepositoryMemberCountFilter(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repoSet) {
+		seedUsers(t, repos)
+		small := mustCreateTeam(t, repos, "Satış", true)
+		mustCreateUser(t, repos, models.User{Name: "Burak", Account: "burak@x", Type: models.Agent, TeamID: &small.ID})
+		mustCreateTeam(t, repos, "Arşiv", false)
+
+		tests := []struct {
+			name      string
+			query     string
+			wantNames []string
+			wantCount []int64
+		}{
+			{name: "at least two members", query: "filter.member_count.gte=2", wantNames: []string{"Destek"}, wantCount: []int64{4}},
+			{name: "empty teams", query: "filter.member_count.eq=0", wantNames: []string{"Arşiv"}, wantCount: []int64{0}},
+			{name: "sort by member count", query: "filter.status.eq=true&sort=-member_count", wantNames: []string{"Destek", "Satış"}, wantCount: []int64{4, 1}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				teams, _, err := repos.teams.FindAndPaginate(listParams(t, testTeamSpec, tt.query))
+				if err != nil {
+					t.Fatalf("FindAndPaginate() error = %v", err)
+				}
+				if got := teamNames(teams); !equalStrings(got, tt.wantNames) {
+					t.Fatalf("names = %v, want %v", got, tt.wantNames)
+				}
+				for i, team := range teams {
+					if team.MemberCount != tt.wantCount[i] {
+						t.Fatalf("%s MemberCount = %d, want %d", team.Name, team.MemberCount, tt.wantCount[i])
+					}
+				}
+			})
+		}
+	})
+}
+
+func uintString(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
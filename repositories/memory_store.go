@@ -0,0 +1,302 @@
+package repositories
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"zatrano/models"
+	"zatrano/utils"
+
+	"gorm.io/gorm"
+)
+
+type MemoryStore struct {
+	mu sync.RWMutex
+
+	users       map[uint]*models.User
+	teams       map[uint]*models.Team
+	preferences map[uint]*models.UserPreference
+	nextUserID  uint
+	nextTeamID  uint
+
+	announcements      map[uint]*models.Announcement
+	receipts           map[receiptKey]*models.AnnouncementReceipt
+	nextAnnouncementID uint
+
+	notifications      map[uint]*models.Notification
+	nextNotificationID uint
+
+	shifts                map[uint]*models.Shift
+	shiftAssignments      map[uint]*models.ShiftAssignment
+	nextShiftID           uint
+	nextShiftAssignmentID uint
+
+	attendanceSessions      map[uint]*models.AttendanceSession
+	attendanceBreaks        map[uint]*models.AttendanceBreak
+	nextAttendanceSessionID uint
+	nextAttendanceBreakID   uint
+
+	leaveRequests      map[uint]*models.LeaveRequest
+	leaveBalances      map[uint]*models.LeaveBalance
+	nextLeaveRequestID uint
+	nextLeaveBalanceID uint
+
+	tasks             map[uint]*models.Task
+	taskComments      map[uint]*models.TaskComment
+	nextTaskID        uint
+	nextTaskCommentID uint
+
+	kpiDefinitions      map[uint]*models.KPIDefinition
+	kpiValues           map[kpiValueKey]*models.KPIValue
+	nextKPIDefinitionID uint
+	nextKPIValueID      uint
+
+	loginEvents      map[uint]*models.LoginEvent
+	nextLoginEventID uint
+
+	teamChanges      map[uint]*models.TeamMembershipChange
+	nextTeamChangeID uint
+
+	reportSchedules      map[uint]*models.ReportSchedule
+	reportSubscriptions  map[reportSubscriptionKey]*models.ReportSubscription
+	nextReportScheduleID uint
+
+	jobs      map[uint]*models.Job
+	nextJobID uint
+}
+
+type reportSubscriptionKey struct {
+	scheduleID uint
+	userID     uint
+}
+
+type kpiValueKey struct {
+	definitionID uint
+	userID       uint
+	day          time.Time
+}
+
+type receiptKey struct {
+	announcementID uint
+	userID         uint
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		users:       make(map[uint]*models.User),
+		teams:       make(map[uint]*models.Team),
+		preferences: make(map[uint]*models.UserPreference),
+		nextUserID:  1,
+		nextTeamID:  1,
+
+		announcements:      make(map[uint]*models.Announcement),
+		receipts:           make(map[receiptKey]*models.AnnouncementReceipt),
+		nextAnnouncementID: 1,
+
+		notifications:      make(map[uint]*models.Notification),
+		nextNotificationID: 1,
+
+		shifts:                make(map[uint]*models.Shift),
+		shiftAssignments:      make(map[uint]*models.ShiftAssignment),
+		nextShiftID:           1,
+		nextShiftAssignmentID: 1,
+
+		attendanceSessions:      make(map[uint]*models.AttendanceSession),
+		attendanceBreaks:        make(map[uint]*models.AttendanceBreak),
+		nextAttendanceSessionID: 1,
+		nextAttendanceBreakID:   1,
+
+		leaveRequests:      make(map[uint]*models.LeaveRequest),
+		leaveBalances:      make(map[uint]*models.LeaveBalance),
+		nextLeaveRequestID: 1,
+		nextLeaveBalanceID: 1,
+
+		tasks:             make(map[uint]*models.Task),
+		taskComments:      make(map[uint]*models.TaskComment),
+		nextTaskID:        1,
+		nextTaskCommentID: 1,
+
+		kpiDefinitions:      make(map[uint]*models.KPIDefinition),
+		kpiValues:           make(map[kpiValueKey]*models.KPIValue),
+		nextKPIDefinitionID: 1,
+		nextKPIValueID:      1,
+
+		loginEvents:      make(map[uint]*models.LoginEvent),
+		nextLoginEventID: 1,
+
+		teamChanges:      make(map[uint]*models.TeamMembershipChange),
+		nextTeamChangeID: 1,
+
+		reportSchedules:      make(map[uint]*models.ReportSchedule),
+		reportSubscriptions:  make(map[reportSubscriptionKey]*models.ReportSubscription),
+		nextReportScheduleID: 1,
+
+		jobs:      make(map[uint]*models.Job),
+		nextJobID: 1,
+	}
+}
+
+func memoryNow() time.Time {
+	return time.Now().UTC()
+}
+
+func isSoftDeleted(m gorm.Model) bool {
+	return m.DeletedAt.Valid
+}
+
+func (s *MemoryStore) copyUser(u *models.User, withTeam bool) models.User {
+	out := *u
+	out.Team = nil
+	if u.DeactivatedAt != nil {
+		deactivatedAt := *u.DeactivatedAt
+		out.DeactivatedAt = &deactivatedAt
+	}
+	if u.TeamID != nil {
+		teamID := *u.TeamID
+		out.TeamID = &teamID
+		if withTeam {
+			if t, ok := s.teams[teamID]; ok && !isSoftDeleted(t.Model) {
+				team := *t
+				team.Agents = nil
+				out.Team = &team
+			}
+		}
+	}
+	return out
+}
+
+func (s *MemoryStore) teamMemberCounts() map[uint]int64 {
+	counts := make(map[uint]int64)
+	for _, u := range s.users {
+		if u.TeamID != nil && !isSoftDeleted(u.Model) {
+			counts[*u.TeamID]++
+		}
+	}
+	return counts
+}
+
+func (s *MemoryStore) accountTaken(account string, exceptID uint) bool {
+	for id, u := range s.users {
+		if id != exceptID && !isSoftDeleted(u.Model) && u.Account == account {
+			return true
+		}
+	}
+	return false
+}
+
+func applyUserUpdates(u *models.User, data map[string]interface{}) {
+	for key, value := range data {
+		switch key {
+		case "name":
+			u.Name, _ = value.(string)
+		case "account":
+			u.Account, _ = value.(string)
+		case "password":
+			u.Password, _ = value.(string)
+		case "status":
+			u.Status, _ = value.(bool)
+		case "type":
+			switch t := value.(type) {
+			case models.UserType:
+				u.Type = t
+			case string:
+				u.Type = models.UserType(t)
+			}
+		case "team_id":
+			u.TeamID = toUintPtr(value)
+		case "deactivated_at":
+			u.DeactivatedAt = nil
+			if at, ok := value.(*time.Time); ok && at != nil {
+				deactivatedAt := *at
+				u.DeactivatedAt = &deactivatedAt
+			}
+		}
+	}
+}
+
+func applyTeamUpdates(t *models.Team, data map[string]interface{}) {
+	for key, value := range data {
+		switch key {
+		case "name":
+			t.Name, _ = value.(string)
+		case "status":
+			t.Status, _ = value.(bool)
+		}
+	}
+}
+
+func toUintPtr(value interface{}) *uint {
+	switch v := value.(type) {
+	case *uint:
+		if v == nil {
+			return nil
+		}
+		id := *v
+		return &id
+	case uint:
+		return &v
+	case int:
+		id := uint(v)
+		return &id
+	case float64:
+		id := uint(v)
+		return &id
+	}
+	return nil
+}
+
+func compareOrdered[T ~int | ~int64 | ~uint | ~string](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case !a:
+		return -1
+	}
+	return 1
+}
+
+func sortAndPage[T any](items []T, params utils.ListParams, fields memoryFields[T], fallback string, id func(T) uint) []T {
+	var sorts []utils.SortField
+	for _, s := range params.SortFields() {
+		if _, ok := fields[s.Field]; ok {
+			sorts = append(sorts, s)
+		}
+	}
+	if len(sorts) == 0 {
+		sorts = []utils.SortField{{Field: fallback, Desc: fallbackDesc(params)}}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		for _, s := range sorts {
+			c := compareValues(fields[s.Field](items[i]), fields[s.Field](items[j]))
+			if c == 0 {
+				continue
+			}
+			if s.Desc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return id(items[i]) < id(items[j])
+	})
+
+	offset := params.CalculateOffset()
+	if offset >= len(items) {
+		return []T{}
+	}
+	end := len(items)
+	if params.PerPage > 0 && offset+params.PerPage < end {
+		end = offset + params.PerPage
+	}
+	return items[offset:end]
+}
@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"slices"
+	"time"
+
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+type MemoryNotificationRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryNotificationRepository(store *MemoryStore) INotificationRepository {
+	return &MemoryNotificationRepository{store: store}
+}
+
+func copyNotification(n *models.Notification) models.Notification {
+	out := *n
+	out.User = nil
+	out.Params = slices.Clone(n.Params)
+	if n.ReadAt != nil {
+		readAt := *n.ReadAt
+		out.ReadAt = &readAt
+	}
+	return out
+}
+
+func (r *MemoryNotificationRepository) Create(notification *models.Notification) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	notification.ID = r.store.nextNotificationID
+	if notification.CreatedAt.IsZero() {
+		notification.CreatedAt = memoryNow()
+	}
+	r.store.nextNotificationID++
+
+	stored := copyNotification(notification)
+	r.store.notifications[notification.ID] = &stored
+	return nil
+}
+
+func (r *MemoryNotificationRepository) FindRecent(userID uint, limit int) ([]models.Notification, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	notifications := []models.Notification{}
+	for _, n := range r.store.notifications {
+		if n.UserID == userID {
+			notifications = append(notifications, copyNotification(n))
+		}
+	}
+	slices.SortFunc(notifications, func(a, b models.Notification) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return compareOrdered(b.ID, a.ID)
+	})
+	if len(notifications) > limit {
+		notifications = notifications[:limit]
+	}
+	return notifications, nil
+}
+
+func (r *MemoryNotificationRepository) CountUnread(userID uint) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var count int64
+	for _, n := range r.store.notifications {
+		if n.UserID == userID && n.ReadAt == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *MemoryNotificationRepository) MarkRead(userID, id uint, at time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	n, ok := r.store.notifications[id]
+	if !ok || n.UserID != userID {
+		return gorm.ErrRecordNotFound
+	}
+	if n.ReadAt == nil {
+		readAt := at
+		n.ReadAt = &readAt
+	}
+	return nil
+}
+
+func (r *MemoryNotificationRepository) MarkAllRead(userID uint, at time.Time) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var updated int64
+	for _, n := range r.store.notifications {
+		if n.UserID == userID && n.ReadAt == nil {
+			readAt := at
+			n.ReadAt = &readAt
+			updated++
+		}
+	}
+	return updated, nil
+}
+
+var _ INotificationRepository = (*MemoryNotificationRepository)(nil)
@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+func TestNotificationRepositoryUnreadState(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repoSet) {
+		team := mustCreateTeam(t, repos, "Destek", true)
+		ali := mustCreateUser(t, repos, models.User{Name: "Ali", Account: "ali@x", Type: models.Agent, TeamID: &team.ID})
+		ayse := mustCreateUser(t, repos, models.User{Name: "Ayşe", Account: "ayse@x", Type: models.Agent, TeamID: &team.ID})
+
+		base := time.Now().UTC().Truncate(time.Second)
+		var ids []uint
+		for i, params := range [][]string{{"Satış"}, {"Destek"}, {"Arşiv"}} {
+			n := &models.Notification{UserID: ali.ID, MessageID: "notifications.team_changed", Params: params, CreatedAt: base.Add(time.Duration(i) * time.Minute)}
+			if err := repos.notifications.Create(n); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			ids = append(ids, n.ID)
+		}
+		if err := repos.notifications.Create(&models.Notification{UserID: ayse.ID, MessageID: "notifications.team_changed", Params: []string{"Satış"}}); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		recent, err := repos.notifications.FindRecent(ali.ID, 2)
+		if err != nil {
+			t.Fatalf("FindRecent() error = %v", err)
+		}
+		if len(recent) != 2 || recent[0].ID != ids[2] || recent[1].ID != ids[1] || recent[0].Params[0] != "Arşiv" {
+			t.Fatalf("FindRecent() = %+v, want the two newest notifications", recent)
+		}
+
+		if err := repos.notifications.MarkRead(ayse.ID, ids[0], base); err != gorm.ErrRecordNotFound {
+			t.Fatalf("MarkRead(other user) error = %v, want ErrRecordNotFound", err)
+		}
+		if err := repos.notifications.MarkRead(ali.ID, ids[0], base); err != nil {
+			t.Fatalf("MarkRead() error = %v", err)
+		}
+		if err := repos.notifications.MarkRead(ali.ID, ids[0], base.Add(time.Hour)); err != nil {
+			t.Fatalf("MarkRead() again error = %v", err)
+		}
+		if unread, _ := repos.notifications.CountUnread(ali.ID); unread != 2 {
+			t.Fatalf("CountUnread() = %d, want 2", unread)
+		}
+
+		updated, err := repos.notifications.MarkAllRead(ali.ID, base)
+		if err != nil || updated != 2 {
+			t.Fatalf("MarkAllRead() = %d, %v; want 2", updated, err)
+		}
+		if unread, _ := repos.notifications.CountUnread(ali.ID); unread != 0 {
+			t.Fatalf("CountUnread() after MarkAllRead = %d, want 0", unread)
+		}
+		if unread, _ := repos.notifications.CountUnread(ayse.ID); unread != 1 {
+			t.Fatalf("CountUnread(other user) = %d, want 1", unread)
+		}
+	})
+}
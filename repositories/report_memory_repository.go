@@ -0,0 +1,242 @@
+package repositories
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+type MemoryReportRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryReportRepository(store *MemoryStore) IReportRepository {
+	return &MemoryReportRepository{store: store}
+}
+
+func (r *MemoryReportRepository) copyReportSchedule(s *models.ReportSchedule) models.ReportSchedule {
+	out := *s
+	if s.LastRunAt != nil {
+		lastRunAt := *s.LastRunAt
+		out.LastRunAt = &lastRunAt
+	}
+	if s.NextRunAt != nil {
+		nextRunAt := *s.NextRunAt
+		out.NextRunAt = &nextRunAt
+	}
+	return out
+}
+
+func (r *MemoryReportRepository) sortedSchedules(keep func(*models.ReportSchedule) bool) []models.ReportSchedule {
+	schedules := []models.ReportSchedule{}
+	for _, s := range r.store.reportSchedules {
+		if keep(s) {
+			schedules = append(schedules, r.copyReportSchedule(s))
+		}
+	}
+	slices.SortFunc(schedules, func(a, b models.ReportSchedule) int {
+		if c := compareOrdered(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return compareOrdered(a.ID, b.ID)
+	})
+	return schedules
+}
+
+func (r *MemoryReportRepository) FindSchedules() ([]models.ReportSchedule, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	schedules := r.sortedSchedules(func(*models.ReportSchedule) bool { return true })
+	for i := range schedules {
+		for key := range r.store.reportSubscriptions {
+			if key.scheduleID == schedules[i].ID {
+				schedules[i].SubscriberCount++
+			}
+		}
+	}
+	return schedules, nil
+}
+
+func (r *MemoryReportRepository) FindActiveSchedules() ([]models.ReportSchedule, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.sortedSchedules(func(s *models.ReportSchedule) bool { return s.Status }), nil
+}
+
+func (r *MemoryReportRepository) FindScheduleByID(id uint) (*models.ReportSchedule, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	s, ok := r.store.reportSchedules[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	schedule := r.copyReportSchedule(s)
+	return &schedule, nil
+}
+
+func (r *MemoryReportRepository) CreateSchedule(schedule *models.ReportSchedule) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	now := memoryNow()
+	schedule.ID = r.store.nextReportScheduleID
+	schedule.CreatedAt = now
+	schedule.UpdatedAt = now
+	r.store.nextReportScheduleID++
+
+	stored := r.copyReportSchedule(schedule)
+	stored.Name = strings.Clone(schedule.Name)
+	stored.Cron = strings.Clone(schedule.Cron)
+	stored.Timezone = strings.Clone(schedule.Timezone)
+	stored.SubscriberCount = 0
+	r.store.reportSchedules[schedule.ID] = &stored
+	return nil
+}
+
+func (r *MemoryReportRepository) UpdateSchedule(id uint, data map[string]interface{}) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	s, ok := r.store.reportSchedules[id]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	for key, value := range data {
+		switch key {
+		case "name":
+			name, _ := value.(string)
+			s.Name = strings.Clone(name)
+		case "template":
+			s.Template, _ = value.(models.ReportTemplate)
+		case "cron":
+			cron, _ := value.(string)
+			s.Cron = strings.Clone(cron)
+		case "timezone":
+			timezone, _ := value.(string)
+			s.Timezone = strings.Clone(timezone)
+		case "status":
+			s.Status, _ = value.(bool)
+		case "next_run_at":
+			s.NextRunAt = copyTimePtr(value)
+		}
+	}
+	s.UpdatedAt = memoryNow()
+	return nil
+}
+
+func copyTimePtr(value interface{}) *time.Time {
+	t, ok := value.(*time.Time)
+	if !ok || t == nil {
+		return nil
+	}
+	out := *t
+	return &out
+}
+
+func (r *MemoryReportRepository) DeleteSchedule(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.reportSchedules[id]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	delete(r.store.reportSchedules, id)
+	for key := range r.store.reportSubscriptions {
+		if key.scheduleID == id {
+			delete(r.store.reportSubscriptions, key)
+		}
+	}
+	return nil
+}
+
+func (r *MemoryReportRepository) FindDueSchedules(now time.Time) ([]models.ReportSchedule, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var schedules []models.ReportSchedule
+	for _, s := range r.store.reportSchedules {
+		if s.Status && s.NextRunAt != nil && !s.NextRunAt.After(now) {
+			schedules = append(schedules, r.copyReportSchedule(s))
+		}
+	}
+	slices.SortFunc(schedules, func(a, b models.ReportSchedule) int {
+		if c := a.NextRunAt.Compare(*b.NextRunAt); c != 0 {
+			return c
+		}
+		return compareOrdered(a.ID, b.ID)
+	})
+	return schedules, nil
+}
+
+func (r *MemoryReportRepository) ClaimSchedule(id uint, now, next time.Time) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	s, ok := r.store.reportSchedules[id]
+	if !ok || !s.Status || s.NextRunAt == nil || s.NextRunAt.After(now) {
+		return false, nil
+	}
+	s.LastRunAt = &now
+	s.NextRunAt = &next
+	s.UpdatedAt = memoryNow()
+	return true, nil
+}
+
+func (r *MemoryReportRepository) FindSubscribers(scheduleID uint) ([]models.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var users []models.User
+	for key := range r.store.reportSubscriptions {
+		if key.scheduleID != scheduleID {
+			continue
+		}
+		u, ok := r.store.users[key.userID]
+		if !ok || isSoftDeleted(u.Model) || !u.Status {
+			continue
+		}
+		users = append(users, r.store.copyUser(u, true))
+	}
+	slices.SortFunc(users, func(a, b models.User) int { return compareOrdered(a.ID, b.ID) })
+	return users, nil
+}
+
+func (r *MemoryReportRepository) FindSubscribedScheduleIDs(userID uint) ([]uint, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []uint
+	for key := range r.store.reportSubscriptions {
+		if key.userID == userID {
+			ids = append(ids, key.scheduleID)
+		}
+	}
+	slices.Sort(ids)
+	return ids, nil
+}
+
+func (r *MemoryReportRepository) ReplaceSubscriptions(userID uint, scheduleIDs []uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for key := range r.store.reportSubscriptions {
+		if key.userID == userID {
+			delete(r.store.reportSubscriptions, key)
+		}
+	}
+	now := memoryNow()
+	for _, id := range scheduleIDs {
+		key := reportSubscriptionKey{scheduleID: id, userID: userID}
+		r.store.reportSubscriptions[key] = &models.ReportSubscription{ScheduleID: id, UserID: userID, CreatedAt: now}
+	}
+	return nil
+}
+
+var _ IReportRepository = (*MemoryReportRepository)(nil)
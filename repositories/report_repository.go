@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	"time"
+
+	"zatrano/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IReportRepository interface {
+	FindSchedules() ([]models.ReportSchedule, error)
+	FindActiveSchedules() ([]models.ReportSchedule, error)
+	FindScheduleByID(id uint) (*models.ReportSchedule, error)
+	CreateSchedule(schedule *models.ReportSchedule) error
+	UpdateSchedule(id uint, data map[string]interface{}) error
+	DeleteSchedule(id uint) error
+	FindDueSchedules(now time.Time) ([]models.ReportSchedule, error)
+	ClaimSchedule(id uint, now, next time.Time) (bool, error)
+	FindSubscribers(scheduleID uint) ([]models.User, error)
+	FindSubscribedScheduleIDs(userID uint) ([]uint, error)
+	ReplaceSubscriptions(userID uint, scheduleIDs []uint) error
+}
+
+const reportSubscriberCountSQL = "(SELECT COUNT(*) FROM report_subscriptions WHERE report_subscriptions.schedule_id = report_schedules.id)"
+
+type ReportRepository struct {
+	db *gorm.DB
+}
+
+func NewReportRepository(db *gorm.DB) IReportRepository {
+	return &ReportRepository{db: db}
+}
+
+func (r *ReportRepository) FindSchedules() ([]models.ReportSchedule, error) {
+	var schedules []models.ReportSchedule
+	err := r.db.Select("report_schedules.*, " + reportSubscriberCountSQL + " AS subscriber_count").
+		Order("name ASC, id ASC").
+		Find(&schedules).Error
+	return schedules, err
+}
+
+func (r *ReportRepository) FindActiveSchedules() ([]models.ReportSchedule, error) {
+	var schedules []models.ReportSchedule
+	err := r.db.Where("status = ?", true).Order("name ASC, id ASC").Find(&schedules).Error
+	return schedules, err
+}
+
+func (r *ReportRepository) FindScheduleByID(id uint) (*models.ReportSchedule, error) {
+	var schedule models.ReportSchedule
+	if err := r.db.First(&schedule, id).Error; err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+func (r *ReportRepository) CreateSchedule(schedule *models.ReportSchedule) error {
+	return r.db.Create(schedule).Error
+}
+
+func (r *ReportRepository) UpdateSchedule(id uint, data map[string]interface{}) error {
+	result := r.db.Model(&models.ReportSchedule{}).Where("id = ?", id).Updates(data)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *ReportRepository) DeleteSchedule(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// SQLite'ta yabancı anahtarlar varsayılan olarak kapalı olduğundan
+		// abonelikler CASCADE'e bırakılmaz.
+		if err := tx.Where("schedule_id = ?", id).Delete(&models.ReportSubscription{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.ReportSchedule{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func (r *ReportRepository) FindDueSchedules(now time.Time) ([]models.ReportSchedule, error) {
+	var schedules []models.ReportSchedule
+	err := r.db.Where("status = ? AND next_run_at <= ?", true, now).
+		Order("next_run_at ASC, id ASC").
+		Find(&schedules).Error
+	return schedules, err
+}
+
+func (r *ReportRepository) ClaimSchedule(id uint, now, next time.Time) (bool, error) {
+	result := r.db.Model(&models.ReportSchedule{}).
+		Where("id = ? AND status = ? AND next_run_at <= ?", id, true, now).
+		Updates(map[string]interface{}{"last_run_at": now, "next_run_at": next})
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *ReportRepository) FindSubscribers(scheduleID uint) ([]models.User, error) {
+	var users []models.User
+	err := r.db.Preload("Team").
+		Joins("JOIN report_subscriptions ON report_subscriptions.user_id = users.id").
+		Where("report_subscriptions.schedule_id = ? AND users.status = ?", scheduleID, true).
+		Order("users.id ASC").
+		Find(&users).Error
+	return users, err
+}
+
+func (r *ReportRepository) FindSubscribedScheduleIDs(userID uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&models.ReportSubscription{}).
+		Where("user_id = ?", userID).
+		Order("schedule_id ASC").
+		Pluck("schedule_id", &ids).Error
+	return ids, err
+}
+
+func (r *ReportRepository) ReplaceSubscriptions(userID uint, scheduleIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.ReportSubscription{}).Error; err != nil {
+			return err
+		}
+		if len(scheduleIDs) == 0 {
+			return nil
+		}
+		subscriptions := make([]models.ReportSubscription, 0, len(scheduleIDs))
+		for _, id := range scheduleIDs {
+			subscriptions = append(subscriptions, models.ReportSubscription{ScheduleID: id, UserID: userID})
+		}
+		return tx.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&subscriptions).Error
+	})
+}
+
+var _ IReportRepository = (*ReportRepository)(nil)
@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"zatrano/models"
+)
+
+func TestReportRepositorySchedules(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repoSet) {
+		now := time.Now().UTC().Truncate(time.Minute)
+		due := now.Add(-time.Minute)
+		later := now.Add(time.Hour)
+
+		weekly := &models.ReportSchedule{Name: "Haftalık", Template: models.ReportTemplateWeeklySummary, Cron: "0 8 * * 1", Timezone: "UTC", Status: true, NextRunAt: &due}
+		daily := &models.ReportSchedule{Name: "Günlük", Template: models.ReportTemplateDailySummary, Cron: "0 7 * * *", Timezone: "UTC", Status: true, NextRunAt: &later}
+		paused := &models.ReportSchedule{Name: "Arşiv", Template: models.ReportTemplateWeeklySummary, Cron: "0 8 * * 1", Timezone: "UTC", Status: false}
+		for _, s := range []*models.ReportSchedule{weekly, daily, paused} {
+			if err := repos.reports.CreateSchedule(s); err != nil {
+				t.Fatalf("CreateSchedule() error = %v", err)
+			}
+		}
+
+		team := mustCreateTeam(t, repos, "Destek", true)
+		manager := mustCreateUser(t, repos, models.User{Name: "Yönetici", Account: "manager@x", Type: models.Manager, TeamID: &team.ID})
+		inactive := mustCreateUser(t, repos, models.User{Name: "Eski", Account: "old@x", Type: models.Manager, TeamID: &team.ID})
+		if err := repos.users.Update(inactive.ID, map[string]interface{}{"status": false}); err != nil {
+			t.Fatal(err)
+		}
+		if err := repos.reports.ReplaceSubscriptions(manager.ID, []uint{weekly.ID, daily.ID}); err != nil {
+			t.Fatalf("ReplaceSubscriptions() error = %v", err)
+		}
+		if err := repos.reports.ReplaceSubscriptions(inactive.ID, []uint{weekly.ID}); err != nil {
+			t.Fatalf("ReplaceSubscriptions() error = %v", err)
+		}
+
+		schedules, err := repos.reports.FindSchedules()
+		if err != nil || len(schedules) != 3 || schedules[0].Name != "Arşiv" || schedules[2].Name != "Haftalık" || schedules[2].SubscriberCount != 2 {
+			t.Fatalf("FindSchedules() = %+v, %v", schedules, err)
+		}
+		if active, _ := repos.reports.FindActiveSchedules(); len(active) != 2 || active[0].Name != "Günlük" {
+			t.Fatalf("FindActiveSchedules() = %+v", active)
+		}
+
+		subscribers, err := repos.reports.FindSubscribers(weekly.ID)
+		if err != nil || len(subscribers) != 1 || subscribers[0].ID != manager.ID || subscribers[0].Team == nil {
+			t.Fatalf("FindSubscribers() = %+v, %v; want only the active manager with team", subscribers, err)
+		}
+		if ids, _ := repos.reports.FindSubscribedScheduleIDs(manager.ID); !slices.Equal(ids, []uint{weekly.ID, daily.ID}) {
+			t.Fatalf("FindSubscribedScheduleIDs() = %v", ids)
+		}
+		if err := repos.reports.ReplaceSubscriptions(manager.ID, []uint{daily.ID}); err != nil {
+			t.Fatal(err)
+		}
+		if ids, _ := repos.reports.FindSubscribedScheduleIDs(manager.ID); !slices.Equal(ids, []uint{daily.ID}) {
+			t.Fatalf("FindSubscribedScheduleIDs() after replace = %v", ids)
+		}
+
+		dueSchedules, err := repos.reports.FindDueSchedules(now)
+		if err != nil || len(dueSchedules) != 1 || dueSchedules[0].ID != weekly.ID {
+			t.Fatalf("FindDueSchedules() = %+v, %v", dueSchedules, err)
+		}
+		next := now.Add(7 * 24 * time.Hour)
+		if claimed, err := repos.reports.ClaimSchedule(weekly.ID, now, next); err != nil || !claimed {
+			t.Fatalf("ClaimSchedule() = %v, %v", claimed, err)
+		}
+		if claimed, _ := repos.reports.ClaimSchedule(weekly.ID, now, next); claimed {
+			t.Fatal("ClaimSchedule() claimed the same run twice")
+		}
+		stored, _ := repos.reports.FindScheduleByID(weekly.ID)
+		if stored.LastRunAt == nil || !stored.LastRunAt.Equal(now) || stored.NextRunAt == nil || !stored.NextRunAt.Equal(next) {
+			t.Fatalf("claimed schedule = %+v", stored)
+		}
+
+		if err := repos.reports.UpdateSchedule(daily.ID, map[string]interface{}{"status": false, "next_run_at": nil}); err != nil {
+			t.Fatalf("UpdateSchedule() error = %v", err)
+		}
+		if stored, _ := repos.reports.FindScheduleByID(daily.ID); stored.Status || stored.NextRunAt != nil {
+			t.Fatalf("paused schedule = %+v", stored)
+		}
+
+		if err := repos.reports.DeleteSchedule(daily.ID); err != nil {
+			t.Fatalf("DeleteSchedule() error = %v", err)
+		}
+		if ids, _ := repos.reports.FindSubscribedScheduleIDs(manager.ID); len(ids) != 0 {
+			t.Fatalf("subscriptions survived deletion: %v", ids)
+		}
+		if err := repos.reports.DeleteSchedule(daily.ID); err == nil {
+			t.Fatal("DeleteSchedule() of a missing schedule succeeded")
+		}
+	})
+}
@@ -0,0 +1,290 @@
+package repositories
+
+import (
+	"sort"
+	"time"
+
+	"zatrano/models"
+)
+
+type MemoryReportingRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryReportingRepository(store *MemoryStore) IReportingRepository {
+	return &MemoryReportingRepository{store: store}
+}
+
+func (r *MemoryReportingRepository) CountUsersByTypeAndStatus() ([]UserTypeStatusCount, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	type key struct {
+		userType models.UserType
+		status   bool
+	}
+	counts := map[key]int64{}
+	for _, u := range r.store.users {
+		if !isSoftDeleted(u.Model) && u.Type != models.System {
+			counts[key{u.Type, u.Status}]++
+		}
+	}
+	rows := make([]UserTypeStatusCount, 0, len(counts))
+	for k, count := range counts {
+		rows = append(rows, UserTypeStatusCount{Type: k.userType, Status: k.status, Count: count})
+	}
+	return rows, nil
+}
+
+func (r *MemoryReportingRepository) FindTeamSizes() ([]TeamSize, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	sizes := make(map[uint]*TeamSize)
+	for _, t := range r.store.teams {
+		if !isSoftDeleted(t.Model) {
+			sizes[t.ID] = &TeamSize{TeamID: t.ID, Name: t.Name, Status: t.Status}
+		}
+	}
+	for _, u := range r.store.users {
+		if u.TeamID == nil || isSoftDeleted(u.Model) {
+			continue
+		}
+		size, ok := sizes[*u.TeamID]
+		if !ok {
+			continue
+		}
+		switch u.Type {
+		case models.Manager:
+			size.Managers++
+		case models.Agent:
+			size.Agents++
+		}
+		if !u.Status {
+			size.Inactive++
+		}
+	}
+
+	rows := make([]TeamSize, 0, len(sizes))
+	for _, size := range sizes {
+		rows = append(rows, *size)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Name != rows[j].Name {
+			return rows[i].Name < rows[j].Name
+		}
+		return rows[i].TeamID < rows[j].TeamID
+	})
+	return rows, nil
+}
+
+func (r *MemoryReportingRepository) FindUserCreationTimes(since time.Time) ([]time.Time, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var times []time.Time
+	for _, u := range r.store.users {
+		if !isSoftDeleted(u.Model) && u.Type != models.System && !u.CreatedAt.Before(since) {
+			times = append(times, u.CreatedAt)
+		}
+	}
+	sortTimes(times)
+	return times, nil
+}
+
+func (r *MemoryReportingRepository) FindDeactivationTimes(since time.Time) ([]time.Time, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var times []time.Time
+	for _, u := range r.store.users {
+		if !isSoftDeleted(u.Model) && u.Type != models.System && u.DeactivatedAt != nil && !u.DeactivatedAt.Before(since) {
+			times = append(times, *u.DeactivatedAt)
+		}
+	}
+	sortTimes(times)
+	return times, nil
+}
+
+func (r *MemoryReportingRepository) FindLoginTimes(since time.Time, success bool) ([]time.Time, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var times []time.Time
+	for _, e := range r.store.loginEvents {
+		if e.Success == success && !e.CreatedAt.Before(since) {
+			times = append(times, e.CreatedAt)
+		}
+	}
+	sortTimes(times)
+	return times, nil
+}
+
+func (r *MemoryReportingRepository) FindRecentLogins(limit int) ([]models.LoginEvent, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var events []models.LoginEvent
+	for _, e := range r.store.loginEvents {
+		if !e.Success {
+			continue
+		}
+		event := *e
+		event.User = nil
+		if e.UserID != nil {
+			if u, ok := r.store.users[*e.UserID]; ok && !isSoftDeleted(u.Model) {
+				user := r.store.copyUser(u, false)
+				event.User = &user
+			}
+		}
+		events = append(events, event)
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].CreatedAt.Equal(events[j].CreatedAt) {
+			return events[i].CreatedAt.After(events[j].CreatedAt)
+		}
+		return events[i].ID > events[j].ID
+	})
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	return events, nil
+}
+
+func (r *MemoryReportingRepository) CountFailedLoginsByAccount(since time.Time, limit int) ([]AccountLoginCount, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	counts := make(map[string]int64)
+	for _, e := range r.store.loginEvents {
+		if !e.Success && !e.CreatedAt.Before(since) {
+			counts[e.Account]++
+		}
+	}
+	rows := make([]AccountLoginCount, 0, len(counts))
+	for account, count := range counts {
+		rows = append(rows, AccountLoginCount{Account: account, Count: count})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Count != rows[j].Count {
+			return rows[i].Count > rows[j].Count
+		}
+		return rows[i].Account < rows[j].Account
+	})
+	if len(rows) > limit {
+		rows = rows[:limit]
+	}
+	return rows, nil
+}
+
+func (r *MemoryReportingRepository) FindTeamChanges(since time.Time, teamID *uint) ([]models.TeamMembershipChange, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	inTeam := func(id *uint) bool { return id != nil && *id == *teamID }
+	team := func(id *uint) *models.Team {
+		if id == nil {
+			return nil
+		}
+		t, ok := r.store.teams[*id]
+		if !ok {
+			return nil
+		}
+		out := *t
+		out.Agents = nil
+		return &out
+	}
+
+	var changes []models.TeamMembershipChange
+	for _, c := range r.store.teamChanges {
+		if c.CreatedAt.Before(since) || (teamID != nil && !inTeam(c.FromTeamID) && !inTeam(c.ToTeamID)) {
+			continue
+		}
+		change := *c
+		change.FromTeamID, change.ToTeamID = toUintPtr(c.FromTeamID), toUintPtr(c.ToTeamID)
+		change.FromTeam, change.ToTeam = team(c.FromTeamID), team(c.ToTeamID)
+		if u, ok := r.store.users[c.UserID]; ok {
+			user := r.store.copyUser(u, false)
+			change.User = &user
+		}
+		changes = append(changes, change)
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if !changes[i].CreatedAt.Equal(changes[j].CreatedAt) {
+			return changes[i].CreatedAt.Before(changes[j].CreatedAt)
+		}
+		return changes[i].ID < changes[j].ID
+	})
+	return changes, nil
+}
+
+func (r *MemoryReportingRepository) FindUsersByStatus(status bool, teamID *uint) ([]models.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var users []models.User
+	for _, u := range r.store.users {
+		if isSoftDeleted(u.Model) || u.Type == models.System || u.Status != status {
+			continue
+		}
+		if teamID != nil && (u.TeamID == nil || *u.TeamID != *teamID) {
+			continue
+		}
+		users = append(users, r.store.copyUser(u, true))
+	}
+	sort.Slice(users, func(i, j int) bool {
+		if users[i].Name != users[j].Name {
+			return users[i].Name < users[j].Name
+		}
+		return users[i].ID < users[j].ID
+	})
+	return users, nil
+}
+
+func (r *MemoryReportingRepository) FindLoggedInUserIDs(since time.Time) ([]uint, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	seen := make(map[uint]bool)
+	var ids []uint
+	for _, e := range r.store.loginEvents {
+		if e.Success && e.UserID != nil && !e.CreatedAt.Before(since) && !seen[*e.UserID] {
+			seen[*e.UserID] = true
+			ids = append(ids, *e.UserID)
+		}
+	}
+	return ids, nil
+}
+
+func (r *MemoryReportingRepository) CountLogins(since time.Time, teamID *uint) (LoginTotals, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var totals LoginTotals
+	for _, e := range r.store.loginEvents {
+		if e.CreatedAt.Before(since) {
+			continue
+		}
+		if teamID != nil {
+			if e.UserID == nil {
+				continue
+			}
+			u, ok := r.store.users[*e.UserID]
+			if !ok || u.TeamID == nil || *u.TeamID != *teamID {
+				continue
+			}
+		}
+		if e.Success {
+			totals.Succeeded++
+		} else {
+			totals.Failed++
+		}
+	}
+	return totals, nil
+}
+
+func sortTimes(times []time.Time) {
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+}
+
+var _ IReportingRepository = (*MemoryReportingRepository)(nil)
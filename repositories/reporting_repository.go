@@ -0,0 +1,189 @@
+package repositories
+
+import (
+	"time"
+
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+type IReportingRepository interface {
+	CountUsersByTypeAndStatus() ([]UserTypeStatusCount, error)
+	FindTeamSizes() ([]TeamSize, error)
+	FindUserCreationTimes(since time.Time) ([]time.Time, error)
+	FindDeactivationTimes(since time.Time) ([]time.Time, error)
+	FindLoginTimes(since time.Time, success bool) ([]time.Time, error)
+	FindRecentLogins(limit int) ([]models.LoginEvent, error)
+	CountFailedLoginsByAccount(since time.Time, limit int) ([]AccountLoginCount, error)
+
+	// Zamanlanmış raporlar; teamID nil değilse sonuçlar o takımla sınırlıdır.
+
+	FindTeamChanges(since time.Time, teamID *uint) ([]models.TeamMembershipChange, error)
+	FindUsersByStatus(status bool, teamID *uint) ([]models.User, error)
+	FindLoggedInUserIDs(since time.Time) ([]uint, error)
+	CountLogins(since time.Time, teamID *uint) (LoginTotals, error)
+}
+
+type TeamSize struct {
+	TeamID   uint
+	Name     string
+	Status   bool
+	Managers int64
+	Agents   int64
+	Inactive int64
+}
+
+func (t TeamSize) Members() int64 {
+	return t.Managers + t.Agents
+}
+
+type AccountLoginCount struct {
+	Account string
+	Count   int64
+}
+
+type LoginTotals struct {
+	Succeeded int64
+	Failed    int64
+}
+
+type ReportingRepository struct {
+	db *gorm.DB
+}
+
+func NewReportingRepository(db *gorm.DB) IReportingRepository {
+	return &ReportingRepository{db: db}
+}
+
+func (r *ReportingRepository) CountUsersByTypeAndStatus() ([]UserTypeStatusCount, error) {
+	var rows []UserTypeStatusCount
+	err := r.db.Model(&models.User{}).
+		Select("type, status, count(*) as count").
+		Where("type <> ?", models.System).
+		Group("type, status").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *ReportingRepository) FindTeamSizes() ([]TeamSize, error) {
+	var rows []TeamSize
+	err := r.db.Model(&models.Team{}).
+		Select(`teams.id AS team_id, teams.name, teams.status,
+			COALESCE(SUM(CASE WHEN users.type = ? THEN 1 ELSE 0 END), 0) AS managers,
+			COALESCE(SUM(CASE WHEN users.type = ? THEN 1 ELSE 0 END), 0) AS agents,
+			COALESCE(SUM(CASE WHEN users.status = ? THEN 1 ELSE 0 END), 0) AS inactive`,
+			models.Manager, models.Agent, false).
+		Joins("LEFT JOIN users ON users.team_id = teams.id AND users.deleted_at IS NULL").
+		Group("teams.id, teams.name, teams.status").
+		Order("teams.name ASC, teams.id ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *ReportingRepository) FindUserCreationTimes(since time.Time) ([]time.Time, error) {
+	var times []time.Time
+	err := r.db.Model(&models.User{}).
+		Where("type <> ? AND created_at >= ?", models.System, since).
+		Order("created_at ASC").
+		Pluck("created_at", &times).Error
+	return times, err
+}
+
+func (r *ReportingRepository) FindDeactivationTimes(since time.Time) ([]time.Time, error) {
+	var times []time.Time
+	err := r.db.Model(&models.User{}).
+		Where("type <> ? AND deactivated_at >= ?", models.System, since).
+		Order("deactivated_at ASC").
+		Pluck("deactivated_at", &times).Error
+	return times, err
+}
+
+func (r *ReportingRepository) FindLoginTimes(since time.Time, success bool) ([]time.Time, error) {
+	var times []time.Time
+	err := r.db.Model(&models.LoginEvent{}).
+		Where("success = ? AND created_at >= ?", success, since).
+		Order("created_at ASC").
+		Pluck("created_at", &times).Error
+	return times, err
+}
+
+func (r *ReportingRepository) FindRecentLogins(limit int) ([]models.LoginEvent, error) {
+	var events []models.LoginEvent
+	err := r.db.Preload("User").
+		Where("success = ?", true).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+func (r *ReportingRepository) CountFailedLoginsByAccount(since time.Time, limit int) ([]AccountLoginCount, error) {
+	var rows []AccountLoginCount
+	err := r.db.Model(&models.LoginEvent{}).
+		Select("account, count(*) AS count").
+		Where("success = ? AND created_at >= ?", false, since).
+		Group("account").
+		Order("count DESC, account ASC").
+		Limit(limit).
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *ReportingRepository) FindTeamChanges(since time.Time, teamID *uint) ([]models.TeamMembershipChange, error) {
+	unscoped := func(db *gorm.DB) *gorm.DB { return db.Unscoped() }
+	query := r.db.Preload("User", unscoped).Preload("FromTeam", unscoped).Preload("ToTeam", unscoped).
+		Where("created_at >= ?", since)
+	if teamID != nil {
+		query = query.Where("(from_team_id = ? OR to_team_id = ?)", *teamID, *teamID)
+	}
+	var changes []models.TeamMembershipChange
+	err := query.Order("created_at ASC, id ASC").Find(&changes).Error
+	return changes, err
+}
+
+func (r *ReportingRepository) FindUsersByStatus(status bool, teamID *uint) ([]models.User, error) {
+	query := r.db.Preload("Team").Where("type <> ? AND status = ?", models.System, status)
+	if teamID != nil {
+		query = query.Where("team_id = ?", *teamID)
+	}
+	var users []models.User
+	err := query.Order("name ASC, id ASC").Find(&users).Error
+	return users, err
+}
+
+func (r *ReportingRepository) FindLoggedInUserIDs(since time.Time) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&models.LoginEvent{}).
+		Distinct("user_id").
+		Where("success = ? AND user_id IS NOT NULL AND created_at >= ?", true, since).
+		Pluck("user_id", &ids).Error
+	return ids, err
+}
+
+func (r *ReportingRepository) CountLogins(since time.Time, teamID *uint) (LoginTotals, error) {
+	var rows []struct {
+		Success bool
+		Count   int64
+	}
+	query := r.db.Model(&models.LoginEvent{}).
+		Select("login_events.success, count(*) AS count").
+		Where("login_events.created_at >= ?", since)
+	if teamID != nil {
+		query = query.Joins("JOIN users ON users.id = login_events.user_id").Where("users.team_id = ?", *teamID)
+	}
+	var totals LoginTotals
+	if err := query.Group("login_events.success").Scan(&rows).Error; err != nil {
+		return totals, err
+	}
+	for _, row := range rows {
+		if row.Success {
+			totals.Succeeded = row.Count
+		} else {
+			totals.Failed = row.Count
+		}
+	}
+	return totals, nil
+}
+
+var _ IReportingRepository = (*ReportingRepository)(nil)
@@ -0,0 +1,184 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"zatrano/models"
+)
+
+func TestReportingRepositoryUsersAndTeams(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repoSet) {
+		sales := mustCreateTeam(t, repos, "Satış", true)
+		support := mustCreateTeam(t, repos, "Destek", true)
+		mustCreateTeam(t, repos, "Arşiv", false)
+		mustCreateUser(t, repos, models.User{Name: "Sistem", Account: "system@system", Type: models.System})
+		mustCreateUser(t, repos, models.User{Name: "Yönetici", Account: "manager@x", Type: models.Manager, TeamID: &sales.ID})
+		mustCreateUser(t, repos, models.User{Name: "Ali", Account: "ali@x", Type: models.Agent, TeamID: &sales.ID})
+		veli := mustCreateUser(t, repos, models.User{Name: "Veli", Account: "veli@x", Type: models.Agent, TeamID: &support.ID})
+
+		deactivatedAt := time.Now().UTC().Add(-time.Hour)
+		if err := repos.users.Update(veli.ID, map[string]interface{}{"status": false, "deactivated_at": &deactivatedAt}); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		counts, err := repos.reporting.CountUsersByTypeAndStatus()
+		if err != nil {
+			t.Fatalf("CountUsersByTypeAndStatus() error = %v", err)
+		}
+		got := map[models.UserType]map[bool]int64{}
+		for _, row := range counts {
+			if got[row.Type] == nil {
+				got[row.Type] = map[bool]int64{}
+			}
+			got[row.Type][row.Status] = row.Count
+		}
+		if len(got[models.System]) != 0 || got[models.Manager][true] != 1 || got[models.Agent][true] != 1 || got[models.Agent][false] != 1 {
+			t.Fatalf("CountUsersByTypeAndStatus() = %+v", got)
+		}
+
+		sizes, err := repos.reporting.FindTeamSizes()
+		if err != nil || len(sizes) != 3 {
+			t.Fatalf("FindTeamSizes() = %+v, %v", sizes, err)
+		}
+		// İsme göre sıralı: Arşiv (boş), Destek (yöneticisiz), Satış.
+		if sizes[0].Name != "Arşiv" || sizes[0].Status || sizes[0].Members() != 0 {
+			t.Fatalf("empty team = %+v", sizes[0])
+		}
+		if sizes[1].Name != "Destek" || sizes[1].Managers != 0 || sizes[1].Agents != 1 || sizes[1].Inactive != 1 {
+			t.Fatalf("team without manager = %+v", sizes[1])
+		}
+		if sizes[2].Name != "Satış" || sizes[2].Managers != 1 || sizes[2].Agents != 1 || sizes[2].Inactive != 0 {
+			t.Fatalf("full team = %+v", sizes[2])
+		}
+
+		since := time.Now().UTC().Add(-24 * time.Hour)
+		if created, err := repos.reporting.FindUserCreationTimes(since); err != nil || len(created) != 3 {
+			t.Fatalf("FindUserCreationTimes() = %v, %v; want 3 non-system users", created, err)
+		}
+		deactivated, err := repos.reporting.FindDeactivationTimes(since)
+		if err != nil || len(deactivated) != 1 || !deactivated[0].Equal(deactivatedAt) {
+			t.Fatalf("FindDeactivationTimes() = %v, %v", deactivated, err)
+		}
+		if deactivated, _ := repos.reporting.FindDeactivationTimes(time.Now().UTC()); len(deactivated) != 0 {
+			t.Fatalf("FindDeactivationTimes(now) = %v", deactivated)
+		}
+	})
+}
+
+func TestReportingRepositoryLogins(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repoSet) {
+		team := mustCreateTeam(t, repos, "Destek", true)
+		ali := mustCreateUser(t, repos, models.User{Name: "Ali", Account: "ali@x", Type: models.Agent, TeamID: &team.ID})
+
+		now := time.Now().UTC().Truncate(time.Second)
+		events := []models.LoginEvent{
+			{UserID: &ali.ID, Account: "ali@x", Success: true, CreatedAt: now.Add(-3 * time.Hour)},
+			{UserID: &ali.ID, Account: "ali@x", Success: true, CreatedAt: now.Add(-time.Hour)},
+			{UserID: &ali.ID, Account: "ali@x", Success: false, Reason: "errors.auth.invalid_credentials", CreatedAt: now.Add(-2 * time.Hour)},
+			{Account: "yok@x", Success: false, Reason: "errors.auth.invalid_credentials", CreatedAt: now.Add(-2 * time.Hour)},
+			{Account: "yok@x", Success: false, Reason: "errors.auth.invalid_credentials", CreatedAt: now.Add(-time.Hour)},
+			{Account: "yok@x", Success: false, Reason: "errors.auth.invalid_credentials", CreatedAt: now.Add(-48 * time.Hour)},
+		}
+		for i := range events {
+			if err := repos.auth.CreateLoginEvent(&events[i]); err != nil {
+				t.Fatalf("CreateLoginEvent() error = %v", err)
+			}
+		}
+
+		since := now.Add(-24 * time.Hour)
+		if times, err := repos.reporting.FindLoginTimes(since, true); err != nil || len(times) != 2 || !times[0].Before(times[1]) {
+			t.Fatalf("FindLoginTimes(success) = %v, %v", times, err)
+		}
+		if times, err := repos.reporting.FindLoginTimes(since, false); err != nil || len(times) != 3 {
+			t.Fatalf("FindLoginTimes(failure) = %v, %v", times, err)
+		}
+
+		recent, err := repos.reporting.FindRecentLogins(1)
+		if err != nil || len(recent) != 1 || !recent[0].CreatedAt.Equal(now.Add(-time.Hour)) || recent[0].User == nil || recent[0].User.Name != "Ali" {
+			t.Fatalf("FindRecentLogins() = %+v, %v", recent, err)
+		}
+
+		accounts, err := repos.reporting.CountFailedLoginsByAccount(since, 5)
+		if err != nil || len(accounts) != 2 || accounts[0] != (AccountLoginCount{Account: "yok@x", Count: 2}) || accounts[1] != (AccountLoginCount{Account: "ali@x", Count: 1}) {
+			t.Fatalf("CountFailedLoginsByAccount() = %+v, %v", accounts, err)
+		}
+		if accounts, _ := repos.reporting.CountFailedLoginsByAccount(since, 1); len(accounts) != 1 {
+			t.Fatalf("CountFailedLoginsByAccount(limit 1) = %+v", accounts)
+		}
+	})
+}
+
+func TestReportingRepositoryReportQueries(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repoSet) {
+		sales := mustCreateTeam(t, repos, "Satış", true)
+		support := mustCreateTeam(t, repos, "Destek", true)
+		ali := mustCreateUser(t, repos, models.User{Name: "Ali", Account: "ali@x", Type: models.Agent, TeamID: &sales.ID})
+		veli := mustCreateUser(t, repos, models.User{Name: "Veli", Account: "veli@x", Type: models.Agent, TeamID: &support.ID})
+		mustCreateUser(t, repos, models.User{Name: "Ayşe", Account: "ayse@x", Type: models.Agent, TeamID: &support.ID})
+		mustCreateUser(t, repos, models.User{Name: "Sistem", Account: "system@system", Type: models.System})
+		if err := repos.users.Update(veli.ID, map[string]interface{}{"status": false}); err != nil {
+			t.Fatal(err)
+		}
+
+		now := time.Now().UTC().Truncate(time.Second)
+		since := now.Add(-24 * time.Hour)
+		changes := []models.TeamMembershipChange{
+			{UserID: ali.ID, ToTeamID: &sales.ID, CreatedAt: now.Add(-48 * time.Hour)},
+			{UserID: ali.ID, FromTeamID: &sales.ID, ToTeamID: &support.ID, CreatedAt: now.Add(-2 * time.Hour)},
+			{UserID: veli.ID, FromTeamID: &support.ID, ToTeamID: &sales.ID, CreatedAt: now.Add(-time.Hour)},
+		}
+		for i := range changes {
+			if err := repos.users.CreateTeamChange(&changes[i]); err != nil {
+				t.Fatalf("CreateTeamChange() error = %v", err)
+			}
+		}
+		if err := repos.users.Delete(veli.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		found, err := repos.reporting.FindTeamChanges(since, nil)
+		if err != nil || len(found) != 2 || found[0].UserID != ali.ID {
+			t.Fatalf("FindTeamChanges() = %+v, %v", found, err)
+		}
+		if found[0].FromTeam == nil || found[0].FromTeam.Name != "Satış" || found[0].ToTeam == nil || found[0].ToTeam.Name != "Destek" {
+			t.Fatalf("teams not loaded: %+v", found[0])
+		}
+		if found[1].User == nil || found[1].User.Name != "Veli" {
+			t.Fatalf("deleted user not loaded: %+v", found[1])
+		}
+		if scoped, _ := repos.reporting.FindTeamChanges(now.Add(-72*time.Hour), &sales.ID); len(scoped) != 3 {
+			t.Fatalf("FindTeamChanges(sales) = %+v", scoped)
+		}
+
+		active, err := repos.reporting.FindUsersByStatus(true, &support.ID)
+		if err != nil || len(active) != 1 || active[0].Name != "Ayşe" || active[0].Team == nil {
+			t.Fatalf("FindUsersByStatus(true, support) = %+v, %v", active, err)
+		}
+		if all, _ := repos.reporting.FindUsersByStatus(true, nil); len(all) != 2 {
+			t.Fatalf("FindUsersByStatus(true) = %+v; system and deleted users must be skipped", all)
+		}
+
+		events := []models.LoginEvent{
+			{UserID: &ali.ID, Account: "ali@x", Success: true, CreatedAt: now.Add(-time.Hour)},
+			{UserID: &ali.ID, Account: "ali@x", Success: true, CreatedAt: now.Add(-2 * time.Hour)},
+			{UserID: &ali.ID, Account: "ali@x", Success: false, CreatedAt: now.Add(-time.Hour)},
+			{Account: "yok@x", Success: false, CreatedAt: now.Add(-time.Hour)},
+			{UserID: &ali.ID, Account: "ali@x", Success: true, CreatedAt: now.Add(-48 * time.Hour)},
+		}
+		for i := range events {
+			if err := repos.auth.CreateLoginEvent(&events[i]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if ids, err := repos.reporting.FindLoggedInUserIDs(since); err != nil || len(ids) != 1 || ids[0] != ali.ID {
+			t.Fatalf("FindLoggedInUserIDs() = %v, %v", ids, err)
+		}
+		if totals, err := repos.reporting.CountLogins(since, nil); err != nil || totals != (LoginTotals{Succeeded: 2, Failed: 2}) {
+			t.Fatalf("CountLogins() = %+v, %v", totals, err)
+		}
+		if totals, _ := repos.reporting.CountLogins(since, &sales.ID); totals != (LoginTotals{Succeeded: 2, Failed: 1}) {
+			t.Fatalf("CountLogins(sales) = %+v; unknown accounts must not be counted", totals)
+		}
+	})
+}
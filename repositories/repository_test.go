@@ -0,0 +1,154 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"testing"
+
+	"zatrano/models"
+	"zatrano/utils"
+
+	"github.com/mattn/go-sqlite3"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const sqliteDriver = "sqlite3_search"
+
+func init() {
+	sql.Register(sqliteDriver, &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			return conn.RegisterFunc("search_fold", utils.FoldSearch, true)
+		},
+	})
+}
+
+var sqliteSearchColumns = []string{
+	"ALTER TABLE users ADD COLUMN name_folded TEXT GENERATED ALWAYS AS (search_fold(name)) VIRTUAL",
+	"ALTER TABLE users ADD COLUMN account_folded TEXT GENERATED ALWAYS AS (search_fold(account)) VIRTUAL",
+	"ALTER TABLE teams ADD COLUMN name_folded TEXT GENERATED ALWAYS AS (search_fold(name)) VIRTUAL",
+}
+
+func TestMain(m *testing.M) {
+	utils.InitLogger("test", "error")
+	os.Exit(m.Run())
+}
+
+type repoSet struct {
+	users IUserRepository
+	teams ITeamRepository
+	auth  IAuthRepository
+	prefs IUserPreferenceRepository
+
+	announcements IAnnouncementRepository
+	notifications INotificationRepository
+	shifts        IShiftRepository
+	attendance    IAttendanceRepository
+	leaves        ILeaveRepository
+	tasks         ITaskRepository
+	kpis          IKPIRepository
+	reporting     IReportingRepository
+	reports       IReportRepository
+	jobs          IJobRepository
+}
+
+func openSQLite(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name())
+	db, err := gorm.Open(sqlite.New(sqlite.Config{DriverName: sqliteDriver, DSN: dsn}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("sqlite açılamadı: %v", err)
+	}
+	if err := db.AutoMigrate(&models.Team{}, &models.User{}, &models.UserPreference{}, &models.Announcement{}, &models.AnnouncementReceipt{}, &models.Notification{}, &models.Shift{}, &models.ShiftAssignment{}, &models.AttendanceSession{}, &models.AttendanceBreak{}, &models.LeaveRequest{}, &models.LeaveBalance{}, &models.Task{}, &models.TaskComment{}, &models.KPIDefinition{}, &models.KPIValue{}, &models.LoginEvent{}, &models.TeamMembershipChange{}, &models.ReportSchedule{}, &models.ReportSubscription{}, &models.Job{}); err != nil {
+		t.Fatalf("sqlite migrasyonu başarısız: %v", err)
+	}
+	for _, stmt := range sqliteSearchColumns {
+		if err := db.Exec(stmt).Error; err != nil {
+			t.Fatalf("sqlite arama sütunu eklenemedi: %v", err)
+		}
+	}
+	sqlDB, _ := db.DB()
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	return db
+}
+
+func backends() map[string]func(t *testing.T) repoSet {
+	return map[string]func(t *testing.T) repoSet{
+		"gorm-sqlite": func(t *testing.T) repoSet {
+			db := openSQLite(t)
+			return repoSet{
+				users: NewUserRepository(db),
+				teams: NewTeamRepository(db),
+				auth:  NewAuthRepository(db),
+				prefs: NewUserPreferenceRepository(db),
+
+				announcements: NewAnnouncementRepository(db),
+				notifications: NewNotificationRepository(db),
+				shifts:        NewShiftRepository(db),
+				attendance:    NewAttendanceRepository(db),
+				leaves:        NewLeaveRepository(db),
+				tasks:         NewTaskRepository(db),
+				kpis:          NewKPIRepository(db),
+				reporting:     NewReportingRepository(db),
+				reports:       NewReportRepository(db),
+				jobs:          NewJobRepository(db),
+			}
+		},
+		"memory": func(t *testing.T) repoSet {
+			store := NewMemoryStore()
+			return repoSet{
+				users: NewMemoryUserRepository(store),
+				teams: NewMemoryTeamRepository(store),
+				auth:  NewMemoryAuthRepository(store),
+				prefs: NewMemoryUserPreferenceRepository(store),
+
+				announcements: NewMemoryAnnouncementRepository(store),
+				notifications: NewMemoryNotificationRepository(store),
+				shifts:        NewMemoryShiftRepository(store),
+				attendance:    NewMemoryAttendanceRepository(store),
+				leaves:        NewMemoryLeaveRepository(store),
+				tasks:         NewMemoryTaskRepository(store),
+				kpis:          NewMemoryKPIRepository(store),
+				reporting:     NewMemoryReportingRepository(store),
+				reports:       NewMemoryReportRepository(store),
+				jobs:          NewMemoryJobRepository(store),
+			}
+		},
+	}
+}
+
+func forEachBackend(t *testing.T, fn func(t *testing.T, repos repoSet)) {
+	for name, open := range backends() {
+		t.Run(name, func(t *testing.T) {
+			fn(t, open(t))
+		})
+	}
+}
+
+func mustCreateTeam(t *testing.T, repos repoSet, name string, status bool) *models.Team {
+	t.Helper()
+	team := &models.Team{Name: name, Status: status}
+	if err := repos.teams.Create(team); err != nil {
+		t.Fatalf("takım oluşturulamadı: %v", err)
+	}
+	if !status {
+		// GORM, default:true etiketli alanlarda false değerini atlar.
+		if err := repos.teams.Update(team.ID, map[string]interface{}{"status": false}); err != nil {
+			t.Fatalf("takım durumu güncellenemedi: %v", err)
+		}
+	}
+	return team
+}
+
+func mustCreateUser(t *testing.T, repos repoSet, user models.User) *models.User {
+	t.Helper()
+	if user.Password == "" {
+		user.Password = "secret"
+	}
+	if err := repos.users.Create(&user); err != nil {
+		t.Fatalf("kullanıcı oluşturulamadı (%s): %v", user.Account, err)
+	}
+	return &user
+}
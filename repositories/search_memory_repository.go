@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"sort"
+
+	"zatrano/utils"
+)
+
+type MemorySearchRepository struct {
+	store *MemoryStore
+}
+
+func NewMemorySearchRepository(store *MemoryStore) ISearchRepository {
+	return &MemorySearchRepository{store: store}
+}
+
+func (r *MemorySearchRepository) Search(term string, limit int) ([]SearchHit, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	hits := []SearchHit{}
+	for _, u := range r.store.users {
+		if isSoftDeleted(u.Model) || u.ID == 1 {
+			continue
+		}
+		score := max(utils.WordSimilarity(term, u.Name), utils.WordSimilarity(term, u.Account))
+		if utils.MatchNormalized(u.Name, term) || utils.MatchNormalized(u.Account, term) || score >= utils.SearchSimilarityThreshold {
+			hits = append(hits, SearchHit{Kind: SearchKindUser, ID: u.ID, Title: u.Name, Subtitle: u.Account, Score: score})
+		}
+	}
+	for _, t := range r.store.teams {
+		if isSoftDeleted(t.Model) {
+			continue
+		}
+		score := utils.WordSimilarity(term, t.Name)
+		if utils.MatchNormalized(t.Name, term) || score >= utils.SearchSimilarityThreshold {
+			hits = append(hits, SearchHit{Kind: SearchKindTeam, ID: t.ID, Title: t.Name, Score: score})
+		}
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Title < hits[j].Title
+	})
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
+
+var _ ISearchRepository = (*MemorySearchRepository)(nil)
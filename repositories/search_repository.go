@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"zatrano/utils"
+
+	"gorm.io/gorm"
+)
+
+type SearchKind string
+
+const (
+	SearchKindUser SearchKind = "user"
+	SearchKindTeam SearchKind = "team"
+)
+
+type SearchHit struct {
+	Kind     SearchKind
+	ID       uint
+	Title    string
+	Subtitle string
+	Score    float64
+}
+
+type ISearchRepository interface {
+	Search(term string, limit int) ([]SearchHit, error)
+}
+
+type SearchRepository struct {
+	db *gorm.DB
+}
+
+func NewSearchRepository(db *gorm.DB) ISearchRepository {
+	return &SearchRepository{db: db}
+}
+
+const searchSQL = `
+SELECT 'user' AS kind, id, name AS title, account AS subtitle,
+	GREATEST(word_similarity(@term, name_folded), word_similarity(@term, account_folded)) AS score
+FROM users
+WHERE deleted_at IS NULL AND id <> 1 AND (
+	name_folded LIKE @pattern OR account_folded LIKE @pattern
+	OR @term <% name_folded OR @term <% account_folded)
+UNION ALL
+SELECT 'team' AS kind, id, name AS title, '' AS subtitle,
+	word_similarity(@term, name_folded) AS score
+FROM teams
+WHERE deleted_at IS NULL AND (name_folded LIKE @pattern OR @term <% name_folded)
+ORDER BY score DESC, title
+LIMIT @limit`
+
+func (r *SearchRepository) Search(term string, limit int) ([]SearchHit, error) {
+	folded := utils.FoldSearch(term)
+	hits := []SearchHit{}
+	err := r.db.Raw(searchSQL, map[string]interface{}{
+		"term":    folded,
+		"pattern": "%" + utils.EscapeLike(folded) + "%",
+		"limit":   limit,
+	}).Scan(&hits).Error
+	return hits, err
+}
+
+var _ ISearchRepository = (*SearchRepository)(nil)
@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const SessionTable = "sessions"
+
+type ISessionRepository interface {
+	CountActive() (int64, error)
+}
+
+type SessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) ISessionRepository {
+	return &SessionRepository{db: db}
+}
+
+func (r *SessionRepository) CountActive() (int64, error) {
+	var count int64
+	err := r.db.Table(SessionTable).
+		Where("e = 0 OR e > ?", time.Now().Unix()).
+		Count(&count).Error
+	return count, err
+}
+
+var _ ISessionRepository = (*SessionRepository)(nil)
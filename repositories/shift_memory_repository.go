@@ -0,0 +1,224 @@
+package repositories
+
+import (
+	"slices"
+	"time"
+
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+type MemoryShiftRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryShiftRepository(store *MemoryStore) IShiftRepository {
+	return &MemoryShiftRepository{store: store}
+}
+
+func (r *MemoryShiftRepository) activeShift(id uint) (*models.Shift, bool) {
+	s, ok := r.store.shifts[id]
+	if !ok || isSoftDeleted(s.Model) {
+		return nil, false
+	}
+	return s, true
+}
+
+func (r *MemoryShiftRepository) copyAssignment(a *models.ShiftAssignment) models.ShiftAssignment {
+	out := *a
+	out.Shift, out.User = nil, nil
+	if s, ok := r.activeShift(a.ShiftID); ok {
+		shift := *s
+		shift.Team = nil
+		out.Shift = &shift
+	}
+	if u, ok := r.store.users[a.UserID]; ok && !isSoftDeleted(u.Model) {
+		user := r.store.copyUser(u, false)
+		out.User = &user
+	}
+	return out
+}
+
+func (r *MemoryShiftRepository) filterAssignments(match func(a *models.ShiftAssignment) bool) []models.ShiftAssignment {
+	assignments := []models.ShiftAssignment{}
+	for _, a := range r.store.shiftAssignments {
+		if isSoftDeleted(a.Model) || !match(a) {
+			continue
+		}
+		assignments = append(assignments, r.copyAssignment(a))
+	}
+	slices.SortFunc(assignments, func(a, b models.ShiftAssignment) int {
+		if c := a.StartDate.Compare(b.StartDate); c != 0 {
+			return c
+		}
+		return compareOrdered(a.ID, b.ID)
+	})
+	return assignments
+}
+
+func (r *MemoryShiftRepository) FindByTeam(teamID uint) ([]models.Shift, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	shifts := []models.Shift{}
+	for _, s := range r.store.shifts {
+		if !isSoftDeleted(s.Model) && s.TeamID == teamID {
+			shifts = append(shifts, *s)
+		}
+	}
+	slices.SortFunc(shifts, func(a, b models.Shift) int {
+		if c := compareOrdered(a.StartMinute, b.StartMinute); c != 0 {
+			return c
+		}
+		if c := compareOrdered(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return compareOrdered(a.ID, b.ID)
+	})
+	return shifts, nil
+}
+
+func (r *MemoryShiftRepository) FindByID(id uint) (*models.Shift, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	s, ok := r.activeShift(id)
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	shift := *s
+	return &shift, nil
+}
+
+func (r *MemoryShiftRepository) Create(shift *models.Shift) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	now := memoryNow()
+	shift.ID = r.store.nextShiftID
+	shift.CreatedAt = now
+	shift.UpdatedAt = now
+	r.store.nextShiftID++
+
+	stored := *shift
+	stored.Team = nil
+	r.store.shifts[shift.ID] = &stored
+	return nil
+}
+
+func (r *MemoryShiftRepository) Update(id uint, data map[string]interface{}) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	s, ok := r.activeShift(id)
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	applyShiftUpdates(s, data)
+	s.UpdatedAt = memoryNow()
+	return nil
+}
+
+func (r *MemoryShiftRepository) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	s, ok := r.activeShift(id)
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	now := memoryNow()
+	for _, a := range r.store.shiftAssignments {
+		if a.ShiftID == id && !isSoftDeleted(a.Model) {
+			a.DeletedAt = gorm.DeletedAt{Time: now, Valid: true}
+		}
+	}
+	s.DeletedAt = gorm.DeletedAt{Time: now, Valid: true}
+	return nil
+}
+
+func (r *MemoryShiftRepository) FindAssignmentsByTeam(teamID uint, from, to time.Time) ([]models.ShiftAssignment, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.filterAssignments(func(a *models.ShiftAssignment) bool {
+		s, ok := r.activeShift(a.ShiftID)
+		return ok && s.TeamID == teamID && !a.StartDate.After(to) && !a.EndDate.Before(from)
+	}), nil
+}
+
+func (r *MemoryShiftRepository) FindAssignmentsByUser(userID uint, from, to time.Time) ([]models.ShiftAssignment, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.filterAssignments(func(a *models.ShiftAssignment) bool {
+		return a.UserID == userID && !a.StartDate.After(to) && !a.EndDate.Before(from)
+	}), nil
+}
+
+func (r *MemoryShiftRepository) FindAssignmentsByShift(shiftID uint) ([]models.ShiftAssignment, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.filterAssignments(func(a *models.ShiftAssignment) bool {
+		return a.ShiftID == shiftID
+	}), nil
+}
+
+func (r *MemoryShiftRepository) FindAssignmentByID(id uint) (*models.ShiftAssignment, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	a, ok := r.store.shiftAssignments[id]
+	if !ok || isSoftDeleted(a.Model) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	assignment := r.copyAssignment(a)
+	return &assignment, nil
+}
+
+func (r *MemoryShiftRepository) CreateAssignment(assignment *models.ShiftAssignment) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	now := memoryNow()
+	assignment.ID = r.store.nextShiftAssignmentID
+	assignment.CreatedAt = now
+	assignment.UpdatedAt = now
+	r.store.nextShiftAssignmentID++
+
+	stored := *assignment
+	stored.Shift, stored.User = nil, nil
+	r.store.shiftAssignments[assignment.ID] = &stored
+	return nil
+}
+
+func (r *MemoryShiftRepository) DeleteAssignment(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	a, ok := r.store.shiftAssignments[id]
+	if !ok || isSoftDeleted(a.Model) {
+		return gorm.ErrRecordNotFound
+	}
+	a.DeletedAt = gorm.DeletedAt{Time: memoryNow(), Valid: true}
+	return nil
+}
+
+func applyShiftUpdates(s *models.Shift, data map[string]interface{}) {
+	for key, value := range data {
+		switch key {
+		case "name":
+			s.Name, _ = value.(string)
+		case "start_minute":
+			s.StartMinute, _ = value.(int)
+		case "end_minute":
+			s.EndMinute, _ = value.(int)
+		case "days":
+			s.Days, _ = value.(models.WeekdaySet)
+		}
+	}
+}
+
+var _ IShiftRepository = (*MemoryShiftRepository)(nil)
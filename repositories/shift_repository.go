@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"time"
+
+	"zatrano/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IShiftRepository interface {
+	FindByTeam(teamID uint) ([]models.Shift, error)
+	FindByID(id uint) (*models.Shift, error)
+	Create(shift *models.Shift) error
+	Update(id uint, data map[string]interface{}) error
+	Delete(id uint) error
+	FindAssignmentsByTeam(teamID uint, from, to time.Time) ([]models.ShiftAssignment, error)
+	FindAssignmentsByUser(userID uint, from, to time.Time) ([]models.ShiftAssignment, error)
+	FindAssignmentsByShift(shiftID uint) ([]models.ShiftAssignment, error)
+	FindAssignmentByID(id uint) (*models.ShiftAssignment, error)
+	CreateAssignment(assignment *models.ShiftAssignment) error
+	DeleteAssignment(id uint) error
+}
+
+const shiftOrderSQL = "start_minute ASC, name ASC, id ASC"
+
+const assignmentOrderSQL = "shift_assignments.start_date ASC, shift_assignments.id ASC"
+
+type ShiftRepository struct {
+	db *gorm.DB
+}
+
+func NewShiftRepository(db *gorm.DB) IShiftRepository {
+	return &ShiftRepository{db: db}
+}
+
+func (r *ShiftRepository) FindByTeam(teamID uint) ([]models.Shift, error) {
+	var shifts []models.Shift
+	err := r.db.Where("team_id = ?", teamID).Order(shiftOrderSQL).Find(&shifts).Error
+	return shifts, err
+}
+
+func (r *ShiftRepository) FindByID(id uint) (*models.Shift, error) {
+	var shift models.Shift
+	if err := r.db.First(&shift, id).Error; err != nil {
+		return nil, err
+	}
+	return &shift, nil
+}
+
+func (r *ShiftRepository) Create(shift *models.Shift) error {
+	return r.db.Omit(clause.Associations).Create(shift).Error
+}
+
+func (r *ShiftRepository) Update(id uint, data map[string]interface{}) error {
+	result := r.db.Model(&models.Shift{}).Where("id = ?", id).Updates(data)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *ShiftRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("shift_id = ?", id).Delete(&models.ShiftAssignment{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.Shift{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func (r *ShiftRepository) findAssignments(query *gorm.DB) ([]models.ShiftAssignment, error) {
+	var assignments []models.ShiftAssignment
+	err := query.Preload("Shift").Preload("User").Order(assignmentOrderSQL).Find(&assignments).Error
+	return assignments, err
+}
+
+func (r *ShiftRepository) FindAssignmentsByTeam(teamID uint, from, to time.Time) ([]models.ShiftAssignment, error) {
+	return r.findAssignments(r.db.
+		Joins("JOIN shifts ON shifts.id = shift_assignments.shift_id AND shifts.deleted_at IS NULL").
+		Where("shifts.team_id = ? AND shift_assignments.start_date <= ? AND shift_assignments.end_date >= ?", teamID, to, from))
+}
+
+func (r *ShiftRepository) FindAssignmentsByUser(userID uint, from, to time.Time) ([]models.ShiftAssignment, error) {
+	return r.findAssignments(r.db.Where("user_id = ? AND start_date <= ? AND end_date >= ?", userID, to, from))
+}
+
+func (r *ShiftRepository) FindAssignmentsByShift(shiftID uint) ([]models.ShiftAssignment, error) {
+	return r.findAssignments(r.db.Where("shift_id = ?", shiftID))
+}
+
+func (r *ShiftRepository) FindAssignmentByID(id uint) (*models.ShiftAssignment, error) {
+	var assignment models.ShiftAssignment
+	if err := r.db.Preload("Shift").Preload("User").First(&assignment, id).Error; err != nil {
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+func (r *ShiftRepository) CreateAssignment(assignment *models.ShiftAssignment) error {
+	return r.db.Omit(clause.Associations).Create(assignment).Error
+}
+
+func (r *ShiftRepository) DeleteAssignment(id uint) error {
+	result := r.db.Delete(&models.ShiftAssignment{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+var _ IShiftRepository = (*ShiftRepository)(nil)
@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"zatrano/models"
+)
+
+func assignmentIDs(items []models.ShiftAssignment) []uint {
+	ids := make([]uint, len(items))
+	for i, a := range items {
+		ids[i] = a.ID
+	}
+	return ids
+}
+
+func TestShiftRepositoryAssignments(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repoSet) {
+		sales := mustCreateTeam(t, repos, "Satış", true)
+		support := mustCreateTeam(t, repos, "Destek", true)
+		mustCreateUser(t, repos, models.User{Name: "Yönetici", Account: "manager@x", Type: models.Manager, TeamID: &sales.ID})
+		ali := mustCreateUser(t, repos, models.User{Name: "Ali", Account: "ali@x", Type: models.Agent, TeamID: &sales.ID})
+		ayse := mustCreateUser(t, repos, models.User{Name: "Ayşe", Account: "ayse@x", Type: models.Agent, TeamID: &sales.ID})
+		mustCreateUser(t, repos, models.User{Name: "Can", Account: "can@x", Type: models.Agent, TeamID: &support.ID})
+
+		weekdays := models.NewWeekdaySet(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday)
+		evening := &models.Shift{TeamID: sales.ID, Name: "Akşam", StartMinute: 16 * 60, EndMinute: 0, Days: weekdays}
+		morning := &models.Shift{TeamID: sales.ID, Name: "Sabah", StartMinute: 8 * 60, EndMinute: 16 * 60, Days: weekdays}
+		other := &models.Shift{TeamID: support.ID, Name: "Destek", StartMinute: 9 * 60, EndMinute: 17 * 60, Days: weekdays}
+		for _, s := range []*models.Shift{evening, morning, other} {
+			if err := repos.shifts.Create(s); err != nil {
+				t.Fatalf("Create(%s) error = %v", s.Name, err)
+			}
+		}
+
+		shifts, err := repos.shifts.FindByTeam(sales.ID)
+		if err != nil || len(shifts) != 2 || shifts[0].Name != "Sabah" || shifts[1].Days != weekdays {
+			t.Fatalf("FindByTeam() = %+v, %v; want Sabah then Akşam", shifts, err)
+		}
+
+		day := func(d int) time.Time { return time.Date(2026, time.March, d, 0, 0, 0, 0, time.UTC) }
+		assign := func(shift *models.Shift, user *models.User, from, to time.Time) *models.ShiftAssignment {
+			a := &models.ShiftAssignment{ShiftID: shift.ID, UserID: user.ID, StartDate: from, EndDate: to}
+			if err := repos.shifts.CreateAssignment(a); err != nil {
+				t.Fatalf("CreateAssignment() error = %v", err)
+			}
+			return a
+		}
+		first := assign(morning, ali, day(2), day(8))
+		second := assign(evening, ali, day(9), day(15))
+		third := assign(evening, ayse, day(2), day(31))
+
+		week, err := repos.shifts.FindAssignmentsByTeam(sales.ID, day(9), day(15))
+		if err != nil {
+			t.Fatalf("FindAssignmentsByTeam() error = %v", err)
+		}
+		if got := assignmentIDs(week); len(got) != 2 || got[0] != third.ID || got[1] != second.ID {
+			t.Fatalf("FindAssignmentsByTeam() = %v, want [%d %d]", got, third.ID, second.ID)
+		}
+		if week[1].Shift == nil || week[1].Shift.Name != "Akşam" || week[1].User == nil || week[1].User.Name != "Ali" {
+			t.Fatalf("FindAssignmentsByTeam()[1] = %+v, want Shift and User loaded", week[1])
+		}
+		if !week[1].StartDate.Equal(day(9)) || !week[1].EndDate.Equal(day(15)) {
+			t.Fatalf("assignment dates = %v - %v, want %v - %v", week[1].StartDate, week[1].EndDate, day(9), day(15))
+		}
+
+		// Aralığın uçları dahildir.
+		mine, err := repos.shifts.FindAssignmentsByUser(ali.ID, day(8), day(9))
+		if err != nil || len(mine) != 2 || mine[0].ID != first.ID {
+			t.Fatalf("FindAssignmentsByUser() = %v, %v; want both assignments", assignmentIDs(mine), err)
+		}
+
+		if err := repos.shifts.Update(evening.ID, map[string]interface{}{"name": "Gece", "end_minute": 60}); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		updated, err := repos.shifts.FindByID(evening.ID)
+		if err != nil || updated.Name != "Gece" || updated.EndMinute != 60 || !updated.IsOvernight() {
+			t.Fatalf("FindByID() after update = %+v, %v", updated, err)
+		}
+
+		if err := repos.shifts.Delete(evening.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if _, err := repos.shifts.FindAssignmentByID(second.ID); err == nil {
+			t.Fatal("assignment of deleted shift is still found")
+		}
+		rest, err := repos.shifts.FindAssignmentsByTeam(sales.ID, day(1), day(31))
+		if got := assignmentIDs(rest); err != nil || len(got) != 1 || got[0] != first.ID {
+			t.Fatalf("FindAssignmentsByTeam() after delete = %v, %v; want [%d]", got, err, first.ID)
+		}
+
+		if err := repos.shifts.DeleteAssignment(first.ID); err != nil {
+			t.Fatalf("DeleteAssignment() error = %v", err)
+		}
+		if err := repos.shifts.DeleteAssignment(first.ID); err == nil {
+			t.Fatal("DeleteAssignment() twice error = nil")
+		}
+	})
+}
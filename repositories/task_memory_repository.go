@@ -0,0 +1,264 @@
+package repositories
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"zatrano/models"
+	"zatrano/utils"
+
+	"gorm.io/gorm"
+)
+
+type MemoryTaskRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryTaskRepository(store *MemoryStore) ITaskRepository {
+	return &MemoryTaskRepository{store: store}
+}
+
+var taskFields = memoryFields[models.Task]{
+	"id":          func(t models.Task) interface{} { return int64(t.ID) },
+	"title":       func(t models.Task) interface{} { return t.Title },
+	"status":      func(t models.Task) interface{} { return string(t.Status) },
+	"priority":    func(t models.Task) interface{} { return string(t.Priority) },
+	"assignee_id": func(t models.Task) interface{} { return int64(t.AssigneeID) },
+	"due_date": func(t models.Task) interface{} {
+		if t.DueDate == nil {
+			return nil
+		}
+		return *t.DueDate
+	},
+	"created_at": func(t models.Task) interface{} { return t.CreatedAt },
+}
+
+func (r *MemoryTaskRepository) copyTask(t *models.Task, withUsers bool) models.Task {
+	out := *t
+	out.Assignee, out.CreatedBy = nil, nil
+	if withUsers {
+		out.Assignee = r.activeUser(t.AssigneeID)
+		out.CreatedBy = r.activeUser(t.CreatedByID)
+	}
+	return out
+}
+
+func (r *MemoryTaskRepository) activeUser(id uint) *models.User {
+	u, ok := r.store.users[id]
+	if !ok || isSoftDeleted(u.Model) {
+		return nil
+	}
+	user := r.store.copyUser(u, false)
+	return &user
+}
+
+func (r *MemoryTaskRepository) activeTask(id uint) (*models.Task, bool) {
+	t, ok := r.store.tasks[id]
+	if !ok || isSoftDeleted(t.Model) {
+		return nil, false
+	}
+	return t, true
+}
+
+func (r *MemoryTaskRepository) FindAndPaginate(teamID uint, today time.Time, params utils.ListParams) ([]models.Task, int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	overdue, filterOverdue := overdueFilter(params.Filters)
+	tasks := []models.Task{}
+	for _, t := range r.store.tasks {
+		if isSoftDeleted(t.Model) || t.TeamID != teamID {
+			continue
+		}
+		if params.Name != "" && !utils.MatchNormalized(t.Title, params.Name) {
+			continue
+		}
+		if filterOverdue && t.IsOverdue(today) != overdue {
+			continue
+		}
+		if !matchFilters(*t, params.Filters, taskFields) {
+			continue
+		}
+		tasks = append(tasks, r.copyTask(t, true))
+	}
+
+	totalCount := int64(len(tasks))
+	if totalCount == 0 {
+		return tasks, 0, nil
+	}
+
+	page := sortAndPage(tasks, params, taskFields, "id", func(t models.Task) uint { return t.ID })
+	return page, totalCount, nil
+}
+
+func (r *MemoryTaskRepository) FindByAssignee(assigneeID uint) ([]models.Task, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	tasks := []models.Task{}
+	for _, t := range r.store.tasks {
+		if !isSoftDeleted(t.Model) && t.AssigneeID == assigneeID && !t.IsDone() {
+			tasks = append(tasks, r.copyTask(t, false))
+		}
+	}
+	// taskDueOrderSQL ile aynı sıra: teslim günü olmayanlar en sonda.
+	slices.SortFunc(tasks, func(a, b models.Task) int {
+		switch {
+		case a.DueDate == nil && b.DueDate != nil:
+			return 1
+		case a.DueDate != nil && b.DueDate == nil:
+			return -1
+		case a.DueDate != nil && b.DueDate != nil:
+			if c := a.DueDate.Compare(*b.DueDate); c != 0 {
+				return c
+			}
+		}
+		return compareOrdered(a.ID, b.ID)
+	})
+	return tasks, nil
+}
+
+func (r *MemoryTaskRepository) FindByID(id uint) (*models.Task, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	t, ok := r.activeTask(id)
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	task := r.copyTask(t, true)
+	return &task, nil
+}
+
+func (r *MemoryTaskRepository) Create(task *models.Task) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	now := memoryNow()
+	task.ID = r.store.nextTaskID
+	task.CreatedAt = now
+	task.UpdatedAt = now
+	if task.Priority == "" {
+		task.Priority = models.TaskPriorityNormal
+	}
+	if task.Status == "" {
+		task.Status = models.TaskOpen
+	}
+	r.store.nextTaskID++
+
+	stored := *task
+	stored.Assignee, stored.CreatedBy = nil, nil
+	cloneTaskStrings(&stored)
+	r.store.tasks[task.ID] = &stored
+	return nil
+}
+
+func (r *MemoryTaskRepository) Update(id uint, data map[string]interface{}) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	t, ok := r.activeTask(id)
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	applyTaskUpdates(t, data)
+	t.UpdatedAt = memoryNow()
+	return nil
+}
+
+func (r *MemoryTaskRepository) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	t, ok := r.activeTask(id)
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	t.DeletedAt = gorm.DeletedAt{Time: memoryNow(), Valid: true}
+	return nil
+}
+
+func (r *MemoryTaskRepository) FindComments(taskID uint) ([]models.TaskComment, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	comments := []models.TaskComment{}
+	for _, c := range r.store.taskComments {
+		if c.TaskID != taskID {
+			continue
+		}
+		comment := *c
+		comment.Task = nil
+		comment.Author = r.activeUser(c.AuthorID)
+		comments = append(comments, comment)
+	}
+	slices.SortFunc(comments, func(a, b models.TaskComment) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return compareOrdered(a.ID, b.ID)
+	})
+	return comments, nil
+}
+
+func (r *MemoryTaskRepository) CreateComment(comment *models.TaskComment) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	comment.ID = r.store.nextTaskCommentID
+	if comment.CreatedAt.IsZero() {
+		comment.CreatedAt = memoryNow()
+	}
+	r.store.nextTaskCommentID++
+
+	stored := *comment
+	stored.Task, stored.Author = nil, nil
+	stored.Body = strings.Clone(comment.Body)
+	r.store.taskComments[comment.ID] = &stored
+	return nil
+}
+
+func cloneTaskStrings(t *models.Task) {
+	t.Title = strings.Clone(t.Title)
+	t.Description = strings.Clone(t.Description)
+	t.Priority = models.TaskPriority(strings.Clone(string(t.Priority)))
+	t.Status = models.TaskStatus(strings.Clone(string(t.Status)))
+}
+
+func applyTaskUpdates(t *models.Task, data map[string]interface{}) {
+	for key, value := range data {
+		switch key {
+		case "title":
+			t.Title, _ = value.(string)
+		case "description":
+			t.Description, _ = value.(string)
+		case "due_date":
+			t.DueDate = toTimePtr(value)
+		case "priority":
+			t.Priority, _ = value.(models.TaskPriority)
+		case "status":
+			t.Status, _ = value.(models.TaskStatus)
+		case "assignee_id":
+			t.AssigneeID, _ = value.(uint)
+		case "completed_at":
+			t.CompletedAt = toTimePtr(value)
+		}
+	}
+	cloneTaskStrings(t)
+}
+
+func toTimePtr(value interface{}) *time.Time {
+	switch v := value.(type) {
+	case time.Time:
+		return &v
+	case *time.Time:
+		if v != nil {
+			at := *v
+			return &at
+		}
+	}
+	return nil
+}
+
+var _ ITaskRepository = (*MemoryTaskRepository)(nil)
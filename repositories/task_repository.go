@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"time"
+
+	"zatrano/models"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const TaskOverdueFilter = "overdue"
+
+type ITaskRepository interface {
+	FindAndPaginate(teamID uint, today time.Time, params utils.ListParams) ([]models.Task, int64, error)
+	FindByAssignee(assigneeID uint) ([]models.Task, error)
+	FindByID(id uint) (*models.Task, error)
+	Create(task *models.Task) error
+	Update(id uint, data map[string]interface{}) error
+	Delete(id uint) error
+	FindComments(taskID uint) ([]models.TaskComment, error)
+	CreateComment(comment *models.TaskComment) error
+}
+
+var taskListColumns = listColumns{
+	"id":          "tasks.id",
+	"title":       "tasks.title",
+	"status":      "tasks.status",
+	"priority":    "tasks.priority",
+	"assignee_id": "tasks.assignee_id",
+	"due_date":    "tasks.due_date",
+	"created_at":  "tasks.created_at",
+}
+
+const taskDueOrderSQL = "tasks.due_date IS NULL, tasks.due_date ASC, tasks.id ASC"
+
+const taskOverdueSQL = "(tasks.due_date IS NOT NULL AND tasks.due_date < ? AND tasks.status <> ?)"
+
+type TaskRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskRepository(db *gorm.DB) ITaskRepository {
+	return &TaskRepository{db: db}
+}
+
+func overdueFilter(filters []utils.Filter) (overdue bool, ok bool) {
+	for _, f := range filters {
+		if f.Field == TaskOverdueFilter && f.Op == utils.OpEq {
+			overdue, ok = f.Value.(bool)
+		}
+	}
+	return overdue, ok
+}
+
+func (r *TaskRepository) FindAndPaginate(teamID uint, today time.Time, params utils.ListParams) ([]models.Task, int64, error) {
+	var tasks []models.Task
+	var totalCount int64
+
+	query := r.db.Model(&models.Task{}).Where("tasks.team_id = ?", teamID)
+
+	if params.Name != "" {
+		// Görev sayısı takım başına sınırlı olduğundan başlık için katlanmış
+		// sütun ve trigram index tutulmaz; search_fold() sorguda uygulanır.
+		sqlQueryFragment, queryParams := utils.SQLSearch(params.Name, "search_fold(tasks.title)")
+		query = query.Where(sqlQueryFragment, queryParams...)
+	}
+	if overdue, ok := overdueFilter(params.Filters); ok {
+		if overdue {
+			query = query.Where(taskOverdueSQL, today, models.TaskDone)
+		} else {
+			query = query.Where("NOT "+taskOverdueSQL, today, models.TaskDone)
+		}
+	}
+
+	query = applyFilters(query, params.Filters, taskListColumns)
+
+	err := query.Count(&totalCount).Error
+	if err != nil {
+		utils.Log.Error("Görev sayısı alınırken hata (FindAndPaginate)", zap.Error(err))
+		return nil, 0, err
+	}
+
+	if totalCount == 0 {
+		return tasks, 0, nil
+	}
+
+	query = applySort(query.Preload("Assignee"), params, taskListColumns, "id")
+
+	offset := params.CalculateOffset()
+	query = query.Limit(params.PerPage).Offset(offset)
+
+	err = query.Find(&tasks).Error
+	if err != nil {
+		utils.Log.Error("Görev verisi çekilirken hata (FindAndPaginate)", zap.Error(err))
+		return nil, totalCount, err
+	}
+
+	return tasks, totalCount, nil
+}
+
+func (r *TaskRepository) FindByAssignee(assigneeID uint) ([]models.Task, error) {
+	var tasks []models.Task
+	err := r.db.Where("assignee_id = ? AND status <> ?", assigneeID, models.TaskDone).
+		Order(taskDueOrderSQL).
+		Find(&tasks).Error
+	return tasks, err
+}
+
+func (r *TaskRepository) FindByID(id uint) (*models.Task, error) {
+	var task models.Task
+	if err := r.db.Preload("Assignee").Preload("CreatedBy").First(&task, id).Error; err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (r *TaskRepository) Create(task *models.Task) error {
+	return r.db.Omit(clause.Associations).Create(task).Error
+}
+
+func (r *TaskRepository) Update(id uint, data map[string]interface{}) error {
+	result := r.db.Model(&models.Task{}).Where("id = ?", id).Updates(data)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *TaskRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.Task{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *TaskRepository) FindComments(taskID uint) ([]models.TaskComment, error) {
+	var comments []models.TaskComment
+	err := r.db.Preload("Author").Where("task_id = ?", taskID).Order("created_at ASC, id ASC").Find(&comments).Error
+	return comments, err
+}
+
+func (r *TaskRepository) CreateComment(comment *models.TaskComment) error {
+	return r.db.Omit(clause.Associations).Create(comment).Error
+}
+
+var _ ITaskRepository = (*TaskRepository)(nil)
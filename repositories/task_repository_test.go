@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"zatrano/models"
+	"zatrano/utils"
+
+	"gorm.io/gorm"
+)
+
+func TestTaskRepositoryListAndComments(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repoSet) {
+		sales := mustCreateTeam(t, repos, "Satış", true)
+		support := mustCreateTeam(t, repos, "Destek", true)
+		manager := mustCreateUser(t, repos, models.User{Name: "Yönetici", Account: "manager@x", Type: models.Manager, TeamID: &sales.ID})
+		ali := mustCreateUser(t, repos, models.User{Name: "Ali", Account: "ali@x", Type: models.Agent, TeamID: &sales.ID})
+		can := mustCreateUser(t, repos, models.User{Name: "Can", Account: "can@x", Type: models.Agent, TeamID: &support.ID})
+
+		date := func(day int) *time.Time {
+			d := time.Date(2026, time.March, day, 0, 0, 0, 0, time.UTC)
+			return &d
+		}
+		create := func(assignee *models.User, title string, due *time.Time, status models.TaskStatus) *models.Task {
+			t.Helper()
+			task := &models.Task{TeamID: *assignee.TeamID, CreatedByID: manager.ID, AssigneeID: assignee.ID, Title: title,
+				DueDate: due, Priority: models.TaskPriorityNormal, Status: status}
+			if err := repos.tasks.Create(task); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			return task
+		}
+		late := create(ali, "Rapor hazırla", date(2), models.TaskOpen)
+		create(ali, "İade listesi", date(20), models.TaskInProgress)
+		done := create(ali, "Eğitim", date(1), models.TaskDone)
+		create(ali, "Toplantı notları", nil, models.TaskOpen)
+		create(can, "Başka takım", date(2), models.TaskOpen)
+
+		today := *date(10)
+		list := func(params utils.ListParams) []models.Task {
+			t.Helper()
+			params.PerPage = 10
+			tasks, total, err := repos.tasks.FindAndPaginate(sales.ID, today, params)
+			if err != nil || int(total) != len(tasks) {
+				t.Fatalf("FindAndPaginate(%+v) = %d/%d, %v", params, len(tasks), total, err)
+			}
+			return tasks
+		}
+		ids := func(tasks []models.Task) []uint {
+			out := make([]uint, len(tasks))
+			for i, task := range tasks {
+				out[i] = task.ID
+			}
+			return out
+		}
+
+		all := list(utils.ListParams{})
+		if len(all) != 4 || all[0].Assignee == nil || all[0].Assignee.ID != ali.ID {
+			t.Fatalf("team list = %+v, want 4 tasks with assignee", all)
+		}
+		overdue := list(utils.ListParams{Filters: []utils.Filter{{Field: TaskOverdueFilter, Op: utils.OpEq, Value: true}}})
+		if len(overdue) != 1 || overdue[0].ID != late.ID {
+			t.Fatalf("overdue = %v, want [%d]", ids(overdue), late.ID)
+		}
+		notOverdue := list(utils.ListParams{Filters: []utils.Filter{{Field: TaskOverdueFilter, Op: utils.OpEq, Value: false}}})
+		if len(notOverdue) != 3 {
+			t.Fatalf("not overdue = %v, want 3 tasks", ids(notOverdue))
+		}
+		open := list(utils.ListParams{Filters: []utils.Filter{{Field: "status", Op: utils.OpIn, Value: []interface{}{"open", "in_progress"}}}})
+		if len(open) != 3 {
+			t.Fatalf("open = %v, want 3 tasks", ids(open))
+		}
+		if found := list(utils.ListParams{Name: "iade"}); len(found) != 1 || found[0].Title != "İade listesi" {
+			t.Fatalf("search = %+v, want İade listesi", found)
+		}
+		var byDue []uint
+		for _, task := range list(utils.ListParams{Sort: []utils.SortField{{Field: "due_date"}}}) {
+			if task.DueDate != nil {
+				byDue = append(byDue, task.ID)
+			}
+		}
+		if len(byDue) != 3 || byDue[0] != done.ID || byDue[1] != late.ID {
+			t.Fatalf("sorted by due date = %v", byDue)
+		}
+
+		mine, err := repos.tasks.FindByAssignee(ali.ID)
+		if err != nil || len(mine) != 3 || mine[0].ID != late.ID || mine[2].DueDate != nil {
+			t.Fatalf("FindByAssignee() = %v, %v", ids(mine), err)
+		}
+
+		completedAt := today.Add(9 * time.Hour)
+		if err := repos.tasks.Update(late.ID, map[string]interface{}{"status": models.TaskDone, "completed_at": &completedAt, "due_date": nil}); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		got, err := repos.tasks.FindByID(late.ID)
+		if err != nil || !got.IsDone() || got.CompletedAt == nil || got.DueDate != nil || got.CreatedBy == nil || got.CreatedBy.ID != manager.ID {
+			t.Fatalf("FindByID() = %+v, %v", got, err)
+		}
+
+		for _, body := range []string{"Başladım", "Bitti"} {
+			author := ali
+			if body == "Bitti" {
+				author = manager
+			}
+			if err := repos.tasks.CreateComment(&models.TaskComment{TaskID: late.ID, AuthorID: author.ID, Body: body}); err != nil {
+				t.Fatalf("CreateComment() error = %v", err)
+			}
+		}
+		comments, err := repos.tasks.FindComments(late.ID)
+		if err != nil || len(comments) != 2 || comments[0].Body != "Başladım" || comments[1].Author == nil || comments[1].Author.ID != manager.ID {
+			t.Fatalf("FindComments() = %+v, %v", comments, err)
+		}
+
+		if err := repos.tasks.Delete(late.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if _, err := repos.tasks.FindByID(late.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Fatalf("FindByID(deleted) error = %v, want ErrRecordNotFound", err)
+		}
+		if err := repos.tasks.Update(late.ID, map[string]interface{}{"title": "x"}); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Fatalf("Update(deleted) error = %v, want ErrRecordNotFound", err)
+		}
+	})
+}
@@ -0,0 +1,149 @@
+package repositories
+
+import (
+	"zatrano/models"
+	"zatrano/utils"
+
+	"gorm.io/gorm"
+)
+
+type MemoryTeamRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryTeamRepository(store *MemoryStore) ITeamRepository {
+	return &MemoryTeamRepository{store: store}
+}
+
+var teamFields = memoryFields[models.Team]{
+	"id":           func(t models.Team) interface{} { return int64(t.ID) },
+	"name":         func(t models.Team) interface{} { return t.Name },
+	"status":       func(t models.Team) interface{} { return t.Status },
+	"created_at":   func(t models.Team) interface{} { return t.CreatedAt },
+	"member_count": func(t models.Team) interface{} { return t.MemberCount },
+}
+
+func (r *MemoryTeamRepository) activeTeams() []models.Team {
+	teams := []models.Team{}
+	for _, t := range r.store.teams {
+		if isSoftDeleted(t.Model) {
+			continue
+		}
+		team := *t
+		team.Agents = nil
+		teams = append(teams, team)
+	}
+	return teams
+}
+
+func (r *MemoryTeamRepository) FindAll() ([]models.Team, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	teams := r.activeTeams()
+	return sortAndPage(teams, utils.ListParams{OrderBy: "asc"}, teamFields, "id", func(t models.Team) uint { return t.ID }), nil
+}
+
+func (r *MemoryTeamRepository) FindAndPaginate(params utils.ListParams) ([]models.Team, int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	memberCounts := r.store.teamMemberCounts()
+	teams := []models.Team{}
+	for _, t := range r.activeTeams() {
+		if params.Name != "" && !utils.MatchNormalized(t.Name, params.Name) {
+			continue
+		}
+		t.MemberCount = memberCounts[t.ID]
+		if !matchFilters(t, params.Filters, teamFields) {
+			continue
+		}
+		teams = append(teams, t)
+	}
+
+	totalCount := int64(len(teams))
+	if totalCount == 0 {
+		return teams, 0, nil
+	}
+
+	page := sortAndPage(teams, params, teamFields, "id", func(t models.Team) uint { return t.ID })
+	return page, totalCount, nil
+}
+
+func (r *MemoryTeamRepository) FindByID(id uint) (*models.Team, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	t, ok := r.store.teams[id]
+	if !ok || isSoftDeleted(t.Model) {
+		return &models.Team{}, gorm.ErrRecordNotFound
+	}
+	team := *t
+	team.Agents = nil
+	return &team, nil
+}
+
+func (r *MemoryTeamRepository) Create(team *models.Team) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if !team.Status {
+		team.Status = true
+	}
+
+	now := memoryNow()
+	team.ID = r.store.nextTeamID
+	team.CreatedAt = now
+	team.UpdatedAt = now
+	r.store.nextTeamID++
+
+	stored := *team
+	stored.Agents = nil
+	r.store.teams[team.ID] = &stored
+	return nil
+}
+
+func (r *MemoryTeamRepository) Update(id uint, data map[string]interface{}) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	t, ok := r.store.teams[id]
+	if !ok || isSoftDeleted(t.Model) {
+		return nil
+	}
+	applyTeamUpdates(t, data)
+	t.UpdatedAt = memoryNow()
+	return nil
+}
+
+func (r *MemoryTeamRepository) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	t, ok := r.store.teams[id]
+	if !ok || isSoftDeleted(t.Model) {
+		return gorm.ErrRecordNotFound
+	}
+	t.DeletedAt = gorm.DeletedAt{Time: memoryNow(), Valid: true}
+	return nil
+}
+
+func (r *MemoryTeamRepository) Count() (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return int64(len(r.activeTeams())), nil
+}
+
+func (r *MemoryTeamRepository) CountByStatus() (map[bool]int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	counts := map[bool]int64{true: 0, false: 0}
+	for _, t := range r.activeTeams() {
+		counts[t.Status]++
+	}
+	return counts, nil
+}
+
+var _ ITeamRepository = (*MemoryTeamRepository)(nil)
@@ -1,8 +1,6 @@
 package repositories
 
 import (
-	"strings"
-	"zatrano/configs"
 	"zatrano/models"
 	"zatrano/utils"
 
@@ -18,14 +16,25 @@ type ITeamRepository interface {
 	Update(id uint, data map[string]interface{}) error
 	Delete(id uint) error
 	Count() (int64, error)
+	CountByStatus() (map[bool]int64, error)
+}
+
+const teamMemberCountSQL = "(SELECT COUNT(*) FROM users WHERE users.team_id = teams.id AND users.deleted_at IS NULL)"
+
+var teamListColumns = listColumns{
+	"id":           "teams.id",
+	"name":         "teams.name",
+	"status":       "teams.status",
+	"created_at":   "teams.created_at",
+	"member_count": teamMemberCountSQL,
 }
 
 type TeamRepository struct {
 	db *gorm.DB
 }
 
-func NewTeamRepository() ITeamRepository {
-	return &TeamRepository{db: configs.GetDB()}
+func NewTeamRepository(db *gorm.DB) ITeamRepository {
+	return &TeamRepository{db: db}
 }
 
 func (r *TeamRepository) FindAll() ([]models.Team, error) {
@@ -41,10 +50,12 @@ func (r *TeamRepository) FindAndPaginate(params utils.ListParams) ([]models.Team
 	query := r.db.Model(&models.Team{})
 
 	if params.Name != "" {
-		sqlQueryFragment, queryParams := utils.SQLFilter("name", params.Name)
+		sqlQueryFragment, queryParams := utils.SQLSearch(params.Name, "name_folded")
 		query = query.Where(sqlQueryFragment, queryParams...)
 	}
 
+	query = applyFilters(query, params.Filters, teamListColumns)
+
 	err := query.Count(&totalCount).Error
 	if err != nil {
 		utils.Log.Error("Takım sayısı alınırken hata (FindAndPaginate)", zap.Error(err))
@@ -55,18 +66,8 @@ func (r *TeamRepository) FindAndPaginate(params utils.ListParams) ([]models.Team
 		return teams, 0, nil
 	}
 
-	sortBy := params.SortBy
-	orderBy := strings.ToLower(params.OrderBy)
-	if orderBy != "asc" && orderBy != "desc" {
-		orderBy = utils.DefaultOrderBy
-	}
-
-	allowedSortColumns := map[string]bool{"id": true, "name": true, "status": true, "created_at": true}
-	if _, ok := allowedSortColumns[sortBy]; !ok {
-		sortBy = "id"
-	}
-	orderClause := sortBy + " " + orderBy
-	query = query.Order(orderClause)
+	query = query.Select("teams.*, " + teamMemberCountSQL + " AS member_count")
+	query = applySort(query, params, teamListColumns, "id")
 
 	offset := params.CalculateOffset()
 	query = query.Limit(params.PerPage).Offset(offset)
@@ -114,4 +115,23 @@ func (r *TeamRepository) Count() (int64, error) {
 	return count, err
 }
 
+func (r *TeamRepository) CountByStatus() (map[bool]int64, error) {
+	var rows []struct {
+		Status bool
+		Count  int64
+	}
+	err := r.db.Model(&models.Team{}).
+		Select("status, count(*) as count").
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	counts := map[bool]int64{true: 0, false: 0}
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
 var _ ITeamRepository = (*TeamRepository)(nil)
@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"testing"
+
+	"zatrano/models"
+	"zatrano/utils"
+
+	"gorm.io/gorm"
+)
+
+func teamNames(teams []models.Team) []string {
+	names := make([]string, len(teams))
+	for i, t := range teams {
+		names[i] = t.Name
+	}
+	return names
+}
+
+func TestTeamRepositoryFindAndPaginate(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repoSet) {
+		mustCreateTeam(t, repos, "Satış", true)
+		mustCreateTeam(t, repos, "Destek", false)
+		mustCreateTeam(t, repos, "Arşiv", true)
+
+		tests := []struct {
+			name      string
+			params    utils.ListParams
+			wantTotal int64
+			wantNames []string
+		}{
+			{
+				name:      "id descending",
+				params:    utils.ListParams{Page: 1, PerPage: 10, SortBy: "id", OrderBy: "desc"},
+				wantTotal: 3,
+				wantNames: []string{"Arşiv", "Destek", "Satış"},
+			},
+			{
+				name:      "status ascending puts passive teams first",
+				params:    utils.ListParams{Page: 1, PerPage: 1, SortBy: "status", OrderBy: "asc"},
+				wantTotal: 3,
+				wantNames: []string{"Destek"},
+			},
+			{
+				name:      "name filter",
+				params:    utils.ListParams{Page: 1, PerPage: 10, SortBy: "id", OrderBy: "asc", Name: "satis"},
+				wantTotal: 1,
+				wantNames: []string{"Satış"},
+			},
+			{
+				name:      "no match",
+				params:    utils.ListParams{Page: 1, PerPage: 10, SortBy: "id", OrderBy: "asc", Name: "yok"},
+				wantTotal: 0,
+				wantNames: []string{},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				teams, total, err := repos.teams.FindAndPaginate(tt.params)
+				if err != nil {
+					t.Fatalf("FindAndPaginate() error = %v", err)
+				}
+				if total != tt.wantTotal {
+					t.Errorf("total = %d, want %d", total, tt.wantTotal)
+				}
+				if got := teamNames(teams); !equalStrings(got, tt.wantNames) {
+					t.Errorf("names = %v, want %v", got, tt.wantNames)
+				}
+			})
+		}
+	})
+}
+
+func TestTeamRepositoryCRUD(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repoSet) {
+		team := mustCreateTeam(t, repos, "Satış", true)
+		mustCreateTeam(t, repos, "Destek", true)
+
+		if err := repos.teams.Update(team.ID, map[string]interface{}{"name": "Satış 2", "status": false}); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		got, err := repos.teams.FindByID(team.ID)
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		if got.Name != "Satış 2" || got.Status {
+			t.Fatalf("Update() not applied: %+v", got)
+		}
+
+		all, err := repos.teams.FindAll()
+		if err != nil || len(all) != 2 {
+			t.Fatalf("FindAll() = %d teams, %v; want 2", len(all), err)
+		}
+
+		if err := repos.teams.Delete(team.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if err := repos.teams.Delete(team.ID); err != gorm.ErrRecordNotFound {
+			t.Fatalf("second Delete() error = %v, want ErrRecordNotFound", err)
+		}
+		if _, err := repos.teams.FindByID(team.ID); err != gorm.ErrRecordNotFound {
+			t.Fatalf("FindByID() after delete error = %v, want ErrRecordNotFound", err)
+		}
+		if count, _ := repos.teams.Count(); count != 1 {
+			t.Fatalf("Count() = %d, want 1", count)
+		}
+	})
+}
@@ -0,0 +1,239 @@
+package repositories
+
+import (
+	"slices"
+
+	"zatrano/models"
+	"zatrano/utils"
+
+	"gorm.io/gorm"
+)
+
+type MemoryUserRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryUserRepository(store *MemoryStore) IUserRepository {
+	return &MemoryUserRepository{store: store}
+}
+
+var userFields = memoryFields[models.User]{
+	"id":      func(u models.User) interface{} { return int64(u.ID) },
+	"name":    func(u models.User) interface{} { return u.Name },
+	"account": func(u models.User) interface{} { return u.Account },
+	"team_id": func(u models.User) interface{} {
+		if u.TeamID == nil {
+			return nil
+		}
+		return int64(*u.TeamID)
+	},
+	"type":       func(u models.User) interface{} { return string(u.Type) },
+	"status":     func(u models.User) interface{} { return u.Status },
+	"created_at": func(u models.User) interface{} { return u.CreatedAt },
+}
+
+func (r *MemoryUserRepository) FindAndPaginate(params utils.ListParams) ([]models.User, int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	users := []models.User{}
+	for _, u := range r.store.users {
+		if isSoftDeleted(u.Model) || u.ID == 1 {
+			continue
+		}
+		if params.Name != "" && !utils.MatchNormalized(u.Name, params.Name) && !utils.MatchNormalized(u.Account, params.Name) {
+			continue
+		}
+		if !matchFilters(*u, params.Filters, userFields) {
+			continue
+		}
+		users = append(users, r.store.copyUser(u, true))
+	}
+
+	totalCount := int64(len(users))
+	if totalCount == 0 {
+		return users, 0, nil
+	}
+
+	page := sortAndPage(users, params, userFields, utils.DefaultSortBy, func(u models.User) uint { return u.ID })
+	return page, totalCount, nil
+}
+
+func (r *MemoryUserRepository) FindByCursor(params utils.ListParams, cursor utils.CursorParams) ([]models.User, utils.CursorMeta, error) {
+	k, err := newKeyset(params, cursor, userKeysetSortable, utils.DefaultSortBy, userFields)
+	if err != nil {
+		return nil, utils.CursorMeta{}, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	users := []models.User{}
+	for _, u := range r.store.users {
+		if isSoftDeleted(u.Model) || u.ID == 1 {
+			continue
+		}
+		if params.Name != "" && !utils.MatchNormalized(u.Name, params.Name) && !utils.MatchNormalized(u.Account, params.Name) {
+			continue
+		}
+		if !matchFilters(*u, params.Filters, userFields) {
+			continue
+		}
+		users = append(users, r.store.copyUser(u, true))
+	}
+	total := int64(len(users))
+
+	id := func(u models.User) uint { return u.ID }
+	page, meta := keysetPage(k, keysetSlice(users, k, userFields, id), userFields[k.field], id)
+	if !params.HasFilters() {
+		meta.EstimatedTotal = total
+	}
+	return page, meta, nil
+}
+
+func (r *MemoryUserRepository) FindByID(id uint) (*models.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	u, ok := r.store.users[id]
+	if !ok || isSoftDeleted(u.Model) {
+		return &models.User{}, gorm.ErrRecordNotFound
+	}
+	user := r.store.copyUser(u, true)
+	return &user, nil
+}
+
+func (r *MemoryUserRepository) Create(user *models.User) error {
+	if err := user.BeforeCreate(&gorm.DB{Statement: &gorm.Statement{Dest: user}}); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.store.accountTaken(user.Account, 0) {
+		return gorm.ErrDuplicatedKey
+	}
+
+	// GORM, default etiketi olan alanlarda sıfır değeri yazmaz; veritabanı
+	// varsayılanı (status=true) geçerli olur.
+	if !user.Status {
+		user.Status = true
+	}
+
+	now := memoryNow()
+	user.ID = r.store.nextUserID
+	user.CreatedAt = now
+	user.UpdatedAt = now
+	r.store.nextUserID++
+
+	stored := r.store.copyUser(user, false)
+	r.store.users[user.ID] = &stored
+	return nil
+}
+
+func (r *MemoryUserRepository) Update(id uint, data map[string]interface{}) error {
+	if err := (&models.User{}).BeforeUpdate(&gorm.DB{Statement: &gorm.Statement{Dest: data}}); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	u, ok := r.store.users[id]
+	if !ok || isSoftDeleted(u.Model) {
+		return nil
+	}
+	if account, ok := data["account"].(string); ok && r.store.accountTaken(account, id) {
+		return gorm.ErrDuplicatedKey
+	}
+
+	applyUserUpdates(u, data)
+	u.UpdatedAt = memoryNow()
+	return nil
+}
+
+func (r *MemoryUserRepository) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	u, ok := r.store.users[id]
+	if !ok || isSoftDeleted(u.Model) {
+		return gorm.ErrRecordNotFound
+	}
+	u.DeletedAt = gorm.DeletedAt{Time: memoryNow(), Valid: true}
+	return nil
+}
+
+func (r *MemoryUserRepository) Count() (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var count int64
+	for _, u := range r.store.users {
+		if !isSoftDeleted(u.Model) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *MemoryUserRepository) CountByTypeAndStatus() ([]UserTypeStatusCount, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	type key struct {
+		userType models.UserType
+		status   bool
+	}
+	counts := map[key]int64{}
+	for _, u := range r.store.users {
+		if !isSoftDeleted(u.Model) {
+			counts[key{u.Type, u.Status}]++
+		}
+	}
+	rows := make([]UserTypeStatusCount, 0, len(counts))
+	for k, count := range counts {
+		rows = append(rows, UserTypeStatusCount{Type: k.userType, Status: k.status, Count: count})
+	}
+	return rows, nil
+}
+
+func (r *MemoryUserRepository) FindAgentsByTeam(teamID uint) ([]models.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	users := []models.User{}
+	for _, u := range r.store.users {
+		if isSoftDeleted(u.Model) || u.Type != models.Agent || u.TeamID == nil || *u.TeamID != teamID {
+			continue
+		}
+		users = append(users, r.store.copyUser(u, false))
+	}
+	slices.SortFunc(users, func(a, b models.User) int {
+		if c := compareOrdered(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return compareOrdered(a.ID, b.ID)
+	})
+	return users, nil
+}
+
+func (r *MemoryUserRepository) CreateTeamChange(change *models.TeamMembershipChange) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	change.ID = r.store.nextTeamChangeID
+	if change.CreatedAt.IsZero() {
+		change.CreatedAt = memoryNow()
+	}
+	r.store.nextTeamChangeID++
+
+	stored := *change
+	stored.User, stored.FromTeam, stored.ToTeam = nil, nil, nil
+	stored.FromTeamID, stored.ToTeamID = toUintPtr(change.FromTeamID), toUintPtr(change.ToTeamID)
+	r.store.teamChanges[change.ID] = &stored
+	return nil
+}
+
+var _ IUserRepository = (*MemoryUserRepository)(nil)
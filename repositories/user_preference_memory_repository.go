@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+type MemoryUserPreferenceRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryUserPreferenceRepository(store *MemoryStore) IUserPreferenceRepository {
+	return &MemoryUserPreferenceRepository{store: store}
+}
+
+func (r *MemoryUserPreferenceRepository) FindByUserID(userID uint) (*models.UserPreference, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	p, ok := r.store.preferences[userID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	pref := *p
+	return &pref, nil
+}
+
+func (r *MemoryUserPreferenceRepository) Save(pref *models.UserPreference) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	now := memoryNow()
+	if existing, ok := r.store.preferences[pref.UserID]; ok {
+		pref.CreatedAt = existing.CreatedAt
+	} else {
+		pref.CreatedAt = now
+	}
+	pref.UpdatedAt = now
+
+	stored := *pref
+	stored.User = nil
+	r.store.preferences[pref.UserID] = &stored
+	return nil
+}
+
+var _ IUserPreferenceRepository = (*MemoryUserPreferenceRepository)(nil)
@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"zatrano/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IUserPreferenceRepository interface {
+	FindByUserID(userID uint) (*models.UserPreference, error)
+	Save(pref *models.UserPreference) error
+}
+
+type UserPreferenceRepository struct {
+	db *gorm.DB
+}
+
+func NewUserPreferenceRepository(db *gorm.DB) IUserPreferenceRepository {
+	return &UserPreferenceRepository{db: db}
+}
+
+func (r *UserPreferenceRepository) FindByUserID(userID uint) (*models.UserPreference, error) {
+	var pref models.UserPreference
+	if err := r.db.Where("user_id = ?", userID).First(&pref).Error; err != nil {
+		return nil, err
+	}
+	return &pref, nil
+}
+
+func (r *UserPreferenceRepository) Save(pref *models.UserPreference) error {
+	return r.db.Omit(clause.Associations).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"locale", "timezone", "date_format", "per_page", "updated_at"}),
+	}).Create(pref).Error
+}
+
+var _ IUserPreferenceRepository = (*UserPreferenceRepository)(nil)
@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"testing"
+
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+func TestUserPreferenceRepositorySave(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repoSet) {
+		user := mustCreateUser(t, repos, models.User{Name: "Sistem", Account: "system@x", Type: models.System})
+
+		if _, err := repos.prefs.FindByUserID(user.ID); err != gorm.ErrRecordNotFound {
+			t.Fatalf("FindByUserID() before save error = %v, want ErrRecordNotFound", err)
+		}
+
+		pref := &models.UserPreference{UserID: user.ID, Locale: "en", Timezone: "Europe/Istanbul", DateFormat: "2006-01-02", PerPage: 50}
+		if err := repos.prefs.Save(pref); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		// İkinci kayıt aynı satırı günceller.
+		update := &models.UserPreference{UserID: user.ID, Locale: "", Timezone: "UTC", DateFormat: "02.01.2006", PerPage: 10}
+		if err := repos.prefs.Save(update); err != nil {
+			t.Fatalf("Save() update error = %v", err)
+		}
+
+		got, err := repos.prefs.FindByUserID(user.ID)
+		if err != nil {
+			t.Fatalf("FindByUserID() error = %v", err)
+		}
+		if got.Locale != "" || got.Timezone != "UTC" || got.DateFormat != "02.01.2006" || got.PerPage != 10 {
+			t.Fatalf("FindByUserID() = %+v, want updated preference", got)
+		}
+	})
+}
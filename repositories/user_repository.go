@@ -1,8 +1,6 @@
 package repositories
 
 import (
-	"strings"
-	"zatrano/configs"
 	"zatrano/models"
 	"zatrano/utils"
 
@@ -13,19 +11,44 @@ import (
 
 type IUserRepository interface {
 	FindAndPaginate(params utils.ListParams) ([]models.User, int64, error)
+	FindByCursor(params utils.ListParams, cursor utils.CursorParams) ([]models.User, utils.CursorMeta, error)
 	FindByID(id uint) (*models.User, error)
 	Create(user *models.User) error
 	Update(id uint, data map[string]interface{}) error
 	Delete(id uint) error
 	Count() (int64, error)
+	CountByTypeAndStatus() ([]UserTypeStatusCount, error)
+	FindAgentsByTeam(teamID uint) ([]models.User, error)
+	CreateTeamChange(change *models.TeamMembershipChange) error
+}
+
+type UserTypeStatusCount struct {
+	Type   models.UserType
+	Status bool
+	Count  int64
+}
+
+var userListColumns = listColumns{
+	"id":         "id",
+	"name":       "name",
+	"account":    "account",
+	"team_id":    "team_id",
+	"type":       "type",
+	"status":     "status",
+	"created_at": "created_at",
+}
+
+func userKeysetSortable(field string) bool {
+	_, ok := userListColumns[field]
+	return ok && field != "team_id"
 }
 
 type UserRepository struct {
 	db *gorm.DB
 }
 
-func NewUserRepository() IUserRepository {
-	return &UserRepository{db: configs.GetDB()}
+func NewUserRepository(db *gorm.DB) IUserRepository {
+	return &UserRepository{db: db}
 }
 
 func (r *UserRepository) FindAndPaginate(params utils.ListParams) ([]models.User, int64, error) {
@@ -35,10 +58,12 @@ func (r *UserRepository) FindAndPaginate(params utils.ListParams) ([]models.User
 	query := r.db.Model(&models.User{}).Where("id != ?", 1)
 
 	if params.Name != "" {
-		sqlQueryFragment, queryParams := utils.SQLFilter("name", params.Name)
+		sqlQueryFragment, queryParams := utils.SQLSearch(params.Name, "name_folded", "account_folded")
 		query = query.Where(sqlQueryFragment, queryParams...)
 	}
 
+	query = applyFilters(query, params.Filters, userListColumns)
+
 	err := query.Count(&totalCount).Error
 	if err != nil {
 		utils.Log.Error("Kullanıcı sayısı alınırken hata (FindAndPaginate)", zap.Error(err))
@@ -49,17 +74,7 @@ func (r *UserRepository) FindAndPaginate(params utils.ListParams) ([]models.User
 		return users, 0, nil
 	}
 
-	sortBy := params.SortBy
-	orderBy := strings.ToLower(params.OrderBy)
-	if orderBy != "asc" && orderBy != "desc" {
-		orderBy = utils.DefaultOrderBy
-	}
-	allowedSortColumns := map[string]bool{"id": true, "name": true, "account": true, "team_id": true, "created_at": true, "status": true, "type": true}
-	if _, ok := allowedSortColumns[sortBy]; !ok {
-		sortBy = utils.DefaultSortBy
-	}
-	orderClause := sortBy + " " + orderBy
-	query = query.Order(orderClause)
+	query = applySort(query, params, userListColumns, utils.DefaultSortBy)
 
 	query = query.Preload(clause.Associations)
 
@@ -75,6 +90,38 @@ func (r *UserRepository) FindAndPaginate(params utils.ListParams) ([]models.User
 	return users, totalCount, nil
 }
 
+func (r *UserRepository) FindByCursor(params utils.ListParams, cursor utils.CursorParams) ([]models.User, utils.CursorMeta, error) {
+	k, err := newKeyset(params, cursor, userKeysetSortable, utils.DefaultSortBy, userFields)
+	if err != nil {
+		return nil, utils.CursorMeta{}, err
+	}
+
+	query := r.db.Model(&models.User{}).Where("id != ?", 1)
+	if params.Name != "" {
+		sqlQueryFragment, queryParams := utils.SQLSearch(params.Name, "name_folded", "account_folded")
+		query = query.Where(sqlQueryFragment, queryParams...)
+	}
+	query = applyFilters(query, params.Filters, userListColumns)
+
+	var users []models.User
+	err = applyKeyset(query, k, userListColumns).Preload(clause.Associations).Find(&users).Error
+	if err != nil {
+		utils.Log.Error("Kullanıcı verisi çekilirken hata (FindByCursor)", zap.Error(err))
+		return nil, utils.CursorMeta{}, err
+	}
+
+	users, meta := keysetPage(k, users, userFields[k.field], func(u models.User) uint { return u.ID })
+	if !params.HasFilters() {
+		total, err := estimateCount(r.db, &models.User{}, "users")
+		if err != nil {
+			utils.Log.Warn("Tahmini kullanıcı sayısı alınamadı (FindByCursor)", zap.Error(err))
+		} else {
+			meta.EstimatedTotal = total
+		}
+	}
+	return users, meta, nil
+}
+
 func (r *UserRepository) FindByID(id uint) (*models.User, error) {
 	var user models.User
 	err := r.db.Preload(clause.Associations).First(&user, id).Error
@@ -114,4 +161,23 @@ func (r *UserRepository) Count() (int64, error) {
 	return count, err
 }
 
+func (r *UserRepository) CountByTypeAndStatus() ([]UserTypeStatusCount, error) {
+	var rows []UserTypeStatusCount
+	err := r.db.Model(&models.User{}).
+		Select("type, status, count(*) as count").
+		Group("type, status").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *UserRepository) FindAgentsByTeam(teamID uint) ([]models.User, error) {
+	var users []models.User
+	err := r.db.Where("team_id = ? AND type = ?", teamID, models.Agent).Order("name ASC, id ASC").Find(&users).Error
+	return users, err
+}
+
+func (r *UserRepository) CreateTeamChange(change *models.TeamMembershipChange) error {
+	return r.db.Omit(clause.Associations).Create(change).Error
+}
+
 var _ IUserRepository = (*UserRepository)(nil)
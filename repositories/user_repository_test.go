@@ -0,0 +1,246 @@
+package repositories
+
+import (
+	"testing"
+
+	"zatrano/models"
+	"zatrano/utils"
+
+	"gorm.io/gorm"
+)
+
+func seedUsers(t *testing.T, repos repoSet) (*models.Team, []*models.User) {
+	t.Helper()
+	team := mustCreateTeam(t, repos, "Destek", true)
+	users := []*models.User{
+		mustCreateUser(t, repos, models.User{Name: "System", Account: "system@system", Type: models.System}),
+		mustCreateUser(t, repos, models.User{Name: "Zeynep", Account: "zeynep@x", Type: models.Manager, TeamID: &team.ID}),
+		mustCreateUser(t, repos, models.User{Name: "Ahmet", Account: "ahmet@x", Type: models.Agent, TeamID: &team.ID}),
+		mustCreateUser(t, repos, models.User{Name: "Çağla", Account: "cagla@x", Type: models.Agent, TeamID: &team.ID}),
+		mustCreateUser(t, repos, models.User{Name: "Mehmet", Account: "mehmet@x", Type: models.Agent, TeamID: &team.ID}),
+	}
+	return team, users
+}
+
+func userNames(users []models.User) []string {
+	names := make([]string, len(users))
+	for i, u := range users {
+		names[i] = u.Name
+	}
+	return names
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestUserRepositoryFindAndPaginate(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repoSet) {
+		seedUsers(t, repos)
+
+		tests := []struct {
+			name      string
+			params    utils.ListParams
+			wantTotal int64
+			wantNames []string
+		}{
+			{
+				name:      "default sort excludes system user",
+				params:    utils.ListParams{Page: 1, PerPage: 10, SortBy: "id", OrderBy: "desc"},
+				wantTotal: 4,
+				wantNames: []string{"Mehmet", "Çağla", "Ahmet", "Zeynep"},
+			},
+			{
+				name:      "name ascending second page",
+				params:    utils.ListParams{Page: 2, PerPage: 2, SortBy: "account", OrderBy: "asc"},
+				wantTotal: 4,
+				wantNames: []string{"Mehmet", "Zeynep"},
+			},
+			{
+				name:      "unknown sort column falls back to id",
+				params:    utils.ListParams{Page: 1, PerPage: 2, SortBy: "password", OrderBy: "asc"},
+				wantTotal: 4,
+				wantNames: []string{"Zeynep", "Ahmet"},
+			},
+			{
+				name:      "invalid order falls back to desc",
+				params:    utils.ListParams{Page: 1, PerPage: 1, SortBy: "id", OrderBy: "sideways"},
+				wantTotal: 4,
+				wantNames: []string{"Mehmet"},
+			},
+			{
+				name:      "page past the end",
+				params:    utils.ListParams{Page: 5, PerPage: 2, SortBy: "id", OrderBy: "asc"},
+				wantTotal: 4,
+				wantNames: []string{},
+			},
+			{
+				name:      "accent insensitive name filter",
+				params:    utils.ListParams{Page: 1, PerPage: 10, SortBy: "id", OrderBy: "asc", Name: "cag"},
+				wantTotal: 1,
+				wantNames: []string{"Çağla"},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				users, total, err := repos.users.FindAndPaginate(tt.params)
+				if err != nil {
+					t.Fatalf("FindAndPaginate() error = %v", err)
+				}
+				if total != tt.wantTotal {
+					t.Errorf("total = %d, want %d", total, tt.wantTotal)
+				}
+				if got := userNames(users); !equalStrings(got, tt.wantNames) {
+					t.Errorf("names = %v, want %v", got, tt.wantNames)
+				}
+			})
+		}
+	})
+}
+
+func TestUserRepositoryFindByIDPreloadsTeam(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repoSet) {
+		team, users := seedUsers(t, repos)
+
+		user, err := repos.users.FindByID(users[2].ID)
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		if user.Team == nil || user.Team.ID != team.ID {
+			t.Fatalf("Team = %+v, want team %d preloaded", user.Team, team.ID)
+		}
+
+		if _, err := repos.users.FindByID(999); err != gorm.ErrRecordNotFound {
+			t.Fatalf("FindByID(999) error = %v, want ErrRecordNotFound", err)
+		}
+	})
+}
+
+func TestUserRepositoryFindAgentsByTeam(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repoSet) {
+		sales := mustCreateTeam(t, repos, "Satış", true)
+		support := mustCreateTeam(t, repos, "Destek", true)
+		mustCreateUser(t, repos, models.User{Name: "Yönetici", Account: "manager@x", Type: models.Manager, TeamID: &sales.ID})
+		mustCreateUser(t, repos, models.User{Name: "Ayşe", Account: "ayse@x", Type: models.Agent, TeamID: &sales.ID})
+		mustCreateUser(t, repos, models.User{Name: "Ali", Account: "ali@x", Type: models.Agent, TeamID: &sales.ID})
+		mustCreateUser(t, repos, models.User{Name: "Can", Account: "can@x", Type: models.Agent, TeamID: &support.ID})
+
+		agents, err := repos.users.FindAgentsByTeam(sales.ID)
+		if err != nil || len(agents) != 2 || agents[0].Name != "Ali" || agents[1].Name != "Ayşe" {
+			t.Fatalf("FindAgentsByTeam() = %+v, %v; want Ali and Ayşe", agents, err)
+		}
+	})
+}
+
+func TestUserRepositoryCreateRunsModelHooks(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repoSet) {
+		err := repos.users.Create(&models.User{Name: "Ajan", Account: "ajan@x", Password: "secret", Type: models.Agent})
+		if err != models.ErrUserMissingTeam {
+			t.Fatalf("Create() error = %v, want %v", err, models.ErrUserMissingTeam)
+		}
+
+		user := &models.User{Name: "Sys", Account: "sys@x", Password: "secret", Type: models.System}
+		if err := repos.users.Create(user); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if user.ID == 0 || user.Password == "secret" {
+			t.Fatalf("Create() did not assign an ID or hash the password: %+v", user)
+		}
+		if err := repos.users.Create(&models.User{Name: "Dup", Account: "sys@x", Password: "secret", Type: models.System}); err == nil {
+			t.Fatal("Create() with duplicate account returned nil error")
+		}
+	})
+}
+
+func TestUserRepositoryUpdate(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repoSet) {
+		_, users := seedUsers(t, repos)
+		agent := users[2]
+
+		err := repos.users.Update(agent.ID, map[string]interface{}{"name": "Ahmet Yılmaz", "status": false})
+		if err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		updated, _ := repos.users.FindByID(agent.ID)
+		if updated.Name != "Ahmet Yılmaz" || updated.Status {
+			t.Fatalf("Update() not applied: name=%q status=%v", updated.Name, updated.Status)
+		}
+
+		err = repos.users.Update(agent.ID, map[string]interface{}{"type": "system", "team_id": agent.TeamID})
+		if err != models.ErrSystemUserHasTeam {
+			t.Fatalf("Update() error = %v, want %v", err, models.ErrSystemUserHasTeam)
+		}
+
+		if err := repos.users.Update(999, map[string]interface{}{"name": "Yok"}); err != nil {
+			t.Fatalf("Update() on missing ID error = %v, want nil", err)
+		}
+	})
+}
+
+func TestUserRepositorySoftDelete(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repoSet) {
+		_, users := seedUsers(t, repos)
+
+		if err := repos.users.Delete(users[3].ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if err := repos.users.Delete(users[3].ID); err != gorm.ErrRecordNotFound {
+			t.Fatalf("second Delete() error = %v, want ErrRecordNotFound", err)
+		}
+		if _, err := repos.users.FindByID(users[3].ID); err != gorm.ErrRecordNotFound {
+			t.Fatalf("FindByID() after delete error = %v, want ErrRecordNotFound", err)
+		}
+
+		count, err := repos.users.Count()
+		if err != nil || count != 4 {
+			t.Fatalf("Count() = %d, %v; want 4", count, err)
+		}
+		_, total, _ := repos.users.FindAndPaginate(utils.ListParams{Page: 1, PerPage: 10, SortBy: "id", OrderBy: "asc"})
+		if total != 3 {
+			t.Fatalf("FindAndPaginate() total after delete = %d, want 3", total)
+		}
+	})
+}
+
+func TestUserRepositoryTurkishNameSearch(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repoSet) {
+		team, _ := seedUsers(t, repos)
+		mustCreateUser(t, repos, models.User{Name: "IŞIK İNCE", Account: "isik@x", Type: models.Agent, TeamID: &team.ID})
+		mustCreateUser(t, repos, models.User{Name: "ılgaz İzmirli", Account: "ilgaz@x", Type: models.Agent, TeamID: &team.ID})
+
+		tests := []struct {
+			search    string
+			wantNames []string
+		}{
+			{search: "ışık", wantNames: []string{"IŞIK İNCE"}},
+			{search: "isik ince", wantNames: []string{"IŞIK İNCE"}},
+			{search: "ILGAZ", wantNames: []string{"ılgaz İzmirli"}},
+			{search: "İZMİR", wantNames: []string{"ılgaz İzmirli"}},
+			{search: "izmir", wantNames: []string{"ılgaz İzmirli"}},
+			{search: "I", wantNames: []string{"IŞIK İNCE", "ılgaz İzmirli"}},
+			{search: "%", wantNames: []string{}},
+			{search: "ahmet@", wantNames: []string{"Ahmet"}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.search, func(t *testing.T) {
+				params := utils.ListParams{Page: 1, PerPage: 10, SortBy: "id", OrderBy: "asc", Name: tt.search}
+				users, _, err := repos.users.FindAndPaginate(params)
+				if err != nil {
+					t.Fatalf("FindAndPaginate() error = %v", err)
+				}
+				if got := userNames(users); !equalStrings(got, tt.wantNames) {
+					t.Fatalf("names = %v, want %v", got, tt.wantNames)
+				}
+			})
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"zatrano/container"
 	handlers "zatrano/handlers/agent"
 	"zatrano/middlewares"
 	"zatrano/models"
@@ -8,13 +9,34 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func registerAgentRoutes(app *fiber.App) {
+func registerAgentRoutes(app *fiber.App, c *container.Container) {
 	agentGroup := app.Group("/agent")
 	agentGroup.Use(
-		middlewares.AuthMiddleware,
-		middlewares.StatusMiddleware,
-		middlewares.TypeMiddleware(models.Agent),
+		middlewares.AuthMiddleware(c.AuthService),
+		middlewares.StatusMiddleware(c.AuthService),
+		middlewares.TypeMiddleware(c.AuthService, models.Agent),
 	)
 
-	agentGroup.Get("/home", handlers.AgentHomeHandler)
+	homeHandler := handlers.NewHomeHandler(c.AnnouncementService, c.ShiftService, c.AttendanceService, c.TaskService)
+	agentGroup.Get("/home", homeHandler.HomePage)
+	agentGroup.Post("/announcements/:id/acknowledge", homeHandler.AcknowledgeAnnouncement)
+
+	attendanceHandler := handlers.NewAttendanceHandler(c.AttendanceService)
+	agentGroup.Post("/attendance/clock-in", attendanceHandler.ClockIn)
+	agentGroup.Post("/attendance/clock-out", attendanceHandler.ClockOut)
+	agentGroup.Post("/attendance/break/start", attendanceHandler.StartBreak)
+	agentGroup.Post("/attendance/break/end", attendanceHandler.EndBreak)
+
+	leaveHandler := handlers.NewLeaveHandler(c.LeaveService)
+	agentGroup.Get("/leaves", leaveHandler.ShowLeaves)
+	agentGroup.Post("/leaves", leaveHandler.RequestLeave)
+	agentGroup.Post("/leaves/:id/cancel", leaveHandler.CancelLeave)
+
+	taskHandler := handlers.NewTaskHandler(c.TaskService)
+	agentGroup.Get("/tasks/:id", taskHandler.ShowTask)
+	agentGroup.Post("/tasks/:id/status", taskHandler.ChangeStatus)
+	agentGroup.Post("/tasks/:id/comments", taskHandler.AddComment)
+
+	kpiHandler := handlers.NewKPIHandler(c.KPIService)
+	agentGroup.Get("/scorecard", kpiHandler.ShowScorecard)
 }
@@ -0,0 +1,80 @@
+package routes
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestAnnouncementsFlow(t *testing.T) {
+	env := newTestEnv(t)
+
+	manager := env.browser(t)
+	assertRedirect(t, manager.login("manager@x", testPassword), fiber.StatusFound, "/manager/home")
+
+	resp, body := manager.submit("/manager/announcements/create", "/manager/announcements/create", url.Values{"title": {""}, "body": {"x"}})
+	assertStatus(t, resp, fiber.StatusBadRequest)
+	if !strings.Contains(body, "duyuru başlığı boş olamaz") {
+		t.Fatal("validation error not rendered")
+	}
+	resp, _ = manager.submit("/manager/announcements/create", "/manager/announcements/create", url.Values{
+		"title": {"Vardiya değişikliği"}, "body": {"Yarın 9'da başlıyoruz."}, "priority": {"urgent"}, "expires_at": {"2999-01-01T09:00"},
+	})
+	assertRedirect(t, resp, fiber.StatusFound, "/manager/announcements")
+
+	system := env.browser(t)
+	assertRedirect(t, system.login("system@system", testPassword), fiber.StatusFound, "/dashboard/home")
+	resp, _ = system.submit("/dashboard/announcements/create", "/dashboard/announcements/create", url.Values{
+		"title": {"Bakım çalışması"}, "body": {"Sistem gece bakımda."}, "priority": {"normal"},
+	})
+	assertRedirect(t, resp, fiber.StatusFound, "/dashboard/announcements")
+
+	items, err := env.container.AnnouncementService.ListAnnouncements(context.Background(), env.manager)
+	if err != nil || len(items) != 1 {
+		t.Fatalf("ListAnnouncements(manager) = %d, %v; want the team announcement only", len(items), err)
+	}
+	id := strconv.Itoa(int(items[0].ID))
+
+	agent := env.browser(t)
+	assertRedirect(t, agent.login("agent@x", testPassword), fiber.StatusFound, "/agent/home")
+	resp, body = agent.get("/agent/home")
+	assertStatus(t, resp, fiber.StatusOK)
+	if !strings.Contains(body, "Vardiya değişikliği") || !strings.Contains(body, "Bakım çalışması") {
+		t.Fatal("agent home does not list the team and global announcements")
+	}
+
+	_, body = manager.get("/manager/announcements/" + id)
+	if !strings.Contains(body, "Temsilci") || strings.Contains(body, "Okunmadı") {
+		t.Fatal("manager status page does not show the agent as read")
+	}
+
+	resp, _ = agent.submit("/agent/home", "/agent/announcements/"+id+"/acknowledge", nil)
+	assertRedirect(t, resp, fiber.StatusFound, "/agent/home")
+	_, body = agent.get("/agent/home")
+	if strings.Contains(body, "Vardiya değişikliği") || !strings.Contains(body, "Duyuru onaylandı.") {
+		t.Fatal("acknowledged announcement is still listed")
+	}
+
+	_, body = manager.get("/manager/announcements/" + id)
+	if strings.Contains(body, "Bekliyor") {
+		t.Fatal("manager status page does not show the acknowledgement")
+	}
+
+	// Yönetici genel duyuruyu silemez; sistem kullanıcısı takım duyurusunu silebilir.
+	all, _ := env.container.AnnouncementService.ListAnnouncements(context.Background(), env.system)
+	for _, a := range all {
+		if a.IsGlobal() {
+			resp, _ = manager.submit("/manager/announcements", "/manager/announcements/delete/"+strconv.Itoa(int(a.ID)), nil)
+			assertRedirect(t, resp, fiber.StatusSeeOther, "/manager/announcements")
+		}
+	}
+	resp, _ = system.submit("/dashboard/announcements", "/dashboard/announcements/delete/"+id, nil)
+	assertRedirect(t, resp, fiber.StatusFound, "/dashboard/announcements")
+
+	resp, _ = agent.get("/manager/announcements")
+	assertStatus(t, resp, fiber.StatusForbidden)
+}
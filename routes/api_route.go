@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"zatrano/configs"
+	"zatrano/container"
+	handlers "zatrano/handlers/api"
+	"zatrano/middlewares"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupAPIRoutes, session ve CSRF yerine Bearer token ile korunan makine
+// uç noktalarını ekler; CSRF middleware'inden önce çağrılmalıdır. Token
+// tanımlı olmayan uç noktalar açılmaz.
+func SetupAPIRoutes(app *fiber.App, c *container.Container, kpi configs.KPIConfig) {
+	if kpi.IngestToken == "" {
+		utils.Log.Warn("KPI_INGEST_TOKEN tanımlı değil, /api/kpis/values devre dışı")
+		return
+	}
+	kpiHandler := handlers.NewKPIHandler(c.KPIService)
+	app.Post("/api/kpis/values", middlewares.BearerTokenMiddleware(kpi.IngestToken, "kpi"), kpiHandler.IngestValues)
+}
@@ -0,0 +1,78 @@
+package routes
+
+import (
+	"context"
+	"encoding/csv"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestAttendanceFlow(t *testing.T) {
+	env := newTestEnv(t)
+
+	agent := env.browser(t)
+	assertRedirect(t, agent.login("agent@x", testPassword), fiber.StatusFound, "/agent/home")
+	resp, _ := agent.submit("/agent/home", "/agent/attendance/clock-in", url.Values{})
+	assertRedirect(t, resp, fiber.StatusFound, "/agent/home")
+	resp, _ = agent.submit("/agent/home", "/agent/attendance/clock-in", url.Values{})
+	assertRedirect(t, resp, fiber.StatusSeeOther, "/agent/home")
+	_, body := agent.get("/agent/home")
+	if !strings.Contains(body, "zaten giriş yapmışsınız") || !strings.Contains(body, `action="/agent/attendance/clock-out"`) {
+		t.Fatal("agent home does not show the open session")
+	}
+
+	resp, _ = agent.submit("/agent/home", "/agent/attendance/break/start", url.Values{})
+	assertRedirect(t, resp, fiber.StatusFound, "/agent/home")
+	_, body = agent.get("/agent/home")
+	if !strings.Contains(body, "Molada") || !strings.Contains(body, `action="/agent/attendance/break/end"`) {
+		t.Fatal("agent home does not show the break")
+	}
+	resp, _ = agent.submit("/agent/home", "/agent/attendance/clock-out", url.Values{})
+	assertRedirect(t, resp, fiber.StatusFound, "/agent/home")
+	_, body = agent.get("/agent/home")
+	if !strings.Contains(body, `action="/agent/attendance/clock-in"`) {
+		t.Fatal("agent home does not offer clock-in after clock-out")
+	}
+
+	resp, _ = agent.get("/manager/attendance")
+	assertStatus(t, resp, fiber.StatusForbidden)
+
+	manager := env.browser(t)
+	assertRedirect(t, manager.login("manager@x", testPassword), fiber.StatusFound, "/manager/home")
+	resp, body = manager.get("/manager/attendance")
+	assertStatus(t, resp, fiber.StatusOK)
+	if !strings.Contains(body, "Temsilci") || !strings.Contains(body, "0:00") {
+		t.Fatal("daily report does not list the agent")
+	}
+	resp, body = manager.get("/manager/attendance?view=week")
+	assertStatus(t, resp, fiber.StatusOK)
+	if !strings.Contains(body, "Haftalık") || !strings.Contains(body, "Temsilci") {
+		t.Fatal("weekly report does not list the agent")
+	}
+
+	resp, body = manager.get("/manager/attendance/export?view=day")
+	assertStatus(t, resp, fiber.StatusOK)
+	if !strings.HasPrefix(resp.Header.Get(fiber.HeaderContentType), "text/csv") {
+		t.Fatalf("Content-Type = %q", resp.Header.Get(fiber.HeaderContentType))
+	}
+	rows, err := csv.NewReader(strings.NewReader(body)).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV %q: %v", body, err)
+	}
+	if len(rows) != 2 || rows[1][2] != env.agent.Account || rows[1][4] == "" || rows[1][5] == "" {
+		t.Fatalf("export rows = %v, want header and the agent's session", rows)
+	}
+
+	renamed := *env.agent
+	renamed.Name, renamed.Password = "@SUM(1+1)", ""
+	if err := env.container.UserService.UpdateUser(context.Background(), env.agent.ID, &renamed); err != nil {
+		t.Fatalf("UpdateUser() error = %v", err)
+	}
+	_, body = manager.get("/manager/attendance/export?view=day")
+	if rows, _ = csv.NewReader(strings.NewReader(body)).ReadAll(); len(rows) != 2 || rows[1][1] != "'@SUM(1+1)" {
+		t.Fatalf("export rows = %v, want the formula name escaped", rows)
+	}
+}
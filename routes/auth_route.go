@@ -1,21 +1,26 @@
 package routes
 
 import (
+	"zatrano/container"
 	handlers "zatrano/handlers/auth"
 	"zatrano/middlewares"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func registerAuthRoutes(app *fiber.App) {
-	authHandler := handlers.NewAuthHandler()
+func registerAuthRoutes(app *fiber.App, c *container.Container) {
+	authHandler := handlers.NewAuthHandler(c.AuthService, c.PreferenceService, c.ReportService)
+	guest := middlewares.GuestMiddleware(c.AuthService)
+	auth := middlewares.AuthMiddleware(c.AuthService)
 
 	authGroup := app.Group("/auth")
 
-	authGroup.Get("/login", middlewares.GuestMiddleware, authHandler.ShowLogin)
-	authGroup.Post("/login", middlewares.GuestMiddleware, authHandler.Login)
+	authGroup.Get("/login", guest, authHandler.ShowLogin)
+	authGroup.Post("/login", guest, authHandler.Login)
 
-	authGroup.Get("/logout", middlewares.AuthMiddleware, authHandler.Logout)
-	authGroup.Get("/profile", middlewares.AuthMiddleware, authHandler.Profile)
-	authGroup.Post("/profile/update-password", middlewares.AuthMiddleware, authHandler.UpdatePassword)
+	authGroup.Get("/logout", auth, authHandler.Logout)
+	authGroup.Get("/profile", auth, authHandler.Profile)
+	authGroup.Post("/profile/update-password", auth, authHandler.UpdatePassword)
+	authGroup.Post("/profile/preferences", auth, authHandler.UpdatePreferences)
+	authGroup.Post("/profile/reports", auth, authHandler.UpdateReportSubscriptions)
 }
@@ -0,0 +1,171 @@
+package routes
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"zatrano/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestLogin(t *testing.T) {
+	env := newTestEnv(t)
+	if err := env.container.UserService.UpdateUser(context.Background(), env.agent.ID, &models.User{
+		Name: env.agent.Name, Account: env.agent.Account, Type: env.agent.Type, TeamID: env.agent.TeamID, Status: false,
+	}); err != nil {
+		t.Fatalf("UpdateUser() error = %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		account      string
+		password     string
+		wantStatus   int
+		wantLocation string
+	}{
+		{name: "system user", account: "system@system", password: testPassword, wantStatus: fiber.StatusFound, wantLocation: "/dashboard/home"},
+		{name: "manager", account: "manager@x", password: testPassword, wantStatus: fiber.StatusFound, wantLocation: "/manager/home"},
+		{name: "wrong password", account: "system@system", password: "wrong", wantStatus: fiber.StatusSeeOther, wantLocation: "/auth/login"},
+		{name: "unknown account", account: "nobody@x", password: testPassword, wantStatus: fiber.StatusSeeOther, wantLocation: "/auth/login"},
+		{name: "inactive user", account: "agent@x", password: testPassword, wantStatus: fiber.StatusSeeOther, wantLocation: "/auth/login"},
+		{name: "empty fields", wantStatus: fiber.StatusSeeOther, wantLocation: "/auth/login"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := env.browser(t)
+			resp := b.login(tt.account, tt.password)
+			assertRedirect(t, resp, tt.wantStatus, tt.wantLocation)
+		})
+	}
+}
+
+func TestLoginShowsFlashError(t *testing.T) {
+	env := newTestEnv(t)
+	b := env.browser(t)
+
+	b.login("system@system", "wrong")
+	_, body := b.get("/auth/login")
+	if !strings.Contains(body, "Kullanıcı adı veya şifre hatalı.") {
+		t.Fatal("login page does not show the invalid credentials flash message")
+	}
+}
+
+func TestLoginRequiresCSRFToken(t *testing.T) {
+	env := newTestEnv(t)
+	b := env.browser(t)
+
+	b.get("/auth/login")
+	resp, _ := b.post("/auth/login", url.Values{"account": {"system@system"}, "password": {testPassword}})
+	if resp.Header.Get(fiber.HeaderLocation) == "/dashboard/home" {
+		t.Fatal("login without CSRF token succeeded")
+	}
+
+	resp, _ = b.get("/dashboard/home")
+	assertRedirect(t, resp, fiber.StatusFound, "/auth/login")
+}
+
+func TestGuestMiddlewareRedirectsLoggedInUser(t *testing.T) {
+	env := newTestEnv(t)
+	b := env.browser(t)
+	b.login("manager@x", testPassword)
+
+	resp, _ := b.get("/auth/login")
+	assertRedirect(t, resp, fiber.StatusFound, "/manager/home")
+}
+
+func TestLogout(t *testing.T) {
+	env := newTestEnv(t)
+	b := env.browser(t)
+	b.login("system@system", testPassword)
+
+	resp, _ := b.get("/auth/logout")
+	assertRedirect(t, resp, fiber.StatusFound, "/auth/login")
+
+	resp, _ = b.get("/dashboard/home")
+	assertRedirect(t, resp, fiber.StatusFound, "/auth/login")
+}
+
+func TestProfile(t *testing.T) {
+	env := newTestEnv(t)
+	b := env.browser(t)
+
+	resp, _ := b.get("/auth/profile")
+	assertRedirect(t, resp, fiber.StatusFound, "/auth/login")
+
+	b.login("manager@x", testPassword)
+	resp, body := b.get("/auth/profile")
+	assertStatus(t, resp, fiber.StatusOK)
+	if !strings.Contains(body, `action="/auth/profile/update-password"`) {
+		t.Fatal("profile page does not render the password form")
+	}
+}
+
+func TestUpdatePassword(t *testing.T) {
+	tests := []struct {
+		name         string
+		current      string
+		newPassword  string
+		confirm      string
+		wantLocation string
+		wantChanged  bool
+	}{
+		{name: "success logs the user out", current: testPassword, newPassword: "yeni-sifre", confirm: "yeni-sifre", wantLocation: "/auth/login", wantChanged: true},
+		{name: "confirmation mismatch", current: testPassword, newPassword: "yeni-sifre", confirm: "baska", wantLocation: "/auth/profile"},
+		{name: "wrong current password", current: "wrong", newPassword: "yeni-sifre", confirm: "yeni-sifre", wantLocation: "/auth/profile"},
+		{name: "too short", current: testPassword, newPassword: "abc", confirm: "abc", wantLocation: "/auth/profile"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			b := env.browser(t)
+			b.login("agent@x", testPassword)
+
+			resp, _ := b.submit("/auth/profile", "/auth/profile/update-password", url.Values{
+				"current_password": {tt.current},
+				"new_password":     {tt.newPassword},
+				"confirm_password": {tt.confirm},
+			})
+			if got := resp.Header.Get(fiber.HeaderLocation); got != tt.wantLocation {
+				t.Fatalf("Location = %q, want %q", got, tt.wantLocation)
+			}
+
+			_, err := env.container.AuthService.Authenticate(context.Background(), "agent@x", tt.newPassword)
+			if changed := err == nil; changed != tt.wantChanged {
+				t.Fatalf("password changed = %v, want %v", changed, tt.wantChanged)
+			}
+		})
+	}
+}
+
+func TestCookieFlags(t *testing.T) {
+	env := newTestEnv(t)
+	b := env.browser(t)
+
+	_, page := b.get("/auth/login")
+	match := csrfTokenPattern.FindStringSubmatch(page)
+	if match == nil {
+		t.Fatal("login page has no CSRF token")
+	}
+	resp, _ := b.post("/auth/login", url.Values{"account": {"system@system"}, "password": {testPassword}, "csrf_token": {match[1]}})
+	assertRedirect(t, resp, fiber.StatusFound, "/dashboard/home")
+
+	if resp.Header.Get(fiber.HeaderXFrameOptions) != "DENY" || resp.Header.Get(fiber.HeaderContentSecurityPolicy) == "" {
+		t.Fatal("security headers missing on login response")
+	}
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name != "session_id" {
+			continue
+		}
+		if !cookie.HttpOnly || cookie.SameSite != http.SameSiteLaxMode {
+			t.Fatalf("session cookie flags = HttpOnly:%v SameSite:%v", cookie.HttpOnly, cookie.SameSite)
+		}
+		return
+	}
+	t.Fatal("login response did not set the session cookie")
+}
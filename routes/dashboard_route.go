@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"zatrano/container"
 	handlers "zatrano/handlers/dashboard"
 	"zatrano/middlewares"
 	"zatrano/models"
@@ -8,18 +9,22 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func registerDashboardRoutes(app *fiber.App) {
+func registerDashboardRoutes(app *fiber.App, c *container.Container) {
 	dashboardGroup := app.Group("/dashboard")
 	dashboardGroup.Use(
-		middlewares.AuthMiddleware,
-		middlewares.StatusMiddleware,
-		middlewares.TypeMiddleware(models.System),
+		middlewares.AuthMiddleware(c.AuthService),
+		middlewares.StatusMiddleware(c.AuthService),
+		middlewares.TypeMiddleware(c.AuthService, models.System),
 	)
 
-	homeHandler := handlers.NewHomeHandler()
+	homeHandler := handlers.NewHomeHandler(c.ReportingService)
 	dashboardGroup.Get("/home", homeHandler.HomePage)
+	dashboardGroup.Get("/api/analytics", homeHandler.Analytics)
 
-	teamHandler := handlers.NewTeamHandler()
+	searchHandler := handlers.NewSearchHandler(c.SearchService)
+	dashboardGroup.Get("/search", searchHandler.Search)
+
+	teamHandler := handlers.NewTeamHandler(c.TeamService)
 	dashboardGroup.Get("/teams", teamHandler.ListTeams)
 	dashboardGroup.Get("/teams/create", teamHandler.ShowCreateTeam)
 	dashboardGroup.Post("/teams/create", teamHandler.CreateTeam)
@@ -28,12 +33,46 @@ func registerDashboardRoutes(app *fiber.App) {
 	dashboardGroup.Post("/teams/delete/:id", teamHandler.DeleteTeam)
 	dashboardGroup.Delete("/teams/delete/:id", teamHandler.DeleteTeam)
 
-	userHandler := handlers.NewUserHandler()
+	userHandler := handlers.NewUserHandler(c.UserService, c.TeamService)
 	dashboardGroup.Get("/users", userHandler.ListUsers)
+	dashboardGroup.Get("/users/export", userHandler.ExportUsers)
+	dashboardGroup.Get("/api/users", userHandler.ListUsersAPI)
 	dashboardGroup.Get("/users/create", userHandler.ShowCreateUser)
 	dashboardGroup.Post("/users/create", userHandler.CreateUser)
 	dashboardGroup.Get("/users/update/:id", userHandler.ShowUpdateUser)
 	dashboardGroup.Post("/users/update/:id", userHandler.UpdateUser)
 	dashboardGroup.Post("/users/delete/:id", userHandler.DeleteUser)
 	dashboardGroup.Delete("/users/delete/:id", userHandler.DeleteUser)
+
+	announcementHandler := handlers.NewAnnouncementHandler(c.AnnouncementService)
+	dashboardGroup.Get("/announcements", announcementHandler.ListAnnouncements)
+	dashboardGroup.Get("/announcements/create", announcementHandler.ShowCreateAnnouncement)
+	dashboardGroup.Post("/announcements/create", announcementHandler.CreateAnnouncement)
+	dashboardGroup.Get("/announcements/:id", announcementHandler.ShowAnnouncement)
+	dashboardGroup.Post("/announcements/delete/:id", announcementHandler.DeleteAnnouncement)
+
+	leaveBalanceHandler := handlers.NewLeaveBalanceHandler(c.LeaveService)
+	dashboardGroup.Get("/leave-balances", leaveBalanceHandler.ListBalances)
+	dashboardGroup.Post("/leave-balances/:id", leaveBalanceHandler.UpdateBalance)
+
+	kpiHandler := handlers.NewKPIHandler(c.KPIService)
+	dashboardGroup.Get("/kpis", kpiHandler.ListDefinitions)
+	dashboardGroup.Get("/kpis/create", kpiHandler.ShowCreateDefinition)
+	dashboardGroup.Post("/kpis/create", kpiHandler.CreateDefinition)
+	dashboardGroup.Get("/kpis/update/:id", kpiHandler.ShowUpdateDefinition)
+	dashboardGroup.Post("/kpis/update/:id", kpiHandler.UpdateDefinition)
+	dashboardGroup.Post("/kpis/import", kpiHandler.ImportValues)
+
+	reportHandler := handlers.NewReportHandler(c.ReportService)
+	dashboardGroup.Get("/reports", reportHandler.ListSchedules)
+	dashboardGroup.Get("/reports/create", reportHandler.ShowCreateSchedule)
+	dashboardGroup.Post("/reports/create", reportHandler.CreateSchedule)
+	dashboardGroup.Get("/reports/update/:id", reportHandler.ShowUpdateSchedule)
+	dashboardGroup.Post("/reports/update/:id", reportHandler.UpdateSchedule)
+	dashboardGroup.Post("/reports/delete/:id", reportHandler.DeleteSchedule)
+	dashboardGroup.Get("/reports/preview/:template", reportHandler.PreviewReport)
+
+	jobHandler := handlers.NewJobHandler(c.JobService, c.JobRegistry)
+	dashboardGroup.Get("/jobs", jobHandler.ListJobs)
+	dashboardGroup.Post("/jobs/retry/:id", jobHandler.RetryJob)
 }
@@ -0,0 +1,357 @@
+package routes
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"zatrano/models"
+	"zatrano/services"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func loggedInAsSystem(t *testing.T) (*testEnv, *browser) {
+	t.Helper()
+	env := newTestEnv(t)
+	b := env.browser(t)
+	resp := b.login("system@system", testPassword)
+	assertRedirect(t, resp, fiber.StatusFound, "/dashboard/home")
+	return env, b
+}
+
+func TestDashboardHome(t *testing.T) {
+	_, b := loggedInAsSystem(t)
+
+	resp, body := b.get("/dashboard/home")
+	assertStatus(t, resp, fiber.StatusOK)
+	if !strings.Contains(body, "Başarıyla giriş yapıldı.") {
+		t.Fatal("dashboard home does not show the login flash message")
+	}
+	if !strings.Contains(body, "<h3>2</h3>") || !strings.Contains(body, "system@system") || !strings.Contains(body, `data-url="/dashboard/api/analytics"`) {
+		t.Fatal("dashboard home does not show the overview")
+	}
+}
+
+func TestDashboardAnalyticsAPI(t *testing.T) {
+	env, b := loggedInAsSystem(t)
+	assertStatus(t, env.browser(t).login("agent@x", "wrong-password"), fiber.StatusSeeOther)
+
+	resp, body := b.get("/dashboard/api/analytics")
+	assertStatus(t, resp, fiber.StatusOK)
+	var data struct {
+		Users struct {
+			Total  int64 `json:"total"`
+			ByType []struct {
+				Type   string `json:"type"`
+				Active int64  `json:"active"`
+			} `json:"by_type"`
+		} `json:"users"`
+		Teams struct {
+			Sizes []struct {
+				Name     string `json:"name"`
+				Managers int64  `json:"managers"`
+				Agents   int64  `json:"agents"`
+			} `json:"sizes"`
+		} `json:"teams"`
+		NewUsers struct {
+			Counts []int64 `json:"counts"`
+		} `json:"new_users"`
+		Logins struct {
+			Dates     []string `json:"dates"`
+			Succeeded []int64  `json:"succeeded"`
+			Failed    []int64  `json:"failed"`
+		} `json:"logins"`
+		FailedAccounts []struct {
+			Account string `json:"account"`
+			Count   int64  `json:"count"`
+		} `json:"failed_accounts"`
+	}
+	if err := json.Unmarshal([]byte(body), &data); err != nil {
+		t.Fatalf("invalid JSON %q: %v", body, err)
+	}
+	if data.Users.Total != 2 || len(data.Users.ByType) != 2 || data.Users.ByType[0].Type != "manager" {
+		t.Fatalf("users = %+v; the system user must not be counted", data.Users)
+	}
+	if len(data.Teams.Sizes) != 1 || data.Teams.Sizes[0].Name != env.team.Name || data.Teams.Sizes[0].Managers != 1 || data.Teams.Sizes[0].Agents != 1 {
+		t.Fatalf("teams = %+v", data.Teams)
+	}
+	if weeks := len(data.NewUsers.Counts); weeks != services.ReportingWeeks || data.NewUsers.Counts[weeks-1] != 2 {
+		t.Fatalf("new users = %+v", data.NewUsers)
+	}
+	last := len(data.Logins.Dates) - 1
+	if last != services.ReportingLoginDays-1 || data.Logins.Succeeded[last] != 1 || data.Logins.Failed[last] != 1 {
+		t.Fatalf("logins = %+v", data.Logins)
+	}
+	if len(data.FailedAccounts) != 1 || data.FailedAccounts[0].Account != "agent@x" {
+		t.Fatalf("failed accounts = %+v", data.FailedAccounts)
+	}
+
+	agent := env.browser(t)
+	assertRedirect(t, agent.login("agent@x", testPassword), fiber.StatusFound, "/agent/home")
+	resp, _ = agent.get("/dashboard/api/analytics")
+	assertStatus(t, resp, fiber.StatusForbidden)
+}
+
+func TestDashboardTeamCRUD(t *testing.T) {
+	env, b := loggedInAsSystem(t)
+
+	resp, _ := b.submit("/dashboard/teams/create", "/dashboard/teams/create", url.Values{"name": {"Satış"}, "status": {"true"}})
+	assertRedirect(t, resp, fiber.StatusFound, "/dashboard/teams")
+
+	resp, body := b.get("/dashboard/teams?name=sat")
+	assertStatus(t, resp, fiber.StatusOK)
+	if !strings.Contains(body, "Satış") || strings.Contains(body, ">Destek<") {
+		t.Fatal("filtered team list does not contain only the new team")
+	}
+
+	teams, _ := env.container.TeamService.GetAllTeams(context.Background())
+	var created *models.Team
+	for i := range teams {
+		if teams[i].Name == "Satış" {
+			created = &teams[i]
+		}
+	}
+	if created == nil {
+		t.Fatal("created team not found in the service")
+	}
+	id := strconv.Itoa(int(created.ID))
+
+	resp, _ = b.submit("/dashboard/teams/update/"+id, "/dashboard/teams/update/"+id, url.Values{"name": {"Satış Ekibi"}, "status": {"false"}})
+	assertRedirect(t, resp, fiber.StatusFound, "/dashboard/teams")
+	updated, _ := env.container.TeamService.GetTeamByID(context.Background(), created.ID)
+	if updated.Name != "Satış Ekibi" || updated.Status {
+		t.Fatalf("team not updated: %+v", updated)
+	}
+
+	resp, body = b.submit("/dashboard/teams/update/"+id, "/dashboard/teams/update/"+id, url.Values{"name": {""}})
+	assertStatus(t, resp, fiber.StatusBadRequest)
+	if !strings.Contains(body, "Takım adı boş olamaz.") {
+		t.Fatal("validation error not rendered")
+	}
+
+	resp, _ = b.submit("/dashboard/teams", "/dashboard/teams/delete/"+id, nil)
+	assertRedirect(t, resp, fiber.StatusFound, "/dashboard/teams")
+	if _, err := env.container.TeamService.GetTeamByID(context.Background(), created.ID); err != services.ErrTeamNotFound {
+		t.Fatalf("GetTeamByID() after delete error = %v, want %v", err, services.ErrTeamNotFound)
+	}
+
+	resp, _ = b.submit("/dashboard/teams", "/dashboard/teams/delete/"+id, nil)
+	assertRedirect(t, resp, fiber.StatusSeeOther, "/dashboard/teams")
+}
+
+func TestDashboardUserCRUD(t *testing.T) {
+	env, b := loggedInAsSystem(t)
+	teamID := strconv.Itoa(int(env.team.ID))
+
+	resp, body := b.submit("/dashboard/users/create", "/dashboard/users/create", url.Values{
+		"name": {"Yeni Ajan"}, "account": {"yeni@x"}, "password": {"sifre123"}, "type": {"agent"}, "status": {"true"},
+	})
+	assertStatus(t, resp, fiber.StatusInternalServerError)
+	if !strings.Contains(body, models.ErrUserMissingTeam.Error()) {
+		t.Fatal("model validation error not rendered on the create form")
+	}
+
+	resp, _ = b.submit("/dashboard/users/create", "/dashboard/users/create", url.Values{
+		"name": {"Yeni Ajan"}, "account": {"yeni@x"}, "password": {"sifre123"}, "type": {"agent"}, "status": {"true"}, "team_id": {teamID},
+	})
+	assertRedirect(t, resp, fiber.StatusFound, "/dashboard/users")
+
+	created, err := env.container.AuthService.Authenticate(context.Background(), "yeni@x", "sifre123")
+	if err != nil {
+		t.Fatalf("created user cannot log in: %v", err)
+	}
+	id := strconv.Itoa(int(created.ID))
+
+	resp, body = b.get("/dashboard/users")
+	assertStatus(t, resp, fiber.StatusOK)
+	if !strings.Contains(body, "yeni@x") {
+		t.Fatal("user list does not contain the new user")
+	}
+
+	resp, _ = b.submit("/dashboard/users/update/"+id, "/dashboard/users/update/"+id, url.Values{
+		"name": {"Yeni Yönetici"}, "account": {"yeni@x"}, "type": {"manager"}, "status": {"true"}, "team_id": {teamID},
+	})
+	assertRedirect(t, resp, fiber.StatusFound, "/dashboard/users")
+	updated, _ := env.container.UserService.GetUserByID(context.Background(), created.ID)
+	if updated.Name != "Yeni Yönetici" || updated.Type != models.Manager {
+		t.Fatalf("user not updated: %+v", updated)
+	}
+
+	resp, body = b.submit("/dashboard/users/update/"+id, "/dashboard/users/update/"+id, url.Values{
+		"name": {"Yeni Yönetici"}, "account": {"yeni@x"}, "type": {"system"}, "status": {"true"}, "team_id": {teamID},
+	})
+	assertStatus(t, resp, fiber.StatusBadRequest)
+	if !strings.Contains(body, models.ErrSystemUserHasTeam.Error()) {
+		t.Fatal("model validation error not rendered on the update form")
+	}
+
+	resp, _ = b.submit("/dashboard/users", "/dashboard/users/delete/"+id, nil)
+	assertRedirect(t, resp, fiber.StatusFound, "/dashboard/users")
+	if _, err := env.container.UserService.GetUserByID(context.Background(), created.ID); err != services.ErrUserServiceUserNotFound {
+		t.Fatalf("GetUserByID() after delete error = %v, want %v", err, services.ErrUserServiceUserNotFound)
+	}
+}
+
+func TestDashboardRejectsInvalidIDs(t *testing.T) {
+	_, b := loggedInAsSystem(t)
+
+	tests := []struct {
+		path         string
+		wantLocation string
+	}{
+		{path: "/dashboard/users/update/abc", wantLocation: "/dashboard/users"},
+		{path: "/dashboard/users/update/999", wantLocation: "/dashboard/users"},
+		{path: "/dashboard/teams/update/0", wantLocation: "/dashboard/teams"},
+		{path: "/dashboard/teams/update/999", wantLocation: "/dashboard/teams"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			resp, _ := b.get(tt.path)
+			assertRedirect(t, resp, fiber.StatusSeeOther, tt.wantLocation)
+		})
+	}
+}
+
+func TestDashboardListFilters(t *testing.T) {
+	env, b := loggedInAsSystem(t)
+
+	resp, body := b.get("/dashboard/users?filter.type.eq=manager&sort=-created_at,name")
+	assertStatus(t, resp, fiber.StatusOK)
+	if !strings.Contains(body, "<td>"+env.manager.Account+"</td>") || strings.Contains(body, "<td>"+env.agent.Account+"</td>") {
+		t.Fatal("user list is not filtered by type")
+	}
+	if !strings.Contains(body, `<option value="manager" selected>`) {
+		t.Fatal("type filter control does not show the applied value")
+	}
+	// Sıralama bağlantıları filtreyi korur ve tıklanan sütunu birincil yapar.
+	if !strings.Contains(body, `href="?filter.type.eq=manager&amp;page=1&amp;perPage=20&amp;sort=account%2C-created_at%2Cname"`) {
+		t.Fatal("sort link does not keep the filter and previous sort columns")
+	}
+
+	// Geçersiz filtreler yok sayılır; liste yine açılır.
+	resp, body = b.get("/dashboard/users?filter.password.eq=x&sort=password")
+	assertStatus(t, resp, fiber.StatusOK)
+	if !strings.Contains(body, "<td>"+env.agent.Account+"</td>") {
+		t.Fatal("invalid filters hide users")
+	}
+
+	resp, body = b.get("/dashboard/teams?filter.member_count.gte=2")
+	assertStatus(t, resp, fiber.StatusOK)
+	if !strings.Contains(body, "<td>"+env.team.Name+"</td>") || !strings.Contains(body, "<td>2</td>") {
+		t.Fatal("team list does not show the member count filter result")
+	}
+	_, body = b.get("/dashboard/teams?filter.member_count.gte=3")
+	if strings.Contains(body, "<td>"+env.team.Name+"</td>") {
+		t.Fatal("team list ignores the member count filter")
+	}
+}
+
+func TestDashboardUsersAPICursorPagination(t *testing.T) {
+	env, b := loggedInAsSystem(t)
+
+	type page struct {
+		Data []struct {
+			Account  string `json:"account"`
+			TeamName string `json:"team_name"`
+		} `json:"data"`
+		Meta struct {
+			NextCursor string `json:"next_cursor"`
+			PrevCursor string `json:"prev_cursor"`
+		} `json:"meta"`
+	}
+	get := func(query string) (*http.Response, page) {
+		t.Helper()
+		resp, body := b.get("/dashboard/api/users?" + query)
+		var p page
+		if resp.StatusCode == fiber.StatusOK {
+			if err := json.Unmarshal([]byte(body), &p); err != nil {
+				t.Fatalf("invalid JSON %q: %v", body, err)
+			}
+			if strings.Contains(body, "password") || strings.Contains(body, "Password") {
+				t.Fatal("API response exposes password hashes")
+			}
+		}
+		return resp, p
+	}
+
+	resp, first := get("sort=account&limit=1")
+	assertStatus(t, resp, fiber.StatusOK)
+	if len(first.Data) != 1 || first.Data[0].Account != env.agent.Account || first.Data[0].TeamName != env.team.Name || first.Meta.NextCursor == "" {
+		t.Fatalf("first page = %+v", first)
+	}
+	resp, second := get("sort=account&limit=1&cursor=" + url.QueryEscape(first.Meta.NextCursor))
+	assertStatus(t, resp, fiber.StatusOK)
+	if len(second.Data) != 1 || second.Data[0].Account != env.manager.Account || second.Meta.NextCursor != "" || second.Meta.PrevCursor == "" {
+		t.Fatalf("second page = %+v", second)
+	}
+
+	// İmleç, üretildiği sıralama dışında kullanılamaz.
+	resp, _ = get("sort=-account&cursor=" + url.QueryEscape(first.Meta.NextCursor))
+	assertStatus(t, resp, fiber.StatusBadRequest)
+	forged := utils.EncodeCursor(utils.Cursor{Field: "created_at", Desc: true, Value: "x", ID: 1})
+	resp, _ = get("sort=-created_at&cursor=" + url.QueryEscape(forged))
+	assertStatus(t, resp, fiber.StatusBadRequest)
+}
+
+func TestDashboardUsersExport(t *testing.T) {
+	env, b := loggedInAsSystem(t)
+
+	resp, body := b.get("/dashboard/users/export?filter.type.eq=agent")
+	assertStatus(t, resp, fiber.StatusOK)
+	if !strings.HasPrefix(resp.Header.Get(fiber.HeaderContentType), "text/csv") {
+		t.Fatalf("Content-Type = %q", resp.Header.Get(fiber.HeaderContentType))
+	}
+	rows, err := csv.NewReader(strings.NewReader(body)).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV %q: %v", body, err)
+	}
+	if len(rows) != 2 || rows[1][2] != env.agent.Account || rows[1][4] != env.team.Name {
+		t.Fatalf("export rows = %v, want header and the agent", rows)
+	}
+}
+
+func TestDashboardUsersExportEscapesFormulas(t *testing.T) {
+	env, b := loggedInAsSystem(t)
+	env.mustCreateUser(t, models.User{Name: `=HYPERLINK("http://x")`, Account: "formula@x", Type: models.Agent, TeamID: &env.team.ID})
+
+	_, body := b.get("/dashboard/users/export?filter.type.eq=agent&sort=-id")
+	rows, err := csv.NewReader(strings.NewReader(body)).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV %q: %v", body, err)
+	}
+	if len(rows) != 3 || rows[1][2] != "formula@x" || rows[1][1] != `'=HYPERLINK("http://x")` {
+		t.Fatalf("export rows = %v, want the formula name escaped", rows)
+	}
+}
+
+func TestDashboardGlobalSearch(t *testing.T) {
+	env, b := loggedInAsSystem(t)
+
+	_, body := b.get("/dashboard/home")
+	if !strings.Contains(body, `action="/dashboard/search"`) || strings.Contains(body, "no value") {
+		t.Fatal("dashboard layout has no empty global search box")
+	}
+
+	// "Temsilci" hesabı yazım hatasıyla da bulunur; takım ve kullanıcı birlikte listelenir.
+	resp, body := b.get("/dashboard/search?q=temsilc")
+	assertStatus(t, resp, fiber.StatusOK)
+	if !strings.Contains(body, `href="/dashboard/users/update/`+strconv.FormatUint(uint64(env.agent.ID), 10)+`"`) {
+		t.Fatal("search does not find the agent by a partial name")
+	}
+	_, body = b.get("/dashboard/search?q=destk")
+	if !strings.Contains(body, `href="/dashboard/teams/update/`+strconv.FormatUint(uint64(env.team.ID), 10)+`"`) {
+		t.Fatal("search does not tolerate typos in team names")
+	}
+
+	_, body = b.get("/dashboard/search?q=x")
+	if strings.Contains(body, "list-group-item") {
+		t.Fatal("search runs for too short queries")
+	}
+}
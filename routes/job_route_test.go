@@ -0,0 +1,68 @@
+package routes
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"zatrano/models"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestJobFlow(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	jobs := env.container.JobService
+
+	failing := true
+	env.container.JobRegistry.Register("test.bulk_hash", 1, func(context.Context, []byte) error {
+		if failing {
+			return errors.New("bcrypt zaman aşımı")
+		}
+		return nil
+	})
+	if err := jobs.Enqueue(ctx, "test.bulk_hash", map[string]int{"count": 3}); err != nil {
+		t.Fatal(err)
+	}
+	if ran, err := jobs.RunNext(ctx, "test-worker"); !ran || err != nil {
+		t.Fatalf("RunNext() = %v, %v", ran, err)
+	}
+	result, _, _ := env.container.JobRepository.FindAndPaginate(utils.ListParams{Page: 1, PerPage: 10})
+	if len(result) != 1 || result[0].Status != models.JobDead {
+		t.Fatalf("jobs = %+v; want one dead job", result)
+	}
+	id := strconv.FormatUint(uint64(result[0].ID), 10)
+
+	admin := env.browser(t)
+	assertRedirect(t, admin.login("system@system", testPassword), fiber.StatusFound, "/dashboard/home")
+	resp, body := admin.get("/dashboard/jobs?filter.status.eq=dead")
+	assertStatus(t, resp, fiber.StatusOK)
+	if !strings.Contains(body, "test.bulk_hash") || !strings.Contains(body, "bcrypt zaman aşımı") || !strings.Contains(body, `action="/dashboard/jobs/retry/`+id+`"`) {
+		t.Fatal("jobs page does not list the dead job with a retry button")
+	}
+	_, body = admin.get("/dashboard/jobs?filter.status.eq=succeeded")
+	if strings.Contains(body, "bcrypt zaman aşımı") || !strings.Contains(body, `<option value="test.bulk_hash"`) {
+		t.Fatal("status filter is ignored or the type filter does not offer registered types")
+	}
+
+	resp, _ = admin.submit("/dashboard/jobs?filter.status.eq=dead", "/dashboard/jobs/retry/"+id, nil)
+	assertRedirect(t, resp, fiber.StatusFound, "/dashboard/jobs")
+	failing = false
+	if ran, _ := jobs.RunNext(ctx, "test-worker"); !ran {
+		t.Fatal("retried job was not queued")
+	}
+	if job, _ := env.container.JobRepository.FindByID(result[0].ID); job.Status != models.JobSucceeded {
+		t.Fatalf("retried job = %+v", job)
+	}
+	resp, _ = admin.submit("/dashboard/jobs", "/dashboard/jobs/retry/"+id, nil)
+	assertRedirect(t, resp, fiber.StatusSeeOther, "/dashboard/jobs")
+
+	manager := env.browser(t)
+	manager.login("manager@x", testPassword)
+	resp, _ = manager.get("/dashboard/jobs")
+	assertStatus(t, resp, fiber.StatusForbidden)
+}
@@ -0,0 +1,132 @@
+package routes
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"zatrano/configs"
+	"zatrano/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func (b *browser) upload(pagePath, actionPath, field, filename, content string) string {
+	b.t.Helper()
+	_, page := b.get(pagePath)
+	match := csrfTokenPattern.FindStringSubmatch(page)
+	if match == nil {
+		b.t.Fatalf("%s sayfasında CSRF token bulunamadı", pagePath)
+	}
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	_ = writer.WriteField("csrf_token", match[1])
+	part, _ := writer.CreateFormFile(field, filename)
+	_, _ = part.Write([]byte(content))
+	_ = writer.Close()
+
+	req := httptest.NewRequest(fiber.MethodPost, actionPath, &body)
+	req.Header.Set(fiber.HeaderContentType, writer.FormDataContentType())
+	resp, respBody := b.do(req)
+	if resp.StatusCode == fiber.StatusFound || resp.StatusCode == fiber.StatusSeeOther {
+		_, respBody = b.get(resp.Header.Get(fiber.HeaderLocation))
+	}
+	return respBody
+}
+
+func TestKPIFlow(t *testing.T) {
+	env := newTestEnv(t)
+
+	admin := env.browser(t)
+	assertRedirect(t, admin.login("system@system", testPassword), fiber.StatusFound, "/dashboard/home")
+	resp, _ := admin.submit("/dashboard/kpis/create", "/dashboard/kpis/create", url.Values{
+		"key":              {"avg_handle_time"},
+		"name":             {"Ortalama görüşme süresi"},
+		"unit":             {"seconds"},
+		"aggregation":      {"avg"},
+		"higher_is_better": {"false"},
+		"status":           {"false", "true"},
+	})
+	assertRedirect(t, resp, fiber.StatusFound, "/dashboard/kpis")
+	definition, err := env.container.KPIRepository.FindDefinitionByKey("avg_handle_time")
+	if err != nil || definition.HigherIsBetter || !definition.Status {
+		t.Fatalf("FindDefinitionByKey() = %+v, %v", definition, err)
+	}
+
+	body := admin.upload("/dashboard/kpis", "/dashboard/kpis/import", "file", "aht.csv",
+		"metric,account,date,value\navg_handle_time,agent@x,2026-03-02,240\navg_handle_time,nobody@x,2026-03-02,10\n")
+	if !strings.Contains(body, "nobody@x") {
+		t.Fatal("import page does not list the invalid row")
+	}
+	body = admin.upload("/dashboard/kpis", "/dashboard/kpis/import", "file", "aht.csv",
+		"metric,account,date,value\navg_handle_time,agent@x,2026-10-12,240\navg_handle_time,agent@x,2026-10-13,300\n")
+	if strings.Contains(body, "nobody@x") {
+		t.Fatal("valid import was rejected")
+	}
+
+	manager := env.browser(t)
+	assertRedirect(t, manager.login("manager@x", testPassword), fiber.StatusFound, "/manager/home")
+	resp, body = manager.get("/manager/scorecard?weeks=26")
+	assertStatus(t, resp, fiber.StatusOK)
+	if !strings.Contains(body, "Temsilci") || !strings.Contains(body, "Ortalama görüşme süresi") {
+		t.Fatal("manager scorecard does not show the agent and the KPI")
+	}
+	resp, _ = manager.get("/manager/scorecard?kpi=999")
+	assertRedirect(t, resp, fiber.StatusSeeOther, "/manager/scorecard")
+
+	agent := env.browser(t)
+	assertRedirect(t, agent.login("agent@x", testPassword), fiber.StatusFound, "/agent/home")
+	resp, _ = agent.get("/agent/scorecard")
+	assertStatus(t, resp, fiber.StatusOK)
+	resp, _ = agent.get("/manager/scorecard")
+	assertStatus(t, resp, fiber.StatusForbidden)
+	resp, _ = agent.get("/dashboard/kpis")
+	assertStatus(t, resp, fiber.StatusForbidden)
+}
+
+func TestKPIIngestAPI(t *testing.T) {
+	env := newTestEnv(t)
+	const token = "0123456789abcdef-kpi"
+	app := fiber.New()
+	SetupAPIRoutes(app, env.container, configs.KPIConfig{IngestToken: token})
+	calls := &models.KPIDefinition{Key: "calls_handled", Name: "Karşılanan çağrı", Unit: models.KPIUnitCount, Aggregation: models.KPIAggregationSum, HigherIsBetter: true, Status: true}
+	if err := env.container.KPIService.CreateDefinition(context.Background(), calls); err != nil {
+		t.Fatalf("CreateDefinition() error = %v", err)
+	}
+
+	post := func(auth, payload string) int {
+		req := httptest.NewRequest(fiber.MethodPost, "/api/kpis/values", strings.NewReader(payload))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		if auth != "" {
+			req.Header.Set(fiber.HeaderAuthorization, auth)
+		}
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.StatusCode
+	}
+
+	valid := `{"values":[{"metric":"calls_handled","account":"agent@x","date":"2026-03-02","value":42}]}`
+	if status := post("", valid); status != fiber.StatusUnauthorized {
+		t.Fatalf("no token status = %d", status)
+	}
+	if status := post("Bearer wrong-token-value", valid); status != fiber.StatusUnauthorized {
+		t.Fatalf("wrong token status = %d", status)
+	}
+	if status := post("Bearer "+token, `{"values":[{"metric":"calls_handled","account":"agent@x","date":"2026-03-02"}]}`); status != fiber.StatusUnprocessableEntity {
+		t.Fatalf("missing value status = %d", status)
+	}
+	if status := post("Bearer "+token, valid); status != fiber.StatusOK {
+		t.Fatalf("valid upload status = %d", status)
+	}
+	day := time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC)
+	if values, _ := env.container.KPIRepository.FindValues([]uint{calls.ID}, day, day); len(values) != 1 || values[0].Value != 42 {
+		t.Fatalf("FindValues() = %+v", values)
+	}
+}
@@ -0,0 +1,69 @@
+package routes
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestLeaveRequestFlow(t *testing.T) {
+	env := newTestEnv(t)
+
+	// Gelecek yılın mart ayındaki ilk pazartesiden başlayan üç iş günü.
+	start := time.Date(time.Now().Year()+1, time.March, 1, 0, 0, 0, 0, time.UTC)
+	for start.Weekday() != time.Monday {
+		start = start.AddDate(0, 0, 1)
+	}
+	end := start.AddDate(0, 0, 2)
+
+	agent := env.browser(t)
+	assertRedirect(t, agent.login("agent@x", testPassword), fiber.StatusFound, "/agent/home")
+	resp, _ := agent.submit("/agent/leaves", "/agent/leaves", url.Values{
+		"type":       {"annual"},
+		"start_date": {start.Format("2006-01-02")},
+		"end_date":   {end.Format("2006-01-02")},
+		"reason":     {"Tatil"},
+	})
+	assertRedirect(t, resp, fiber.StatusFound, "/agent/leaves")
+	_, body := agent.get("/agent/leaves?year=" + start.Format("2006"))
+	if !strings.Contains(body, "Onay bekliyor") || !strings.Contains(body, "Tatil") {
+		t.Fatal("agent page does not list the pending request")
+	}
+
+	resp, _ = agent.get("/manager/leaves")
+	assertStatus(t, resp, fiber.StatusForbidden)
+
+	manager := env.browser(t)
+	assertRedirect(t, manager.login("manager@x", testPassword), fiber.StatusFound, "/manager/home")
+	month := start.Format("2006-01")
+	resp, body = manager.get("/manager/leaves?month=" + month)
+	assertStatus(t, resp, fiber.StatusOK)
+	if !strings.Contains(body, "Temsilci") || !strings.Contains(body, "/approve") {
+		t.Fatal("manager calendar does not list the pending request")
+	}
+
+	leaves, err := env.container.LeaveRepository.FindByUser(env.agent.ID)
+	if err != nil || len(leaves) != 1 {
+		t.Fatalf("FindByUser = %v, %v", leaves, err)
+	}
+	approve := "/manager/leaves/" + strconv.Itoa(int(leaves[0].ID)) + "/approve"
+	resp, _ = manager.submit("/manager/leaves?month="+month, approve, url.Values{"month": {month}})
+	assertRedirect(t, resp, fiber.StatusFound, "/manager/leaves?month="+month)
+
+	_, body = agent.get("/agent/leaves?year=" + start.Format("2006"))
+	if !strings.Contains(body, "Onaylandı") {
+		t.Fatal("agent page does not show the approved request")
+	}
+
+	system := env.browser(t)
+	assertRedirect(t, system.login("system@system", testPassword), fiber.StatusFound, "/dashboard/home")
+	resp, body = system.get("/dashboard/leave-balances?year=" + start.Format("2006"))
+	assertStatus(t, resp, fiber.StatusOK)
+	if !strings.Contains(body, "Temsilci") || !strings.Contains(body, "/dashboard/leave-balances/"+strconv.Itoa(int(env.agent.ID))) {
+		t.Fatal("balances page does not list the agent")
+	}
+}
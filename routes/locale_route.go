@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"zatrano/container"
+	handlers "zatrano/handlers/locale"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func registerLocaleRoutes(app *fiber.App, c *container.Container) {
+	localeHandler := handlers.NewLocaleHandler(c.PreferenceService)
+
+	// Dil seçimi tercihleri kalıcı olarak değiştirdiğinden CSRF korumalı
+	// bir POST formuyla yapılır.
+	app.Post("/locale/:locale", localeHandler.SetLocale)
+}
@@ -0,0 +1,130 @@
+package routes
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestLocaleFromAcceptLanguage(t *testing.T) {
+	env := newTestEnv(t)
+	b := env.browser(t)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/auth/login", nil)
+	req.Header.Set(fiber.HeaderAcceptLanguage, "en-GB,en;q=0.9,tr;q=0.8")
+	resp, body := b.do(req)
+	assertStatus(t, resp, fiber.StatusOK)
+	if got := resp.Header.Get(fiber.HeaderContentLanguage); got != "en" {
+		t.Fatalf("Content-Language = %q, want en", got)
+	}
+	if !strings.Contains(body, `<html lang="en">`) || !strings.Contains(body, "Sign In") {
+		t.Fatal("login page is not rendered in English")
+	}
+}
+
+func switchLocale(b *browser, pagePath, locale, referer string) *http.Response {
+	b.t.Helper()
+	_, page := b.get(pagePath)
+	match := csrfTokenPattern.FindStringSubmatch(page)
+	if match == nil {
+		b.t.Fatalf("%s sayfasında CSRF token bulunamadı", pagePath)
+	}
+	form := url.Values{"csrf_token": {match[1]}}
+	req := httptest.NewRequest(fiber.MethodPost, "/locale/"+locale, strings.NewReader(form.Encode()))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationForm)
+	req.Header.Set(fiber.HeaderReferer, referer)
+	resp, _ := b.do(req)
+	return resp
+}
+
+func TestLocaleSwitcherTranslatesFlashMessages(t *testing.T) {
+	env := newTestEnv(t)
+	b := env.browser(t)
+
+	_, body := b.get("/auth/login")
+	if !strings.Contains(body, `action="/locale/en"`) {
+		t.Fatal("login page has no language switch form")
+	}
+	resp := switchLocale(b, "/auth/login", "en", "http://example.com/auth/login")
+	assertRedirect(t, resp, fiber.StatusSeeOther, "/auth/login")
+	if b.cookies[utils.LocaleCookieName] != "en" {
+		t.Fatalf("%s cookie = %q, want en", utils.LocaleCookieName, b.cookies[utils.LocaleCookieName])
+	}
+
+	// Flash mesajı ID olarak saklanır ve okunduğu istekte çevrilir.
+	b.login("system@system", "wrong")
+	_, body = b.get("/auth/login")
+	if !strings.Contains(body, "Incorrect username or password.") {
+		t.Fatal("invalid credentials flash message is not translated to English")
+	}
+
+	b.cookies[utils.LocaleCookieName] = "tr"
+	b.login("system@system", "wrong")
+	_, body = b.get("/auth/login")
+	if !strings.Contains(body, "Kullanıcı adı veya şifre hatalı.") {
+		t.Fatal("invalid credentials flash message is not rendered in Turkish")
+	}
+}
+
+func TestLocaleSwitcherRejectsForeignReferer(t *testing.T) {
+	env := newTestEnv(t)
+	b := env.browser(t)
+
+	for _, referer := range []string{"https://evil.example/phish", "http://example.com//evil.example"} {
+		resp := switchLocale(b, "/auth/login", "en", referer)
+		assertRedirect(t, resp, fiber.StatusSeeOther, "/")
+	}
+
+	resp := switchLocale(b, "/auth/login", "xx", "")
+	assertRedirect(t, resp, fiber.StatusSeeOther, "/")
+	if b.cookies[utils.LocaleCookieName] != "en" {
+		t.Fatal("unsupported locale overwrote the language cookie")
+	}
+}
+
+func TestLocaleSwitcherRequiresPostWithCSRF(t *testing.T) {
+	env := newTestEnv(t)
+	b := env.browser(t)
+	b.login("system@system", testPassword)
+
+	b.get("/locale/en")
+	resp, _ := b.post("/locale/en", url.Values{})
+	assertRedirect(t, resp, fiber.StatusFound, "/auth/login")
+
+	if _, ok := b.cookies[utils.LocaleCookieName]; ok {
+		t.Fatal("language cookie was set without a CSRF protected form")
+	}
+	pref, err := env.container.PreferenceService.GetPreferences(context.Background(), env.system.ID)
+	if err != nil {
+		t.Fatalf("GetPreferences() error = %v", err)
+	}
+	if pref.Locale == "en" {
+		t.Fatal("stored locale changed without a CSRF protected form")
+	}
+}
+
+func TestDashboardRendersInEnglish(t *testing.T) {
+	_, b := loggedInAsSystem(t)
+	b.cookies[utils.LocaleCookieName] = "en"
+
+	for path, want := range map[string]string{
+		"/dashboard/users":        "Showing 1 - 2 of 2 records.",
+		"/dashboard/teams":        "Filter by Team Name",
+		"/dashboard/users/create": "Select User Type",
+		"/dashboard/teams/create": "Add New Team",
+		"/dashboard/home":         "User Management",
+	} {
+		resp, body := b.get(path)
+		assertStatus(t, resp, fiber.StatusOK)
+		if !strings.Contains(body, want) {
+			t.Errorf("%s does not contain %q", path, want)
+		}
+	}
+}
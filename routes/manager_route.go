@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"zatrano/container"
 	handlers "zatrano/handlers/manager"
 	"zatrano/middlewares"
 	"zatrano/models"
@@ -8,13 +9,54 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func registerManagerRoutes(app *fiber.App) {
+func registerManagerRoutes(app *fiber.App, c *container.Container) {
 	managerGroup := app.Group("/manager")
 	managerGroup.Use(
-		middlewares.AuthMiddleware,
-		middlewares.StatusMiddleware,
-		middlewares.TypeMiddleware(models.Manager),
+		middlewares.AuthMiddleware(c.AuthService),
+		middlewares.StatusMiddleware(c.AuthService),
+		middlewares.TypeMiddleware(c.AuthService, models.Manager),
 	)
 
 	managerGroup.Get("/home", handlers.ManagerHomeHandler)
+
+	announcementHandler := handlers.NewAnnouncementHandler(c.AnnouncementService)
+	managerGroup.Get("/announcements", announcementHandler.ListAnnouncements)
+	managerGroup.Get("/announcements/create", announcementHandler.ShowCreateAnnouncement)
+	managerGroup.Post("/announcements/create", announcementHandler.CreateAnnouncement)
+	managerGroup.Get("/announcements/:id", announcementHandler.ShowAnnouncement)
+	managerGroup.Post("/announcements/delete/:id", announcementHandler.DeleteAnnouncement)
+
+	shiftHandler := handlers.NewShiftHandler(c.ShiftService)
+	managerGroup.Get("/shifts", shiftHandler.ShowSchedule)
+	managerGroup.Post("/shifts/assignments", shiftHandler.CreateAssignment)
+	managerGroup.Post("/shifts/assignments/delete/:id", shiftHandler.DeleteAssignment)
+	managerGroup.Get("/shifts/definitions", shiftHandler.ListShifts)
+	managerGroup.Get("/shifts/definitions/create", shiftHandler.ShowCreateShift)
+	managerGroup.Post("/shifts/definitions/create", shiftHandler.CreateShift)
+	managerGroup.Get("/shifts/definitions/update/:id", shiftHandler.ShowUpdateShift)
+	managerGroup.Post("/shifts/definitions/update/:id", shiftHandler.UpdateShift)
+	managerGroup.Post("/shifts/definitions/delete/:id", shiftHandler.DeleteShift)
+
+	attendanceHandler := handlers.NewAttendanceHandler(c.AttendanceService)
+	managerGroup.Get("/attendance", attendanceHandler.ShowReport)
+	managerGroup.Get("/attendance/export", attendanceHandler.ExportReport)
+
+	leaveHandler := handlers.NewLeaveHandler(c.LeaveService)
+	managerGroup.Get("/leaves", leaveHandler.ShowCalendar)
+	managerGroup.Post("/leaves/:id/approve", leaveHandler.ApproveLeave)
+	managerGroup.Post("/leaves/:id/reject", leaveHandler.RejectLeave)
+
+	taskHandler := handlers.NewTaskHandler(c.TaskService)
+	managerGroup.Get("/tasks", taskHandler.ListTasks)
+	managerGroup.Get("/tasks/create", taskHandler.ShowCreateTask)
+	managerGroup.Post("/tasks/create", taskHandler.CreateTask)
+	managerGroup.Get("/tasks/update/:id", taskHandler.ShowUpdateTask)
+	managerGroup.Post("/tasks/update/:id", taskHandler.UpdateTask)
+	managerGroup.Post("/tasks/delete/:id", taskHandler.DeleteTask)
+	managerGroup.Get("/tasks/:id", taskHandler.ShowTask)
+	managerGroup.Post("/tasks/:id/status", taskHandler.ChangeStatus)
+	managerGroup.Post("/tasks/:id/comments", taskHandler.AddComment)
+
+	kpiHandler := handlers.NewKPIHandler(c.KPIService)
+	managerGroup.Get("/scorecard", kpiHandler.ShowScorecard)
 }
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"zatrano/container"
+	handlers "zatrano/handlers/notification"
+	"zatrano/middlewares"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func registerNotificationRoutes(app *fiber.App, c *container.Container) {
+	notificationGroup := app.Group("/notifications")
+	notificationGroup.Use(
+		middlewares.AuthMiddleware(c.AuthService),
+		middlewares.StatusMiddleware(c.AuthService),
+	)
+
+	notificationHandler := handlers.NewNotificationHandler(c.NotificationService)
+	notificationGroup.Get("/", notificationHandler.ListNotifications)
+	notificationGroup.Get("/stream", notificationHandler.Stream)
+	notificationGroup.Post("/read-all", notificationHandler.MarkAllRead)
+	notificationGroup.Post("/:id/read", notificationHandler.MarkRead)
+}
@@ -0,0 +1,135 @@
+package routes
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type notificationListBody struct {
+	Unread int64 `json:"unread"`
+	Items  []struct {
+		ID      uint   `json:"id"`
+		Message string `json:"message"`
+		Read    bool   `json:"read"`
+	} `json:"items"`
+}
+
+func decodeNotifications(t *testing.T, body string) notificationListBody {
+	t.Helper()
+	var list notificationListBody
+	if err := json.Unmarshal([]byte(body), &list); err != nil {
+		t.Fatalf("notification list is not JSON: %v (%q)", err, body)
+	}
+	return list
+}
+
+func TestNotificationsFlow(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	agent := env.browser(t)
+	assertRedirect(t, agent.login("agent@x", testPassword), fiber.StatusFound, "/agent/home")
+
+	resp, body := agent.get("/notifications")
+	assertStatus(t, resp, fiber.StatusOK)
+	if list := decodeNotifications(t, body); list.Unread != 0 || len(list.Items) != 0 {
+		t.Fatalf("new user has notifications: %+v", list)
+	}
+
+	for _, message := range []string{"İlk", "İkinci"} {
+		if err := env.container.NotificationService.Notify(ctx, env.agent.ID, "notifications.team_changed", "", message); err != nil {
+			t.Fatalf("Notify() error = %v", err)
+		}
+	}
+	_, body = agent.get("/notifications")
+	list := decodeNotifications(t, body)
+	if list.Unread != 2 || len(list.Items) != 2 || !strings.Contains(list.Items[0].Message, "İkinci") {
+		t.Fatalf("notification list = %+v; want two unread, newest first", list)
+	}
+
+	resp, body = agent.submit("/agent/home", "/notifications/"+strconv.Itoa(int(list.Items[1].ID))+"/read", nil)
+	assertStatus(t, resp, fiber.StatusOK)
+	if list = decodeNotifications(t, body); list.Unread != 1 || !list.Items[1].Read {
+		t.Fatalf("after MarkRead = %+v; want one unread", list)
+	}
+
+	// Başka kullanıcının bildirimi işaretlenemez.
+	manager := env.browser(t)
+	assertRedirect(t, manager.login("manager@x", testPassword), fiber.StatusFound, "/manager/home")
+	resp, _ = manager.submit("/manager/home", "/notifications/"+strconv.Itoa(int(list.Items[0].ID))+"/read", nil)
+	assertStatus(t, resp, fiber.StatusNotFound)
+
+	resp, body = agent.submit("/agent/home", "/notifications/read-all", nil)
+	assertStatus(t, resp, fiber.StatusOK)
+	if list = decodeNotifications(t, body); list.Unread != 0 {
+		t.Fatalf("after MarkAllRead unread = %d, want 0", list.Unread)
+	}
+
+	resp, _ = env.browser(t).get("/notifications")
+	assertRedirect(t, resp, fiber.StatusFound, "/auth/login")
+}
+
+func TestNotificationStream(t *testing.T) {
+	env := newTestEnv(t)
+
+	agent := env.browser(t)
+	assertRedirect(t, agent.login("agent@x", testPassword), fiber.StatusFound, "/agent/home")
+
+	// SSE yanıtı app.Test ile okunamaz (gövde akış bitene kadar tamponlanır);
+	// bu yüzden uygulama gerçek bir dinleyicide çalıştırılır ve ilk olay
+	// akış açıkken okunur.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = env.app.Listener(ln) }()
+	t.Cleanup(func() { _ = env.app.ShutdownWithTimeout(time.Second) })
+
+	req, err := http.NewRequest(fiber.MethodGet, "http://"+ln.Addr().String()+"/notifications/stream", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, value := range agent.cookies {
+		req.AddCookie(&http.Cookie{Name: name, Value: value})
+	}
+	resp, err := (&http.Client{Timeout: 5 * time.Second}).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	assertStatus(t, resp, fiber.StatusOK)
+	if ct := resp.Header.Get(fiber.HeaderContentType); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q, want text/event-stream", ct)
+	}
+
+	// Başlıklar geldiğinde abonelik kurulmuştur.
+	if err := env.container.NotificationService.Notify(context.Background(), env.agent.ID, "notifications.team_changed", "/agent/home", "Satış"); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	reader := bufio.NewReader(resp.Body)
+	var lines []string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("stream read error = %v after %q", err, lines)
+		}
+		lines = append(lines, line)
+		if strings.HasPrefix(line, "data: ") {
+			break
+		}
+	}
+	stream := strings.Join(lines, "")
+	if !strings.Contains(stream, "retry: 5000") || !strings.Contains(stream, "event: notification") || !strings.Contains(stream, "Satış") {
+		t.Fatalf("stream = %q; want the pushed notification", stream)
+	}
+}
@@ -0,0 +1,108 @@
+package routes
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"zatrano/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestProfilePreferencesApplyToListsAndDates(t *testing.T) {
+	env := newTestEnv(t)
+	b := env.browser(t)
+	b.login("system@system", testPassword)
+
+	resp, _ := b.submit("/auth/profile", "/auth/profile/preferences", url.Values{
+		"locale":      {"en"},
+		"timezone":    {"America/New_York"},
+		"date_format": {"2006-01-02"},
+		"per_page":    {"10"},
+	})
+	assertRedirect(t, resp, fiber.StatusSeeOther, "/auth/profile")
+
+	_, body := b.get("/auth/profile")
+	if !strings.Contains(body, "Your preferences have been saved.") {
+		t.Fatal("profile does not show the success message in the preferred language")
+	}
+
+	stored, err := env.container.PreferenceService.GetPreferences(context.Background(), env.system.ID)
+	if err != nil {
+		t.Fatalf("GetPreferences() error = %v", err)
+	}
+	if stored.Timezone != "America/New_York" || stored.PerPage != 10 {
+		t.Fatalf("stored preference = %+v", stored)
+	}
+
+	loc, _ := time.LoadLocation("America/New_York")
+	wantDate := env.team.CreatedAt.In(loc).Format("2006-01-02")
+	_, body = b.get("/dashboard/teams")
+	if !strings.Contains(body, `<option value="10" selected>10</option>`) {
+		t.Fatal("team list does not default to the preferred page size")
+	}
+	if !strings.Contains(body, "<td>"+wantDate+"</td>") {
+		t.Fatalf("team list does not show the creation date as %s", wantDate)
+	}
+}
+
+func TestPreferencesAreLoadedAtLogin(t *testing.T) {
+	env := newTestEnv(t)
+	if err := env.container.PreferenceService.UpdatePreferences(context.Background(), env.system.ID, &models.UserPreference{
+		Locale: "en", Timezone: "UTC", DateFormat: "02.01.2006", PerPage: 50,
+	}); err != nil {
+		t.Fatalf("UpdatePreferences() error = %v", err)
+	}
+
+	b := env.browser(t)
+	b.login("system@system", testPassword)
+	resp, body := b.get("/dashboard/users")
+	assertStatus(t, resp, fiber.StatusOK)
+	if got := resp.Header.Get(fiber.HeaderContentLanguage); got != "en" {
+		t.Fatalf("Content-Language = %q, want en", got)
+	}
+	if !strings.Contains(body, `<option value="50" selected>50</option>`) {
+		t.Fatal("user list does not default to the preferred page size")
+	}
+}
+
+func TestInvalidPreferencesAreRejected(t *testing.T) {
+	env := newTestEnv(t)
+	b := env.browser(t)
+	b.login("system@system", testPassword)
+
+	resp, _ := b.submit("/auth/profile", "/auth/profile/preferences", url.Values{
+		"timezone":    {"Mars/Olympus"},
+		"date_format": {"2006-01-02"},
+		"per_page":    {"10"},
+	})
+	assertRedirect(t, resp, fiber.StatusSeeOther, "/auth/profile")
+
+	_, body := b.get("/auth/profile")
+	if !strings.Contains(body, "geçersiz saat dilimi") {
+		t.Fatal("profile does not show the invalid time zone error")
+	}
+	if _, body = b.get("/dashboard/teams"); !strings.Contains(body, `<option value="20" selected>20</option>`) {
+		t.Fatal("rejected preferences changed the page size")
+	}
+}
+
+func TestLocaleSwitcherUpdatesStoredPreference(t *testing.T) {
+	env := newTestEnv(t)
+	b := env.browser(t)
+	b.login("system@system", testPassword)
+
+	resp := switchLocale(b, "/dashboard/home", "en", "http://example.com/dashboard/home")
+	assertRedirect(t, resp, fiber.StatusSeeOther, "/dashboard/home")
+
+	pref, err := env.container.PreferenceService.GetPreferences(context.Background(), env.system.ID)
+	if err != nil {
+		t.Fatalf("GetPreferences() error = %v", err)
+	}
+	if pref.Locale != "en" {
+		t.Fatalf("stored locale = %q, want en", pref.Locale)
+	}
+}
@@ -0,0 +1,89 @@
+package routes
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestReportFlow(t *testing.T) {
+	env := newTestEnv(t)
+
+	admin := env.browser(t)
+	assertRedirect(t, admin.login("system@system", testPassword), fiber.StatusFound, "/dashboard/home")
+	resp, body := admin.submit("/dashboard/reports/create", "/dashboard/reports/create", url.Values{
+		"name":     {"Haftalık özet"},
+		"template": {"weekly_summary"},
+		"cron":     {"every monday"},
+		"timezone": {"Europe/Istanbul"},
+		"status":   {"false", "true"},
+	})
+	assertStatus(t, resp, fiber.StatusUnprocessableEntity)
+	if !strings.Contains(body, "Geçersiz cron ifadesi.") {
+		t.Fatal("create page does not show the cron error")
+	}
+	resp, _ = admin.submit("/dashboard/reports/create", "/dashboard/reports/create", url.Values{
+		"name":     {"Haftalık özet"},
+		"template": {"weekly_summary"},
+		"cron":     {"0 8 * * 1"},
+		"timezone": {"Europe/Istanbul"},
+		"status":   {"false", "true"},
+	})
+	assertRedirect(t, resp, fiber.StatusFound, "/dashboard/reports")
+	schedules, _ := env.container.ReportRepository.FindSchedules()
+	if len(schedules) != 1 || !schedules[0].Status || schedules[0].NextRunAt == nil {
+		t.Fatalf("FindSchedules() = %+v", schedules)
+	}
+	id := strconv.FormatUint(uint64(schedules[0].ID), 10)
+
+	resp, body = admin.get("/dashboard/reports/preview/weekly_summary")
+	assertStatus(t, resp, fiber.StatusOK)
+	if !strings.Contains(body, "Tüm takımlar") || !strings.Contains(body, "Giriş Yapmayan Hesaplar") {
+		t.Fatal("preview does not render the summary report")
+	}
+	resp, body = admin.get("/dashboard/reports/preview/weekly_summary?format=csv")
+	assertStatus(t, resp, fiber.StatusOK)
+	if !strings.HasPrefix(resp.Header.Get(fiber.HeaderContentType), "text/csv") || !strings.HasPrefix(body, "section,name,account,team,previous_team,count,at\n") {
+		t.Fatalf("CSV preview = %q (%s)", body, resp.Header.Get(fiber.HeaderContentType))
+	}
+
+	manager := env.browser(t)
+	assertRedirect(t, manager.login("manager@x", testPassword), fiber.StatusFound, "/manager/home")
+	_, body = manager.get("/auth/profile")
+	if !strings.Contains(body, `name="schedule_ids" id="report-`+id+`" value="`+id+`"`) {
+		t.Fatal("profile does not offer the report subscription")
+	}
+	resp, _ = manager.submit("/auth/profile", "/auth/profile/reports", url.Values{"schedule_ids": {id}})
+	assertRedirect(t, resp, fiber.StatusSeeOther, "/auth/profile")
+	if subscribers, _ := env.container.ReportRepository.FindSubscribers(schedules[0].ID); len(subscribers) != 1 || subscribers[0].ID != env.manager.ID {
+		t.Fatalf("FindSubscribers() = %+v", subscribers)
+	}
+	resp, _ = manager.submit("/auth/profile", "/auth/profile/reports", nil)
+	assertRedirect(t, resp, fiber.StatusSeeOther, "/auth/profile")
+	if ids, _ := env.container.ReportRepository.FindSubscribedScheduleIDs(env.manager.ID); len(ids) != 0 {
+		t.Fatalf("manager is still subscribed to %v", ids)
+	}
+
+	agent := env.browser(t)
+	agent.login("agent@x", testPassword)
+	_, body = agent.get("/auth/profile")
+	if strings.Contains(body, "/auth/profile/reports") {
+		t.Fatal("agents are offered report subscriptions")
+	}
+	resp, _ = agent.submit("/auth/profile", "/auth/profile/reports", url.Values{"schedule_ids": {id}})
+	assertRedirect(t, resp, fiber.StatusSeeOther, "/auth/profile")
+	if ids, _ := env.container.ReportRepository.FindSubscribedScheduleIDs(env.agent.ID); len(ids) != 0 {
+		t.Fatalf("agent subscribed to %v", ids)
+	}
+	resp, _ = agent.get("/dashboard/reports")
+	assertStatus(t, resp, fiber.StatusForbidden)
+
+	resp, _ = admin.submit("/dashboard/reports", "/dashboard/reports/delete/"+id, nil)
+	assertRedirect(t, resp, fiber.StatusFound, "/dashboard/reports")
+	if schedules, _ := env.container.ReportRepository.FindSchedules(); len(schedules) != 0 {
+		t.Fatalf("schedule survived deletion: %+v", schedules)
+	}
+}
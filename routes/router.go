@@ -2,27 +2,26 @@ package routes
 
 import (
 	"zatrano/configs"
+	"zatrano/container"
 	"zatrano/models"
 	"zatrano/utils"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/logger"
-	"gorm.io/gorm"
 )
 
-func SetupRoutes(app *fiber.App, db *gorm.DB) {
-	app.Use(logger.New())
-
+func SetupRoutes(app *fiber.App, c *container.Container) {
 	sessionStore := configs.SetupSession()
 	app.Use(func(c *fiber.Ctx) error {
 		c.Locals("session", sessionStore)
 		return c.Next()
 	})
 
-	registerAuthRoutes(app)
-	registerDashboardRoutes(app)
-	registerManagerRoutes(app)
-	registerAgentRoutes(app)
+	registerLocaleRoutes(app, c)
+	registerAuthRoutes(app, c)
+	registerDashboardRoutes(app, c)
+	registerManagerRoutes(app, c)
+	registerAgentRoutes(app, c)
+	registerNotificationRoutes(app, c)
 
 	app.Use(rootRedirector)
 }
@@ -51,6 +50,6 @@ func rootRedirector(c *fiber.Ctx) error {
 	case models.System:
 		return c.Redirect("/dashboard/home")
 	default:
-		return c.SendString("Geçersiz kullanıcı tipi")
+		return c.SendString(utils.T(c, "errors.session.invalid_user_type"))
 	}
 }
@@ -0,0 +1,232 @@
+package routes
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"zatrano/configs"
+	"zatrano/container"
+	"zatrano/middlewares"
+	"zatrano/models"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/template/html/v2"
+)
+
+const testPassword = "S1st3m@S1st3m"
+
+func TestMain(m *testing.M) {
+	utils.InitLogger("test", "error")
+	os.Exit(m.Run())
+}
+
+type testEnv struct {
+	app       *fiber.App
+	container *container.Container
+	team      *models.Team
+	system    *models.User
+	manager   *models.User
+	agent     *models.User
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+
+	cookies := configs.CookieConfig{SameSite: "Lax"}
+	configs.UseSessionStore(configs.NewSessionStore(nil, configs.SessionConfig{ExpirationHours: 24}, cookies))
+	c := container.NewInMemory()
+
+	engine := html.New("../views", ".html")
+	engine.AddFunc("getFlashMessages", utils.GetFlashMessages)
+	engine.AddFuncMap(utils.TemplateHelpers())
+
+	app := fiber.New(fiber.Config{Views: engine, PassLocalsToViews: true, DisableStartupMessage: true})
+	app.Use(middlewares.RequestLoggerMiddleware())
+	app.Use(middlewares.SecurityHeadersMiddleware(configs.SecurityConfig{
+		ContentSecurityPolicy: configs.DefaultContentSecurityPolicy,
+		ReferrerPolicy:        "strict-origin-when-cross-origin",
+	}))
+	app.Use(middlewares.PreferencesMiddleware())
+	app.Use(configs.SetupCSRF(cookies))
+	SetupRoutes(app, c)
+
+	env := &testEnv{app: app, container: c}
+	env.system = env.mustCreateUser(t, models.User{Name: "System", Account: "system@system", Type: models.System})
+	env.team = &models.Team{Name: "Destek", Status: true}
+	if err := c.TeamService.CreateTeam(context.Background(), env.team); err != nil {
+		t.Fatalf("CreateTeam() error = %v", err)
+	}
+	env.manager = env.mustCreateUser(t, models.User{Name: "Yönetici", Account: "manager@x", Type: models.Manager, TeamID: &env.team.ID})
+	env.agent = env.mustCreateUser(t, models.User{Name: "Temsilci", Account: "agent@x", Type: models.Agent, TeamID: &env.team.ID})
+	return env
+}
+
+func (e *testEnv) mustCreateUser(t *testing.T, user models.User) *models.User {
+	t.Helper()
+	user.Password = testPassword
+	if err := e.container.UserService.CreateUser(context.Background(), &user); err != nil {
+		t.Fatalf("CreateUser(%s) error = %v", user.Account, err)
+	}
+	return &user
+}
+
+type browser struct {
+	t       *testing.T
+	app     *fiber.App
+	cookies map[string]string
+}
+
+func (e *testEnv) browser(t *testing.T) *browser {
+	return &browser{t: t, app: e.app, cookies: map[string]string{}}
+}
+
+func (b *browser) do(req *http.Request) (*http.Response, string) {
+	b.t.Helper()
+	for name, value := range b.cookies {
+		req.AddCookie(&http.Cookie{Name: name, Value: value})
+	}
+	resp, err := b.app.Test(req, -1)
+	if err != nil {
+		b.t.Fatalf("%s %s: %v", req.Method, req.URL, err)
+	}
+	for _, cookie := range resp.Cookies() {
+		if cookie.Value == "" || cookie.MaxAge < 0 {
+			delete(b.cookies, cookie.Name)
+		} else {
+			b.cookies[cookie.Name] = cookie.Value
+		}
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	return resp, string(body)
+}
+
+func (b *browser) get(path string) (*http.Response, string) {
+	b.t.Helper()
+	return b.do(httptest.NewRequest(fiber.MethodGet, path, nil))
+}
+
+func (b *browser) post(path string, form url.Values) (*http.Response, string) {
+	b.t.Helper()
+	req := httptest.NewRequest(fiber.MethodPost, path, strings.NewReader(form.Encode()))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationForm)
+	return b.do(req)
+}
+
+var csrfTokenPattern = regexp.MustCompile(`name="csrf_token" (?:value|content)="([^"]+)"`)
+
+func (b *browser) submit(pagePath, actionPath string, form url.Values) (*http.Response, string) {
+	b.t.Helper()
+	_, page := b.get(pagePath)
+	match := csrfTokenPattern.FindStringSubmatch(page)
+	if match == nil {
+		b.t.Fatalf("%s sayfasında CSRF token bulunamadı", pagePath)
+	}
+	if form == nil {
+		form = url.Values{}
+	}
+	form.Set("csrf_token", match[1])
+	return b.post(actionPath, form)
+}
+
+func (b *browser) login(account, password string) *http.Response {
+	b.t.Helper()
+	resp, _ := b.submit("/auth/login", "/auth/login", url.Values{"account": {account}, "password": {password}})
+	return resp
+}
+
+func assertRedirect(t *testing.T, resp *http.Response, wantStatus int, wantLocation string) {
+	t.Helper()
+	if resp.StatusCode != wantStatus {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, wantStatus)
+	}
+	if got := resp.Header.Get(fiber.HeaderLocation); got != wantLocation {
+		t.Fatalf("Location = %q, want %q", got, wantLocation)
+	}
+}
+
+func assertStatus(t *testing.T, resp *http.Response, wantStatus int) {
+	t.Helper()
+	if resp.StatusCode != wantStatus {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, wantStatus)
+	}
+}
+
+func TestRootRedirector(t *testing.T) {
+	env := newTestEnv(t)
+
+	tests := []struct {
+		name    string
+		account string
+		want    string
+	}{
+		{name: "guest", want: "/auth/login"},
+		{name: "system", account: "system@system", want: "/dashboard/home"},
+		{name: "manager", account: "manager@x", want: "/manager/home"},
+		{name: "agent", account: "agent@x", want: "/agent/home"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := env.browser(t)
+			if tt.account != "" {
+				b.login(tt.account, testPassword)
+			}
+			resp, _ := b.get("/")
+			assertRedirect(t, resp, fiber.StatusFound, tt.want)
+		})
+	}
+}
+
+func TestRoleMiddlewares(t *testing.T) {
+	env := newTestEnv(t)
+
+	tests := []struct {
+		name       string
+		account    string
+		path       string
+		wantStatus int
+	}{
+		{name: "guest is redirected to login", path: "/dashboard/home", wantStatus: fiber.StatusFound},
+		{name: "system opens dashboard", account: "system@system", path: "/dashboard/home", wantStatus: fiber.StatusOK},
+		{name: "agent cannot open dashboard", account: "agent@x", path: "/dashboard/home", wantStatus: fiber.StatusForbidden},
+		{name: "manager opens manager home", account: "manager@x", path: "/manager/home", wantStatus: fiber.StatusOK},
+		{name: "agent cannot open manager home", account: "agent@x", path: "/manager/home", wantStatus: fiber.StatusForbidden},
+		{name: "agent opens agent home", account: "agent@x", path: "/agent/home", wantStatus: fiber.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := env.browser(t)
+			if tt.account != "" {
+				b.login(tt.account, testPassword)
+			}
+			resp, _ := b.get(tt.path)
+			assertStatus(t, resp, tt.wantStatus)
+		})
+	}
+}
+
+func TestStatusMiddlewareRejectsDeactivatedUser(t *testing.T) {
+	env := newTestEnv(t)
+	b := env.browser(t)
+	b.login("agent@x", testPassword)
+
+	err := env.container.UserService.UpdateUser(context.Background(), env.agent.ID, &models.User{
+		Name: env.agent.Name, Account: env.agent.Account, Type: env.agent.Type, TeamID: env.agent.TeamID, Status: false,
+	})
+	if err != nil {
+		t.Fatalf("UpdateUser() error = %v", err)
+	}
+
+	resp, _ := b.get("/agent/home")
+	assertStatus(t, resp, fiber.StatusForbidden)
+}
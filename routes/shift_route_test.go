@@ -0,0 +1,85 @@
+package routes
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"zatrano/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestShiftSchedulingFlow(t *testing.T) {
+	env := newTestEnv(t)
+
+	manager := env.browser(t)
+	assertRedirect(t, manager.login("manager@x", testPassword), fiber.StatusFound, "/manager/home")
+
+	resp, body := manager.submit("/manager/shifts/definitions/create", "/manager/shifts/definitions/create", url.Values{
+		"name": {"Sabah"}, "start": {"08:00"}, "end": {"08:00"}, "days": {"1"},
+	})
+	assertStatus(t, resp, fiber.StatusBadRequest)
+	if !strings.Contains(body, "başlangıç ve bitiş farklı olmalı") {
+		t.Fatal("shift validation error not rendered")
+	}
+	resp, _ = manager.submit("/manager/shifts/definitions/create", "/manager/shifts/definitions/create", url.Values{
+		"name": {"Sabah"}, "start": {"08:00"}, "end": {"16:00"}, "days": {"0", "1", "2", "3", "4", "5", "6"},
+	})
+	assertRedirect(t, resp, fiber.StatusFound, "/manager/shifts/definitions")
+
+	shifts, err := env.container.ShiftService.ListShifts(context.Background(), env.manager)
+	if err != nil || len(shifts) != 1 || shifts[0].Days != models.NewWeekdaySet(models.ShiftWeekdays...) {
+		t.Fatalf("ListShifts() = %+v, %v; want one shift on every day", shifts, err)
+	}
+
+	resp, body = manager.get("/manager/shifts/definitions")
+	assertStatus(t, resp, fiber.StatusOK)
+	if !strings.Contains(body, "Sabah") {
+		t.Fatal("shift list does not show the new shift")
+	}
+	resp, body = manager.get("/manager/shifts/definitions/update/" + strconv.Itoa(int(shifts[0].ID)))
+	assertStatus(t, resp, fiber.StatusOK)
+	if !strings.Contains(body, `value="16:00"`) {
+		t.Fatal("update form is not filled with the shift")
+	}
+
+	today := models.CivilDate(time.Now().UTC())
+	week := models.WeekStart(today).Format("2006-01-02")
+	form := url.Values{
+		"user_id":    {strconv.Itoa(int(env.agent.ID))},
+		"shift_id":   {strconv.Itoa(int(shifts[0].ID))},
+		"start_date": {today.Format("2006-01-02")},
+		"end_date":   {today.AddDate(0, 0, 6).Format("2006-01-02")},
+		"week":       {week},
+	}
+	resp, _ = manager.submit("/manager/shifts", "/manager/shifts/assignments", form)
+	assertRedirect(t, resp, fiber.StatusFound, "/manager/shifts?week="+week)
+
+	resp, body = manager.get("/manager/shifts?week=" + week)
+	assertStatus(t, resp, fiber.StatusOK)
+	if !strings.Contains(body, "Temsilci") || !strings.Contains(body, "08:00 – 16:00") {
+		t.Fatal("weekly schedule does not show the assignment")
+	}
+
+	resp, _ = manager.submit("/manager/shifts", "/manager/shifts/assignments", form)
+	assertRedirect(t, resp, fiber.StatusSeeOther, "/manager/shifts?week="+week)
+	_, body = manager.get("/manager/shifts?week=" + week)
+	if !strings.Contains(body, "başka bir vardiya atamasıyla çakışıyor") {
+		t.Fatal("overlap error not shown")
+	}
+
+	agent := env.browser(t)
+	assertRedirect(t, agent.login("agent@x", testPassword), fiber.StatusFound, "/agent/home")
+	resp, body = agent.get("/agent/home")
+	assertStatus(t, resp, fiber.StatusOK)
+	if !strings.Contains(body, "Sabah") || !strings.Contains(body, "Bugün") {
+		t.Fatal("agent home does not list upcoming shifts")
+	}
+
+	resp, _ = agent.get("/manager/shifts")
+	assertStatus(t, resp, fiber.StatusForbidden)
+}
@@ -0,0 +1,62 @@
+package routes
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestTaskFlow(t *testing.T) {
+	env := newTestEnv(t)
+
+	manager := env.browser(t)
+	assertRedirect(t, manager.login("manager@x", testPassword), fiber.StatusFound, "/manager/home")
+	resp, _ := manager.submit("/manager/tasks/create", "/manager/tasks/create", url.Values{
+		"title":       {"Haftalık rapor"},
+		"description": {"Satış özetini çıkar"},
+		"priority":    {"high"},
+		"due_date":    {"2020-01-06"},
+		"assignee_id": {strconv.Itoa(int(env.agent.ID))},
+	})
+	assertRedirect(t, resp, fiber.StatusFound, "/manager/tasks")
+
+	// Teslim günü geçmiş olduğundan görev gecikmiş filtresinde görünür.
+	resp, body := manager.get("/manager/tasks?filter.overdue.eq=true")
+	assertStatus(t, resp, fiber.StatusOK)
+	if !strings.Contains(body, "Haftalık rapor") || !strings.Contains(body, "table-danger") {
+		t.Fatal("manager list does not highlight the overdue task")
+	}
+	_, body = manager.get("/manager/tasks?filter.status.eq=done")
+	if strings.Contains(body, "Haftalık rapor") {
+		t.Fatal("status filter is not applied")
+	}
+
+	tasks, err := env.container.TaskRepository.FindByAssignee(env.agent.ID)
+	if err != nil || len(tasks) != 1 {
+		t.Fatalf("FindByAssignee = %v, %v", tasks, err)
+	}
+	detail := "/agent/tasks/" + strconv.Itoa(int(tasks[0].ID))
+
+	agent := env.browser(t)
+	assertRedirect(t, agent.login("agent@x", testPassword), fiber.StatusFound, "/agent/home")
+	_, body = agent.get("/agent/home")
+	if !strings.Contains(body, detail) {
+		t.Fatal("agent home does not list the task")
+	}
+	resp, _ = agent.submit(detail, detail+"/status", url.Values{"status": {"in_progress"}})
+	assertRedirect(t, resp, fiber.StatusFound, detail)
+	resp, _ = agent.submit(detail, detail+"/comments", url.Values{"body": {"Başladım"}})
+	assertRedirect(t, resp, fiber.StatusFound, detail)
+	resp, _ = agent.get("/manager/tasks")
+	assertStatus(t, resp, fiber.StatusForbidden)
+
+	managerDetail := "/manager/tasks/" + strconv.Itoa(int(tasks[0].ID))
+	resp, body = manager.get(managerDetail)
+	assertStatus(t, resp, fiber.StatusOK)
+	if !strings.Contains(body, "Başladım") || !strings.Contains(body, "Devam ediyor") {
+		t.Fatal("manager detail does not show the agent's update")
+	}
+}
@@ -0,0 +1,246 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"zatrano/i18n"
+	"zatrano/models"
+	"zatrano/repositories"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type AnnouncementServiceError string
+
+func (e AnnouncementServiceError) Error() string {
+	return i18n.T(i18n.DefaultLocale, string(e))
+}
+
+func (e AnnouncementServiceError) Code() string {
+	return string(e)
+}
+
+const (
+	ErrAnnouncementNotFound          AnnouncementServiceError = "errors.announcement.not_found"
+	ErrAnnouncementTitleRequired     AnnouncementServiceError = "errors.announcement.title_required"
+	ErrAnnouncementTitleTooLong      AnnouncementServiceError = "errors.announcement.title_too_long"
+	ErrAnnouncementBodyRequired      AnnouncementServiceError = "errors.announcement.body_required"
+	ErrAnnouncementInvalidPriority   AnnouncementServiceError = "errors.announcement.invalid_priority"
+	ErrAnnouncementExpiryInPast      AnnouncementServiceError = "errors.announcement.expiry_in_past"
+	ErrAnnouncementForbidden         AnnouncementServiceError = "errors.announcement.forbidden"
+	ErrAnnouncementCreationFailed    AnnouncementServiceError = "errors.announcement.creation_failed"
+	ErrAnnouncementDeletionFailed    AnnouncementServiceError = "errors.announcement.deletion_failed"
+	ErrAnnouncementAcknowledgeFailed AnnouncementServiceError = "errors.announcement.acknowledge_failed"
+)
+
+const AnnouncementTitleMaxLength = 200
+
+type AnnouncementListItem struct {
+	models.Announcement
+	repositories.ReceiptCount
+	Expired bool
+}
+
+type IAnnouncementService interface {
+	ListAnnouncements(ctx context.Context, actor *models.User) ([]AnnouncementListItem, error)
+	GetAnnouncementStatus(ctx context.Context, actor *models.User, id uint) (*models.Announcement, []repositories.AnnouncementRecipient, error)
+	CreateAnnouncement(ctx context.Context, actor *models.User, announcement *models.Announcement) error
+	DeleteAnnouncement(ctx context.Context, actor *models.User, id uint) error
+	PendingForUser(ctx context.Context, user *models.User) ([]repositories.UserAnnouncement, error)
+	Acknowledge(ctx context.Context, user *models.User, id uint) error
+}
+
+type AnnouncementService struct {
+	repo repositories.IAnnouncementRepository
+	now  func() time.Time
+}
+
+func NewAnnouncementService(repo repositories.IAnnouncementRepository) IAnnouncementService {
+	return &AnnouncementService{repo: repo, now: func() time.Time { return time.Now().UTC() }}
+}
+
+func canManage(actor *models.User, announcement *models.Announcement) bool {
+	switch actor.Type {
+	case models.System:
+		return true
+	case models.Manager:
+		return announcement.TeamID != nil && actor.TeamID != nil && *announcement.TeamID == *actor.TeamID
+	}
+	return false
+}
+
+func canSee(user *models.User, announcement *models.Announcement) bool {
+	if announcement.TeamID == nil {
+		return true
+	}
+	return user.TeamID != nil && *announcement.TeamID == *user.TeamID
+}
+
+func (s *AnnouncementService) ListAnnouncements(ctx context.Context, actor *models.User) ([]AnnouncementListItem, error) {
+	var announcements []models.Announcement
+	var err error
+	switch {
+	case actor.Type == models.System:
+		announcements, err = s.repo.FindAll()
+	case actor.Type == models.Manager && actor.TeamID != nil:
+		announcements, err = s.repo.FindByTeam(*actor.TeamID)
+	default:
+		return nil, ErrAnnouncementForbidden
+	}
+	if err != nil {
+		utils.LogFrom(ctx).Error("Duyurular alınırken hata oluştu", zap.Error(err))
+		return nil, err
+	}
+
+	ids := make([]uint, len(announcements))
+	for i, a := range announcements {
+		ids[i] = a.ID
+	}
+	counts, err := s.repo.CountReceipts(ids)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Duyuru okuma sayıları alınırken hata oluştu", zap.Error(err))
+		return nil, err
+	}
+
+	now := s.now()
+	items := make([]AnnouncementListItem, len(announcements))
+	for i, a := range announcements {
+		items[i] = AnnouncementListItem{Announcement: a, ReceiptCount: counts[a.ID], Expired: !a.IsActiveAt(now)}
+	}
+	return items, nil
+}
+
+func (s *AnnouncementService) find(ctx context.Context, id uint) (*models.Announcement, error) {
+	announcement, err := s.repo.FindByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrAnnouncementNotFound
+		}
+		utils.LogFrom(ctx).Error("Duyuru alınırken hata oluştu", zap.Uint("announcement_id", id), zap.Error(err))
+		return nil, err
+	}
+	return announcement, nil
+}
+
+func (s *AnnouncementService) GetAnnouncementStatus(ctx context.Context, actor *models.User, id uint) (*models.Announcement, []repositories.AnnouncementRecipient, error) {
+	announcement, err := s.find(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !canManage(actor, announcement) {
+		return nil, nil, ErrAnnouncementNotFound
+	}
+	recipients, err := s.repo.FindRecipients(announcement)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Duyuru alıcıları alınırken hata oluştu", zap.Uint("announcement_id", id), zap.Error(err))
+		return nil, nil, err
+	}
+	return announcement, recipients, nil
+}
+
+func (s *AnnouncementService) CreateAnnouncement(ctx context.Context, actor *models.User, announcement *models.Announcement) error {
+	switch {
+	case actor.Type == models.System:
+		announcement.TeamID = nil
+	case actor.Type == models.Manager && actor.TeamID != nil:
+		teamID := *actor.TeamID
+		announcement.TeamID = &teamID
+	default:
+		return ErrAnnouncementForbidden
+	}
+
+	announcement.Title = strings.TrimSpace(announcement.Title)
+	announcement.Body = strings.TrimSpace(announcement.Body)
+	if announcement.Priority == "" {
+		announcement.Priority = models.PriorityNormal
+	}
+	switch {
+	case announcement.Title == "":
+		return ErrAnnouncementTitleRequired
+	case utf8.RuneCountInString(announcement.Title) > AnnouncementTitleMaxLength:
+		return ErrAnnouncementTitleTooLong
+	case announcement.Body == "":
+		return ErrAnnouncementBodyRequired
+	case !announcement.Priority.IsValid():
+		return ErrAnnouncementInvalidPriority
+	case announcement.ExpiresAt != nil && !announcement.ExpiresAt.After(s.now()):
+		return ErrAnnouncementExpiryInPast
+	}
+
+	announcement.AuthorID = actor.ID
+	if err := s.repo.Create(announcement); err != nil {
+		utils.LogFrom(ctx).Error("Duyuru oluşturulurken veritabanı hatası", zap.String("title", announcement.Title), zap.Error(err))
+		return ErrAnnouncementCreationFailed
+	}
+	utils.SLogFrom(ctx).Infof("Duyuru yayınlandı: %s (ID: %d)", announcement.Title, announcement.ID)
+	return nil
+}
+
+func (s *AnnouncementService) DeleteAnnouncement(ctx context.Context, actor *models.User, id uint) error {
+	announcement, err := s.find(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !canManage(actor, announcement) {
+		return ErrAnnouncementNotFound
+	}
+	if err := s.repo.Delete(id); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrAnnouncementNotFound
+		}
+		utils.LogFrom(ctx).Error("Duyuru silinirken hata oluştu", zap.Uint("announcement_id", id), zap.Error(err))
+		return ErrAnnouncementDeletionFailed
+	}
+	utils.SLogFrom(ctx).Infof("Duyuru silindi: ID %d", id)
+	return nil
+}
+
+func (s *AnnouncementService) PendingForUser(ctx context.Context, user *models.User) ([]repositories.UserAnnouncement, error) {
+	now := s.now()
+	active, err := s.repo.FindActiveForUser(user.ID, user.TeamID, now)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Aktif duyurular alınırken hata oluştu", zap.Error(err))
+		return nil, err
+	}
+
+	pending := []repositories.UserAnnouncement{}
+	var unread []uint
+	for _, a := range active {
+		if a.AcknowledgedAt != nil {
+			continue
+		}
+		if a.ReadAt == nil {
+			unread = append(unread, a.ID)
+		}
+		pending = append(pending, a)
+	}
+	// Okuma kaydı yalnızca yönetici ekranındaki durum içindir; yazılamaması
+	// duyuruların gösterilmesini engellemez.
+	if err := s.repo.MarkRead(unread, user.ID, now); err != nil {
+		utils.LogFrom(ctx).Warn("Duyurular okundu olarak işaretlenemedi", zap.Error(err))
+	}
+	return pending, nil
+}
+
+func (s *AnnouncementService) Acknowledge(ctx context.Context, user *models.User, id uint) error {
+	announcement, err := s.find(ctx, id)
+	if err != nil {
+		return err
+	}
+	now := s.now()
+	if !canSee(user, announcement) || !announcement.IsActiveAt(now) {
+		return ErrAnnouncementNotFound
+	}
+	if err := s.repo.Acknowledge(id, user.ID, now); err != nil {
+		utils.LogFrom(ctx).Error("Duyuru onaylanırken hata oluştu", zap.Uint("announcement_id", id), zap.Error(err))
+		return ErrAnnouncementAcknowledgeFailed
+	}
+	return nil
+}
+
+var _ IAnnouncementService = (*AnnouncementService)(nil)
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"zatrano/models"
+)
+
+func TestAnnouncementServiceScopesAndAcknowledge(t *testing.T) {
+	s := newTestServices(t)
+	ctx := context.Background()
+	sales := s.mustCreateTeam(t, "Satış")
+	support := s.mustCreateTeam(t, "Destek")
+	system := s.mustCreateUser(t, models.User{Name: "Sistem", Account: "system@x", Password: "secret1", Type: models.System})
+	manager := s.mustCreateUser(t, models.User{Name: "Yönetici", Account: "manager@x", Password: "secret1", Type: models.Manager, TeamID: &sales.ID})
+	other := s.mustCreateUser(t, models.User{Name: "Diğer", Account: "other@x", Password: "secret1", Type: models.Manager, TeamID: &support.ID})
+	agent := s.mustCreateUser(t, models.User{Name: "Ali", Account: "ali@x", Password: "secret1", Type: models.Agent, TeamID: &sales.ID})
+
+	past := time.Now().Add(-time.Hour)
+	invalid := []struct {
+		name string
+		in   models.Announcement
+		want error
+	}{
+		{"empty title", models.Announcement{Title: " ", Body: "x"}, ErrAnnouncementTitleRequired},
+		{"empty body", models.Announcement{Title: "x"}, ErrAnnouncementBodyRequired},
+		{"unknown priority", models.Announcement{Title: "x", Body: "x", Priority: "low"}, ErrAnnouncementInvalidPriority},
+		{"expired", models.Announcement{Title: "x", Body: "x", ExpiresAt: &past}, ErrAnnouncementExpiryInPast},
+	}
+	for _, tt := range invalid {
+		if err := s.announcements.CreateAnnouncement(ctx, manager, &tt.in); err != tt.want {
+			t.Errorf("CreateAnnouncement(%s) error = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+	if err := s.announcements.CreateAnnouncement(ctx, agent, &models.Announcement{Title: "x", Body: "x"}); err != ErrAnnouncementForbidden {
+		t.Fatalf("CreateAnnouncement(agent) error = %v, want ErrAnnouncementForbidden", err)
+	}
+
+	// Yöneticinin gönderdiği takım kimliği yok sayılır; duyuru kendi takımına gider.
+	teamNote := &models.Announcement{TeamID: &support.ID, Title: "Vardiya", Body: "Yarın 9'da", Priority: models.PriorityUrgent}
+	if err := s.announcements.CreateAnnouncement(ctx, manager, teamNote); err != nil {
+		t.Fatalf("CreateAnnouncement(manager) error = %v", err)
+	}
+	if teamNote.TeamID == nil || *teamNote.TeamID != sales.ID || teamNote.AuthorID != manager.ID {
+		t.Fatalf("CreateAnnouncement(manager) = team %v author %d, want team %d author %d", teamNote.TeamID, teamNote.AuthorID, sales.ID, manager.ID)
+	}
+	global := &models.Announcement{TeamID: &sales.ID, Title: "Bakım", Body: "Sistem bakımı"}
+	if err := s.announcements.CreateAnnouncement(ctx, system, global); err != nil {
+		t.Fatalf("CreateAnnouncement(system) error = %v", err)
+	}
+	if !global.IsGlobal() {
+		t.Fatalf("CreateAnnouncement(system) TeamID = %v, want global", global.TeamID)
+	}
+
+	if _, _, err := s.announcements.GetAnnouncementStatus(ctx, other, teamNote.ID); err != ErrAnnouncementNotFound {
+		t.Fatalf("GetAnnouncementStatus(other team) error = %v, want ErrAnnouncementNotFound", err)
+	}
+	if err := s.announcements.DeleteAnnouncement(ctx, manager, global.ID); err != ErrAnnouncementNotFound {
+		t.Fatalf("DeleteAnnouncement(manager, global) error = %v, want ErrAnnouncementNotFound", err)
+	}
+
+	pending, err := s.announcements.PendingForUser(ctx, agent)
+	if err != nil || len(pending) != 2 || pending[0].ID != teamNote.ID {
+		t.Fatalf("PendingForUser() = %+v, %v; want urgent team note first", pending, err)
+	}
+	if err := s.announcements.Acknowledge(ctx, agent, teamNote.ID); err != nil {
+		t.Fatalf("Acknowledge() error = %v", err)
+	}
+	pending, err = s.announcements.PendingForUser(ctx, agent)
+	if err != nil || len(pending) != 1 || pending[0].ID != global.ID || pending[0].ReadAt == nil {
+		t.Fatalf("PendingForUser() after acknowledge = %+v, %v; want read global note", pending, err)
+	}
+
+	_, recipients, err := s.announcements.GetAnnouncementStatus(ctx, manager, teamNote.ID)
+	if err != nil || len(recipients) != 1 || recipients[0].AcknowledgedAt == nil {
+		t.Fatalf("GetAnnouncementStatus() = %+v, %v; want acknowledged agent", recipients, err)
+	}
+	items, err := s.announcements.ListAnnouncements(ctx, manager)
+	if err != nil || len(items) != 1 || items[0].Acknowledged != 1 {
+		t.Fatalf("ListAnnouncements(manager) = %+v, %v; want one acknowledged note", items, err)
+	}
+}
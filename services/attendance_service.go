@@ -0,0 +1,421 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"zatrano/i18n"
+	"zatrano/models"
+	"zatrano/repositories"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type AttendanceServiceError string
+
+func (e AttendanceServiceError) Error() string {
+	return i18n.T(i18n.DefaultLocale, string(e))
+}
+
+func (e AttendanceServiceError) Code() string {
+	return string(e)
+}
+
+const (
+	ErrAttendanceForbidden        AttendanceServiceError = "errors.attendance.forbidden"
+	ErrAttendanceAlreadyClockedIn AttendanceServiceError = "errors.attendance.already_clocked_in"
+	ErrAttendanceNotClockedIn     AttendanceServiceError = "errors.attendance.not_clocked_in"
+	ErrAttendanceAlreadyOnBreak   AttendanceServiceError = "errors.attendance.already_on_break"
+	ErrAttendanceNotOnBreak       AttendanceServiceError = "errors.attendance.not_on_break"
+	ErrAttendanceClockInFailed    AttendanceServiceError = "errors.attendance.clock_in_failed"
+	ErrAttendanceClockOutFailed   AttendanceServiceError = "errors.attendance.clock_out_failed"
+	ErrAttendanceBreakFailed      AttendanceServiceError = "errors.attendance.break_failed"
+)
+
+const AttendanceLateGrace = 5 * time.Minute
+
+type AttendancePeriod string
+
+const (
+	AttendancePeriodDay  AttendancePeriod = "day"
+	AttendancePeriodWeek AttendancePeriod = "week"
+)
+
+type AttendanceStatus struct {
+	Open     *models.AttendanceSession
+	Sessions []models.AttendanceSession
+	Worked   time.Duration
+}
+
+func (s *AttendanceStatus) OnBreak() bool {
+	return s.Open != nil && s.Open.OpenBreak() != nil
+}
+
+type AttendanceShiftCheck struct {
+	Occurrence ShiftOccurrence
+	ClockIn    *time.Time
+	LateBy     time.Duration
+	Absent     bool
+}
+
+func (c AttendanceShiftCheck) Late() bool {
+	return c.LateBy > 0
+}
+
+type AttendanceDay struct {
+	Date     time.Time
+	Sessions []models.AttendanceSession
+	Checks   []AttendanceShiftCheck
+	Worked   time.Duration
+	Break    time.Duration
+}
+
+func (d AttendanceDay) Late() bool {
+	return slices.ContainsFunc(d.Checks, AttendanceShiftCheck.Late)
+}
+
+func (d AttendanceDay) Absent() bool {
+	return slices.ContainsFunc(d.Checks, func(c AttendanceShiftCheck) bool { return c.Absent })
+}
+
+func (d AttendanceDay) Open() bool {
+	return slices.ContainsFunc(d.Sessions, func(s models.AttendanceSession) bool { return s.IsOpen() })
+}
+
+type AttendanceRow struct {
+	Agent  models.User
+	Days   []AttendanceDay
+	Worked time.Duration
+	Late   int
+	Absent int
+}
+
+type AttendanceReportQuery struct {
+	Period   AttendancePeriod
+	Date     time.Time
+	Location *time.Location
+	Now      time.Time
+}
+
+type AttendanceReport struct {
+	Period AttendancePeriod
+	Start  time.Time
+	End    time.Time
+	Days   []time.Time
+	Rows   []AttendanceRow
+}
+
+func (r *AttendanceReport) Previous() time.Time {
+	return r.Start.AddDate(0, 0, -len(r.Days))
+}
+
+func (r *AttendanceReport) Next() time.Time {
+	return r.Start.AddDate(0, 0, len(r.Days))
+}
+
+type IAttendanceService interface {
+	Status(ctx context.Context, user *models.User, loc *time.Location, now time.Time) (*AttendanceStatus, error)
+	ClockIn(ctx context.Context, user *models.User, ip string) error
+	ClockOut(ctx context.Context, user *models.User, ip string) error
+	StartBreak(ctx context.Context, user *models.User) error
+	EndBreak(ctx context.Context, user *models.User) error
+	CloseStaleSessions(ctx context.Context, now time.Time, maxOpen time.Duration) (int, error)
+	Report(ctx context.Context, actor *models.User, query AttendanceReportQuery) (*AttendanceReport, error)
+}
+
+type AttendanceService struct {
+	repo   repositories.IAttendanceRepository
+	shifts repositories.IShiftRepository
+	users  repositories.IUserRepository
+}
+
+func NewAttendanceService(repo repositories.IAttendanceRepository, shifts repositories.IShiftRepository, users repositories.IUserRepository) IAttendanceService {
+	return &AttendanceService{repo: repo, shifts: shifts, users: users}
+}
+
+func (s *AttendanceService) openSession(user *models.User) (*models.AttendanceSession, error) {
+	session, err := s.repo.FindOpenByUser(user.ID)
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	return session, err
+}
+
+func (s *AttendanceService) Status(ctx context.Context, user *models.User, loc *time.Location, now time.Time) (*AttendanceStatus, error) {
+	local := now.In(loc)
+	dayStart := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	sessions, err := s.repo.FindByUser(user.ID, dayStart, dayStart.AddDate(0, 0, 1))
+	if err != nil {
+		utils.LogFrom(ctx).Error("Devam kayıtları alınırken hata oluştu", zap.Uint("user_id", user.ID), zap.Error(err))
+		return nil, err
+	}
+
+	// Açık oturum dün başlamış olsa da bugünün aralığıyla kesişir.
+	status := &AttendanceStatus{Sessions: []models.AttendanceSession{}}
+	for i := range sessions {
+		session := &sessions[i]
+		if session.IsOpen() {
+			status.Open = session
+		}
+		if !session.ClockInAt.Before(dayStart) {
+			status.Sessions = append(status.Sessions, *session)
+			status.Worked += session.WorkedDuration(now)
+		}
+	}
+	return status, nil
+}
+
+func (s *AttendanceService) ClockIn(ctx context.Context, user *models.User, ip string) error {
+	if user.Type != models.Agent {
+		return ErrAttendanceForbidden
+	}
+	open, err := s.openSession(user)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Açık devam kaydı alınırken hata oluştu", zap.Uint("user_id", user.ID), zap.Error(err))
+		return ErrAttendanceClockInFailed
+	}
+	if open != nil {
+		return ErrAttendanceAlreadyClockedIn
+	}
+
+	session := &models.AttendanceSession{UserID: user.ID, ClockInAt: time.Now().UTC(), ClockInIP: ip}
+	if err := s.repo.Create(session); err != nil {
+		// Eşzamanlı iki giriş isteğinden biri açık oturum kısıtına takılır.
+		if open, _ := s.openSession(user); open != nil {
+			return ErrAttendanceAlreadyClockedIn
+		}
+		utils.LogFrom(ctx).Error("Giriş kaydı oluşturulurken veritabanı hatası", zap.Uint("user_id", user.ID), zap.Error(err))
+		return ErrAttendanceClockInFailed
+	}
+	utils.SLogFrom(ctx).Infof("Giriş yapıldı: kullanıcı %d (oturum ID: %d)", user.ID, session.ID)
+	return nil
+}
+
+func (s *AttendanceService) ClockOut(ctx context.Context, user *models.User, ip string) error {
+	open, err := s.openSession(user)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Açık devam kaydı alınırken hata oluştu", zap.Uint("user_id", user.ID), zap.Error(err))
+		return ErrAttendanceClockOutFailed
+	}
+	if open == nil {
+		return ErrAttendanceNotClockedIn
+	}
+	if err := s.repo.Close(open.ID, time.Now().UTC(), ip, false); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrAttendanceNotClockedIn
+		}
+		utils.LogFrom(ctx).Error("Çıkış kaydedilirken veritabanı hatası", zap.Uint("session_id", open.ID), zap.Error(err))
+		return ErrAttendanceClockOutFailed
+	}
+	utils.SLogFrom(ctx).Infof("Çıkış yapıldı: kullanıcı %d (oturum ID: %d)", user.ID, open.ID)
+	return nil
+}
+
+func (s *AttendanceService) StartBreak(ctx context.Context, user *models.User) error {
+	open, err := s.openSession(user)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Açık devam kaydı alınırken hata oluştu", zap.Uint("user_id", user.ID), zap.Error(err))
+		return ErrAttendanceBreakFailed
+	}
+	if open == nil {
+		return ErrAttendanceNotClockedIn
+	}
+	if open.OpenBreak() != nil {
+		return ErrAttendanceAlreadyOnBreak
+	}
+	if err := s.repo.StartBreak(&models.AttendanceBreak{SessionID: open.ID, StartedAt: time.Now().UTC()}); err != nil {
+		if current, _ := s.openSession(user); current != nil && current.OpenBreak() != nil {
+			return ErrAttendanceAlreadyOnBreak
+		}
+		utils.LogFrom(ctx).Error("Mola başlatılırken veritabanı hatası", zap.Uint("session_id", open.ID), zap.Error(err))
+		return ErrAttendanceBreakFailed
+	}
+	return nil
+}
+
+func (s *AttendanceService) EndBreak(ctx context.Context, user *models.User) error {
+	open, err := s.openSession(user)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Açık devam kaydı alınırken hata oluştu", zap.Uint("user_id", user.ID), zap.Error(err))
+		return ErrAttendanceBreakFailed
+	}
+	if open == nil {
+		return ErrAttendanceNotClockedIn
+	}
+	current := open.OpenBreak()
+	if current == nil {
+		return ErrAttendanceNotOnBreak
+	}
+	if err := s.repo.EndBreak(current.ID, time.Now().UTC()); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrAttendanceNotOnBreak
+		}
+		utils.LogFrom(ctx).Error("Mola bitirilirken veritabanı hatası", zap.Uint("break_id", current.ID), zap.Error(err))
+		return ErrAttendanceBreakFailed
+	}
+	return nil
+}
+
+func (s *AttendanceService) CloseStaleSessions(ctx context.Context, now time.Time, maxOpen time.Duration) (int, error) {
+	stale, err := s.repo.FindOpenBefore(now.Add(-maxOpen))
+	if err != nil {
+		utils.LogFrom(ctx).Error("Açık kalan devam kayıtları alınırken hata oluştu", zap.Error(err))
+		return 0, err
+	}
+	closed := 0
+	for _, session := range stale {
+		err := s.repo.Close(session.ID, session.ClockInAt.Add(maxOpen), "", true)
+		switch {
+		case err == gorm.ErrRecordNotFound:
+			// Bu arada kullanıcı çıkış yapmış.
+		case err != nil:
+			utils.LogFrom(ctx).Error("Açık kalan devam kaydı kapatılamadı", zap.Uint("session_id", session.ID), zap.Error(err))
+			return closed, err
+		default:
+			closed++
+		}
+	}
+	if closed > 0 {
+		utils.SLogFrom(ctx).Infof("%d açık devam kaydı otomatik kapatıldı", closed)
+	}
+	return closed, nil
+}
+
+func (s *AttendanceService) Report(ctx context.Context, actor *models.User, query AttendanceReportQuery) (*AttendanceReport, error) {
+	if actor.Type != models.Manager || actor.TeamID == nil {
+		return nil, ErrAttendanceForbidden
+	}
+	teamID := *actor.TeamID
+	loc := query.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	report := &AttendanceReport{Period: AttendancePeriodDay, Start: models.CivilDate(query.Date)}
+	days := 1
+	if query.Period == AttendancePeriodWeek {
+		report.Period, report.Start, days = AttendancePeriodWeek, models.WeekStart(query.Date), len(models.ShiftWeekdays)
+	}
+	report.End = report.Start.AddDate(0, 0, days-1)
+	for i := 0; i < days; i++ {
+		report.Days = append(report.Days, report.Start.AddDate(0, 0, i))
+	}
+
+	agents, err := s.users.FindAgentsByTeam(teamID)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Takım ajanları alınırken hata oluştu", zap.Uint("team_id", teamID), zap.Error(err))
+		return nil, err
+	}
+	assignments, err := s.shifts.FindAssignmentsByTeam(teamID, report.Start, report.End)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Vardiya atamaları alınırken hata oluştu", zap.Uint("team_id", teamID), zap.Error(err))
+		return nil, err
+	}
+	// Gece vardiyalarıyla eşleşebilmeleri için dönemin bir gün öncesi ve
+	// sonrasındaki oturumlar da alınır.
+	from := time.Date(report.Start.Year(), report.Start.Month(), report.Start.Day(), 0, 0, 0, 0, loc)
+	sessions, err := s.repo.FindByTeam(teamID, from.AddDate(0, 0, -1), from.AddDate(0, 0, days+1))
+	if err != nil {
+		utils.LogFrom(ctx).Error("Devam kayıtları alınırken hata oluştu", zap.Uint("team_id", teamID), zap.Error(err))
+		return nil, err
+	}
+
+	sessionsByUser := make(map[uint][]models.AttendanceSession)
+	for _, session := range sessions {
+		sessionsByUser[session.UserID] = append(sessionsByUser[session.UserID], session)
+	}
+	assignmentsByUser := make(map[uint][]*models.ShiftAssignment)
+	for i := range assignments {
+		a := &assignments[i]
+		assignmentsByUser[a.UserID] = append(assignmentsByUser[a.UserID], a)
+	}
+
+	seen := make(map[uint]bool)
+	addRow := func(agent models.User) {
+		seen[agent.ID] = true
+		report.Rows = append(report.Rows, attendanceRow(agent, report, sessionsByUser[agent.ID], assignmentsByUser[agent.ID], loc, query.Now))
+	}
+	for _, agent := range agents {
+		if agent.Status || len(sessionsByUser[agent.ID]) > 0 || len(assignmentsByUser[agent.ID]) > 0 {
+			addRow(agent)
+		}
+	}
+	// Takımdan ayrılmış kullanıcıların dönemdeki vardiyaları da raporda görünür.
+	for _, a := range assignments {
+		if !seen[a.UserID] && a.User != nil {
+			addRow(*a.User)
+		}
+	}
+	return report, nil
+}
+
+func attendanceRow(agent models.User, report *AttendanceReport, sessions []models.AttendanceSession, assignments []*models.ShiftAssignment, loc *time.Location, now time.Time) AttendanceRow {
+	row := AttendanceRow{Agent: agent, Days: make([]AttendanceDay, len(report.Days))}
+	for i, date := range report.Days {
+		row.Days[i] = AttendanceDay{Date: date, Sessions: []models.AttendanceSession{}}
+	}
+	dayIndex := func(date time.Time) int {
+		return int(models.CivilDate(date).Sub(report.Start).Hours() / 24)
+	}
+
+	for _, session := range sessions {
+		i := dayIndex(models.WallClock(session.ClockInAt, loc))
+		if i < 0 || i >= len(row.Days) {
+			continue
+		}
+		day := &row.Days[i]
+		day.Sessions = append(day.Sessions, session)
+		day.Worked += session.WorkedDuration(now)
+		day.Break += session.BreakDuration(now)
+		row.Worked += session.WorkedDuration(now)
+	}
+
+	var occurrences []ShiftOccurrence
+	for _, a := range assignments {
+		occurrences = append(occurrences, shiftOccurrences(a, report.Start, report.End)...)
+	}
+	slices.SortFunc(occurrences, compareOccurrences)
+	wallNow := models.WallClock(now, loc)
+	for _, occurrence := range occurrences {
+		check := checkShift(occurrence, sessions, loc, wallNow)
+		if check.Late() {
+			row.Late++
+		}
+		if check.Absent {
+			row.Absent++
+		}
+		day := &row.Days[dayIndex(occurrence.Date)]
+		day.Checks = append(day.Checks, check)
+	}
+	return row
+}
+
+func checkShift(occurrence ShiftOccurrence, sessions []models.AttendanceSession, loc *time.Location, wallNow time.Time) AttendanceShiftCheck {
+	check := AttendanceShiftCheck{Occurrence: occurrence}
+	for _, session := range sessions {
+		in := models.WallClock(session.ClockInAt, loc)
+		out := wallNow
+		if session.ClockOutAt != nil {
+			out = models.WallClock(*session.ClockOutAt, loc)
+		}
+		if in.Before(occurrence.End) && out.After(occurrence.Start) {
+			check.ClockIn = &in
+			if late := in.Sub(occurrence.Start); late > AttendanceLateGrace {
+				check.LateBy = late
+			}
+			return check
+		}
+	}
+	switch {
+	case !wallNow.Before(occurrence.End):
+		check.Absent = true
+	case wallNow.Sub(occurrence.Start) > AttendanceLateGrace:
+		check.LateBy = wallNow.Sub(occurrence.Start)
+	}
+	return check
+}
+
+var _ IAttendanceService = (*AttendanceService)(nil)
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"zatrano/models"
+	"zatrano/repositories"
+)
+
+func TestAttendanceClockFlow(t *testing.T) {
+	s := newTestServices(t)
+	ctx := context.Background()
+	team := s.mustCreateTeam(t, "Satış")
+	manager := s.mustCreateUser(t, models.User{Name: "Yönetici", Account: "manager@x", Password: "secret1", Type: models.Manager, TeamID: &team.ID})
+	agent := s.mustCreateUser(t, models.User{Name: "Ali", Account: "ali@x", Password: "secret1", Type: models.Agent, TeamID: &team.ID})
+
+	if err := s.attendance.ClockIn(ctx, manager, "10.0.0.9"); err != ErrAttendanceForbidden {
+		t.Fatalf("ClockIn(manager) error = %v, want ErrAttendanceForbidden", err)
+	}
+	if err := s.attendance.StartBreak(ctx, agent); err != ErrAttendanceNotClockedIn {
+		t.Fatalf("StartBreak() before clock-in error = %v, want ErrAttendanceNotClockedIn", err)
+	}
+	if err := s.attendance.ClockIn(ctx, agent, "10.0.0.1"); err != nil {
+		t.Fatalf("ClockIn() error = %v", err)
+	}
+	if err := s.attendance.ClockIn(ctx, agent, "10.0.0.1"); err != ErrAttendanceAlreadyClockedIn {
+		t.Fatalf("ClockIn() twice error = %v, want ErrAttendanceAlreadyClockedIn", err)
+	}
+	if err := s.attendance.EndBreak(ctx, agent); err != ErrAttendanceNotOnBreak {
+		t.Fatalf("EndBreak() without a break error = %v, want ErrAttendanceNotOnBreak", err)
+	}
+	if err := s.attendance.StartBreak(ctx, agent); err != nil {
+		t.Fatalf("StartBreak() error = %v", err)
+	}
+	if err := s.attendance.StartBreak(ctx, agent); err != ErrAttendanceAlreadyOnBreak {
+		t.Fatalf("StartBreak() twice error = %v, want ErrAttendanceAlreadyOnBreak", err)
+	}
+
+	status, err := s.attendance.Status(ctx, agent, time.UTC, time.Now())
+	if err != nil || status.Open == nil || !status.OnBreak() || len(status.Sessions) != 1 || status.Open.ClockInIP != "10.0.0.1" {
+		t.Fatalf("Status() = %+v, %v; want one open session on break", status, err)
+	}
+
+	// Moladayken çıkış yapmak molayı da kapatır.
+	if err := s.attendance.ClockOut(ctx, agent, "10.0.0.2"); err != nil {
+		t.Fatalf("ClockOut() error = %v", err)
+	}
+	if err := s.attendance.ClockOut(ctx, agent, "10.0.0.2"); err != ErrAttendanceNotClockedIn {
+		t.Fatalf("ClockOut() twice error = %v, want ErrAttendanceNotClockedIn", err)
+	}
+	status, _ = s.attendance.Status(ctx, agent, time.UTC, time.Now())
+	if status.Open != nil || len(status.Sessions) != 1 || status.Sessions[0].OpenBreak() != nil || status.Sessions[0].ClockOutIP != "10.0.0.2" {
+		t.Fatalf("Status() after clock-out = %+v", status)
+	}
+
+	if _, err := s.attendance.Report(ctx, agent, AttendanceReportQuery{Date: time.Now()}); err != ErrAttendanceForbidden {
+		t.Fatalf("Report(agent) error = %v, want ErrAttendanceForbidden", err)
+	}
+}
+
+func TestAttendanceReportAgainstShifts(t *testing.T) {
+	s := newTestServices(t)
+	ctx := context.Background()
+	repo := repositories.NewMemoryAttendanceRepository(s.store)
+	team := s.mustCreateTeam(t, "Satış")
+	manager := s.mustCreateUser(t, models.User{Name: "Yönetici", Account: "manager@x", Password: "secret1", Type: models.Manager, TeamID: &team.ID})
+	ali := s.mustCreateUser(t, models.User{Name: "Ali", Account: "ali@x", Password: "secret1", Type: models.Agent, TeamID: &team.ID})
+	veli := s.mustCreateUser(t, models.User{Name: "Veli", Account: "veli@x", Password: "secret1", Type: models.Agent, TeamID: &team.ID})
+
+	weekdays := models.NewWeekdaySet(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday)
+	shift := &models.Shift{Name: "Gündüz", StartMinute: 9 * 60, EndMinute: 17 * 60, Days: weekdays}
+	if err := s.shifts.CreateShift(ctx, manager, shift); err != nil {
+		t.Fatalf("CreateShift() error = %v", err)
+	}
+	// 2026-03-02 bir Pazartesidir.
+	date := func(d int) time.Time { return time.Date(2026, time.March, d, 0, 0, 0, 0, time.UTC) }
+	if err := s.shifts.AssignShift(ctx, manager, &models.ShiftAssignment{ShiftID: shift.ID, UserID: ali.ID, StartDate: date(2), EndDate: date(8)}); err != nil {
+		t.Fatalf("AssignShift() error = %v", err)
+	}
+
+	istanbul, err := time.LoadLocation("Europe/Istanbul")
+	if err != nil {
+		t.Skipf("saat dilimi verisi yok: %v", err)
+	}
+	at := func(d, hour, minute int) time.Time {
+		return time.Date(2026, time.March, d, hour, minute, 0, 0, istanbul)
+	}
+	record := func(user *models.User, in, out time.Time, breaks ...[2]time.Time) {
+		t.Helper()
+		session := &models.AttendanceSession{UserID: user.ID, ClockInAt: in.UTC()}
+		if err := repo.Create(session); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		for _, b := range breaks {
+			br := &models.AttendanceBreak{SessionID: session.ID, StartedAt: b[0].UTC()}
+			if err := repo.StartBreak(br); err != nil {
+				t.Fatalf("StartBreak() error = %v", err)
+			}
+			if err := repo.EndBreak(br.ID, b[1].UTC()); err != nil {
+				t.Fatalf("EndBreak() error = %v", err)
+			}
+		}
+		if !out.IsZero() {
+			if err := repo.Close(session.ID, out.UTC(), "", false); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+		}
+	}
+	// Pazartesi zamanında (5 dakikalık tolerans içinde), Salı 20 dakika geç;
+	// Çarşamba hiç gelmedi, Perşembe saat 10:00 itibarıyla henüz gelmedi.
+	record(ali, at(2, 9, 2), at(2, 17, 0), [2]time.Time{at(2, 12, 0), at(2, 12, 30)})
+	record(ali, at(3, 9, 20), at(3, 17, 0))
+	// Veli'nin vardiyası yok; Çarşamba açık unuttuğu oturum otomatik kapanır.
+	record(veli, at(4, 8, 0), time.Time{})
+	now := at(5, 10, 0)
+
+	closed, err := s.attendance.CloseStaleSessions(ctx, now, 12*time.Hour)
+	if err != nil || closed != 1 {
+		t.Fatalf("CloseStaleSessions() = %d, %v; want 1", closed, err)
+	}
+	if open, _ := repo.FindOpenByUser(veli.ID); open != nil {
+		t.Fatalf("Veli's session is still open: %+v", open)
+	}
+
+	week, err := s.attendance.Report(ctx, manager, AttendanceReportQuery{Period: AttendancePeriodWeek, Date: date(4), Location: istanbul, Now: now})
+	if err != nil {
+		t.Fatalf("Report(week) error = %v", err)
+	}
+	if !week.Start.Equal(date(2)) || len(week.Days) != 7 || len(week.Rows) != 2 || week.Rows[0].Agent.ID != ali.ID {
+		t.Fatalf("Report(week) = start %v, %d days, %d rows", week.Start, len(week.Days), len(week.Rows))
+	}
+	row := week.Rows[0]
+	if row.Late != 2 || row.Absent != 1 {
+		t.Fatalf("Ali late/absent = %d/%d, want 2/1", row.Late, row.Absent)
+	}
+	monday := row.Days[0]
+	if monday.Late() || monday.Absent() || monday.Worked != 7*time.Hour+28*time.Minute || monday.Break != 30*time.Minute {
+		t.Fatalf("Monday = late %v, absent %v, worked %v, break %v", monday.Late(), monday.Absent(), monday.Worked, monday.Break)
+	}
+	if got := row.Days[1].Checks[0].LateBy; got != 20*time.Minute {
+		t.Fatalf("Tuesday LateBy = %v, want 20m", got)
+	}
+	if !row.Days[2].Absent() || !row.Days[3].Late() || row.Days[4].Late() || row.Days[4].Absent() {
+		t.Fatal("Wednesday must be absent, Thursday late so far and Friday pending")
+	}
+	veliWed := week.Rows[1].Days[2]
+	if len(veliWed.Checks) != 0 || len(veliWed.Sessions) != 1 || !veliWed.Sessions[0].AutoClosed || veliWed.Worked != 12*time.Hour {
+		t.Fatalf("Veli's Wednesday = %+v, want one auto closed 12h session", veliWed)
+	}
+
+	day, err := s.attendance.Report(ctx, manager, AttendanceReportQuery{Period: AttendancePeriodDay, Date: date(3), Location: istanbul, Now: now})
+	if err != nil || len(day.Days) != 1 || !day.Previous().Equal(date(2)) || len(day.Rows[0].Days[0].Checks) != 1 {
+		t.Fatalf("Report(day) = %+v, %v", day, err)
+	}
+	if clockIn := day.Rows[0].Days[0].Checks[0].ClockIn; clockIn == nil || !clockIn.Equal(date(3).Add(9*time.Hour+20*time.Minute)) {
+		t.Fatalf("Tuesday ClockIn = %v, want 09:20 wall clock", clockIn)
+	}
+}
@@ -1,6 +1,9 @@
 package services
 
 import (
+	"context"
+
+	"zatrano/i18n"
 	"zatrano/models"
 	"zatrano/repositories"
 	"zatrano/utils"
@@ -13,45 +16,50 @@ import (
 type ServiceError string
 
 func (e ServiceError) Error() string {
+	return i18n.T(i18n.DefaultLocale, string(e))
+}
+
+func (e ServiceError) Code() string {
 	return string(e)
 }
 
 const (
-	ErrInvalidCredentials       ServiceError = "geçersiz kimlik bilgileri"
-	ErrUserNotFound             ServiceError = "kullanıcı bulunamadı"
-	ErrUserInactive             ServiceError = "kullanıcı aktif değil"
-	ErrCurrentPasswordIncorrect ServiceError = "mevcut şifre hatalı"
-	ErrPasswordTooShort         ServiceError = "yeni şifre en az 6 karakter olmalıdır"
-	ErrPasswordSameAsOld        ServiceError = "yeni şifre mevcut şifre ile aynı olamaz"
-	ErrAuthGeneric              ServiceError = "kimlik doğrulaması sırasında bir hata oluştu"
-	ErrProfileGeneric           ServiceError = "profil bilgileri alınırken hata"
-	ErrUpdatePasswordGeneric    ServiceError = "şifre güncellenirken bir hata oluştu"
-	ErrHashingFailed            ServiceError = "yeni şifre oluşturulurken hata"
-	ErrDatabaseUpdateFailed     ServiceError = "veritabanı güncellemesi başarısız oldu"
+	ErrInvalidCredentials       ServiceError = "errors.auth.invalid_credentials"
+	ErrUserNotFound             ServiceError = "errors.auth.user_not_found"
+	ErrUserInactive             ServiceError = "errors.auth.user_inactive"
+	ErrCurrentPasswordIncorrect ServiceError = "errors.auth.current_password_incorrect"
+	ErrPasswordTooShort         ServiceError = "errors.auth.password_too_short"
+	ErrPasswordSameAsOld        ServiceError = "errors.auth.password_same_as_old"
+	ErrAuthGeneric              ServiceError = "errors.auth.generic"
+	ErrProfileGeneric           ServiceError = "errors.auth.profile_generic"
+	ErrUpdatePasswordGeneric    ServiceError = "errors.auth.update_password_generic"
+	ErrHashingFailed            ServiceError = "errors.auth.hashing_failed"
+	ErrDatabaseUpdateFailed     ServiceError = "errors.auth.database_update_failed"
 )
 
 type IAuthService interface {
-	Authenticate(account, password string) (*models.User, error)
-	GetUserProfile(id uint) (*models.User, error)
-	UpdatePassword(userID uint, currentPass, newPassword string) error
+	Authenticate(ctx context.Context, account, password string) (*models.User, error)
+	GetUserProfile(ctx context.Context, id uint) (*models.User, error)
+	UpdatePassword(ctx context.Context, userID uint, currentPass, newPassword string) error
 }
 
 type AuthService struct {
 	repo repositories.IAuthRepository
 }
 
-func NewAuthService() IAuthService {
-	return &AuthService{repo: repositories.NewAuthRepository()}
+func NewAuthService(repo repositories.IAuthRepository) IAuthService {
+	return &AuthService{repo: repo}
 }
 
-func (s *AuthService) Authenticate(account, password string) (*models.User, error) {
+func (s *AuthService) Authenticate(ctx context.Context, account, password string) (*models.User, error) {
 	user, err := s.repo.FindUserByAccount(account)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			utils.Log.Warn("Kimlik doğrulama başarısız: Kullanıcı bulunamadı", zap.String("account", account))
+			utils.LogFrom(ctx).Warn("Kimlik doğrulama başarısız: Kullanıcı bulunamadı", zap.String("account", account))
+			s.recordLogin(ctx, account, nil, ErrInvalidCredentials)
 			return nil, ErrInvalidCredentials
 		}
-		utils.Log.Error("Kimlik doğrulama hatası (DB)",
+		utils.LogFrom(ctx).Error("Kimlik doğrulama hatası (DB)",
 			zap.String("account", account),
 			zap.Error(err),
 		)
@@ -59,37 +67,54 @@ func (s *AuthService) Authenticate(account, password string) (*models.User, erro
 	}
 
 	if !user.Status {
-		utils.Log.Warn("Kimlik doğrulama başarısız: Kullanıcı aktif değil",
+		utils.LogFrom(ctx).Warn("Kimlik doğrulama başarısız: Kullanıcı aktif değil",
 			zap.String("account", account),
 			zap.Uint("user_id", user.ID),
 		)
+		s.recordLogin(ctx, account, user, ErrUserInactive)
 		return nil, ErrUserInactive
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
 	if err != nil {
-		utils.Log.Warn("Kimlik doğrulama başarısız: Geçersiz parola",
+		utils.LogFrom(ctx).Warn("Kimlik doğrulama başarısız: Geçersiz parola",
 			zap.String("account", account),
 			zap.Uint("user_id", user.ID),
 		)
+		s.recordLogin(ctx, account, user, ErrInvalidCredentials)
 		return nil, ErrInvalidCredentials
 	}
 
-	utils.Log.Info("Kimlik doğrulama başarılı",
+	utils.LogFrom(ctx).Info("Kimlik doğrulama başarılı",
 		zap.String("account", account),
 		zap.Uint("user_id", user.ID),
 	)
+	s.recordLogin(ctx, account, user, "")
 	return user, nil
 }
 
-func (s *AuthService) GetUserProfile(id uint) (*models.User, error) {
+func (s *AuthService) recordLogin(ctx context.Context, account string, user *models.User, reason ServiceError) {
+	if runes := []rune(account); len(runes) > models.LoginEventAccountMaxLength {
+		account = string(runes[:models.LoginEventAccountMaxLength])
+	}
+	event := &models.LoginEvent{Account: account, Success: reason == "", Reason: string(reason)}
+	if user != nil {
+		userID := user.ID
+		event.UserID = &userID
+	}
+	if err := s.repo.CreateLoginEvent(event); err != nil {
+		utils.LogFrom(ctx).Warn("Giriş denemesi kaydedilemedi", zap.String("account", account), zap.Error(err))
+	}
+}
+
+func (s *AuthService) GetUserProfile(ctx context.Context, id uint) (*models.User, error) {
 	user, err := s.repo.FindUserByID(id)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			utils.Log.Warn("Profil alınamadı: Kullanıcı bulunamadı", zap.Uint("user_id", id))
+			utils.LogFrom(ctx).Warn("Profil alınamadı: Kullanıcı bulunamadı", zap.Uint("user_id", id))
 			return nil, ErrUserNotFound
 		}
-		utils.Log.Error("Profil alma hatası (DB)",
+		utils.LogFrom(ctx).Error("Profil alma hatası (DB)",
 			zap.Uint("user_id", id),
 			zap.Error(err),
 		)
@@ -98,14 +123,14 @@ func (s *AuthService) GetUserProfile(id uint) (*models.User, error) {
 	return user, nil
 }
 
-func (s *AuthService) UpdatePassword(userID uint, currentPass, newPassword string) error {
+func (s *AuthService) UpdatePassword(ctx context.Context, userID uint, currentPass, newPassword string) error {
 	user, err := s.repo.FindUserByID(userID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			utils.Log.Warn("Parola güncelleme başarısız: Kullanıcı bulunamadı", zap.Uint("user_id", userID))
+			utils.LogFrom(ctx).Warn("Parola güncelleme başarısız: Kullanıcı bulunamadı", zap.Uint("user_id", userID))
 			return ErrUserNotFound
 		}
-		utils.Log.Error("Parola güncelleme hatası: Kullanıcı bulunurken DB hatası",
+		utils.LogFrom(ctx).Error("Parola güncelleme hatası: Kullanıcı bulunurken DB hatası",
 			zap.Uint("user_id", userID),
 			zap.Error(err),
 		)
@@ -113,22 +138,22 @@ func (s *AuthService) UpdatePassword(userID uint, currentPass, newPassword strin
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(currentPass)); err != nil {
-		utils.Log.Warn("Parola güncelleme başarısız: Mevcut parola hatalı", zap.Uint("user_id", userID))
+		utils.LogFrom(ctx).Warn("Parola güncelleme başarısız: Mevcut parola hatalı", zap.Uint("user_id", userID))
 		return ErrCurrentPasswordIncorrect
 	}
 
 	if len(newPassword) < 6 {
-		utils.Log.Warn("Parola güncelleme başarısız: Yeni parola çok kısa", zap.Uint("user_id", userID))
+		utils.LogFrom(ctx).Warn("Parola güncelleme başarısız: Yeni parola çok kısa", zap.Uint("user_id", userID))
 		return ErrPasswordTooShort
 	}
 	if currentPass == newPassword {
-		utils.Log.Warn("Parola güncelleme başarısız: Yeni parola eskiyle aynı", zap.Uint("user_id", userID))
+		utils.LogFrom(ctx).Warn("Parola güncelleme başarısız: Yeni parola eskiyle aynı", zap.Uint("user_id", userID))
 		return ErrPasswordSameAsOld
 	}
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
 	if err != nil {
-		utils.Log.Error("Parola güncelleme hatası: Yeni parola hashlenemedi",
+		utils.LogFrom(ctx).Error("Parola güncelleme hatası: Yeni parola hashlenemedi",
 			zap.Uint("user_id", userID),
 			zap.Error(err),
 		)
@@ -137,14 +162,14 @@ func (s *AuthService) UpdatePassword(userID uint, currentPass, newPassword strin
 
 	user.Password = string(hashedPassword)
 	if err := s.repo.UpdateUser(user); err != nil {
-		utils.Log.Error("Parola güncelleme hatası: Kullanıcı güncellenirken DB hatası",
+		utils.LogFrom(ctx).Error("Parola güncelleme hatası: Kullanıcı güncellenirken DB hatası",
 			zap.Uint("user_id", userID),
 			zap.Error(err),
 		)
 		return ErrDatabaseUpdateFailed
 	}
 
-	utils.Log.Info("Parola başarıyla güncellendi", zap.Uint("user_id", userID))
+	utils.LogFrom(ctx).Info("Parola başarıyla güncellendi", zap.Uint("user_id", userID))
 	return nil
 }
 
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"zatrano/models"
+)
+
+func TestAuthServiceAuthenticate(t *testing.T) {
+	s := newTestServices(t)
+	team := s.mustCreateTeam(t, "Destek")
+	active := s.mustCreateUser(t, models.User{Name: "Ajan", Account: "ajan@x", Password: "secret", Type: models.Agent, TeamID: &team.ID})
+	passive := s.mustCreateUser(t, models.User{Name: "Pasif", Account: "pasif@x", Password: "secret", Type: models.Agent, TeamID: &team.ID})
+	if err := s.users.UpdateUser(context.Background(), passive.ID, &models.User{Name: passive.Name, Account: passive.Account, Type: passive.Type, TeamID: passive.TeamID, Status: false}); err != nil {
+		t.Fatalf("UpdateUser() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		account  string
+		password string
+		wantID   uint
+		wantErr  error
+	}{
+		{name: "valid credentials", account: "ajan@x", password: "secret", wantID: active.ID},
+		{name: "wrong password", account: "ajan@x", password: "wrong", wantErr: ErrInvalidCredentials},
+		{name: "unknown account", account: "yok@x", password: "secret", wantErr: ErrInvalidCredentials},
+		{name: "inactive user", account: "pasif@x", password: "secret", wantErr: ErrUserInactive},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := s.auth.Authenticate(context.Background(), tt.account, tt.password)
+			if err != tt.wantErr {
+				t.Fatalf("Authenticate() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && user.ID != tt.wantID {
+				t.Fatalf("Authenticate() user ID = %d, want %d", user.ID, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestAuthServiceGetUserProfile(t *testing.T) {
+	s := newTestServices(t)
+	user := s.mustCreateUser(t, models.User{Name: "System", Account: "system@system", Password: "secret", Type: models.System})
+
+	got, err := s.auth.GetUserProfile(context.Background(), user.ID)
+	if err != nil || got.Account != "system@system" {
+		t.Fatalf("GetUserProfile() = %+v, %v", got, err)
+	}
+	if _, err := s.auth.GetUserProfile(context.Background(), 999); err != ErrUserNotFound {
+		t.Fatalf("GetUserProfile(999) error = %v, want %v", err, ErrUserNotFound)
+	}
+}
+
+func TestAuthServiceUpdatePassword(t *testing.T) {
+	tests := []struct {
+		name        string
+		userID      uint
+		currentPass string
+		newPass     string
+		wantErr     error
+	}{
+		{name: "success", currentPass: "secret", newPass: "yenisifre"},
+		{name: "wrong current password", currentPass: "wrong", newPass: "yenisifre", wantErr: ErrCurrentPasswordIncorrect},
+		{name: "too short", currentPass: "secret", newPass: "abc", wantErr: ErrPasswordTooShort},
+		{name: "same as old", currentPass: "secret", newPass: "secret", wantErr: ErrPasswordSameAsOld},
+		{name: "unknown user", userID: 999, currentPass: "secret", newPass: "yenisifre", wantErr: ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServices(t)
+			user := s.mustCreateUser(t, models.User{Name: "System", Account: "system@system", Password: "secret", Type: models.System})
+			userID := user.ID
+			if tt.userID != 0 {
+				userID = tt.userID
+			}
+
+			err := s.auth.UpdatePassword(context.Background(), userID, tt.currentPass, tt.newPass)
+			if err != tt.wantErr {
+				t.Fatalf("UpdatePassword() error = %v, want %v", err, tt.wantErr)
+			}
+
+			loginPass := "secret"
+			if err == nil {
+				loginPass = tt.newPass
+			}
+			if _, err := s.auth.Authenticate(context.Background(), "system@system", loginPass); err != nil {
+				t.Fatalf("Authenticate() after UpdatePassword error = %v", err)
+			}
+		})
+	}
+}
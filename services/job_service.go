@@ -0,0 +1,344 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"zatrano/i18n"
+	"zatrano/models"
+	"zatrano/repositories"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type JobServiceError string
+
+func (e JobServiceError) Error() string {
+	return i18n.T(i18n.DefaultLocale, string(e))
+}
+
+func (e JobServiceError) Code() string {
+	return string(e)
+}
+
+const (
+	ErrJobNotFound       JobServiceError = "errors.job.not_found"
+	ErrJobNotRetryable   JobServiceError = "errors.job.not_retryable"
+	ErrJobUnknownType    JobServiceError = "errors.job.unknown_type"
+	ErrJobInvalidPayload JobServiceError = "errors.job.invalid_payload"
+	ErrJobEnqueueFailed  JobServiceError = "errors.job.enqueue_failed"
+	ErrJobRetryFailed    JobServiceError = "errors.job.retry_failed"
+)
+
+const (
+	JobBackoffBase = 30 * time.Second
+	JobBackoffMax  = time.Hour
+)
+
+var JobListSpec = utils.FilterSpec{
+	"id":         {Type: utils.FieldInt, Sortable: true},
+	"type":       {Type: utils.FieldString, Ops: []utils.FilterOp{utils.OpEq}, Sortable: true},
+	"status":     {Type: utils.FieldEnum, Ops: []utils.FilterOp{utils.OpEq, utils.OpIn}, Values: jobStatusValues(), Sortable: true},
+	"attempts":   {Type: utils.FieldInt, Sortable: true},
+	"run_at":     {Type: utils.FieldDate, Sortable: true},
+	"created_at": {Type: utils.FieldDate, Ops: []utils.FilterOp{utils.OpGte, utils.OpLte}, Sortable: true},
+}
+
+func jobStatusValues() []string {
+	values := make([]string, len(models.JobStatuses))
+	for i, s := range models.JobStatuses {
+		values[i] = string(s)
+	}
+	return values
+}
+
+type JobHandler func(ctx context.Context, payload []byte) error
+
+func TypedJobHandler[T any](fn func(ctx context.Context, payload T) error) JobHandler {
+	return func(ctx context.Context, data []byte) error {
+		var payload T
+		if err := json.Unmarshal(data, &payload); err != nil {
+			return PermanentJobError(fmt.Errorf("payload çözülemedi: %w", err))
+		}
+		return fn(ctx, payload)
+	}
+}
+
+type permanentJobError struct {
+	err error
+}
+
+func (e *permanentJobError) Error() string { return e.err.Error() }
+func (e *permanentJobError) Unwrap() error { return e.err }
+
+func PermanentJobError(err error) error {
+	return &permanentJobError{err: err}
+}
+
+type registeredJob struct {
+	handler     JobHandler
+	maxAttempts int
+}
+
+type JobRegistry struct {
+	mu   sync.RWMutex
+	jobs map[string]registeredJob
+}
+
+func NewJobRegistry() *JobRegistry {
+	return &JobRegistry{jobs: make(map[string]registeredJob)}
+}
+
+func (r *JobRegistry) Register(jobType string, maxAttempts int, handler JobHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.jobs[jobType] = registeredJob{handler: handler, maxAttempts: max(maxAttempts, 1)}
+}
+
+func (r *JobRegistry) Types() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	types := make([]string, 0, len(r.jobs))
+	for t := range r.jobs {
+		types = append(types, t)
+	}
+	slices.Sort(types)
+	return types
+}
+
+func (r *JobRegistry) lookup(jobType string) (registeredJob, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	job, ok := r.jobs[jobType]
+	return job, ok
+}
+
+func JobBackoff(attempt int) time.Duration {
+	delay := JobBackoffBase
+	for i := 1; i < attempt && delay < JobBackoffMax; i++ {
+		delay *= 2
+	}
+	return min(delay, JobBackoffMax)
+}
+
+type IJobService interface {
+	Enqueue(ctx context.Context, jobType string, payload interface{}) error
+	ListJobs(ctx context.Context, params utils.ListParams) (*utils.PaginatedResult, error)
+	StatusCounts(ctx context.Context) (map[models.JobStatus]int64, error)
+	RetryJob(ctx context.Context, id uint) error
+	RunNext(ctx context.Context, workerID string) (bool, error)
+	Work(ctx context.Context, workerID string, pollInterval time.Duration)
+	RequeueStale(ctx context.Context, lockTimeout time.Duration) (int64, error)
+	PruneSucceeded(ctx context.Context, retention time.Duration) (int64, error)
+}
+
+type JobService struct {
+	repo     repositories.IJobRepository
+	registry *JobRegistry
+	now      func() time.Time
+}
+
+func NewJobService(repo repositories.IJobRepository, registry *JobRegistry) IJobService {
+	return &JobService{repo: repo, registry: registry, now: func() time.Time { return time.Now().UTC() }}
+}
+
+func (s *JobService) Enqueue(ctx context.Context, jobType string, payload interface{}) error {
+	registered, ok := s.registry.lookup(jobType)
+	if !ok {
+		return ErrJobUnknownType
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		utils.LogFrom(ctx).Error("İş payload'ı JSON'a çevrilemedi", zap.String("type", jobType), zap.Error(err))
+		return ErrJobInvalidPayload
+	}
+	job := &models.Job{
+		Type:        jobType,
+		Payload:     string(data),
+		Status:      models.JobPending,
+		MaxAttempts: registered.maxAttempts,
+		RunAt:       s.now(),
+	}
+	if err := s.repo.Create(job); err != nil {
+		utils.LogFrom(ctx).Error("İş kuyruğa alınamadı", zap.String("type", jobType), zap.Error(err))
+		return ErrJobEnqueueFailed
+	}
+	return nil
+}
+
+func (s *JobService) ListJobs(ctx context.Context, params utils.ListParams) (*utils.PaginatedResult, error) {
+	if params.Page <= 0 {
+		params.Page = utils.DefaultPage
+	}
+	if params.PerPage <= 0 || params.PerPage > utils.MaxPerPage {
+		params.PerPage = utils.DefaultPerPage
+	}
+
+	jobs, totalCount, err := s.repo.FindAndPaginate(params)
+	if err != nil {
+		utils.LogFrom(ctx).Error("İşler alınırken hata oluştu", zap.Error(err))
+		return nil, err
+	}
+	return &utils.PaginatedResult{
+		Data: jobs,
+		Meta: utils.PaginationMeta{
+			CurrentPage: params.Page, PerPage: params.PerPage,
+			TotalItems: totalCount, TotalPages: utils.CalculateTotalPages(totalCount, params.PerPage),
+		},
+	}, nil
+}
+
+func (s *JobService) StatusCounts(ctx context.Context) (map[models.JobStatus]int64, error) {
+	counts, err := s.repo.CountByStatus()
+	if err != nil {
+		utils.LogFrom(ctx).Error("İş sayıları alınırken hata oluştu", zap.Error(err))
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (s *JobService) RetryJob(ctx context.Context, id uint) error {
+	job, err := s.repo.FindByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrJobNotFound
+		}
+		utils.LogFrom(ctx).Error("İş alınırken hata oluştu", zap.Uint("job_id", id), zap.Error(err))
+		return ErrJobRetryFailed
+	}
+	if !job.CanRetry() {
+		return ErrJobNotRetryable
+	}
+	if err := s.repo.Retry(id, s.now()); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			// Bu arada bir işçi işi üstlendi.
+			return ErrJobNotRetryable
+		}
+		utils.LogFrom(ctx).Error("İş yeniden kuyruğa alınamadı", zap.Uint("job_id", id), zap.Error(err))
+		return ErrJobRetryFailed
+	}
+	utils.LogFrom(ctx).Info("İş elle yeniden kuyruğa alındı", zap.Uint("job_id", id), zap.String("type", job.Type))
+	return nil
+}
+
+func (s *JobService) RunNext(ctx context.Context, workerID string) (bool, error) {
+	job, err := s.repo.Claim(s.registry.Types(), workerID, s.now())
+	if err != nil {
+		utils.LogFrom(ctx).Error("Kuyruktan iş alınamadı", zap.String("worker", workerID), zap.Error(err))
+		return false, err
+	}
+	if job == nil {
+		return false, nil
+	}
+
+	log := utils.LogFrom(ctx).With(zap.Uint("job_id", job.ID), zap.String("type", job.Type), zap.Int("attempt", job.Attempts))
+	started := s.now()
+	runErr := s.execute(ctx, job)
+	finished := s.now()
+
+	data := map[string]interface{}{"locked_by": "", "locked_at": nil}
+	var permanent *permanentJobError
+	switch {
+	case runErr == nil:
+		data["status"] = models.JobSucceeded
+		data["last_error"] = ""
+		data["finished_at"] = finished
+		log.Info("İş tamamlandı", zap.Duration("duration", finished.Sub(started)))
+	case ctx.Err() != nil:
+		// Kapanış yüzünden yarıda kalan iş deneme sayılmaz; sonraki
+		// açılışta veya başka bir sunucuda hemen yeniden çalışır.
+		data["status"] = models.JobPending
+		data["attempts"] = job.Attempts - 1
+		data["run_at"] = finished
+		log.Warn("İş kapanış nedeniyle yarıda kaldı, yeniden kuyruğa alındı", zap.Error(runErr))
+	case errors.As(runErr, &permanent) || job.Attempts >= job.MaxAttempts:
+		data["status"] = models.JobDead
+		data["last_error"] = runErr.Error()
+		data["finished_at"] = finished
+		log.Error("İş başarısız oldu ve yeniden denenmeyecek", zap.Int("max_attempts", job.MaxAttempts), zap.Error(runErr))
+	default:
+		retryAt := finished.Add(JobBackoff(job.Attempts))
+		data["status"] = models.JobPending
+		data["last_error"] = runErr.Error()
+		data["run_at"] = retryAt
+		log.Warn("İş başarısız oldu, yeniden denenecek", zap.Time("retry_at", retryAt), zap.Error(runErr))
+	}
+
+	if err := s.repo.Finish(job.ID, workerID, data); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			log.Warn("İş bitmeden başka bir işçiye geçmiş, sonuç kaydedilmedi")
+			return true, nil
+		}
+		log.Error("İş sonucu kaydedilemedi", zap.Error(err))
+		return true, err
+	}
+	return true, nil
+}
+
+func (s *JobService) execute(ctx context.Context, job *models.Job) (err error) {
+	registered, ok := s.registry.lookup(job.Type)
+	if !ok {
+		return PermanentJobError(ErrJobUnknownType)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = PermanentJobError(fmt.Errorf("panic: %v", r))
+		}
+	}()
+	return registered.handler(ctx, []byte(job.Payload))
+}
+
+func (s *JobService) Work(ctx context.Context, workerID string, pollInterval time.Duration) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+		// Kuyruk boşalana kadar bekleme yapmadan devam edilir. Hatalar
+		// RunNext tarafından loglanır; bir sonraki turda yeniden denenir.
+		for ctx.Err() == nil {
+			ran, err := s.RunNext(ctx, workerID)
+			if !ran || err != nil {
+				break
+			}
+		}
+		timer.Reset(pollInterval)
+	}
+}
+
+func (s *JobService) RequeueStale(ctx context.Context, lockTimeout time.Duration) (int64, error) {
+	now := s.now()
+	count, dead, err := s.repo.RequeueStale(now.Add(-lockTimeout), now)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Takılı kalan işler yeniden kuyruğa alınamadı", zap.Error(err))
+		return 0, err
+	}
+	if count > 0 {
+		utils.LogFrom(ctx).Warn("Takılı kalan işler yeniden kuyruğa alındı", zap.Int64("count", count), zap.Duration("lock_timeout", lockTimeout))
+	}
+	if dead > 0 {
+		utils.LogFrom(ctx).Error("Deneme hakkı tükenen takılı işler ölü olarak işaretlendi", zap.Int64("count", dead), zap.Duration("lock_timeout", lockTimeout))
+	}
+	return count, nil
+}
+
+func (s *JobService) PruneSucceeded(ctx context.Context, retention time.Duration) (int64, error) {
+	count, err := s.repo.DeleteSucceeded(s.now().Add(-retention))
+	if err != nil {
+		utils.LogFrom(ctx).Error("Tamamlanan işler silinemedi", zap.Error(err))
+		return 0, err
+	}
+	return count, nil
+}
+
+var _ IJobService = (*JobService)(nil)
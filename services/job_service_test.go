@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"zatrano/models"
+	"zatrano/repositories"
+	"zatrano/utils"
+)
+
+type testJobPayload struct {
+	Name string `json:"name"`
+}
+
+func newTestJobService(t *testing.T) (*JobService, *JobRegistry, *time.Time) {
+	t.Helper()
+	clock := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	registry := NewJobRegistry()
+	jobs := NewJobService(repositories.NewMemoryJobRepository(repositories.NewMemoryStore()), registry).(*JobService)
+	jobs.now = func() time.Time { return clock }
+	return jobs, registry, &clock
+}
+
+func mustFindJob(t *testing.T, jobs *JobService, status models.JobStatus) models.Job {
+	t.Helper()
+	result, err := jobs.ListJobs(context.Background(), utils.ListParams{
+		Filters: []utils.Filter{{Field: "status", Op: utils.OpEq, Value: string(status)}},
+	})
+	if err != nil {
+		t.Fatalf("ListJobs() error = %v", err)
+	}
+	list := result.Data.([]models.Job)
+	if len(list) != 1 {
+		t.Fatalf("ListJobs(%s) = %+v; want one job", status, list)
+	}
+	return list[0]
+}
+
+func TestJobBackoff(t *testing.T) {
+	for attempt, want := range map[int]time.Duration{
+		1: 30 * time.Second, 2: time.Minute, 3: 2 * time.Minute, 7: 32 * time.Minute, 8: time.Hour, 40: time.Hour,
+	} {
+		if got := JobBackoff(attempt); got != want {
+			t.Errorf("JobBackoff(%d) = %v; want %v", attempt, got, want)
+		}
+	}
+}
+
+func TestJobRetriesWithBackoffUntilDead(t *testing.T) {
+	ctx := context.Background()
+	jobs, registry, clock := newTestJobService(t)
+
+	var seen []string
+	registry.Register("greet", 3, TypedJobHandler(func(_ context.Context, p testJobPayload) error {
+		seen = append(seen, p.Name)
+		return errors.New("smtp kapalı")
+	}))
+	if err := jobs.Enqueue(ctx, "unknown", nil); err != ErrJobUnknownType {
+		t.Fatalf("Enqueue(unknown) error = %v", err)
+	}
+	if err := jobs.Enqueue(ctx, "greet", testJobPayload{Name: "Ayşe"}); err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+
+	if ran, err := jobs.RunNext(ctx, "w1"); !ran || err != nil {
+		t.Fatalf("RunNext() = %v, %v", ran, err)
+	}
+	job := mustFindJob(t, jobs, models.JobPending)
+	if job.Attempts != 1 || job.MaxAttempts != 3 || job.LastError != "smtp kapalı" || !job.RunAt.Equal(clock.Add(30*time.Second)) || !job.CanRetry() {
+		t.Fatalf("job after first failure = %+v", job)
+	}
+	if ran, _ := jobs.RunNext(ctx, "w1"); ran {
+		t.Fatal("RunNext() ran a job before its backoff elapsed")
+	}
+
+	*clock = clock.Add(30 * time.Second)
+	jobs.RunNext(ctx, "w1")
+	if job = mustFindJob(t, jobs, models.JobPending); !job.RunAt.Equal(clock.Add(time.Minute)) {
+		t.Fatalf("second backoff run_at = %v", job.RunAt)
+	}
+	*clock = clock.Add(time.Minute)
+	jobs.RunNext(ctx, "w1")
+	job = mustFindJob(t, jobs, models.JobDead)
+	if job.Attempts != 3 || job.FinishedAt == nil || len(seen) != 3 || seen[0] != "Ayşe" {
+		t.Fatalf("dead job = %+v, handler calls %v", job, seen)
+	}
+
+	if err := jobs.RetryJob(ctx, job.ID); err != nil {
+		t.Fatalf("RetryJob() error = %v", err)
+	}
+	registry.Register("greet", 3, TypedJobHandler(func(context.Context, testJobPayload) error { return nil }))
+	jobs.RunNext(ctx, "w1")
+	if job = mustFindJob(t, jobs, models.JobSucceeded); job.Attempts != 1 || job.LastError != "" || job.CanRetry() {
+		t.Fatalf("retried job = %+v", job)
+	}
+	if err := jobs.RetryJob(ctx, job.ID); err != ErrJobNotRetryable {
+		t.Fatalf("RetryJob(succeeded) error = %v", err)
+	}
+	if err := jobs.RetryJob(ctx, 999); err != ErrJobNotFound {
+		t.Fatalf("RetryJob(missing) error = %v", err)
+	}
+	if counts, _ := jobs.StatusCounts(ctx); counts[models.JobSucceeded] != 1 || len(counts) != 1 {
+		t.Fatalf("StatusCounts() = %v", counts)
+	}
+}
+
+func TestJobPermanentFailures(t *testing.T) {
+	ctx := context.Background()
+	jobs, registry, _ := newTestJobService(t)
+
+	registry.Register("typed", 5, TypedJobHandler(func(context.Context, testJobPayload) error { return nil }))
+	registry.Register("permanent", 5, func(context.Context, []byte) error {
+		return PermanentJobError(errors.New("alıcı yok"))
+	})
+	registry.Register("panics", 5, func(context.Context, []byte) error { panic("beklenmeyen durum") })
+	for _, jobType := range []string{"typed", "permanent", "panics"} {
+		payload := interface{}(testJobPayload{Name: "x"})
+		if jobType == "typed" {
+			payload = []string{"not", "an", "object"}
+		}
+		if err := jobs.Enqueue(ctx, jobType, payload); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < 3; i++ {
+		if ran, err := jobs.RunNext(ctx, "w1"); !ran || err != nil {
+			t.Fatalf("RunNext() = %v, %v", ran, err)
+		}
+	}
+
+	result, _ := jobs.ListJobs(ctx, utils.ListParams{SortBy: "id", OrderBy: "asc"})
+	for _, job := range result.Data.([]models.Job) {
+		if job.Status != models.JobDead || job.Attempts != 1 || job.LastError == "" {
+			t.Errorf("%s job = %+v; want dead after its first attempt", job.Type, job)
+		}
+	}
+	if ran, _ := jobs.RunNext(ctx, "w1"); ran {
+		t.Fatal("RunNext() ran a dead job")
+	}
+}
+
+func TestJobShutdownAndMaintenance(t *testing.T) {
+	jobs, registry, clock := newTestJobService(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	registry.Register("slow", 1, func(ctx context.Context, _ []byte) error {
+		cancel()
+		return ctx.Err()
+	})
+	if err := jobs.Enqueue(ctx, "slow", nil); err != nil {
+		t.Fatal(err)
+	}
+	jobs.RunNext(ctx, "w1")
+	if job := mustFindJob(t, jobs, models.JobPending); job.Attempts != 0 || job.LockedAt != nil {
+		t.Fatalf("job interrupted by shutdown = %+v; want it queued again without using an attempt", job)
+	}
+
+	// Work, kuyruk boşalana kadar çalışır ve ctx iptal edilince döner.
+	ctx, cancel = context.WithCancel(context.Background())
+	done := make(chan struct{})
+	registry.Register("slow", 1, func(context.Context, []byte) error {
+		cancel()
+		return nil
+	})
+	go func() {
+		jobs.Work(ctx, "w1", time.Hour)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Work() did not return after cancellation")
+	}
+	finished := mustFindJob(t, jobs, models.JobSucceeded)
+
+	if n, err := jobs.PruneSucceeded(context.Background(), time.Hour); n != 0 || err != nil {
+		t.Fatalf("PruneSucceeded(fresh) = %d, %v", n, err)
+	}
+	*clock = clock.Add(2 * time.Hour)
+	if n, err := jobs.PruneSucceeded(context.Background(), time.Hour); n != 1 || err != nil {
+		t.Fatalf("PruneSucceeded() = %d, %v", n, err)
+	}
+
+	stuck := &models.Job{Type: "slow", Payload: "null", Status: models.JobPending, MaxAttempts: 2, RunAt: *clock}
+	if err := jobs.repo.Create(stuck); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := jobs.repo.Claim([]string{"slow"}, "dead-worker", *clock); err != nil {
+		t.Fatal(err)
+	}
+	*clock = clock.Add(11 * time.Minute)
+	if n, err := jobs.RequeueStale(context.Background(), 10*time.Minute); n != 1 || err != nil {
+		t.Fatalf("RequeueStale() = %d, %v", n, err)
+	}
+	if job := mustFindJob(t, jobs, models.JobPending); job.ID == finished.ID || job.LockedBy != "" {
+		t.Fatalf("requeued job = %+v", job)
+	}
+
+	// İkinci denemede de takılan işin hakkı kalmadığından ölü olur.
+	if _, err := jobs.repo.Claim([]string{"slow"}, "dead-worker", *clock); err != nil {
+		t.Fatal(err)
+	}
+	*clock = clock.Add(11 * time.Minute)
+	if n, err := jobs.RequeueStale(context.Background(), 10*time.Minute); n != 0 || err != nil {
+		t.Fatalf("RequeueStale(no attempts left) = %d, %v", n, err)
+	}
+	if job := mustFindJob(t, jobs, models.JobDead); job.ID != stuck.ID || job.Attempts != 2 || job.LastError == "" {
+		t.Fatalf("exhausted stuck job = %+v", job)
+	}
+}
@@ -0,0 +1,632 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"zatrano/i18n"
+	"zatrano/models"
+	"zatrano/repositories"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type KPIServiceError string
+
+func (e KPIServiceError) Error() string {
+	return i18n.T(i18n.DefaultLocale, string(e))
+}
+
+func (e KPIServiceError) Code() string {
+	return string(e)
+}
+
+const (
+	ErrKPINotFound           KPIServiceError = "errors.kpi.not_found"
+	ErrKPIForbidden          KPIServiceError = "errors.kpi.forbidden"
+	ErrKPIInvalidKey         KPIServiceError = "errors.kpi.invalid_key"
+	ErrKPIKeyTaken           KPIServiceError = "errors.kpi.key_taken"
+	ErrKPINameRequired       KPIServiceError = "errors.kpi.name_required"
+	ErrKPINameTooLong        KPIServiceError = "errors.kpi.name_too_long"
+	ErrKPIInvalidUnit        KPIServiceError = "errors.kpi.invalid_unit"
+	ErrKPIInvalidAggregation KPIServiceError = "errors.kpi.invalid_aggregation"
+	ErrKPICreationFailed     KPIServiceError = "errors.kpi.creation_failed"
+	ErrKPIUpdateFailed       KPIServiceError = "errors.kpi.update_failed"
+	ErrKPIImportInvalidCSV   KPIServiceError = "errors.kpi.import_invalid_csv"
+	ErrKPIImportEmpty        KPIServiceError = "errors.kpi.import_empty"
+	ErrKPIImportTooLarge     KPIServiceError = "errors.kpi.import_too_large"
+	ErrKPIImportInvalidRows  KPIServiceError = "errors.kpi.import_invalid_rows"
+	ErrKPIImportFailed       KPIServiceError = "errors.kpi.import_failed"
+)
+
+const (
+	KPIRowMissingColumns  = "errors.kpi.row.missing_columns"
+	KPIRowUnknownMetric   = "errors.kpi.row.unknown_metric"
+	KPIRowInactiveMetric  = "errors.kpi.row.inactive_metric"
+	KPIRowUnknownAccount  = "errors.kpi.row.unknown_account"
+	KPIRowInvalidDate     = "errors.kpi.row.invalid_date"
+	KPIRowInvalidValue    = "errors.kpi.row.invalid_value"
+	KPIRowDuplicateRecord = "errors.kpi.row.duplicate"
+)
+
+const (
+	KPINameMaxLength    = 100
+	KPIImportMaxRows    = 10000
+	KPIImportDateLayout = "2006-01-02"
+
+	KPIScorecardDefaultWeeks = 8
+	KPIScorecardMaxWeeks     = 26
+)
+
+var KPIScorecardWeekOptions = []int{4, KPIScorecardDefaultWeeks, 13, KPIScorecardMaxWeeks}
+
+var KPICSVHeader = []string{"metric", "account", "date", "value"}
+
+type KPIInput struct {
+	Line    int
+	Metric  string
+	Account string
+	Date    string
+	Value   string
+}
+
+type KPIRowError struct {
+	Line  int
+	Code  string
+	Value string
+}
+
+func (e KPIRowError) Message(locale string) string {
+	if e.Value == "" {
+		return i18n.T(locale, e.Code)
+	}
+	return i18n.T(locale, e.Code, e.Value)
+}
+
+type KPIIngestResult struct {
+	Imported int
+	Errors   []KPIRowError
+}
+
+type KPISeries struct {
+	Weekly []*float64
+	Total  *float64
+	Trend  int
+}
+
+func (s KPISeries) Percents() []int {
+	var peak float64
+	for _, v := range s.Weekly {
+		if v != nil && *v > peak {
+			peak = *v
+		}
+	}
+	percents := make([]int, len(s.Weekly))
+	for i, v := range s.Weekly {
+		if v != nil && peak > 0 {
+			percents[i] = int(math.Round(*v / peak * 100))
+		}
+	}
+	return percents
+}
+
+type KPIScorecardRow struct {
+	Agent models.User
+	Rank  int
+	KPISeries
+}
+
+type KPITeamScorecard struct {
+	Definitions []models.KPIDefinition
+	Definition  *models.KPIDefinition
+	From, To    time.Time
+	Weeks       []time.Time
+	Team        KPISeries
+	Rows        []KPIScorecardRow
+}
+
+type KPIAgentMetric struct {
+	Definition models.KPIDefinition
+	KPISeries
+}
+
+type KPIAgentScorecard struct {
+	From, To time.Time
+	Weeks    []time.Time
+	Metrics  []KPIAgentMetric
+}
+
+type IKPIService interface {
+	ListDefinitions(ctx context.Context) ([]models.KPIDefinition, error)
+	GetDefinition(ctx context.Context, id uint) (*models.KPIDefinition, error)
+	CreateDefinition(ctx context.Context, definition *models.KPIDefinition) error
+	UpdateDefinition(ctx context.Context, id uint, definition *models.KPIDefinition) error
+	ParseCSV(r io.Reader) ([]KPIInput, error)
+	Ingest(ctx context.Context, inputs []KPIInput) (*KPIIngestResult, error)
+	TeamScorecard(ctx context.Context, actor *models.User, definitionID uint, today time.Time, weeks int) (*KPITeamScorecard, error)
+	AgentScorecard(ctx context.Context, actor *models.User, today time.Time, weeks int) (*KPIAgentScorecard, error)
+}
+
+type KPIService struct {
+	repo  repositories.IKPIRepository
+	users repositories.IUserRepository
+}
+
+func NewKPIService(repo repositories.IKPIRepository, users repositories.IUserRepository) IKPIService {
+	return &KPIService{repo: repo, users: users}
+}
+
+func normalizeKPIDefinition(definition *models.KPIDefinition) error {
+	definition.Key = strings.TrimSpace(definition.Key)
+	definition.Name = strings.TrimSpace(definition.Name)
+	switch {
+	case !models.ValidKPIKey(definition.Key):
+		return ErrKPIInvalidKey
+	case definition.Name == "":
+		return ErrKPINameRequired
+	case utf8.RuneCountInString(definition.Name) > KPINameMaxLength:
+		return ErrKPINameTooLong
+	case !definition.Unit.IsValid():
+		return ErrKPIInvalidUnit
+	case !definition.Aggregation.IsValid():
+		return ErrKPIInvalidAggregation
+	}
+	return nil
+}
+
+func (s *KPIService) ListDefinitions(ctx context.Context) ([]models.KPIDefinition, error) {
+	definitions, err := s.repo.FindDefinitions()
+	if err != nil {
+		utils.LogFrom(ctx).Error("KPI tanımları alınırken hata oluştu", zap.Error(err))
+		return nil, err
+	}
+	return definitions, nil
+}
+
+func (s *KPIService) GetDefinition(ctx context.Context, id uint) (*models.KPIDefinition, error) {
+	definition, err := s.repo.FindDefinitionByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrKPINotFound
+		}
+		utils.LogFrom(ctx).Error("KPI tanımı alınırken hata oluştu", zap.Uint("kpi_id", id), zap.Error(err))
+		return nil, err
+	}
+	return definition, nil
+}
+
+func (s *KPIService) CreateDefinition(ctx context.Context, definition *models.KPIDefinition) error {
+	if err := normalizeKPIDefinition(definition); err != nil {
+		return err
+	}
+	if _, err := s.repo.FindDefinitionByKey(definition.Key); err == nil {
+		return ErrKPIKeyTaken
+	} else if err != gorm.ErrRecordNotFound {
+		utils.LogFrom(ctx).Error("KPI anahtarı kontrol edilirken hata oluştu", zap.String("key", definition.Key), zap.Error(err))
+		return ErrKPICreationFailed
+	}
+
+	if err := s.repo.CreateDefinition(definition); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return ErrKPIKeyTaken
+		}
+		utils.LogFrom(ctx).Error("KPI tanımı oluşturulurken veritabanı hatası", zap.String("key", definition.Key), zap.Error(err))
+		return ErrKPICreationFailed
+	}
+	utils.SLogFrom(ctx).Infof("KPI tanımı oluşturuldu: %s (ID: %d)", definition.Key, definition.ID)
+	return nil
+}
+
+func (s *KPIService) UpdateDefinition(ctx context.Context, id uint, definition *models.KPIDefinition) error {
+	existing, err := s.GetDefinition(ctx, id)
+	if err != nil {
+		return err
+	}
+	definition.Key = existing.Key
+	if err := normalizeKPIDefinition(definition); err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{
+		"name":             definition.Name,
+		"unit":             definition.Unit,
+		"aggregation":      definition.Aggregation,
+		"higher_is_better": definition.HigherIsBetter,
+		"status":           definition.Status,
+	}
+	if err := s.repo.UpdateDefinition(id, data); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrKPINotFound
+		}
+		utils.LogFrom(ctx).Error("KPI tanımı güncellenirken veritabanı hatası", zap.Uint("kpi_id", id), zap.Error(err))
+		return ErrKPIUpdateFailed
+	}
+	utils.SLogFrom(ctx).Infof("KPI tanımı güncellendi: %s (ID: %d)", existing.Key, id)
+	return nil
+}
+
+func (s *KPIService) ParseCSV(r io.Reader) ([]KPIInput, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = false
+
+	header, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, ErrKPIImportEmpty
+		}
+		return nil, ErrKPIImportInvalidCSV
+	}
+	columns := make(map[string]int, len(KPICSVHeader))
+	for i, name := range header {
+		// Excel'in UTF-8 dosyalarına eklediği BOM ilk sütun adına yapışır.
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if slices.Contains(KPICSVHeader, name) {
+			columns[name] = i
+		}
+	}
+	if len(columns) != len(KPICSVHeader) {
+		return nil, ErrKPIImportInvalidCSV
+	}
+
+	var inputs []KPIInput
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, ErrKPIImportInvalidCSV
+		}
+		line, _ := reader.FieldPos(0)
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		if len(inputs) == KPIImportMaxRows {
+			return nil, ErrKPIImportTooLarge
+		}
+		input := KPIInput{Line: line}
+		field := func(name string) string {
+			if i := columns[name]; i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		input.Metric, input.Account = field("metric"), field("account")
+		input.Date, input.Value = field("date"), field("value")
+		inputs = append(inputs, input)
+	}
+	if len(inputs) == 0 {
+		return nil, ErrKPIImportEmpty
+	}
+	return inputs, nil
+}
+
+func parseKPIValue(raw string) (float64, bool) {
+	value, err := strconv.ParseFloat(strings.Replace(raw, ",", ".", 1), 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) || value < 0 {
+		return 0, false
+	}
+	return value, true
+}
+
+func (s *KPIService) Ingest(ctx context.Context, inputs []KPIInput) (*KPIIngestResult, error) {
+	if len(inputs) == 0 {
+		return nil, ErrKPIImportEmpty
+	}
+	if len(inputs) > KPIImportMaxRows {
+		return nil, ErrKPIImportTooLarge
+	}
+
+	definitions, err := s.repo.FindDefinitions()
+	if err != nil {
+		utils.LogFrom(ctx).Error("KPI tanımları alınırken hata oluştu", zap.Error(err))
+		return nil, ErrKPIImportFailed
+	}
+	byKey := make(map[string]models.KPIDefinition, len(definitions))
+	for _, d := range definitions {
+		byKey[d.Key] = d
+	}
+
+	var accounts []string
+	for _, input := range inputs {
+		if input.Account != "" && !slices.Contains(accounts, input.Account) {
+			accounts = append(accounts, input.Account)
+		}
+	}
+	agents, err := s.repo.FindAgentsByAccounts(accounts)
+	if err != nil {
+		utils.LogFrom(ctx).Error("KPI yüklemesi için ajanlar alınırken hata oluştu", zap.Error(err))
+		return nil, ErrKPIImportFailed
+	}
+	agentIDs := make(map[string]uint, len(agents))
+	for _, a := range agents {
+		agentIDs[a.Account] = a.ID
+	}
+
+	type valueKey struct {
+		definitionID, userID uint
+		day                  time.Time
+	}
+	result := &KPIIngestResult{}
+	seen := make(map[valueKey]int, len(inputs))
+	values := make([]models.KPIValue, 0, len(inputs))
+	for _, input := range inputs {
+		rowError := func(code, value string) {
+			result.Errors = append(result.Errors, KPIRowError{Line: input.Line, Code: code, Value: value})
+		}
+		if input.Metric == "" || input.Account == "" || input.Date == "" || input.Value == "" {
+			rowError(KPIRowMissingColumns, "")
+			continue
+		}
+		definition, ok := byKey[input.Metric]
+		if !ok {
+			rowError(KPIRowUnknownMetric, input.Metric)
+			continue
+		}
+		if !definition.Status {
+			rowError(KPIRowInactiveMetric, input.Metric)
+			continue
+		}
+		userID, ok := agentIDs[input.Account]
+		if !ok {
+			rowError(KPIRowUnknownAccount, input.Account)
+			continue
+		}
+		day, err := time.Parse(KPIImportDateLayout, input.Date)
+		if err != nil {
+			rowError(KPIRowInvalidDate, input.Date)
+			continue
+		}
+		value, ok := parseKPIValue(input.Value)
+		if !ok {
+			rowError(KPIRowInvalidValue, input.Value)
+			continue
+		}
+		// Aynı gösterge, ajan ve gün için iki satır hangisinin geçerli
+		// olduğu belirsiz olduğundan reddedilir.
+		key := valueKey{definition.ID, userID, day}
+		if line, ok := seen[key]; ok {
+			rowError(KPIRowDuplicateRecord, strconv.Itoa(line))
+			continue
+		}
+		seen[key] = input.Line
+		values = append(values, models.KPIValue{DefinitionID: definition.ID, UserID: userID, Day: day, Value: value})
+	}
+	if len(result.Errors) > 0 {
+		return result, ErrKPIImportInvalidRows
+	}
+
+	if err := s.repo.SaveValues(values); err != nil {
+		utils.LogFrom(ctx).Error("KPI değerleri kaydedilirken veritabanı hatası", zap.Int("count", len(values)), zap.Error(err))
+		return nil, ErrKPIImportFailed
+	}
+	result.Imported = len(values)
+	utils.SLogFrom(ctx).Infof("KPI değerleri yüklendi: %d satır", result.Imported)
+	return result, nil
+}
+
+func kpiWeeks(today time.Time, weeks int) []time.Time {
+	if weeks <= 0 {
+		weeks = KPIScorecardDefaultWeeks
+	}
+	weeks = min(weeks, KPIScorecardMaxWeeks)
+	last := models.WeekStart(models.CivilDate(today))
+	starts := make([]time.Time, weeks)
+	for i := range starts {
+		starts[i] = last.AddDate(0, 0, -7*(weeks-1-i))
+	}
+	return starts
+}
+
+func kpiRange(weeks []time.Time) (from, to time.Time) {
+	return weeks[0], weeks[len(weeks)-1].AddDate(0, 0, 6)
+}
+
+func kpiWeekIndex(weeks []time.Time, day time.Time) int {
+	index := int(models.WeekStart(models.CivilDate(day)).Sub(weeks[0]).Hours() / (24 * 7))
+	if index < 0 || index >= len(weeks) {
+		return -1
+	}
+	return index
+}
+
+type kpiBuckets [][]float64
+
+func newKPIBuckets(weeks int) kpiBuckets {
+	return make(kpiBuckets, weeks)
+}
+
+func (b kpiBuckets) series(definition models.KPIDefinition) KPISeries {
+	series := KPISeries{Weekly: make([]*float64, len(b))}
+	var all []float64
+	for i, values := range b {
+		if v, ok := definition.Aggregation.Aggregate(values); ok {
+			series.Weekly[i] = &v
+		}
+		all = append(all, values...)
+	}
+	if v, ok := definition.Aggregation.Aggregate(all); ok {
+		series.Total = &v
+	}
+
+	var recent []float64
+	for i := len(series.Weekly) - 1; i >= 0 && len(recent) < 2; i-- {
+		if series.Weekly[i] != nil {
+			recent = append(recent, *series.Weekly[i])
+		}
+	}
+	if len(recent) == 2 {
+		switch {
+		case definition.Better(recent[0], recent[1]):
+			series.Trend = 1
+		case definition.Better(recent[1], recent[0]):
+			series.Trend = -1
+		}
+	}
+	return series
+}
+
+func (s *KPIService) activeDefinitions(ctx context.Context) ([]models.KPIDefinition, error) {
+	definitions, err := s.ListDefinitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(definitions, func(d models.KPIDefinition) bool { return !d.Status }), nil
+}
+
+func (s *KPIService) TeamScorecard(ctx context.Context, actor *models.User, definitionID uint, today time.Time, weeks int) (*KPITeamScorecard, error) {
+	if actor.Type != models.Manager || actor.TeamID == nil {
+		return nil, ErrKPIForbidden
+	}
+	teamID := *actor.TeamID
+
+	definitions, err := s.activeDefinitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	scorecard := &KPITeamScorecard{Definitions: definitions, Weeks: kpiWeeks(today, weeks)}
+	scorecard.From, scorecard.To = kpiRange(scorecard.Weeks)
+	if len(definitions) == 0 {
+		return scorecard, nil
+	}
+	index := 0
+	if definitionID != 0 {
+		index = slices.IndexFunc(definitions, func(d models.KPIDefinition) bool { return d.ID == definitionID })
+		if index < 0 {
+			return nil, ErrKPINotFound
+		}
+	}
+	definition := definitions[index]
+	scorecard.Definition = &definition
+
+	agents, err := s.users.FindAgentsByTeam(teamID)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Takım ajanları alınırken hata oluştu", zap.Uint("team_id", teamID), zap.Error(err))
+		return nil, err
+	}
+	if len(agents) == 0 {
+		scorecard.Team = newKPIBuckets(len(scorecard.Weeks)).series(definition)
+		return scorecard, nil
+	}
+	userIDs := make([]uint, len(agents))
+	for i, a := range agents {
+		userIDs[i] = a.ID
+	}
+
+	values, err := s.repo.FindValues([]uint{definition.ID}, scorecard.From, scorecard.To, userIDs...)
+	if err != nil {
+		utils.LogFrom(ctx).Error("KPI değerleri alınırken hata oluştu", zap.Uint("team_id", teamID), zap.Uint("kpi_id", definition.ID), zap.Error(err))
+		return nil, err
+	}
+
+	team := newKPIBuckets(len(scorecard.Weeks))
+	perAgent := make(map[uint]kpiBuckets, len(agents))
+	for _, v := range values {
+		week := kpiWeekIndex(scorecard.Weeks, v.Day)
+		if week < 0 {
+			continue
+		}
+		buckets, ok := perAgent[v.UserID]
+		if !ok {
+			buckets = newKPIBuckets(len(scorecard.Weeks))
+			perAgent[v.UserID] = buckets
+		}
+		buckets[week] = append(buckets[week], v.Value)
+		team[week] = append(team[week], v.Value)
+	}
+	scorecard.Team = team.series(definition)
+
+	// Pasif ajanlar yalnız aralıkta verisi varsa karnede görünür.
+	for _, agent := range agents {
+		buckets, ok := perAgent[agent.ID]
+		if !ok {
+			if !agent.Status {
+				continue
+			}
+			buckets = newKPIBuckets(len(scorecard.Weeks))
+		}
+		scorecard.Rows = append(scorecard.Rows, KPIScorecardRow{Agent: agent, KPISeries: buckets.series(definition)})
+	}
+	rankKPIRows(definition, scorecard.Rows)
+	return scorecard, nil
+}
+
+func rankKPIRows(definition models.KPIDefinition, rows []KPIScorecardRow) {
+	slices.SortStableFunc(rows, func(a, b KPIScorecardRow) int {
+		switch {
+		case a.Total == nil && b.Total == nil:
+			return 0
+		case a.Total == nil:
+			return 1
+		case b.Total == nil:
+			return -1
+		case definition.Better(*a.Total, *b.Total):
+			return -1
+		case definition.Better(*b.Total, *a.Total):
+			return 1
+		}
+		return 0
+	})
+	for i := range rows {
+		switch {
+		case rows[i].Total == nil:
+			rows[i].Rank = 0
+		case i > 0 && rows[i-1].Total != nil && *rows[i-1].Total == *rows[i].Total:
+			rows[i].Rank = rows[i-1].Rank
+		default:
+			rows[i].Rank = i + 1
+		}
+	}
+}
+
+func (s *KPIService) AgentScorecard(ctx context.Context, actor *models.User, today time.Time, weeks int) (*KPIAgentScorecard, error) {
+	if actor.Type != models.Agent {
+		return nil, ErrKPIForbidden
+	}
+	definitions, err := s.activeDefinitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	scorecard := &KPIAgentScorecard{Weeks: kpiWeeks(today, weeks)}
+	scorecard.From, scorecard.To = kpiRange(scorecard.Weeks)
+	if len(definitions) == 0 {
+		return scorecard, nil
+	}
+	definitionIDs := make([]uint, len(definitions))
+	for i, d := range definitions {
+		definitionIDs[i] = d.ID
+	}
+
+	values, err := s.repo.FindValues(definitionIDs, scorecard.From, scorecard.To, actor.ID)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Ajan KPI değerleri alınırken hata oluştu", zap.Uint("user_id", actor.ID), zap.Error(err))
+		return nil, err
+	}
+	perDefinition := make(map[uint]kpiBuckets, len(definitions))
+	for _, d := range definitions {
+		perDefinition[d.ID] = newKPIBuckets(len(scorecard.Weeks))
+	}
+	for _, v := range values {
+		if week := kpiWeekIndex(scorecard.Weeks, v.Day); week >= 0 {
+			perDefinition[v.DefinitionID][week] = append(perDefinition[v.DefinitionID][week], v.Value)
+		}
+	}
+	for _, d := range definitions {
+		scorecard.Metrics = append(scorecard.Metrics, KPIAgentMetric{Definition: d, KPISeries: perDefinition[d.ID].series(d)})
+	}
+	return scorecard, nil
+}
+
+var _ IKPIService = (*KPIService)(nil)
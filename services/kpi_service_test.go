@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"zatrano/i18n"
+	"zatrano/models"
+)
+
+func TestKPIDefinitions(t *testing.T) {
+	s := newTestServices(t)
+	ctx := context.Background()
+
+	invalid := []struct {
+		name       string
+		definition models.KPIDefinition
+		want       error
+	}{
+		{"key", models.KPIDefinition{Key: "Calls", Name: "Çağrı", Unit: models.KPIUnitCount, Aggregation: models.KPIAggregationSum}, ErrKPIInvalidKey},
+		{"name", models.KPIDefinition{Key: "calls", Name: " ", Unit: models.KPIUnitCount, Aggregation: models.KPIAggregationSum}, ErrKPINameRequired},
+		{"long name", models.KPIDefinition{Key: "calls", Name: strings.Repeat("a", KPINameMaxLength+1), Unit: models.KPIUnitCount, Aggregation: models.KPIAggregationSum}, ErrKPINameTooLong},
+		{"unit", models.KPIDefinition{Key: "calls", Name: "Çağrı", Unit: "hours", Aggregation: models.KPIAggregationSum}, ErrKPIInvalidUnit},
+		{"aggregation", models.KPIDefinition{Key: "calls", Name: "Çağrı", Unit: models.KPIUnitCount, Aggregation: "max"}, ErrKPIInvalidAggregation},
+	}
+	for _, tc := range invalid {
+		if err := s.kpis.CreateDefinition(ctx, &tc.definition); err != tc.want {
+			t.Errorf("CreateDefinition(%s) error = %v, want %v", tc.name, err, tc.want)
+		}
+	}
+
+	calls := &models.KPIDefinition{Key: "calls", Name: " Çağrı ", Unit: models.KPIUnitCount, Aggregation: models.KPIAggregationSum, HigherIsBetter: true, Status: true}
+	if err := s.kpis.CreateDefinition(ctx, calls); err != nil || calls.Name != "Çağrı" {
+		t.Fatalf("CreateDefinition() = %+v, %v", calls, err)
+	}
+	if err := s.kpis.CreateDefinition(ctx, &models.KPIDefinition{Key: "calls", Name: "Yine", Unit: models.KPIUnitCount, Aggregation: models.KPIAggregationSum}); err != ErrKPIKeyTaken {
+		t.Fatalf("CreateDefinition(duplicate) error = %v, want ErrKPIKeyTaken", err)
+	}
+
+	update := models.KPIDefinition{Key: "renamed", Name: "Karşılanan çağrı", Unit: models.KPIUnitCount, Aggregation: models.KPIAggregationSum, HigherIsBetter: true}
+	if err := s.kpis.UpdateDefinition(ctx, calls.ID, &update); err != nil {
+		t.Fatalf("UpdateDefinition() error = %v", err)
+	}
+	got, err := s.kpis.GetDefinition(ctx, calls.ID)
+	if err != nil || got.Key != "calls" || got.Name != "Karşılanan çağrı" || got.Status {
+		t.Fatalf("GetDefinition() = %+v, %v", got, err)
+	}
+	if _, err := s.kpis.GetDefinition(ctx, 99); err != ErrKPINotFound {
+		t.Fatalf("GetDefinition(missing) error = %v, want ErrKPINotFound", err)
+	}
+}
+
+func TestKPIIngestAndScorecards(t *testing.T) {
+	s := newTestServices(t)
+	ctx := context.Background()
+	sales := s.mustCreateTeam(t, "Satış")
+	support := s.mustCreateTeam(t, "Destek")
+	manager := s.mustCreateUser(t, models.User{Name: "Yönetici", Account: "manager@x", Password: "secret1", Type: models.Manager, TeamID: &sales.ID})
+	ali := s.mustCreateUser(t, models.User{Name: "Ali", Account: "ali@x", Password: "secret1", Type: models.Agent, TeamID: &sales.ID})
+	veli := s.mustCreateUser(t, models.User{Name: "Veli", Account: "veli@x", Password: "secret1", Type: models.Agent, TeamID: &sales.ID})
+	s.mustCreateUser(t, models.User{Name: "Zeki", Account: "zeki@x", Password: "secret1", Type: models.Agent, TeamID: &sales.ID})
+	s.mustCreateUser(t, models.User{Name: "Can", Account: "can@x", Password: "secret1", Type: models.Agent, TeamID: &support.ID})
+
+	aht := &models.KPIDefinition{Key: "aht", Name: "Ortalama görüşme süresi", Unit: models.KPIUnitSeconds, Aggregation: models.KPIAggregationAvg, Status: true}
+	if err := s.kpis.CreateDefinition(ctx, aht); err != nil {
+		t.Fatalf("CreateDefinition() error = %v", err)
+	}
+	old := &models.KPIDefinition{Key: "old", Name: "Eski", Unit: models.KPIUnitCount, Aggregation: models.KPIAggregationSum}
+	if err := s.kpis.CreateDefinition(ctx, old); err != nil {
+		t.Fatalf("CreateDefinition() error = %v", err)
+	}
+
+	csv := "\ufeffAccount,Metric,Date,Value\n" +
+		"ali@x,aht,2026-03-02,200\n" +
+		"ali@x,aht,2026-03-03,100\n" +
+		"veli@x,aht,2026-03-04,120\n" +
+		"ali@x,aht,2026-03-10,\"90,5\"\n" +
+		"veli@x,aht,2026-03-10,130\n" +
+		"can@x,aht,2026-03-10,60\n"
+	inputs, err := s.kpis.ParseCSV(strings.NewReader(csv))
+	if err != nil || len(inputs) != 6 || inputs[0].Line != 2 || inputs[0].Metric != "aht" || inputs[3].Value != "90,5" {
+		t.Fatalf("ParseCSV() = %+v, %v", inputs, err)
+	}
+	if _, err := s.kpis.ParseCSV(strings.NewReader("account,value\nali@x,1\n")); err != ErrKPIImportInvalidCSV {
+		t.Fatalf("ParseCSV(missing columns) error = %v, want ErrKPIImportInvalidCSV", err)
+	}
+	if _, err := s.kpis.ParseCSV(strings.NewReader("metric,account,date,value\n")); err != ErrKPIImportEmpty {
+		t.Fatalf("ParseCSV(header only) error = %v, want ErrKPIImportEmpty", err)
+	}
+
+	// Geçersiz satırlar varsa hiçbir değer kaydedilmez.
+	bad := append(append([]KPIInput{}, inputs...),
+		KPIInput{Line: 8, Metric: "missing", Account: "ali@x", Date: "2026-03-02", Value: "1"},
+		KPIInput{Line: 9, Metric: "old", Account: "ali@x", Date: "2026-03-02", Value: "1"},
+		KPIInput{Line: 10, Metric: "aht", Account: "manager@x", Date: "2026-03-02", Value: "1"},
+		KPIInput{Line: 11, Metric: "aht", Account: "ali@x", Date: "02.03.2026", Value: "1"},
+		KPIInput{Line: 12, Metric: "aht", Account: "ali@x", Date: "2026-03-05", Value: "-1"},
+		KPIInput{Line: 13, Metric: "aht", Account: "ali@x", Date: "2026-03-02", Value: "1"},
+		KPIInput{Line: 14, Metric: "aht", Account: "", Date: "2026-03-02", Value: "1"},
+	)
+	result, err := s.kpis.Ingest(ctx, bad)
+	if err != ErrKPIImportInvalidRows || result == nil {
+		t.Fatalf("Ingest(invalid) = %+v, %v", result, err)
+	}
+	wantCodes := []string{KPIRowUnknownMetric, KPIRowInactiveMetric, KPIRowUnknownAccount, KPIRowInvalidDate, KPIRowInvalidValue, KPIRowDuplicateRecord, KPIRowMissingColumns}
+	if len(result.Errors) != len(wantCodes) {
+		t.Fatalf("Ingest(invalid) errors = %+v", result.Errors)
+	}
+	for i, code := range wantCodes {
+		if e := result.Errors[i]; e.Code != code || e.Line != 8+i {
+			t.Errorf("row error %d = %+v, want %s", i, e, code)
+		}
+		for _, locale := range i18n.Supported() {
+			if msg := result.Errors[i].Message(locale); msg == code || strings.Contains(msg, "%!") {
+				t.Errorf("%s: %q = %q", locale, code, msg)
+			}
+		}
+	}
+	if card, _ := s.kpis.AgentScorecard(ctx, ali, time.Date(2026, time.March, 11, 0, 0, 0, 0, time.UTC), 2); card.Metrics[0].Total != nil {
+		t.Fatal("invalid upload saved values")
+	}
+
+	if result, err = s.kpis.Ingest(ctx, inputs); err != nil || result.Imported != 6 {
+		t.Fatalf("Ingest() = %+v, %v", result, err)
+	}
+	// Yeniden yükleme değeri günceller.
+	if _, err := s.kpis.Ingest(ctx, []KPIInput{{Line: 1, Metric: "aht", Account: "ali@x", Date: "2026-03-10", Value: "80"}}); err != nil {
+		t.Fatalf("Ingest(update) error = %v", err)
+	}
+
+	today := time.Date(2026, time.March, 11, 15, 0, 0, 0, time.UTC)
+	if _, err := s.kpis.TeamScorecard(ctx, ali, 0, today, 2); err != ErrKPIForbidden {
+		t.Fatalf("TeamScorecard(agent) error = %v, want ErrKPIForbidden", err)
+	}
+	if _, err := s.kpis.TeamScorecard(ctx, manager, old.ID, today, 2); err != ErrKPINotFound {
+		t.Fatalf("TeamScorecard(inactive metric) error = %v, want ErrKPINotFound", err)
+	}
+	card, err := s.kpis.TeamScorecard(ctx, manager, 0, today, 2)
+	if err != nil || card.Definition == nil || card.Definition.ID != aht.ID || len(card.Definitions) != 1 {
+		t.Fatalf("TeamScorecard() = %+v, %v", card, err)
+	}
+	if len(card.Weeks) != 2 || !card.Weeks[0].Equal(time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("TeamScorecard() weeks = %v", card.Weeks)
+	}
+	// Düşük süre daha iyi: Ali (200, 100, 80 → 126.67) Veli'den (120, 130 →
+	// 125) kötüdür; verisi olmayan Zeki sondadır. Can başka takımdadır.
+	if len(card.Rows) != 3 {
+		t.Fatalf("TeamScorecard() rows = %+v", card.Rows)
+	}
+	if r := card.Rows[0]; r.Agent.ID != veli.ID || r.Rank != 1 || *r.Total != 125 || r.Trend != -1 {
+		t.Errorf("row 0 = %+v", r)
+	}
+	if r := card.Rows[1]; r.Agent.ID != ali.ID || r.Rank != 2 || *r.Weekly[0] != 150 || *r.Weekly[1] != 80 || r.Trend != 1 {
+		t.Errorf("row 1 = %+v", r)
+	}
+	if r := card.Rows[2]; r.Agent.Name != "Zeki" || r.Rank != 0 || r.Total != nil {
+		t.Errorf("row 2 = %+v", r)
+	}
+	if *card.Team.Weekly[0] != 140 || *card.Team.Weekly[1] != 105 || card.Team.Trend != 1 {
+		t.Errorf("team series = %+v", card.Team)
+	}
+
+	if _, err := s.kpis.AgentScorecard(ctx, manager, today, 2); err != ErrKPIForbidden {
+		t.Fatalf("AgentScorecard(manager) error = %v, want ErrKPIForbidden", err)
+	}
+	mine, err := s.kpis.AgentScorecard(ctx, veli, today, 2)
+	if err != nil || len(mine.Metrics) != 1 || *mine.Metrics[0].Total != 125 {
+		t.Fatalf("AgentScorecard() = %+v, %v", mine, err)
+	}
+}
@@ -0,0 +1,560 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"zatrano/i18n"
+	"zatrano/models"
+	"zatrano/repositories"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type LeaveServiceError string
+
+func (e LeaveServiceError) Error() string {
+	return i18n.T(i18n.DefaultLocale, string(e))
+}
+
+func (e LeaveServiceError) Code() string {
+	return string(e)
+}
+
+const (
+	ErrLeaveNotFound            LeaveServiceError = "errors.leave.not_found"
+	ErrLeaveForbidden           LeaveServiceError = "errors.leave.forbidden"
+	ErrLeaveInvalidType         LeaveServiceError = "errors.leave.invalid_type"
+	ErrLeaveInvalidDateRange    LeaveServiceError = "errors.leave.invalid_date_range"
+	ErrLeaveSpansYears          LeaveServiceError = "errors.leave.spans_years"
+	ErrLeaveStartInPast         LeaveServiceError = "errors.leave.start_in_past"
+	ErrLeaveNoWorkingDays       LeaveServiceError = "errors.leave.no_working_days"
+	ErrLeaveTextTooLong         LeaveServiceError = "errors.leave.text_too_long"
+	ErrLeaveOverlap             LeaveServiceError = "errors.leave.overlap"
+	ErrLeaveInsufficientBalance LeaveServiceError = "errors.leave.insufficient_balance"
+	ErrLeaveNoApprover          LeaveServiceError = "errors.leave.no_approver"
+	ErrLeaveNotPending          LeaveServiceError = "errors.leave.not_pending"
+	ErrLeaveCommentRequired     LeaveServiceError = "errors.leave.comment_required"
+	ErrLeaveInvalidBalance      LeaveServiceError = "errors.leave.invalid_balance"
+	ErrLeaveCreationFailed      LeaveServiceError = "errors.leave.creation_failed"
+	ErrLeaveUpdateFailed        LeaveServiceError = "errors.leave.update_failed"
+	ErrLeaveBalanceFailed       LeaveServiceError = "errors.leave.balance_failed"
+)
+
+const (
+	AnnualLeaveDaysPerYear = 14
+	SickLeaveDaysPerYear   = 10
+	LeaveMaxCarryOverDays  = 5
+	LeaveTextMaxLength     = 500
+)
+
+type LeaveBalanceView struct {
+	Type        models.LeaveType
+	Entitled    int
+	CarriedOver int
+	Used        int
+	Pending     int
+}
+
+func (b LeaveBalanceView) Total() int {
+	return b.Entitled + b.CarriedOver
+}
+
+func (b LeaveBalanceView) Remaining() int {
+	return b.Total() - b.Used
+}
+
+func (b LeaveBalanceView) Available() int {
+	return b.Remaining() - b.Pending
+}
+
+type LeaveOverview struct {
+	Year     int
+	Balances []LeaveBalanceView
+	Requests []models.LeaveRequest
+}
+
+type LeaveBalanceRow struct {
+	Agent    models.User
+	Balances []LeaveBalanceView
+}
+
+type LeaveCalendarDay struct {
+	Date    time.Time
+	InMonth bool
+	Leaves  []models.LeaveRequest
+}
+
+type LeaveCalendar struct {
+	Month   time.Time
+	Weeks   [][]LeaveCalendarDay
+	Pending []models.LeaveRequest
+}
+
+func (c *LeaveCalendar) PreviousMonth() time.Time {
+	return c.Month.AddDate(0, -1, 0)
+}
+
+func (c *LeaveCalendar) NextMonth() time.Time {
+	return c.Month.AddDate(0, 1, 0)
+}
+
+type ILeaveService interface {
+	Overview(ctx context.Context, user *models.User, year int) (*LeaveOverview, error)
+	RequestLeave(ctx context.Context, user *models.User, request *models.LeaveRequest) error
+	CancelLeave(ctx context.Context, user *models.User, id uint) error
+	ApproveLeave(ctx context.Context, actor *models.User, id uint, comment string) error
+	RejectLeave(ctx context.Context, actor *models.User, id uint, comment string) error
+	TeamCalendar(ctx context.Context, actor *models.User, month time.Time) (*LeaveCalendar, error)
+	Balances(ctx context.Context, actor *models.User, year int) ([]LeaveBalanceRow, error)
+	AdjustBalance(ctx context.Context, actor *models.User, balance *models.LeaveBalance) error
+}
+
+type LeaveService struct {
+	repo          repositories.ILeaveRepository
+	notifications INotificationService
+	now           func() time.Time
+}
+
+func NewLeaveService(repo repositories.ILeaveRepository, notifications INotificationService) ILeaveService {
+	return &LeaveService{repo: repo, notifications: notifications, now: func() time.Time { return time.Now().UTC() }}
+}
+
+func defaultEntitlement(leaveType models.LeaveType) int {
+	switch leaveType {
+	case models.LeaveAnnual:
+		return AnnualLeaveDaysPerYear
+	case models.LeaveSick:
+		return SickLeaveDaysPerYear
+	}
+	return 0
+}
+
+func balanceTypes() []models.LeaveType {
+	var types []models.LeaveType
+	for _, t := range models.LeaveTypes {
+		if t.HasBalance() {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+func userIDs(users []models.User) []uint {
+	ids := make([]uint, len(users))
+	for i, u := range users {
+		ids[i] = u.ID
+	}
+	return ids
+}
+
+func (s *LeaveService) accrue(ctx context.Context, year int, users []models.User) error {
+	if len(users) == 0 {
+		return nil
+	}
+	ids := userIDs(users)
+	existing, err := s.repo.FindBalances(year, ids...)
+	if err != nil {
+		return err
+	}
+	have := make(map[uint]map[models.LeaveType]bool)
+	for _, b := range existing {
+		if have[b.UserID] == nil {
+			have[b.UserID] = make(map[models.LeaveType]bool)
+		}
+		have[b.UserID][b.Type] = true
+	}
+
+	var missing []models.LeaveBalance
+	for _, id := range ids {
+		for _, t := range balanceTypes() {
+			if !have[id][t] {
+				missing = append(missing, models.LeaveBalance{UserID: id, Year: year, Type: t, Entitled: defaultEntitlement(t)})
+			}
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	previous, err := s.balanceViews(year-1, ids)
+	if err != nil {
+		return err
+	}
+	for i := range missing {
+		if missing[i].Type != models.LeaveAnnual {
+			continue
+		}
+		if view, ok := previous[missing[i].UserID][models.LeaveAnnual]; ok {
+			missing[i].CarriedOver = min(max(view.Remaining(), 0), LeaveMaxCarryOverDays)
+		}
+	}
+	if err := s.repo.CreateBalances(missing); err != nil {
+		return err
+	}
+	utils.SLogFrom(ctx).Infof("%d yılı için %d izin bakiyesi tahakkuk etti", year, len(missing))
+	return nil
+}
+
+func (s *LeaveService) balanceViews(year int, ids []uint) (map[uint]map[models.LeaveType]LeaveBalanceView, error) {
+	balances, err := s.repo.FindBalances(year, ids...)
+	if err != nil {
+		return nil, err
+	}
+	views := make(map[uint]map[models.LeaveType]LeaveBalanceView)
+	for _, b := range balances {
+		if views[b.UserID] == nil {
+			views[b.UserID] = make(map[models.LeaveType]LeaveBalanceView)
+		}
+		views[b.UserID][b.Type] = LeaveBalanceView{Type: b.Type, Entitled: b.Entitled, CarriedOver: b.CarriedOver}
+	}
+	if len(views) == 0 {
+		return views, nil
+	}
+
+	totals, err := s.repo.SumDays(year, ids...)
+	if err != nil {
+		return nil, err
+	}
+	for _, total := range totals {
+		view, ok := views[total.UserID][total.Type]
+		if !ok {
+			continue
+		}
+		if total.Status == models.LeaveApproved {
+			view.Used += total.Days
+		} else {
+			view.Pending += total.Days
+		}
+		views[total.UserID][total.Type] = view
+	}
+	return views, nil
+}
+
+func orderedViews(views map[models.LeaveType]LeaveBalanceView) []LeaveBalanceView {
+	ordered := []LeaveBalanceView{}
+	for _, t := range balanceTypes() {
+		if view, ok := views[t]; ok {
+			ordered = append(ordered, view)
+		}
+	}
+	return ordered
+}
+
+func (s *LeaveService) userBalance(ctx context.Context, user *models.User, year int, leaveType models.LeaveType) (LeaveBalanceView, error) {
+	if err := s.accrue(ctx, year, []models.User{*user}); err != nil {
+		return LeaveBalanceView{}, err
+	}
+	views, err := s.balanceViews(year, []uint{user.ID})
+	if err != nil {
+		return LeaveBalanceView{}, err
+	}
+	return views[user.ID][leaveType], nil
+}
+
+func (s *LeaveService) Overview(ctx context.Context, user *models.User, year int) (*LeaveOverview, error) {
+	if user.Type != models.Agent {
+		return nil, ErrLeaveForbidden
+	}
+	if err := s.accrue(ctx, year, []models.User{*user}); err != nil {
+		utils.LogFrom(ctx).Error("İzin bakiyesi tahakkuk ettirilemedi", zap.Uint("user_id", user.ID), zap.Int("year", year), zap.Error(err))
+		return nil, err
+	}
+	views, err := s.balanceViews(year, []uint{user.ID})
+	if err != nil {
+		utils.LogFrom(ctx).Error("İzin bakiyeleri alınırken hata oluştu", zap.Uint("user_id", user.ID), zap.Error(err))
+		return nil, err
+	}
+	requests, err := s.repo.FindByUser(user.ID)
+	if err != nil {
+		utils.LogFrom(ctx).Error("İzin talepleri alınırken hata oluştu", zap.Uint("user_id", user.ID), zap.Error(err))
+		return nil, err
+	}
+	return &LeaveOverview{Year: year, Balances: orderedViews(views[user.ID]), Requests: requests}, nil
+}
+
+func normalizeLeaveRequest(request *models.LeaveRequest, today time.Time) error {
+	request.Reason = strings.TrimSpace(request.Reason)
+	request.StartDate = models.CivilDate(request.StartDate)
+	request.EndDate = models.CivilDate(request.EndDate)
+	switch {
+	case !request.Type.IsValid():
+		return ErrLeaveInvalidType
+	case request.StartDate.IsZero() || request.EndDate.IsZero() || request.EndDate.Before(request.StartDate):
+		return ErrLeaveInvalidDateRange
+	case request.StartDate.Year() != request.EndDate.Year():
+		return ErrLeaveSpansYears
+	// Hastalık izni geriye dönük bildirilebilir; diğer izinler önceden istenir.
+	case request.Type != models.LeaveSick && request.StartDate.Before(today):
+		return ErrLeaveStartInPast
+	case utf8.RuneCountInString(request.Reason) > LeaveTextMaxLength:
+		return ErrLeaveTextTooLong
+	}
+	request.Days = models.LeaveWorkingDays(request.StartDate, request.EndDate)
+	if request.Days == 0 {
+		return ErrLeaveNoWorkingDays
+	}
+	return nil
+}
+
+func (s *LeaveService) RequestLeave(ctx context.Context, user *models.User, request *models.LeaveRequest) error {
+	if user.Type != models.Agent {
+		return ErrLeaveForbidden
+	}
+	if user.TeamID == nil {
+		return ErrLeaveNoApprover
+	}
+	if err := normalizeLeaveRequest(request, models.CivilDate(s.now())); err != nil {
+		return err
+	}
+
+	existing, err := s.repo.FindByUser(user.ID)
+	if err != nil {
+		utils.LogFrom(ctx).Error("İzin talepleri alınırken hata oluştu", zap.Uint("user_id", user.ID), zap.Error(err))
+		return ErrLeaveCreationFailed
+	}
+	for _, other := range existing {
+		if other.IsActive() && !other.StartDate.After(request.EndDate) && !other.EndDate.Before(request.StartDate) {
+			return ErrLeaveOverlap
+		}
+	}
+
+	approver, err := s.repo.FindTeamManager(*user.TeamID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrLeaveNoApprover
+		}
+		utils.LogFrom(ctx).Error("Takım yöneticisi bulunamadı", zap.Uint("team_id", *user.TeamID), zap.Error(err))
+		return ErrLeaveCreationFailed
+	}
+
+	if request.Type.HasBalance() {
+		balance, err := s.userBalance(ctx, user, request.StartDate.Year(), request.Type)
+		if err != nil {
+			utils.LogFrom(ctx).Error("İzin bakiyesi alınamadı", zap.Uint("user_id", user.ID), zap.Error(err))
+			return ErrLeaveCreationFailed
+		}
+		if request.Days > balance.Available() {
+			return ErrLeaveInsufficientBalance
+		}
+	}
+
+	request.UserID = user.ID
+	request.TeamID = *user.TeamID
+	request.ApproverID = &approver.ID
+	request.Status = models.LeavePending
+	request.DecidedByID, request.DecidedAt, request.DecisionComment = nil, nil, ""
+	if err := s.repo.Create(request); err != nil {
+		utils.LogFrom(ctx).Error("İzin talebi oluşturulurken veritabanı hatası", zap.Uint("user_id", user.ID), zap.Error(err))
+		return ErrLeaveCreationFailed
+	}
+	utils.SLogFrom(ctx).Infof("İzin talebi oluşturuldu: %s, %d gün (ID: %d)", user.Account, request.Days, request.ID)
+
+	if err := s.notifications.Notify(ctx, approver.ID, "notifications.leave_requested", "/manager/leaves", user.Name); err != nil {
+		utils.LogFrom(ctx).Warn("İzin talebi bildirimi gönderilemedi", zap.Uint("leave_id", request.ID), zap.Error(err))
+	}
+	return nil
+}
+
+func (s *LeaveService) find(ctx context.Context, id uint) (*models.LeaveRequest, error) {
+	request, err := s.repo.FindByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrLeaveNotFound
+		}
+		utils.LogFrom(ctx).Error("İzin talebi alınırken hata oluştu", zap.Uint("leave_id", id), zap.Error(err))
+		return nil, err
+	}
+	return request, nil
+}
+
+func (s *LeaveService) updatePending(ctx context.Context, id uint, data map[string]interface{}) error {
+	if err := s.repo.UpdatePending(id, data); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrLeaveNotPending
+		}
+		utils.LogFrom(ctx).Error("İzin talebi güncellenemedi", zap.Uint("leave_id", id), zap.Error(err))
+		return ErrLeaveUpdateFailed
+	}
+	return nil
+}
+
+func (s *LeaveService) CancelLeave(ctx context.Context, user *models.User, id uint) error {
+	request, err := s.find(ctx, id)
+	if err != nil {
+		return err
+	}
+	if request.UserID != user.ID {
+		return ErrLeaveNotFound
+	}
+	if !request.IsPending() {
+		return ErrLeaveNotPending
+	}
+	return s.updatePending(ctx, id, map[string]interface{}{"status": models.LeaveCancelled})
+}
+
+func (s *LeaveService) decidable(ctx context.Context, actor *models.User, id uint) (*models.LeaveRequest, error) {
+	if actor.Type != models.Manager || actor.TeamID == nil {
+		return nil, ErrLeaveForbidden
+	}
+	request, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if request.TeamID != *actor.TeamID {
+		return nil, ErrLeaveNotFound
+	}
+	if !request.IsPending() {
+		return nil, ErrLeaveNotPending
+	}
+	return request, nil
+}
+
+func (s *LeaveService) decide(ctx context.Context, actor *models.User, request *models.LeaveRequest, status models.LeaveStatus, comment string) error {
+	err := s.updatePending(ctx, request.ID, map[string]interface{}{
+		"status":           status,
+		"decided_by_id":    actor.ID,
+		"decided_at":       s.now(),
+		"decision_comment": comment,
+	})
+	if err != nil {
+		return err
+	}
+	utils.SLogFrom(ctx).Infof("İzin talebi %s: ID %d, karar veren: %s", status, request.ID, actor.Account)
+
+	messageID := "notifications.leave_approved"
+	if status == models.LeaveRejected {
+		messageID = "notifications.leave_rejected"
+	}
+	period := request.StartDate.Format(utils.DateInputLayout) + " – " + request.EndDate.Format(utils.DateInputLayout)
+	if err := s.notifications.Notify(ctx, request.UserID, messageID, "/agent/leaves", period); err != nil {
+		utils.LogFrom(ctx).Warn("İzin kararı bildirimi gönderilemedi", zap.Uint("leave_id", request.ID), zap.Error(err))
+	}
+	return nil
+}
+
+func (s *LeaveService) ApproveLeave(ctx context.Context, actor *models.User, id uint, comment string) error {
+	request, err := s.decidable(ctx, actor, id)
+	if err != nil {
+		return err
+	}
+	comment = strings.TrimSpace(comment)
+	if utf8.RuneCountInString(comment) > LeaveTextMaxLength {
+		return ErrLeaveTextTooLong
+	}
+	if request.Type.HasBalance() && request.User != nil {
+		balance, err := s.userBalance(ctx, request.User, request.StartDate.Year(), request.Type)
+		if err != nil {
+			utils.LogFrom(ctx).Error("İzin bakiyesi alınamadı", zap.Uint("user_id", request.UserID), zap.Error(err))
+			return ErrLeaveUpdateFailed
+		}
+		if request.Days > balance.Remaining() {
+			return ErrLeaveInsufficientBalance
+		}
+	}
+	return s.decide(ctx, actor, request, models.LeaveApproved, comment)
+}
+
+func (s *LeaveService) RejectLeave(ctx context.Context, actor *models.User, id uint, comment string) error {
+	request, err := s.decidable(ctx, actor, id)
+	if err != nil {
+		return err
+	}
+	comment = strings.TrimSpace(comment)
+	switch {
+	case comment == "":
+		return ErrLeaveCommentRequired
+	case utf8.RuneCountInString(comment) > LeaveTextMaxLength:
+		return ErrLeaveTextTooLong
+	}
+	return s.decide(ctx, actor, request, models.LeaveRejected, comment)
+}
+
+func (s *LeaveService) TeamCalendar(ctx context.Context, actor *models.User, month time.Time) (*LeaveCalendar, error) {
+	if actor.Type != models.Manager || actor.TeamID == nil {
+		return nil, ErrLeaveForbidden
+	}
+	teamID := *actor.TeamID
+	first := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1)
+	gridStart := models.WeekStart(first)
+	gridEnd := models.WeekStart(last).AddDate(0, 0, 6)
+
+	requests, err := s.repo.FindByTeam(teamID, gridStart, gridEnd, models.LeavePending, models.LeaveApproved)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Takım izinleri alınırken hata oluştu", zap.Uint("team_id", teamID), zap.Error(err))
+		return nil, err
+	}
+	pending, err := s.repo.FindPendingByTeam(teamID)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Bekleyen izin talepleri alınırken hata oluştu", zap.Uint("team_id", teamID), zap.Error(err))
+		return nil, err
+	}
+
+	calendar := &LeaveCalendar{Month: first, Pending: pending}
+	for weekStart := gridStart; !weekStart.After(gridEnd); weekStart = weekStart.AddDate(0, 0, 7) {
+		week := make([]LeaveCalendarDay, 7)
+		for i := range week {
+			date := weekStart.AddDate(0, 0, i)
+			week[i] = LeaveCalendarDay{Date: date, InMonth: date.Month() == first.Month()}
+			for _, r := range requests {
+				if r.Covers(date) {
+					week[i].Leaves = append(week[i].Leaves, r)
+				}
+			}
+		}
+		calendar.Weeks = append(calendar.Weeks, week)
+	}
+	return calendar, nil
+}
+
+func (s *LeaveService) Balances(ctx context.Context, actor *models.User, year int) ([]LeaveBalanceRow, error) {
+	if actor.Type != models.System {
+		return nil, ErrLeaveForbidden
+	}
+	agents, err := s.repo.FindAgents()
+	if err != nil {
+		utils.LogFrom(ctx).Error("Ajanlar alınırken hata oluştu", zap.Error(err))
+		return nil, err
+	}
+	if err := s.accrue(ctx, year, agents); err != nil {
+		utils.LogFrom(ctx).Error("İzin bakiyeleri tahakkuk ettirilemedi", zap.Int("year", year), zap.Error(err))
+		return nil, err
+	}
+	views, err := s.balanceViews(year, userIDs(agents))
+	if err != nil {
+		utils.LogFrom(ctx).Error("İzin bakiyeleri alınırken hata oluştu", zap.Int("year", year), zap.Error(err))
+		return nil, err
+	}
+	rows := make([]LeaveBalanceRow, len(agents))
+	for i, agent := range agents {
+		rows[i] = LeaveBalanceRow{Agent: agent, Balances: orderedViews(views[agent.ID])}
+	}
+	return rows, nil
+}
+
+func (s *LeaveService) AdjustBalance(ctx context.Context, actor *models.User, balance *models.LeaveBalance) error {
+	if actor.Type != models.System {
+		return ErrLeaveForbidden
+	}
+	switch {
+	case !slices.Contains(balanceTypes(), balance.Type):
+		return ErrLeaveInvalidType
+	case balance.Year < 1 || balance.Entitled < 0 || balance.CarriedOver < 0:
+		return ErrLeaveInvalidBalance
+	}
+	if err := s.repo.SaveBalance(balance); err != nil {
+		utils.LogFrom(ctx).Error("İzin bakiyesi kaydedilemedi", zap.Uint("user_id", balance.UserID), zap.Int("year", balance.Year), zap.Error(err))
+		return ErrLeaveBalanceFailed
+	}
+	utils.SLogFrom(ctx).Infof("İzin bakiyesi düzeltildi: kullanıcı %d, %d %s, hak %d, devreden %d",
+		balance.UserID, balance.Year, balance.Type, balance.Entitled, balance.CarriedOver)
+	return nil
+}
+
+var _ ILeaveService = (*LeaveService)(nil)
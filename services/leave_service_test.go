@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"zatrano/models"
+)
+
+func TestLeaveRequestApprovalFlow(t *testing.T) {
+	s := newTestServices(t)
+	ctx := context.Background()
+	sales := s.mustCreateTeam(t, "Satış")
+	support := s.mustCreateTeam(t, "Destek")
+	manager := s.mustCreateUser(t, models.User{Name: "Yönetici", Account: "manager@x", Password: "secret1", Type: models.Manager, TeamID: &sales.ID})
+	ali := s.mustCreateUser(t, models.User{Name: "Ali", Account: "ali@x", Password: "secret1", Type: models.Agent, TeamID: &sales.ID})
+	can := s.mustCreateUser(t, models.User{Name: "Can", Account: "can@x", Password: "secret1", Type: models.Agent, TeamID: &support.ID})
+
+	// Testler saate bağlı olmasın diye talepler gelecek yıla açılır.
+	year := time.Now().Year() + 1
+	monday := models.WeekStart(time.Date(year, time.March, 10, 0, 0, 0, 0, time.UTC))
+	day := func(offset int) time.Time { return monday.AddDate(0, 0, offset) }
+	request := func(user *models.User, leaveType models.LeaveType, start, end time.Time) (*models.LeaveRequest, error) {
+		r := &models.LeaveRequest{Type: leaveType, StartDate: start, EndDate: end, Reason: "  tatil  "}
+		return r, s.leaves.RequestLeave(ctx, user, r)
+	}
+
+	if _, err := request(can, models.LeaveAnnual, day(0), day(0)); err != ErrLeaveNoApprover {
+		t.Fatalf("RequestLeave(no manager) error = %v, want ErrLeaveNoApprover", err)
+	}
+	if _, err := request(manager, models.LeaveAnnual, day(0), day(0)); err != ErrLeaveForbidden {
+		t.Fatalf("RequestLeave(manager) error = %v, want ErrLeaveForbidden", err)
+	}
+	invalid := []struct {
+		name       string
+		leaveType  models.LeaveType
+		start, end time.Time
+		want       error
+	}{
+		{"type", models.LeaveType("holiday"), day(0), day(0), ErrLeaveInvalidType},
+		{"reversed", models.LeaveAnnual, day(2), day(0), ErrLeaveInvalidDateRange},
+		{"spans years", models.LeaveUnpaid, time.Date(year, time.December, 30, 0, 0, 0, 0, time.UTC), time.Date(year+1, time.January, 2, 0, 0, 0, 0, time.UTC), ErrLeaveSpansYears},
+		{"past", models.LeaveAnnual, time.Date(year-2, time.June, 1, 0, 0, 0, 0, time.UTC), time.Date(year-2, time.June, 2, 0, 0, 0, 0, time.UTC), ErrLeaveStartInPast},
+		{"weekend", models.LeaveAnnual, day(5), day(6), ErrLeaveNoWorkingDays},
+	}
+	for _, tc := range invalid {
+		if _, err := request(ali, tc.leaveType, tc.start, tc.end); err != tc.want {
+			t.Errorf("RequestLeave(%s) error = %v, want %v", tc.name, err, tc.want)
+		}
+	}
+
+	week, err := request(ali, models.LeaveAnnual, day(0), day(6))
+	if err != nil {
+		t.Fatalf("RequestLeave() error = %v", err)
+	}
+	if week.Days != 5 || week.Reason != "tatil" || week.ApproverID == nil || *week.ApproverID != manager.ID || !week.IsPending() {
+		t.Fatalf("RequestLeave() = %+v, want a pending 5 day request routed to the manager", week)
+	}
+	if recent, _ := s.notifications.ListRecent(ctx, manager.ID); len(recent) != 1 || recent[0].MessageID != "notifications.leave_requested" {
+		t.Fatalf("manager notifications = %+v", recent)
+	}
+	if _, err := request(ali, models.LeaveSick, day(4), day(7)); err != ErrLeaveOverlap {
+		t.Fatalf("RequestLeave(overlap) error = %v, want ErrLeaveOverlap", err)
+	}
+	// 14 günlük haktan 5 gün bekleyen talepte; 10 iş günü sığmaz.
+	if _, err := request(ali, models.LeaveAnnual, day(7), day(18)); err != ErrLeaveInsufficientBalance {
+		t.Fatalf("RequestLeave(over balance) error = %v, want ErrLeaveInsufficientBalance", err)
+	}
+	if _, err := request(ali, models.LeaveUnpaid, day(7), day(18)); err != nil {
+		t.Fatalf("RequestLeave(unpaid) error = %v", err)
+	}
+
+	overview, err := s.leaves.Overview(ctx, ali, year)
+	if err != nil || len(overview.Balances) != 2 || len(overview.Requests) != 2 {
+		t.Fatalf("Overview() = %+v, %v", overview, err)
+	}
+	if annual := overview.Balances[0]; annual.Type != models.LeaveAnnual || annual.Entitled != AnnualLeaveDaysPerYear || annual.Pending != 5 || annual.Available() != 9 {
+		t.Fatalf("annual balance = %+v", annual)
+	}
+
+	otherManager := s.mustCreateUser(t, models.User{Name: "Destek Yöneticisi", Account: "support@x", Password: "secret1", Type: models.Manager, TeamID: &support.ID})
+	if err := s.leaves.ApproveLeave(ctx, otherManager, week.ID, ""); err != ErrLeaveNotFound {
+		t.Fatalf("ApproveLeave(other team) error = %v, want ErrLeaveNotFound", err)
+	}
+	if err := s.leaves.RejectLeave(ctx, manager, week.ID, " "); err != ErrLeaveCommentRequired {
+		t.Fatalf("RejectLeave(no comment) error = %v, want ErrLeaveCommentRequired", err)
+	}
+	if err := s.leaves.ApproveLeave(ctx, manager, week.ID, "İyi tatiller"); err != nil {
+		t.Fatalf("ApproveLeave() error = %v", err)
+	}
+	if err := s.leaves.ApproveLeave(ctx, manager, week.ID, ""); err != ErrLeaveNotPending {
+		t.Fatalf("ApproveLeave() twice error = %v, want ErrLeaveNotPending", err)
+	}
+	if err := s.leaves.CancelLeave(ctx, ali, week.ID); err != ErrLeaveNotPending {
+		t.Fatalf("CancelLeave(approved) error = %v, want ErrLeaveNotPending", err)
+	}
+	recent, _ := s.notifications.ListRecent(ctx, ali.ID)
+	if len(recent) != 1 || recent[0].MessageID != "notifications.leave_approved" || recent[0].Link != "/agent/leaves" {
+		t.Fatalf("agent notifications = %+v", recent)
+	}
+
+	sick, err := request(ali, models.LeaveSick, day(21), day(22))
+	if err != nil {
+		t.Fatalf("RequestLeave(sick) error = %v", err)
+	}
+	if err := s.leaves.CancelLeave(ctx, can, sick.ID); err != ErrLeaveNotFound {
+		t.Fatalf("CancelLeave(someone else's) error = %v, want ErrLeaveNotFound", err)
+	}
+	if err := s.leaves.RejectLeave(ctx, manager, sick.ID, "Rapor eksik"); err != nil {
+		t.Fatalf("RejectLeave() error = %v", err)
+	}
+	if recent, _ := s.notifications.ListRecent(ctx, ali.ID); recent[0].MessageID != "notifications.leave_rejected" {
+		t.Fatalf("latest agent notification = %+v, want leave_rejected", recent[0])
+	}
+
+	calendar, err := s.leaves.TeamCalendar(ctx, manager, day(0))
+	if err != nil {
+		t.Fatalf("TeamCalendar() error = %v", err)
+	}
+	if !calendar.Month.Equal(time.Date(year, time.March, 1, 0, 0, 0, 0, time.UTC)) || len(calendar.Pending) != 1 {
+		t.Fatalf("TeamCalendar() = month %v, %d pending", calendar.Month, len(calendar.Pending))
+	}
+	covered := 0
+	for _, week := range calendar.Weeks {
+		for _, d := range week {
+			if d.Date.Equal(day(0)) && (len(d.Leaves) != 1 || d.Leaves[0].User == nil || d.Leaves[0].User.ID != ali.ID) {
+				t.Fatalf("calendar %v = %+v, want Ali's leave", d.Date, d.Leaves)
+			}
+			covered += len(d.Leaves)
+		}
+	}
+	if covered == 0 {
+		t.Fatal("calendar does not show any leave")
+	}
+	if _, err := s.leaves.TeamCalendar(ctx, ali, day(0)); err != ErrLeaveForbidden {
+		t.Fatalf("TeamCalendar(agent) error = %v, want ErrLeaveForbidden", err)
+	}
+}
+
+func TestLeaveBalancesAccrueAndCarryOver(t *testing.T) {
+	s := newTestServices(t)
+	ctx := context.Background()
+	team := s.mustCreateTeam(t, "Satış")
+	s.mustCreateUser(t, models.User{Name: "Yönetici", Account: "manager@x", Password: "secret1", Type: models.Manager, TeamID: &team.ID})
+	ali := s.mustCreateUser(t, models.User{Name: "Ali", Account: "ali@x", Password: "secret1", Type: models.Agent, TeamID: &team.ID})
+	admin := s.mustCreateUser(t, models.User{Name: "Admin", Account: "admin@x", Password: "secret1", Type: models.System})
+
+	year := time.Now().Year() + 1
+	if _, err := s.leaves.Balances(ctx, ali, year); err != ErrLeaveForbidden {
+		t.Fatalf("Balances(agent) error = %v, want ErrLeaveForbidden", err)
+	}
+	if err := s.leaves.AdjustBalance(ctx, admin, &models.LeaveBalance{UserID: ali.ID, Year: year, Type: models.LeaveUnpaid, Entitled: 3}); err != ErrLeaveInvalidType {
+		t.Fatalf("AdjustBalance(unpaid) error = %v, want ErrLeaveInvalidType", err)
+	}
+	if err := s.leaves.AdjustBalance(ctx, admin, &models.LeaveBalance{UserID: ali.ID, Year: year, Type: models.LeaveAnnual, Entitled: -1}); err != ErrLeaveInvalidBalance {
+		t.Fatalf("AdjustBalance(negative) error = %v, want ErrLeaveInvalidBalance", err)
+	}
+	if err := s.leaves.AdjustBalance(ctx, admin, &models.LeaveBalance{UserID: ali.ID, Year: year, Type: models.LeaveAnnual, Entitled: 20}); err != nil {
+		t.Fatalf("AdjustBalance() error = %v", err)
+	}
+
+	monday := models.WeekStart(time.Date(year, time.June, 10, 0, 0, 0, 0, time.UTC))
+	leave := &models.LeaveRequest{Type: models.LeaveAnnual, StartDate: monday, EndDate: monday.AddDate(0, 0, 4)}
+	if err := s.leaves.RequestLeave(ctx, ali, leave); err != nil {
+		t.Fatalf("RequestLeave() error = %v", err)
+	}
+
+	rows, err := s.leaves.Balances(ctx, admin, year)
+	if err != nil || len(rows) != 1 || rows[0].Agent.ID != ali.ID || len(rows[0].Balances) != 2 {
+		t.Fatalf("Balances() = %+v, %v", rows, err)
+	}
+	annual, sick := rows[0].Balances[0], rows[0].Balances[1]
+	if annual.Total() != 20 || annual.Pending != 5 || annual.Remaining() != 20 || sick.Entitled != SickLeaveDaysPerYear {
+		t.Fatalf("balances = %+v / %+v", annual, sick)
+	}
+
+	// Bekleyen talep devreden günü etkilemez; kalan 20 günün yalnızca 5'i devreder.
+	next, err := s.leaves.Balances(ctx, admin, year+1)
+	if err != nil || next[0].Balances[0].CarriedOver != LeaveMaxCarryOverDays || next[0].Balances[0].Entitled != AnnualLeaveDaysPerYear {
+		t.Fatalf("Balances(next year) = %+v, %v", next, err)
+	}
+	if next[0].Balances[1].CarriedOver != 0 {
+		t.Fatalf("sick leave carried over: %+v", next[0].Balances[1])
+	}
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"sync"
+
+	"zatrano/models"
+)
+
+const notificationBufferSize = 16
+
+type notificationBroker struct {
+	mu          sync.Mutex
+	subscribers map[uint]map[chan models.Notification]struct{}
+	closed      bool
+}
+
+func newNotificationBroker() *notificationBroker {
+	return &notificationBroker{subscribers: make(map[uint]map[chan models.Notification]struct{})}
+}
+
+func (b *notificationBroker) subscribe(userID uint) (<-chan models.Notification, func()) {
+	ch := make(chan models.Notification, notificationBufferSize)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	if b.subscribers[userID] == nil {
+		b.subscribers[userID] = make(map[chan models.Notification]struct{})
+	}
+	b.subscribers[userID][ch] = struct{}{}
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subscribers[userID][ch]; !ok {
+			return
+		}
+		delete(b.subscribers[userID], ch)
+		if len(b.subscribers[userID]) == 0 {
+			delete(b.subscribers, userID)
+		}
+		close(ch)
+	}
+}
+
+func (b *notificationBroker) publish(notification models.Notification) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subscribers[notification.UserID] {
+		select {
+		case ch <- notification:
+		default:
+		}
+	}
+}
+
+func (b *notificationBroker) close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for userID, channels := range b.subscribers {
+		for ch := range channels {
+			close(ch)
+		}
+		delete(b.subscribers, userID)
+	}
+}
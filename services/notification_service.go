@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"zatrano/i18n"
+	"zatrano/models"
+	"zatrano/repositories"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type NotificationServiceError string
+
+func (e NotificationServiceError) Error() string {
+	return i18n.T(i18n.DefaultLocale, string(e))
+}
+
+func (e NotificationServiceError) Code() string {
+	return string(e)
+}
+
+const (
+	ErrNotificationNotFound     NotificationServiceError = "errors.notification.not_found"
+	ErrNotificationUpdateFailed NotificationServiceError = "errors.notification.update_failed"
+)
+
+const NotificationListLimit = 10
+
+type INotificationService interface {
+	Notify(ctx context.Context, userID uint, messageID, link string, params ...string) error
+	ListRecent(ctx context.Context, userID uint) ([]models.Notification, error)
+	UnreadCount(ctx context.Context, userID uint) (int64, error)
+	MarkRead(ctx context.Context, userID, id uint) error
+	MarkAllRead(ctx context.Context, userID uint) error
+	Subscribe(userID uint) (<-chan models.Notification, func())
+	Close()
+}
+
+type NotificationService struct {
+	repo   repositories.INotificationRepository
+	broker *notificationBroker
+	now    func() time.Time
+}
+
+func NewNotificationService(repo repositories.INotificationRepository) INotificationService {
+	return &NotificationService{
+		repo:   repo,
+		broker: newNotificationBroker(),
+		now:    func() time.Time { return time.Now().UTC() },
+	}
+}
+
+func (s *NotificationService) Notify(ctx context.Context, userID uint, messageID, link string, params ...string) error {
+	notification := &models.Notification{
+		UserID:    userID,
+		MessageID: messageID,
+		Params:    params,
+		Link:      link,
+		CreatedAt: s.now(),
+	}
+	if err := s.repo.Create(notification); err != nil {
+		utils.LogFrom(ctx).Error("Bildirim kaydedilemedi", zap.Uint("user_id", userID), zap.String("message_id", messageID), zap.Error(err))
+		return err
+	}
+	s.broker.publish(*notification)
+	return nil
+}
+
+func (s *NotificationService) ListRecent(ctx context.Context, userID uint) ([]models.Notification, error) {
+	notifications, err := s.repo.FindRecent(userID, NotificationListLimit)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Bildirimler alınırken hata oluştu", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, err
+	}
+	return notifications, nil
+}
+
+func (s *NotificationService) UnreadCount(ctx context.Context, userID uint) (int64, error) {
+	count, err := s.repo.CountUnread(userID)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Okunmamış bildirim sayısı alınamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return 0, err
+	}
+	return count, nil
+}
+
+func (s *NotificationService) MarkRead(ctx context.Context, userID, id uint) error {
+	if err := s.repo.MarkRead(userID, id, s.now()); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrNotificationNotFound
+		}
+		utils.LogFrom(ctx).Error("Bildirim okundu olarak işaretlenemedi", zap.Uint("notification_id", id), zap.Error(err))
+		return ErrNotificationUpdateFailed
+	}
+	return nil
+}
+
+func (s *NotificationService) MarkAllRead(ctx context.Context, userID uint) error {
+	if _, err := s.repo.MarkAllRead(userID, s.now()); err != nil {
+		utils.LogFrom(ctx).Error("Bildirimler okundu olarak işaretlenemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return ErrNotificationUpdateFailed
+	}
+	return nil
+}
+
+func (s *NotificationService) Subscribe(userID uint) (<-chan models.Notification, func()) {
+	return s.broker.subscribe(userID)
+}
+
+func (s *NotificationService) Close() {
+	s.broker.close()
+}
+
+var _ INotificationService = (*NotificationService)(nil)
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"zatrano/i18n"
+	"zatrano/models"
+)
+
+func TestUserTeamChangeSendsNotification(t *testing.T) {
+	s := newTestServices(t)
+	ctx := context.Background()
+	sales := s.mustCreateTeam(t, "Satış")
+	support := s.mustCreateTeam(t, "Destek")
+	agent := s.mustCreateUser(t, models.User{Name: "Ali", Account: "ali@x", Password: "secret1", Type: models.Agent, TeamID: &sales.ID})
+
+	stream, cancel := s.notifications.Subscribe(agent.ID)
+	defer cancel()
+
+	// Takımı değişmeyen güncelleme bildirim üretmez.
+	update := models.User{Name: "Ali Veli", Account: "ali@x", Status: true, Type: models.Agent, TeamID: &sales.ID}
+	if err := s.users.UpdateUser(ctx, agent.ID, &update); err != nil {
+		t.Fatalf("UpdateUser() error = %v", err)
+	}
+	update.TeamID = &support.ID
+	if err := s.users.UpdateUser(ctx, agent.ID, &update); err != nil {
+		t.Fatalf("UpdateUser(team) error = %v", err)
+	}
+
+	select {
+	case n := <-stream:
+		if got, want := n.Message(i18n.EN), i18n.T(i18n.EN, "notifications.team_changed", "Destek"); got != want {
+			t.Fatalf("streamed message = %q, want %q", got, want)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("team change notification was not streamed")
+	}
+	select {
+	case n := <-stream:
+		t.Fatalf("unexpected extra notification %+v", n)
+	default:
+	}
+
+	recent, err := s.notifications.ListRecent(ctx, agent.ID)
+	if err != nil || len(recent) != 1 {
+		t.Fatalf("ListRecent() = %d, %v; want 1", len(recent), err)
+	}
+	if err := s.notifications.MarkRead(ctx, agent.ID+1, recent[0].ID); err != ErrNotificationNotFound {
+		t.Fatalf("MarkRead(other user) error = %v, want ErrNotificationNotFound", err)
+	}
+	if err := s.notifications.MarkRead(ctx, agent.ID, recent[0].ID); err != nil {
+		t.Fatalf("MarkRead() error = %v", err)
+	}
+	if unread, _ := s.notifications.UnreadCount(ctx, agent.ID); unread != 0 {
+		t.Fatalf("UnreadCount() = %d, want 0", unread)
+	}
+
+	s.notifications.Close()
+	if _, open := <-stream; open {
+		t.Fatal("stream is still open after Close()")
+	}
+	late, _ := s.notifications.Subscribe(agent.ID)
+	if _, open := <-late; open {
+		t.Fatal("Subscribe() after Close() returned an open stream")
+	}
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"io"
+	netmail "net/mail"
+	"time"
+
+	"zatrano/i18n"
+	"zatrano/mailer"
+	"zatrano/models"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+)
+
+const ReportViewName = "reports/summary_report"
+
+type ReportRenderer interface {
+	Render(out io.Writer, name string, binding interface{}, layout ...string) error
+}
+
+func ReportView(title string, report *SummaryReport, locale string, prefs utils.Preferences) map[string]interface{} {
+	return map[string]interface{}{
+		"Title":  title,
+		"Report": report,
+		"locale": locale,
+		"prefs":  prefs,
+	}
+}
+
+func ReportCSVFilename(report *SummaryReport) string {
+	return string(report.Template) + "-" + report.GeneratedAt.Format(utils.DateInputLayout) + ".csv"
+}
+
+const ReportEmailJob = "report.email"
+
+type ReportEmailPayload struct {
+	ScheduleID uint      `json:"schedule_id"`
+	UserID     uint      `json:"user_id"`
+	RunAt      time.Time `json:"run_at"`
+}
+
+type IReportDeliveryService interface {
+	RunDue(ctx context.Context, now time.Time) (int, error)
+	Deliver(ctx context.Context, payload ReportEmailPayload) error
+}
+
+type ReportDeliveryService struct {
+	reports     IReportService
+	preferences IUserPreferenceService
+	jobs        IJobService
+	renderer    ReportRenderer
+	mailer      mailer.Mailer
+}
+
+func NewReportDeliveryService(reports IReportService, preferences IUserPreferenceService, jobs IJobService, renderer ReportRenderer, m mailer.Mailer) IReportDeliveryService {
+	return &ReportDeliveryService{reports: reports, preferences: preferences, jobs: jobs, renderer: renderer, mailer: m}
+}
+
+func (s *ReportDeliveryService) RunDue(ctx context.Context, now time.Time) (int, error) {
+	runs, err := s.reports.ClaimDueRuns(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+	queued := 0
+	for _, run := range runs {
+		for _, recipient := range run.Recipients {
+			log := utils.LogFrom(ctx).With(zap.Uint("report_id", run.Schedule.ID), zap.Uint("user_id", recipient.ID))
+			if _, err := netmail.ParseAddress(recipient.Account); err != nil {
+				log.Warn("Kullanıcı hesabı bir e-posta adresi değil, rapor gönderilmedi", zap.String("account", recipient.Account))
+				continue
+			}
+			payload := ReportEmailPayload{ScheduleID: run.Schedule.ID, UserID: recipient.ID, RunAt: now}
+			if err := s.jobs.Enqueue(ctx, ReportEmailJob, payload); err != nil {
+				log.Error("Rapor e-postası kuyruğa alınamadı", zap.Error(err))
+				continue
+			}
+			queued++
+		}
+		utils.LogFrom(ctx).Info("Zamanlanmış rapor çalıştı",
+			zap.Uint("report_id", run.Schedule.ID),
+			zap.String("name", run.Schedule.Name),
+			zap.Int("recipients", len(run.Recipients)),
+		)
+	}
+	return queued, nil
+}
+
+func (s *ReportDeliveryService) Deliver(ctx context.Context, payload ReportEmailPayload) error {
+	log := utils.LogFrom(ctx).With(zap.Uint("report_id", payload.ScheduleID), zap.Uint("user_id", payload.UserID))
+	schedule, recipient, err := s.reports.GetRecipient(ctx, payload.ScheduleID, payload.UserID)
+	if err == ErrReportNotFound {
+		log.Info("Rapor zamanlaması veya aboneliği kaldırılmış, e-posta gönderilmedi")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	address, err := netmail.ParseAddress(recipient.Account)
+	if err != nil {
+		return PermanentJobError(err)
+	}
+	msg, err := s.compose(ctx, schedule, recipient, payload.RunAt)
+	if err != nil {
+		return err
+	}
+	msg.To = []string{(&netmail.Address{Name: recipient.Name, Address: address.Address}).String()}
+	return s.mailer.Send(ctx, *msg)
+}
+
+func (s *ReportDeliveryService) compose(ctx context.Context, schedule *models.ReportSchedule, recipient *models.User, now time.Time) (*mailer.Message, error) {
+	pref, err := s.preferences.GetPreferences(ctx, recipient.ID)
+	if err != nil {
+		pref = DefaultUserPreference(recipient.ID)
+	}
+	locale := pref.Locale
+	if !i18n.IsSupported(locale) {
+		locale = i18n.DefaultLocale
+	}
+	prefs := utils.DefaultPreferences()
+	if loc, err := utils.LoadLocation(pref.Timezone); err == nil {
+		prefs.Location = loc
+	}
+	if utils.IsDateFormat(pref.DateFormat) {
+		prefs.DateFormat = pref.DateFormat
+	}
+
+	report, err := s.reports.BuildReport(ctx, schedule.Template, recipient, now.In(prefs.Location))
+	if err != nil {
+		return nil, err
+	}
+	subject := i18n.T(locale, "reports.email.subject", schedule.Name, prefs.FormatDate(report.From), prefs.FormatDate(report.GeneratedAt))
+
+	var html bytes.Buffer
+	if err := s.renderer.Render(&html, ReportViewName, ReportView(subject, report, locale, prefs)); err != nil {
+		return nil, err
+	}
+	var csvData bytes.Buffer
+	if err := WriteReportCSV(&csvData, report); err != nil {
+		return nil, err
+	}
+
+	return &mailer.Message{
+		Subject: subject,
+		HTML:    html.String(),
+		Text: i18n.T(locale, "reports.email.text",
+			subject,
+			len(report.TeamChanges),
+			len(report.Inactive),
+			len(report.Dormant),
+			report.Logins.Succeeded,
+			report.Logins.Failed,
+		),
+		Attachments: []mailer.Attachment{{
+			Filename:    ReportCSVFilename(report),
+			ContentType: "text/csv; charset=utf-8",
+			Data:        csvData.Bytes(),
+		}},
+	}, nil
+}
+
+var _ IReportDeliveryService = (*ReportDeliveryService)(nil)
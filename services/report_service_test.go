@@ -0,0 +1,276 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"zatrano/mailer"
+	"zatrano/models"
+	"zatrano/repositories"
+)
+
+func TestReportSchedules(t *testing.T) {
+	s := newTestServices(t)
+	ctx := context.Background()
+
+	invalid := []struct {
+		schedule models.ReportSchedule
+		want     error
+	}{
+		{models.ReportSchedule{Name: " ", Template: models.ReportTemplateWeeklySummary, Cron: "@weekly"}, ErrReportNameRequired},
+		{models.ReportSchedule{Name: strings.Repeat("a", ReportNameMaxLength+1), Template: models.ReportTemplateWeeklySummary, Cron: "@weekly"}, ErrReportNameTooLong},
+		{models.ReportSchedule{Name: "Aylık", Template: "monthly_summary", Cron: "@weekly"}, ErrReportInvalidTemplate},
+		{models.ReportSchedule{Name: "Bozuk", Template: models.ReportTemplateDailySummary, Cron: "61 * * * *"}, ErrReportInvalidCron},
+		{models.ReportSchedule{Name: "Hiç", Template: models.ReportTemplateDailySummary, Cron: "0 0 30 2 *"}, ErrReportInvalidCron},
+		{models.ReportSchedule{Name: "Mars", Template: models.ReportTemplateDailySummary, Cron: "@daily", Timezone: "Mars/Base"}, ErrReportInvalidTimezone},
+	}
+	for _, tc := range invalid {
+		if err := s.reports.CreateSchedule(ctx, &tc.schedule); err != tc.want {
+			t.Errorf("CreateSchedule(%+v) error = %v, want %v", tc.schedule, err, tc.want)
+		}
+	}
+
+	schedule := &models.ReportSchedule{Name: " Haftalık ", Template: models.ReportTemplateWeeklySummary, Cron: "0  8 * * 1", Timezone: "Europe/Istanbul", Status: true}
+	if err := s.reports.CreateSchedule(ctx, schedule); err != nil {
+		t.Fatalf("CreateSchedule() error = %v", err)
+	}
+	if schedule.Name != "Haftalık" || schedule.Cron != "0 8 * * 1" {
+		t.Fatalf("schedule not normalized: %+v", schedule)
+	}
+	next := schedule.NextRunAt
+	if next == nil || !next.After(time.Now()) || next.Weekday() != time.Monday || next.Hour() != 5 || next.Minute() != 0 {
+		t.Fatalf("NextRunAt = %v; want next Monday 08:00 Istanbul (05:00 UTC)", next)
+	}
+
+	paused := *schedule
+	paused.Status = false
+	if err := s.reports.UpdateSchedule(ctx, schedule.ID, &paused); err != nil {
+		t.Fatalf("UpdateSchedule() error = %v", err)
+	}
+	if stored, _ := s.reports.GetSchedule(ctx, schedule.ID); stored.Status || stored.NextRunAt != nil {
+		t.Fatalf("paused schedule = %+v; want no next run", stored)
+	}
+	if err := s.reports.UpdateSchedule(ctx, 999, &paused); err != ErrReportNotFound {
+		t.Fatalf("UpdateSchedule(missing) error = %v", err)
+	}
+	if err := s.reports.DeleteSchedule(ctx, schedule.ID); err != nil {
+		t.Fatalf("DeleteSchedule() error = %v", err)
+	}
+	if _, err := s.reports.GetSchedule(ctx, schedule.ID); err != ErrReportNotFound {
+		t.Fatalf("GetSchedule() after delete error = %v", err)
+	}
+}
+
+func TestReportSubscriptionsAndBuild(t *testing.T) {
+	s := newTestServices(t)
+	ctx := context.Background()
+	system := s.mustCreateUser(t, models.User{Name: "Sistem", Account: "system@x", Password: "secret1", Type: models.System})
+	sales := s.mustCreateTeam(t, "Satış")
+	support := s.mustCreateTeam(t, "Destek")
+	manager := s.mustCreateUser(t, models.User{Name: "Yönetici", Account: "manager@x", Password: "secret1", Type: models.Manager, TeamID: &sales.ID})
+	ali := s.mustCreateUser(t, models.User{Name: "Ali", Account: "ali@x", Password: "secret1", Type: models.Agent, TeamID: &sales.ID})
+	veli := s.mustCreateUser(t, models.User{Name: "Veli", Account: "veli@x", Password: "secret1", Type: models.Agent, TeamID: &support.ID})
+	if err := s.users.UpdateUser(ctx, veli.ID, &models.User{Name: veli.Name, Account: veli.Account, Type: veli.Type, TeamID: veli.TeamID, Status: false}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.auth.Authenticate(ctx, "ali@x", "secret1"); err != nil {
+		t.Fatal(err)
+	}
+	_, _ = s.auth.Authenticate(ctx, "ali@x", "wrong")
+
+	weekly := &models.ReportSchedule{Name: "Haftalık", Template: models.ReportTemplateWeeklySummary, Cron: "@weekly", Status: true}
+	paused := &models.ReportSchedule{Name: "Arşiv", Template: models.ReportTemplateDailySummary, Cron: "@daily"}
+	for _, schedule := range []*models.ReportSchedule{weekly, paused} {
+		if err := s.reports.CreateSchedule(ctx, schedule); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if options, err := s.reports.SubscriptionOptions(ctx, ali); err != nil || options != nil {
+		t.Fatalf("agent SubscriptionOptions() = %+v, %v; want none", options, err)
+	}
+	if err := s.reports.UpdateSubscriptions(ctx, ali, []uint{weekly.ID}); err != ErrReportForbidden {
+		t.Fatalf("agent UpdateSubscriptions() error = %v", err)
+	}
+	if err := s.reports.UpdateSubscriptions(ctx, manager, []uint{paused.ID}); err != ErrReportUnknownSubscription {
+		t.Fatalf("UpdateSubscriptions(paused) error = %v", err)
+	}
+	if err := s.reports.UpdateSubscriptions(ctx, manager, []uint{weekly.ID, weekly.ID}); err != nil {
+		t.Fatalf("UpdateSubscriptions() error = %v", err)
+	}
+	options, err := s.reports.SubscriptionOptions(ctx, manager)
+	if err != nil || len(options) != 1 || options[0].Schedule.ID != weekly.ID || !options[0].Subscribed {
+		t.Fatalf("manager SubscriptionOptions() = %+v, %v", options, err)
+	}
+
+	now := time.Now()
+	if _, err := s.reports.BuildReport(ctx, models.ReportTemplateWeeklySummary, ali, now); err != ErrReportForbidden {
+		t.Fatalf("agent BuildReport() error = %v", err)
+	}
+
+	manager.Team = sales
+	report, err := s.reports.BuildReport(ctx, models.ReportTemplateWeeklySummary, manager, now)
+	if err != nil {
+		t.Fatalf("manager BuildReport() error = %v", err)
+	}
+	if report.Team == nil || report.Team.ID != sales.ID || !report.From.Equal(now.AddDate(0, 0, -7)) {
+		t.Fatalf("manager report scope = %+v from %v", report.Team, report.From)
+	}
+	if len(report.TeamChanges) != 2 || len(report.Inactive) != 0 || report.FailedAccounts != nil {
+		t.Fatalf("manager report = %+v; want only Satış changes and no failed accounts", report)
+	}
+	if len(report.Dormant) != 1 || report.Dormant[0].ID != manager.ID || report.Logins.Succeeded != 1 || report.Logins.Failed != 1 {
+		t.Fatalf("manager report logins = %+v, dormant = %+v", report.Logins, report.Dormant)
+	}
+
+	report, err = s.reports.BuildReport(ctx, models.ReportTemplateWeeklySummary, system, now)
+	if err != nil {
+		t.Fatalf("system BuildReport() error = %v", err)
+	}
+	if report.Team != nil || len(report.TeamChanges) != 3 || len(report.Inactive) != 1 || report.DeactivatedInPeriod() != 1 || len(report.FailedAccounts) != 1 {
+		t.Fatalf("system report = %+v", report)
+	}
+
+	var out bytes.Buffer
+	if err := WriteReportCSV(&out, report); err != nil {
+		t.Fatalf("WriteReportCSV() error = %v", err)
+	}
+	csv := out.String()
+	if !strings.HasPrefix(csv, strings.Join(ReportCSVHeader, ",")+"\n") ||
+		!strings.Contains(csv, "\ninactive,Veli,veli@x,Destek,,,") ||
+		!strings.Contains(csv, "\nlogins_failed,,,,,1,\n") ||
+		!strings.Contains(csv, "\nfailed_account,,ali@x,,,1,\n") {
+		t.Fatalf("CSV =\n%s", csv)
+	}
+}
+
+func TestWriteReportCSVEscapesFormulas(t *testing.T) {
+	report := &SummaryReport{
+		GeneratedAt:    time.Now(),
+		Dormant:        []models.User{{Name: "=cmd|' /C calc'!A0", Account: "dormant@x"}},
+		FailedAccounts: []repositories.AccountLoginCount{{Account: "+90 555", Count: 2}},
+	}
+	var out bytes.Buffer
+	if err := WriteReportCSV(&out, report); err != nil {
+		t.Fatalf("WriteReportCSV() error = %v", err)
+	}
+	csv := out.String()
+	if !strings.Contains(csv, "\ndormant,'=cmd|' /C calc'!A0,dormant@x,,,,\n") ||
+		!strings.Contains(csv, "\nfailed_account,,'+90 555,,,2,\n") {
+		t.Fatalf("CSV =\n%s", csv)
+	}
+}
+
+type fakeReportRenderer struct {
+	locales []string
+}
+
+func (r *fakeReportRenderer) Render(out io.Writer, name string, binding interface{}, _ ...string) error {
+	data := binding.(map[string]interface{})
+	r.locales = append(r.locales, data["locale"].(string))
+	_, err := io.WriteString(out, "<h1>"+name+": "+data["Title"].(string)+"</h1>")
+	return err
+}
+
+type fakeMailer struct {
+	sent []mailer.Message
+}
+
+func (m *fakeMailer) Send(_ context.Context, msg mailer.Message) error {
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+func TestReportDeliveryRunDue(t *testing.T) {
+	s := newTestServices(t)
+	ctx := context.Background()
+	team := s.mustCreateTeam(t, "Destek")
+	admin := s.mustCreateUser(t, models.User{Name: "Admin", Account: "admin@example.com", Password: "secret1", Type: models.System})
+	manager := s.mustCreateUser(t, models.User{Name: "Ayşe", Account: "ayse@example.com", Password: "secret1", Type: models.Manager, TeamID: &team.ID})
+	noAddress := s.mustCreateUser(t, models.User{Name: "Sistem", Account: "system", Password: "secret1", Type: models.System})
+
+	preferences := NewUserPreferenceService(repositories.NewMemoryUserPreferenceRepository(s.store))
+	if err := preferences.UpdatePreferences(ctx, manager.ID, &models.UserPreference{Locale: "en", Timezone: "Europe/Istanbul", DateFormat: "2006-01-02", PerPage: 20}); err != nil {
+		t.Fatal(err)
+	}
+
+	schedule := &models.ReportSchedule{Name: "Haftalık", Template: models.ReportTemplateWeeklySummary, Cron: "@hourly", Status: true}
+	if err := s.reports.CreateSchedule(ctx, schedule); err != nil {
+		t.Fatal(err)
+	}
+	for _, u := range []*models.User{admin, manager, noAddress} {
+		if err := s.reports.UpdateSubscriptions(ctx, u, []uint{schedule.ID}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	renderer, m := &fakeReportRenderer{}, &fakeMailer{}
+	registry := NewJobRegistry()
+	jobs := NewJobService(repositories.NewMemoryJobRepository(s.store), registry)
+	delivery := NewReportDeliveryService(s.reports, preferences, jobs, renderer, m)
+	registry.Register(ReportEmailJob, 3, TypedJobHandler(delivery.Deliver))
+	runJobs := func() {
+		t.Helper()
+		for {
+			ran, err := jobs.RunNext(ctx, "test")
+			if err != nil {
+				t.Fatalf("RunNext() error = %v", err)
+			}
+			if !ran {
+				return
+			}
+		}
+	}
+
+	if queued, err := delivery.RunDue(ctx, schedule.NextRunAt.Add(-time.Second)); err != nil || queued != 0 {
+		t.Fatalf("RunDue() before the schedule = %d, %v", queued, err)
+	}
+	now := *schedule.NextRunAt
+	queued, err := delivery.RunDue(ctx, now)
+	if err != nil || queued != 2 || len(m.sent) != 0 {
+		t.Fatalf("RunDue() = %d, %v; sent %+v; want two queued e-mails and none sent inline", queued, err, m.sent)
+	}
+	runJobs()
+	if counts, _ := jobs.StatusCounts(ctx); len(m.sent) != 2 || counts[models.JobSucceeded] != 2 {
+		t.Fatalf("after running jobs sent %d e-mails, job counts %v", len(m.sent), counts)
+	}
+	if locales := strings.Join(renderer.locales, ","); locales != "tr,en" {
+		t.Fatalf("rendered locales = %s; want the recipient's language", locales)
+	}
+
+	msg := m.sent[1]
+	wantSubject := "Haftalık: " + now.In(time.FixedZone("TRT", 3*60*60)).AddDate(0, 0, -7).Format("2006-01-02") + " – " + now.In(time.FixedZone("TRT", 3*60*60)).Format("2006-01-02")
+	if len(msg.To) != 1 || msg.To[0] != `=?utf-8?q?Ay=C5=9Fe?= <ayse@example.com>` || msg.Subject != wantSubject {
+		t.Fatalf("message = %q to %v; want %q", msg.Subject, msg.To, wantSubject)
+	}
+	if !strings.Contains(msg.HTML, ReportViewName) || !strings.Contains(msg.Text, "Team membership changes: 1") {
+		t.Fatalf("bodies = %q / %q", msg.HTML, msg.Text)
+	}
+	if len(msg.Attachments) != 1 || !strings.HasPrefix(msg.Attachments[0].Filename, "weekly_summary-") || !bytes.HasPrefix(msg.Attachments[0].Data, []byte("section,")) {
+		t.Fatalf("attachments = %+v", msg.Attachments)
+	}
+
+	if queued, _ := delivery.RunDue(ctx, now); queued != 0 {
+		t.Fatalf("RunDue() queued the same run twice (%d)", queued)
+	}
+	if stored, _ := s.reports.GetSchedule(ctx, schedule.ID); stored.LastRunAt == nil || !stored.NextRunAt.Equal(now.Add(time.Hour)) {
+		t.Fatalf("schedule after run = %+v", stored)
+	}
+
+	// Kuyruktayken abonelikten çıkan alıcıya e-posta gönderilmez.
+	next := now.Add(time.Hour)
+	if queued, _ := delivery.RunDue(ctx, next); queued != 2 {
+		t.Fatalf("RunDue(next) = %d", queued)
+	}
+	if err := s.reports.UpdateSubscriptions(ctx, manager, nil); err != nil {
+		t.Fatal(err)
+	}
+	runJobs()
+	if len(m.sent) != 3 || m.sent[2].To[0] != `"Admin" <admin@example.com>` {
+		t.Fatalf("sent %d e-mails after unsubscribe; last to %v", len(m.sent), m.sent[len(m.sent)-1].To)
+	}
+}
@@ -0,0 +1,286 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"zatrano/i18n"
+	"zatrano/models"
+	"zatrano/repositories"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+)
+
+type ReportingServiceError string
+
+func (e ReportingServiceError) Error() string {
+	return i18n.T(i18n.DefaultLocale, string(e))
+}
+
+func (e ReportingServiceError) Code() string {
+	return string(e)
+}
+
+const (
+	ErrReportingLoadFailed ReportingServiceError = "errors.reporting.load_failed"
+)
+
+const (
+	ReportingWeeks                  = 12
+	ReportingLoginDays              = 14
+	ReportingRecentLoginLimit       = 10
+	ReportingFailedAccountLimit     = 5
+	ReportingFailedLoginSpikeMin    = 10
+	ReportingFailedLoginSpikeFactor = 3
+)
+
+var ReportingUserTypes = []models.UserType{models.Manager, models.Agent}
+
+type UserTypeSummary struct {
+	Type     models.UserType
+	Active   int64
+	Inactive int64
+}
+
+func (s UserTypeSummary) Total() int64 {
+	return s.Active + s.Inactive
+}
+
+type UserSummary struct {
+	Total    int64
+	Active   int64
+	Inactive int64
+	ByType   []UserTypeSummary
+}
+
+type TeamSummary struct {
+	Total          int
+	Active         int
+	WithoutManager int
+	Empty          int
+	Sizes          []repositories.TeamSize
+}
+
+type WeeklyCount struct {
+	Week  time.Time
+	Count int64
+}
+
+type DailyLoginCount struct {
+	Day       time.Time
+	Succeeded int64
+	Failed    int64
+	Spike     bool
+}
+
+type DashboardOverview struct {
+	GeneratedAt    time.Time
+	Users          UserSummary
+	Teams          TeamSummary
+	NewUsers       []WeeklyCount
+	Deactivations  []WeeklyCount
+	Logins         []DailyLoginCount
+	LoginSpikes    int
+	RecentLogins   []models.LoginEvent
+	FailedAccounts []repositories.AccountLoginCount
+}
+
+func (o *DashboardOverview) NewUsersThisWeek() int64 {
+	if len(o.NewUsers) == 0 {
+		return 0
+	}
+	return o.NewUsers[len(o.NewUsers)-1].Count
+}
+
+func (o *DashboardOverview) DeactivationsTotal() int64 {
+	var total int64
+	for _, week := range o.Deactivations {
+		total += week.Count
+	}
+	return total
+}
+
+func (o *DashboardOverview) FailedLoginsToday() int64 {
+	if len(o.Logins) == 0 {
+		return 0
+	}
+	return o.Logins[len(o.Logins)-1].Failed
+}
+
+type IReportingService interface {
+	DashboardOverview(ctx context.Context, now time.Time) (*DashboardOverview, error)
+}
+
+type ReportingService struct {
+	repo repositories.IReportingRepository
+}
+
+func NewReportingService(repo repositories.IReportingRepository) IReportingService {
+	return &ReportingService{repo: repo}
+}
+
+func (s *ReportingService) DashboardOverview(ctx context.Context, now time.Time) (*DashboardOverview, error) {
+	loc := now.Location()
+	today := models.CivilDate(now)
+	overview := &DashboardOverview{GeneratedAt: now}
+
+	userRows, err := s.repo.CountUsersByTypeAndStatus()
+	if err != nil {
+		utils.LogFrom(ctx).Error("Pano özeti: Kullanıcı sayıları alınamadı", zap.Error(err))
+		return nil, ErrReportingLoadFailed
+	}
+	overview.Users = summarizeUsers(userRows)
+
+	sizes, err := s.repo.FindTeamSizes()
+	if err != nil {
+		utils.LogFrom(ctx).Error("Pano özeti: Takım büyüklükleri alınamadı", zap.Error(err))
+		return nil, ErrReportingLoadFailed
+	}
+	overview.Teams = summarizeTeams(sizes)
+
+	weeks := make([]time.Time, ReportingWeeks)
+	lastWeek := models.WeekStart(today)
+	for i := range weeks {
+		weeks[i] = lastWeek.AddDate(0, 0, -7*(ReportingWeeks-1-i))
+	}
+	weekSince := localMidnight(weeks[0], loc)
+
+	created, err := s.repo.FindUserCreationTimes(weekSince)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Pano özeti: Yeni kullanıcılar alınamadı", zap.Error(err))
+		return nil, ErrReportingLoadFailed
+	}
+	overview.NewUsers = weeklyCounts(weeks, countByPeriod(created, loc, weeks, models.WeekStart))
+
+	deactivated, err := s.repo.FindDeactivationTimes(weekSince)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Pano özeti: Pasife alınan hesaplar alınamadı", zap.Error(err))
+		return nil, ErrReportingLoadFailed
+	}
+	overview.Deactivations = weeklyCounts(weeks, countByPeriod(deactivated, loc, weeks, models.WeekStart))
+
+	days := make([]time.Time, ReportingLoginDays)
+	for i := range days {
+		days[i] = today.AddDate(0, 0, -(ReportingLoginDays - 1 - i))
+	}
+	daySince := localMidnight(days[0], loc)
+	sameDay := func(day time.Time) time.Time { return day }
+
+	succeeded, err := s.repo.FindLoginTimes(daySince, true)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Pano özeti: Başarılı girişler alınamadı", zap.Error(err))
+		return nil, ErrReportingLoadFailed
+	}
+	failed, err := s.repo.FindLoginTimes(daySince, false)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Pano özeti: Başarısız girişler alınamadı", zap.Error(err))
+		return nil, ErrReportingLoadFailed
+	}
+	overview.Logins = dailyLoginCounts(days, countByPeriod(succeeded, loc, days, sameDay), countByPeriod(failed, loc, days, sameDay))
+	for _, day := range overview.Logins {
+		if day.Spike {
+			overview.LoginSpikes++
+		}
+	}
+
+	overview.RecentLogins, err = s.repo.FindRecentLogins(ReportingRecentLoginLimit)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Pano özeti: Son girişler alınamadı", zap.Error(err))
+		return nil, ErrReportingLoadFailed
+	}
+	overview.FailedAccounts, err = s.repo.CountFailedLoginsByAccount(now.Add(-24*time.Hour), ReportingFailedAccountLimit)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Pano özeti: Başarısız giriş yapılan hesaplar alınamadı", zap.Error(err))
+		return nil, ErrReportingLoadFailed
+	}
+	return overview, nil
+}
+
+func summarizeUsers(rows []repositories.UserTypeStatusCount) UserSummary {
+	var summary UserSummary
+	for _, userType := range ReportingUserTypes {
+		typeSummary := UserTypeSummary{Type: userType}
+		for _, row := range rows {
+			if row.Type != userType {
+				continue
+			}
+			if row.Status {
+				typeSummary.Active += row.Count
+			} else {
+				typeSummary.Inactive += row.Count
+			}
+		}
+		summary.ByType = append(summary.ByType, typeSummary)
+		summary.Active += typeSummary.Active
+		summary.Inactive += typeSummary.Inactive
+	}
+	summary.Total = summary.Active + summary.Inactive
+	return summary
+}
+
+func summarizeTeams(sizes []repositories.TeamSize) TeamSummary {
+	summary := TeamSummary{Total: len(sizes), Sizes: sizes}
+	for _, size := range sizes {
+		if size.Status {
+			summary.Active++
+		}
+		if size.Managers == 0 {
+			summary.WithoutManager++
+		}
+		if size.Members() == 0 {
+			summary.Empty++
+		}
+	}
+	return summary
+}
+
+func localMidnight(day time.Time, loc *time.Location) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+}
+
+func countByPeriod(times []time.Time, loc *time.Location, starts []time.Time, period func(time.Time) time.Time) []int64 {
+	index := make(map[time.Time]int, len(starts))
+	for i, start := range starts {
+		index[start] = i
+	}
+	counts := make([]int64, len(starts))
+	for _, t := range times {
+		if i, ok := index[period(models.CivilDate(t.In(loc)))]; ok {
+			counts[i]++
+		}
+	}
+	return counts
+}
+
+func weeklyCounts(weeks []time.Time, counts []int64) []WeeklyCount {
+	out := make([]WeeklyCount, len(weeks))
+	for i, week := range weeks {
+		out[i] = WeeklyCount{Week: week, Count: counts[i]}
+	}
+	return out
+}
+
+func dailyLoginCounts(days []time.Time, succeeded, failed []int64) []DailyLoginCount {
+	var totalFailed int64
+	for _, count := range failed {
+		totalFailed += count
+	}
+	out := make([]DailyLoginCount, len(days))
+	for i, day := range days {
+		out[i] = DailyLoginCount{Day: day, Succeeded: succeeded[i], Failed: failed[i]}
+		if failed[i] < ReportingFailedLoginSpikeMin {
+			continue
+		}
+		others := len(days) - 1
+		if others == 0 {
+			out[i].Spike = true
+			continue
+		}
+		baseline := float64(totalFailed-failed[i]) / float64(others)
+		out[i].Spike = float64(failed[i]) > baseline*ReportingFailedLoginSpikeFactor
+	}
+	return out
+}
+
+var _ IReportingService = (*ReportingService)(nil)
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"zatrano/models"
+)
+
+func TestReportingServiceDashboardOverview(t *testing.T) {
+	s := newTestServices(t)
+	ctx := context.Background()
+	s.mustCreateUser(t, models.User{Name: "Sistem", Account: "system@system", Password: "secret1", Type: models.System})
+	sales := s.mustCreateTeam(t, "Satış")
+	support := s.mustCreateTeam(t, "Destek")
+	s.mustCreateTeam(t, "Boş")
+	s.mustCreateUser(t, models.User{Name: "Yönetici", Account: "manager@x", Password: "secret1", Type: models.Manager, TeamID: &sales.ID})
+	s.mustCreateUser(t, models.User{Name: "Ali", Account: "ali@x", Password: "secret1", Type: models.Agent, TeamID: &sales.ID})
+	veli := s.mustCreateUser(t, models.User{Name: "Veli", Account: "veli@x", Password: "secret1", Type: models.Agent, TeamID: &support.ID})
+
+	deactivate := func(status bool) {
+		t.Helper()
+		if err := s.users.UpdateUser(ctx, veli.ID, &models.User{Name: veli.Name, Account: veli.Account, Type: veli.Type, TeamID: veli.TeamID, Status: status}); err != nil {
+			t.Fatalf("UpdateUser() error = %v", err)
+		}
+	}
+	deactivate(false)
+	if user, _ := s.users.GetUserByID(ctx, veli.ID); user.DeactivatedAt == nil {
+		t.Fatal("DeactivatedAt is not set after deactivation")
+	}
+	deactivate(true)
+	if user, _ := s.users.GetUserByID(ctx, veli.ID); user.DeactivatedAt != nil {
+		t.Fatal("DeactivatedAt is not cleared after reactivation")
+	}
+	deactivate(false)
+
+	if _, err := s.auth.Authenticate(ctx, "ali@x", "secret1"); err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	_, _ = s.auth.Authenticate(ctx, "ali@x", "wrong")
+	_, _ = s.auth.Authenticate(ctx, "yok@x", "wrong")
+	_, _ = s.auth.Authenticate(ctx, "veli@x", "secret1")
+
+	overview, err := s.reporting.DashboardOverview(ctx, time.Now().In(time.FixedZone("TRT", 3*60*60)))
+	if err != nil {
+		t.Fatalf("DashboardOverview() error = %v", err)
+	}
+
+	users := overview.Users
+	if users.Total != 3 || users.Active != 2 || users.Inactive != 1 || len(users.ByType) != 2 {
+		t.Fatalf("Users = %+v; system users must not be counted", users)
+	}
+	if agents := users.ByType[1]; agents.Type != models.Agent || agents.Active != 1 || agents.Inactive != 1 {
+		t.Fatalf("agents = %+v", agents)
+	}
+	teams := overview.Teams
+	if teams.Total != 3 || teams.WithoutManager != 2 || teams.Empty != 1 {
+		t.Fatalf("Teams = %+v", teams)
+	}
+
+	if len(overview.NewUsers) != ReportingWeeks || overview.NewUsersThisWeek() != 3 {
+		t.Fatalf("NewUsers = %+v", overview.NewUsers)
+	}
+	if overview.DeactivationsTotal() != 1 || overview.Deactivations[ReportingWeeks-1].Count != 1 {
+		t.Fatalf("Deactivations = %+v", overview.Deactivations)
+	}
+	if weekday := overview.NewUsers[0].Week.Weekday(); weekday != time.Monday {
+		t.Fatalf("weeks start on %s", weekday)
+	}
+
+	if len(overview.Logins) != ReportingLoginDays {
+		t.Fatalf("len(Logins) = %d", len(overview.Logins))
+	}
+	today := overview.Logins[ReportingLoginDays-1]
+	if today.Succeeded != 1 || today.Failed != 3 || today.Spike || overview.FailedLoginsToday() != 3 {
+		t.Fatalf("today = %+v", today)
+	}
+	if len(overview.RecentLogins) != 1 || overview.RecentLogins[0].User == nil || overview.RecentLogins[0].User.Name != "Ali" {
+		t.Fatalf("RecentLogins = %+v", overview.RecentLogins)
+	}
+	if len(overview.FailedAccounts) != 3 {
+		t.Fatalf("FailedAccounts = %+v", overview.FailedAccounts)
+	}
+}
+
+func TestDailyLoginCountsSpikes(t *testing.T) {
+	days := make([]time.Time, 5)
+	for i := range days {
+		days[i] = time.Date(2026, time.March, 2+i, 0, 0, 0, 0, time.UTC)
+	}
+	succeeded := make([]int64, len(days))
+
+	tests := []struct {
+		name   string
+		failed []int64
+		want   []bool
+	}{
+		{name: "quiet days", failed: []int64{1, 2, 0, 3, 2}, want: []bool{false, false, false, false, false}},
+		{name: "spike over the baseline", failed: []int64{2, 3, 40, 2, 1}, want: []bool{false, false, true, false, false}},
+		{name: "below the minimum", failed: []int64{0, 0, 9, 0, 0}, want: []bool{false, false, false, false, false}},
+		{name: "steady high volume", failed: []int64{30, 32, 35, 31, 29}, want: []bool{false, false, false, false, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := dailyLoginCounts(days, succeeded, tt.failed)
+			for i, day := range got {
+				if day.Spike != tt.want[i] || day.Failed != tt.failed[i] || !day.Day.Equal(days[i]) {
+					t.Fatalf("day %d = %+v, want spike %v", i, day, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"zatrano/i18n"
+	"zatrano/repositories"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+)
+
+type SearchServiceError string
+
+func (e SearchServiceError) Error() string {
+	return i18n.T(i18n.DefaultLocale, string(e))
+}
+
+func (e SearchServiceError) Code() string {
+	return string(e)
+}
+
+const ErrSearchFailed SearchServiceError = "errors.search.failed"
+
+const (
+	SearchMinLength = 2
+	SearchMaxLength = 100
+	SearchLimit     = 25
+)
+
+type ISearchService interface {
+	Search(ctx context.Context, term string) ([]repositories.SearchHit, error)
+}
+
+type SearchService struct {
+	repo repositories.ISearchRepository
+}
+
+func NewSearchService(repo repositories.ISearchRepository) ISearchService {
+	return &SearchService{repo: repo}
+}
+
+func (s *SearchService) Search(ctx context.Context, term string) ([]repositories.SearchHit, error) {
+	term = strings.TrimSpace(term)
+	if utf8.RuneCountInString(term) < SearchMinLength {
+		return []repositories.SearchHit{}, nil
+	}
+	if runes := []rune(term); len(runes) > SearchMaxLength {
+		term = string(runes[:SearchMaxLength])
+	}
+
+	hits, err := s.repo.Search(term, SearchLimit)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Genel arama yapılamadı", zap.String("term", term), zap.Error(err))
+		return nil, ErrSearchFailed
+	}
+	return hits, nil
+}
+
+var _ ISearchService = (*SearchService)(nil)
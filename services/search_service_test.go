@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"zatrano/models"
+	"zatrano/repositories"
+)
+
+func TestSearchRanksUsersAndTeamsWithTypos(t *testing.T) {
+	s := newTestServices(t)
+	search := NewSearchService(repositories.NewMemorySearchRepository(s.store))
+	s.mustCreateUser(t, models.User{Name: "System", Account: "system@system", Type: models.System, Password: "secret1"})
+	destek := s.mustCreateTeam(t, "Müşteri Destek")
+	satis := s.mustCreateTeam(t, "Satış")
+	s.mustCreateUser(t, models.User{Name: "Mehmet Öztürk", Account: "mehmet@x", Type: models.Agent, TeamID: &destek.ID, Password: "secret1"})
+	s.mustCreateUser(t, models.User{Name: "Ahmet Yılmaz", Account: "ahmet@x", Type: models.Agent, TeamID: &satis.ID, Password: "secret1"})
+
+	tests := []struct {
+		name      string
+		term      string
+		wantFirst string
+		wantKind  repositories.SearchKind
+	}{
+		{name: "accent insensitive", term: "musteri", wantFirst: "Müşteri Destek", wantKind: repositories.SearchKindTeam},
+		{name: "typo", term: "ozturkk", wantFirst: "Mehmet Öztürk", wantKind: repositories.SearchKindUser},
+		{name: "account", term: "ahmet@", wantFirst: "Ahmet Yılmaz", wantKind: repositories.SearchKindUser},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits, err := search.Search(context.Background(), tt.term)
+			if err != nil {
+				t.Fatalf("Search(%q) error = %v", tt.term, err)
+			}
+			if len(hits) == 0 || hits[0].Title != tt.wantFirst || hits[0].Kind != tt.wantKind {
+				t.Fatalf("Search(%q) = %+v, want %s %q first", tt.term, hits, tt.wantKind, tt.wantFirst)
+			}
+		})
+	}
+
+	// Sistem kullanıcısı ve çok kısa metinler sonuç döndürmez.
+	for _, term := range []string{"system", " a "} {
+		hits, err := search.Search(context.Background(), term)
+		if err != nil || len(hits) != 0 {
+			t.Fatalf("Search(%q) = %+v, %v; want no hits", term, hits, err)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"zatrano/i18n"
+	"zatrano/models"
+	"zatrano/repositories"
+	"zatrano/utils"
+)
+
+func TestMain(m *testing.M) {
+	utils.InitLogger("test", "error")
+	os.Exit(m.Run())
+}
+
+type testServices struct {
+	store *repositories.MemoryStore
+	auth  IAuthService
+	users IUserService
+	teams ITeamService
+
+	announcements IAnnouncementService
+	notifications INotificationService
+	shifts        IShiftService
+	attendance    IAttendanceService
+	leaves        ILeaveService
+	tasks         ITaskService
+	kpis          IKPIService
+	reporting     IReportingService
+	reports       IReportService
+}
+
+func newTestServices(t *testing.T) testServices {
+	t.Helper()
+	store := repositories.NewMemoryStore()
+	notifications := NewNotificationService(repositories.NewMemoryNotificationRepository(store))
+	return testServices{
+		store: store,
+		auth:  NewAuthService(repositories.NewMemoryAuthRepository(store)),
+		users: NewUserService(repositories.NewMemoryUserRepository(store), notifications),
+		teams: NewTeamService(repositories.NewMemoryTeamRepository(store)),
+
+		announcements: NewAnnouncementService(repositories.NewMemoryAnnouncementRepository(store)),
+		notifications: notifications,
+		shifts:        NewShiftService(repositories.NewMemoryShiftRepository(store), repositories.NewMemoryUserRepository(store)),
+		attendance:    NewAttendanceService(repositories.NewMemoryAttendanceRepository(store), repositories.NewMemoryShiftRepository(store), repositories.NewMemoryUserRepository(store)),
+		leaves:        NewLeaveService(repositories.NewMemoryLeaveRepository(store), notifications),
+		tasks:         NewTaskService(repositories.NewMemoryTaskRepository(store), repositories.NewMemoryUserRepository(store), notifications),
+		kpis:          NewKPIService(repositories.NewMemoryKPIRepository(store), repositories.NewMemoryUserRepository(store)),
+		reporting:     NewReportingService(repositories.NewMemoryReportingRepository(store)),
+		reports:       NewReportService(repositories.NewMemoryReportRepository(store), repositories.NewMemoryReportingRepository(store)),
+	}
+}
+
+func (s testServices) mustCreateTeam(t *testing.T, name string) *models.Team {
+	t.Helper()
+	team := &models.Team{Name: name, Status: true}
+	if err := s.teams.CreateTeam(context.Background(), team); err != nil {
+		t.Fatalf("CreateTeam() error = %v", err)
+	}
+	return team
+}
+
+func (s testServices) mustCreateUser(t *testing.T, user models.User) *models.User {
+	t.Helper()
+	if err := s.users.CreateUser(context.Background(), &user); err != nil {
+		t.Fatalf("CreateUser(%s) error = %v", user.Account, err)
+	}
+	return &user
+}
+
+func TestErrorCodesAreTranslated(t *testing.T) {
+	coded := []i18n.Coder{
+		ErrInvalidCredentials, ErrUserNotFound, ErrUserInactive, ErrCurrentPasswordIncorrect,
+		ErrPasswordTooShort, ErrPasswordSameAsOld, ErrAuthGeneric, ErrProfileGeneric,
+		ErrUpdatePasswordGeneric, ErrHashingFailed, ErrDatabaseUpdateFailed,
+		ErrTeamNotFound, ErrTeamCreationFailed, ErrTeamUpdateFailed, ErrTeamDeletionFailed,
+		ErrUserServiceUserNotFound, ErrPasswordHashingFailed, ErrPasswordUpdateFailed,
+		ErrUserCreationFailed, ErrUserUpdateFailed, ErrUserDeletionFailed, ErrPasswordRequired,
+		models.ErrSystemUserHasTeam, models.ErrUserMissingTeam, models.ErrInvalidUserType,
+		models.ErrPasswordCannotBeEmpty, models.ErrInvalidUpdateTypeField, models.ErrInvalidUpdateTeamIDField,
+		ErrPreferenceInvalidLocale, ErrPreferenceInvalidTimezone, ErrPreferenceInvalidDateFormat,
+		ErrPreferenceInvalidPerPage, ErrPreferenceUpdateFailed,
+		ErrUserInvalidCursor, ErrSearchFailed,
+		ErrAnnouncementNotFound, ErrAnnouncementTitleRequired, ErrAnnouncementTitleTooLong,
+		ErrAnnouncementBodyRequired, ErrAnnouncementInvalidPriority, ErrAnnouncementExpiryInPast,
+		ErrAnnouncementForbidden, ErrAnnouncementCreationFailed, ErrAnnouncementDeletionFailed,
+		ErrAnnouncementAcknowledgeFailed, ErrNotificationNotFound, ErrNotificationUpdateFailed,
+		ErrShiftNotFound, ErrShiftForbidden, ErrShiftNameRequired, ErrShiftNameTooLong, ErrShiftInvalidTime,
+		ErrShiftDaysRequired, ErrShiftUpdateOverlap, ErrShiftCreationFailed, ErrShiftUpdateFailed,
+		ErrShiftDeletionFailed, ErrShiftAssignmentNotFound, ErrShiftInvalidDateRange, ErrShiftAgentNotInTeam,
+		ErrShiftAgentInactive, ErrShiftAssignmentOverlap, ErrShiftAssignmentFailed, ErrShiftAssignmentDeletionFailed,
+		ErrAttendanceForbidden, ErrAttendanceAlreadyClockedIn, ErrAttendanceNotClockedIn, ErrAttendanceAlreadyOnBreak,
+		ErrAttendanceNotOnBreak, ErrAttendanceClockInFailed, ErrAttendanceClockOutFailed, ErrAttendanceBreakFailed,
+		ErrLeaveNotFound, ErrLeaveForbidden, ErrLeaveInvalidType, ErrLeaveInvalidDateRange, ErrLeaveSpansYears,
+		ErrLeaveStartInPast, ErrLeaveNoWorkingDays, ErrLeaveTextTooLong, ErrLeaveOverlap, ErrLeaveInsufficientBalance,
+		ErrLeaveNoApprover, ErrLeaveNotPending, ErrLeaveCommentRequired, ErrLeaveInvalidBalance,
+		ErrLeaveCreationFailed, ErrLeaveUpdateFailed, ErrLeaveBalanceFailed,
+		ErrTaskNotFound, ErrTaskForbidden, ErrTaskTitleRequired, ErrTaskTitleTooLong, ErrTaskDescriptionTooLong,
+		ErrTaskInvalidPriority, ErrTaskInvalidStatus, ErrTaskInvalidTransition, ErrTaskAssigneeNotInTeam,
+		ErrTaskAssigneeInactive, ErrTaskCommentRequired, ErrTaskCommentTooLong, ErrTaskCreationFailed,
+		ErrTaskUpdateFailed, ErrTaskDeletionFailed, ErrTaskCommentCreateFailed,
+		ErrKPINotFound, ErrKPIForbidden, ErrKPIInvalidKey, ErrKPIKeyTaken, ErrKPINameRequired, ErrKPINameTooLong,
+		ErrKPIInvalidUnit, ErrKPIInvalidAggregation, ErrKPICreationFailed, ErrKPIUpdateFailed, ErrKPIImportInvalidCSV,
+		ErrKPIImportEmpty, ErrKPIImportTooLarge, ErrKPIImportInvalidRows, ErrKPIImportFailed,
+		ErrReportingLoadFailed,
+		ErrReportNotFound, ErrReportForbidden, ErrReportNameRequired, ErrReportNameTooLong,
+		ErrReportInvalidTemplate, ErrReportInvalidCron, ErrReportInvalidTimezone,
+		ErrReportCreationFailed, ErrReportUpdateFailed, ErrReportDeletionFailed,
+		ErrReportSubscriptionFailed, ErrReportUnknownSubscription, ErrReportBuildFailed,
+		ErrJobNotFound, ErrJobNotRetryable, ErrJobUnknownType, ErrJobInvalidPayload, ErrJobEnqueueFailed, ErrJobRetryFailed,
+	}
+	for _, err := range coded {
+		for _, locale := range i18n.Supported() {
+			if text := i18n.T(locale, err.Code()); text == err.Code() {
+				t.Errorf("%s: %q için çeviri yok", locale, err.Code())
+			}
+		}
+	}
+}
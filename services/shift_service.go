@@ -0,0 +1,469 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"zatrano/i18n"
+	"zatrano/models"
+	"zatrano/repositories"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type ShiftServiceError string
+
+func (e ShiftServiceError) Error() string {
+	return i18n.T(i18n.DefaultLocale, string(e))
+}
+
+func (e ShiftServiceError) Code() string {
+	return string(e)
+}
+
+const (
+	ErrShiftNotFound                 ShiftServiceError = "errors.shift.not_found"
+	ErrShiftForbidden                ShiftServiceError = "errors.shift.forbidden"
+	ErrShiftNameRequired             ShiftServiceError = "errors.shift.name_required"
+	ErrShiftNameTooLong              ShiftServiceError = "errors.shift.name_too_long"
+	ErrShiftInvalidTime              ShiftServiceError = "errors.shift.invalid_time"
+	ErrShiftDaysRequired             ShiftServiceError = "errors.shift.days_required"
+	ErrShiftUpdateOverlap            ShiftServiceError = "errors.shift.update_overlap"
+	ErrShiftCreationFailed           ShiftServiceError = "errors.shift.creation_failed"
+	ErrShiftUpdateFailed             ShiftServiceError = "errors.shift.update_failed"
+	ErrShiftDeletionFailed           ShiftServiceError = "errors.shift.deletion_failed"
+	ErrShiftAssignmentNotFound       ShiftServiceError = "errors.shift.assignment_not_found"
+	ErrShiftInvalidDateRange         ShiftServiceError = "errors.shift.invalid_date_range"
+	ErrShiftAgentNotInTeam           ShiftServiceError = "errors.shift.agent_not_in_team"
+	ErrShiftAgentInactive            ShiftServiceError = "errors.shift.agent_inactive"
+	ErrShiftAssignmentOverlap        ShiftServiceError = "errors.shift.assignment_overlap"
+	ErrShiftAssignmentFailed         ShiftServiceError = "errors.shift.assignment_failed"
+	ErrShiftAssignmentDeletionFailed ShiftServiceError = "errors.shift.assignment_deletion_failed"
+)
+
+const ShiftNameMaxLength = 100
+
+const UpcomingShiftDays = 14
+
+type ShiftOccurrence struct {
+	AssignmentID uint
+	Shift        models.Shift
+	Date         time.Time
+	Start        time.Time
+	End          time.Time
+}
+
+type WeekScheduleRow struct {
+	Agent models.User
+	Days  [][]ShiftOccurrence
+}
+
+type WeekSchedule struct {
+	Start       time.Time
+	End         time.Time
+	Days        []time.Time
+	Shifts      []models.Shift
+	Agents      []models.User
+	Rows        []WeekScheduleRow
+	Assignments []models.ShiftAssignment
+}
+
+func (w *WeekSchedule) PreviousWeek() time.Time {
+	return w.Start.AddDate(0, 0, -7)
+}
+
+func (w *WeekSchedule) NextWeek() time.Time {
+	return w.Start.AddDate(0, 0, 7)
+}
+
+type IShiftService interface {
+	ListShifts(ctx context.Context, actor *models.User) ([]models.Shift, error)
+	GetShift(ctx context.Context, actor *models.User, id uint) (*models.Shift, error)
+	CreateShift(ctx context.Context, actor *models.User, shift *models.Shift) error
+	UpdateShift(ctx context.Context, actor *models.User, id uint, shift *models.Shift) error
+	DeleteShift(ctx context.Context, actor *models.User, id uint) error
+	WeekSchedule(ctx context.Context, actor *models.User, date time.Time) (*WeekSchedule, error)
+	AssignShift(ctx context.Context, actor *models.User, assignment *models.ShiftAssignment) error
+	DeleteAssignment(ctx context.Context, actor *models.User, id uint) error
+	UpcomingShifts(ctx context.Context, user *models.User, from time.Time) ([]ShiftOccurrence, error)
+}
+
+type ShiftService struct {
+	repo  repositories.IShiftRepository
+	users repositories.IUserRepository
+}
+
+func NewShiftService(repo repositories.IShiftRepository, users repositories.IUserRepository) IShiftService {
+	return &ShiftService{repo: repo, users: users}
+}
+
+func managedTeam(actor *models.User) (uint, error) {
+	if actor.Type != models.Manager || actor.TeamID == nil {
+		return 0, ErrShiftForbidden
+	}
+	return *actor.TeamID, nil
+}
+
+func normalizeShift(shift *models.Shift) error {
+	shift.Name = strings.TrimSpace(shift.Name)
+	switch {
+	case shift.Name == "":
+		return ErrShiftNameRequired
+	case utf8.RuneCountInString(shift.Name) > ShiftNameMaxLength:
+		return ErrShiftNameTooLong
+	case shift.StartMinute < 0 || shift.StartMinute >= models.MinutesPerDay,
+		shift.EndMinute < 0 || shift.EndMinute >= models.MinutesPerDay,
+		shift.StartMinute == shift.EndMinute:
+		return ErrShiftInvalidTime
+	case !shift.Days.IsValid():
+		return ErrShiftDaysRequired
+	}
+	return nil
+}
+
+func (s *ShiftService) ListShifts(ctx context.Context, actor *models.User) ([]models.Shift, error) {
+	teamID, err := managedTeam(actor)
+	if err != nil {
+		return nil, err
+	}
+	shifts, err := s.repo.FindByTeam(teamID)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Vardiyalar alınırken hata oluştu", zap.Uint("team_id", teamID), zap.Error(err))
+		return nil, err
+	}
+	return shifts, nil
+}
+
+func (s *ShiftService) GetShift(ctx context.Context, actor *models.User, id uint) (*models.Shift, error) {
+	teamID, err := managedTeam(actor)
+	if err != nil {
+		return nil, err
+	}
+	shift, err := s.repo.FindByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrShiftNotFound
+		}
+		utils.LogFrom(ctx).Error("Vardiya alınırken hata oluştu", zap.Uint("shift_id", id), zap.Error(err))
+		return nil, err
+	}
+	if shift.TeamID != teamID {
+		return nil, ErrShiftNotFound
+	}
+	return shift, nil
+}
+
+func (s *ShiftService) CreateShift(ctx context.Context, actor *models.User, shift *models.Shift) error {
+	teamID, err := managedTeam(actor)
+	if err != nil {
+		return err
+	}
+	if err := normalizeShift(shift); err != nil {
+		return err
+	}
+	shift.TeamID = teamID
+	if err := s.repo.Create(shift); err != nil {
+		utils.LogFrom(ctx).Error("Vardiya oluşturulurken veritabanı hatası", zap.String("name", shift.Name), zap.Error(err))
+		return ErrShiftCreationFailed
+	}
+	utils.SLogFrom(ctx).Infof("Vardiya oluşturuldu: %s (ID: %d)", shift.Name, shift.ID)
+	return nil
+}
+
+func (s *ShiftService) UpdateShift(ctx context.Context, actor *models.User, id uint, shift *models.Shift) error {
+	existing, err := s.GetShift(ctx, actor, id)
+	if err != nil {
+		return err
+	}
+	if err := normalizeShift(shift); err != nil {
+		return err
+	}
+	shift.ID, shift.TeamID = existing.ID, existing.TeamID
+
+	assignments, err := s.repo.FindAssignmentsByShift(id)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Vardiya atamaları alınırken hata oluştu", zap.Uint("shift_id", id), zap.Error(err))
+		return ErrShiftUpdateFailed
+	}
+	for i := range assignments {
+		assignments[i].Shift = shift
+		overlap, err := s.findOverlap(&assignments[i])
+		if err != nil {
+			utils.LogFrom(ctx).Error("Vardiya çakışmaları kontrol edilirken hata oluştu", zap.Uint("shift_id", id), zap.Error(err))
+			return ErrShiftUpdateFailed
+		}
+		if overlap {
+			return ErrShiftUpdateOverlap
+		}
+	}
+
+	err = s.repo.Update(id, map[string]interface{}{
+		"name":         shift.Name,
+		"start_minute": shift.StartMinute,
+		"end_minute":   shift.EndMinute,
+		"days":         shift.Days,
+	})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrShiftNotFound
+		}
+		utils.LogFrom(ctx).Error("Vardiya güncellenirken veritabanı hatası", zap.Uint("shift_id", id), zap.Error(err))
+		return ErrShiftUpdateFailed
+	}
+	utils.SLogFrom(ctx).Infof("Vardiya güncellendi: %s (ID: %d)", shift.Name, id)
+	return nil
+}
+
+func (s *ShiftService) DeleteShift(ctx context.Context, actor *models.User, id uint) error {
+	if _, err := s.GetShift(ctx, actor, id); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(id); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrShiftNotFound
+		}
+		utils.LogFrom(ctx).Error("Vardiya silinirken hata oluştu", zap.Uint("shift_id", id), zap.Error(err))
+		return ErrShiftDeletionFailed
+	}
+	utils.SLogFrom(ctx).Infof("Vardiya silindi: ID %d", id)
+	return nil
+}
+
+func (s *ShiftService) WeekSchedule(ctx context.Context, actor *models.User, date time.Time) (*WeekSchedule, error) {
+	teamID, err := managedTeam(actor)
+	if err != nil {
+		return nil, err
+	}
+	start := models.WeekStart(date)
+	week := &WeekSchedule{Start: start, End: start.AddDate(0, 0, 6), Agents: []models.User{}}
+	for i := range models.ShiftWeekdays {
+		week.Days = append(week.Days, start.AddDate(0, 0, i))
+	}
+
+	if week.Shifts, err = s.repo.FindByTeam(teamID); err != nil {
+		utils.LogFrom(ctx).Error("Vardiyalar alınırken hata oluştu", zap.Uint("team_id", teamID), zap.Error(err))
+		return nil, err
+	}
+	agents, err := s.users.FindAgentsByTeam(teamID)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Takım ajanları alınırken hata oluştu", zap.Uint("team_id", teamID), zap.Error(err))
+		return nil, err
+	}
+	if week.Assignments, err = s.repo.FindAssignmentsByTeam(teamID, week.Start, week.End); err != nil {
+		utils.LogFrom(ctx).Error("Vardiya atamaları alınırken hata oluştu", zap.Uint("team_id", teamID), zap.Error(err))
+		return nil, err
+	}
+
+	rowIndex := make(map[uint]int)
+	addRow := func(user models.User) {
+		rowIndex[user.ID] = len(week.Rows)
+		week.Rows = append(week.Rows, WeekScheduleRow{Agent: user, Days: make([][]ShiftOccurrence, len(week.Days))})
+	}
+	assigned := make(map[uint]bool)
+	for _, a := range week.Assignments {
+		assigned[a.UserID] = true
+	}
+	for _, agent := range agents {
+		if agent.Status {
+			week.Agents = append(week.Agents, agent)
+		}
+		if agent.Status || assigned[agent.ID] {
+			addRow(agent)
+		}
+	}
+
+	for i := range week.Assignments {
+		a := &week.Assignments[i]
+		if a.Shift == nil {
+			continue
+		}
+		if _, ok := rowIndex[a.UserID]; !ok {
+			// Takımdan ayrılmış kullanıcının eski ataması da takvimde görünür ki kaldırılabilsin.
+			if a.User == nil {
+				continue
+			}
+			addRow(*a.User)
+		}
+		row := &week.Rows[rowIndex[a.UserID]]
+		for _, occurrence := range shiftOccurrences(a, week.Start, week.End) {
+			day := int(occurrence.Date.Sub(week.Start).Hours() / 24)
+			row.Days[day] = append(row.Days[day], occurrence)
+		}
+	}
+	for _, row := range week.Rows {
+		for _, day := range row.Days {
+			slices.SortFunc(day, compareOccurrences)
+		}
+	}
+	return week, nil
+}
+
+func (s *ShiftService) AssignShift(ctx context.Context, actor *models.User, assignment *models.ShiftAssignment) error {
+	teamID, err := managedTeam(actor)
+	if err != nil {
+		return err
+	}
+	assignment.StartDate = models.CivilDate(assignment.StartDate)
+	assignment.EndDate = models.CivilDate(assignment.EndDate)
+	if assignment.EndDate.Before(assignment.StartDate) {
+		return ErrShiftInvalidDateRange
+	}
+
+	shift, err := s.GetShift(ctx, actor, assignment.ShiftID)
+	if err != nil {
+		return err
+	}
+	agents, err := s.users.FindAgentsByTeam(teamID)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Takım ajanları alınırken hata oluştu", zap.Uint("team_id", teamID), zap.Error(err))
+		return ErrShiftAssignmentFailed
+	}
+	index := slices.IndexFunc(agents, func(u models.User) bool { return u.ID == assignment.UserID })
+	if index < 0 {
+		return ErrShiftAgentNotInTeam
+	}
+	if !agents[index].Status {
+		return ErrShiftAgentInactive
+	}
+
+	assignment.Shift = shift
+	overlap, err := s.findOverlap(assignment)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Vardiya çakışmaları kontrol edilirken hata oluştu", zap.Uint("user_id", assignment.UserID), zap.Error(err))
+		return ErrShiftAssignmentFailed
+	}
+	if overlap {
+		return ErrShiftAssignmentOverlap
+	}
+
+	if err := s.repo.CreateAssignment(assignment); err != nil {
+		utils.LogFrom(ctx).Error("Vardiya ataması oluşturulurken veritabanı hatası", zap.Uint("user_id", assignment.UserID), zap.Error(err))
+		return ErrShiftAssignmentFailed
+	}
+	utils.SLogFrom(ctx).Infof("Vardiya ataması oluşturuldu: kullanıcı %d, vardiya %d (ID: %d)", assignment.UserID, assignment.ShiftID, assignment.ID)
+	return nil
+}
+
+func (s *ShiftService) DeleteAssignment(ctx context.Context, actor *models.User, id uint) error {
+	teamID, err := managedTeam(actor)
+	if err != nil {
+		return err
+	}
+	assignment, err := s.repo.FindAssignmentByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrShiftAssignmentNotFound
+		}
+		utils.LogFrom(ctx).Error("Vardiya ataması alınırken hata oluştu", zap.Uint("assignment_id", id), zap.Error(err))
+		return err
+	}
+	if assignment.Shift == nil || assignment.Shift.TeamID != teamID {
+		return ErrShiftAssignmentNotFound
+	}
+	if err := s.repo.DeleteAssignment(id); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrShiftAssignmentNotFound
+		}
+		utils.LogFrom(ctx).Error("Vardiya ataması silinirken hata oluştu", zap.Uint("assignment_id", id), zap.Error(err))
+		return ErrShiftAssignmentDeletionFailed
+	}
+	utils.SLogFrom(ctx).Infof("Vardiya ataması silindi: ID %d", id)
+	return nil
+}
+
+func (s *ShiftService) UpcomingShifts(ctx context.Context, user *models.User, from time.Time) ([]ShiftOccurrence, error) {
+	from = models.CivilDate(from)
+	to := from.AddDate(0, 0, UpcomingShiftDays-1)
+	assignments, err := s.repo.FindAssignmentsByUser(user.ID, from, to)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Yaklaşan vardiyalar alınırken hata oluştu", zap.Uint("user_id", user.ID), zap.Error(err))
+		return nil, err
+	}
+	upcoming := []ShiftOccurrence{}
+	for i := range assignments {
+		upcoming = append(upcoming, shiftOccurrences(&assignments[i], from, to)...)
+	}
+	slices.SortFunc(upcoming, compareOccurrences)
+	return upcoming, nil
+}
+
+func (s *ShiftService) findOverlap(assignment *models.ShiftAssignment) (bool, error) {
+	// Gece vardiyaları komşu güne taştığından aralık bir gün genişletilir.
+	others, err := s.repo.FindAssignmentsByUser(assignment.UserID, assignment.StartDate.AddDate(0, 0, -1), assignment.EndDate.AddDate(0, 0, 1))
+	if err != nil {
+		return false, err
+	}
+	for i := range others {
+		other := &others[i]
+		if other.ID == assignment.ID || other.Shift == nil {
+			continue
+		}
+		if other.ShiftID == assignment.ShiftID {
+			other.Shift = assignment.Shift
+		}
+		if assignmentsOverlap(assignment, other) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func assignmentsOverlap(a, b *models.ShiftAssignment) bool {
+	from := a.StartDate
+	if b.StartDate.After(from) {
+		from = b.StartDate
+	}
+	to := a.EndDate
+	if b.EndDate.Before(to) {
+		to = b.EndDate
+	}
+	from, to = from.AddDate(0, 0, -1), to.AddDate(0, 0, 1)
+	if limit := from.AddDate(0, 0, 9); to.After(limit) {
+		to = limit
+	}
+
+	for _, x := range shiftOccurrences(a, from, to) {
+		for _, y := range shiftOccurrences(b, from, to) {
+			if x.Start.Before(y.End) && y.Start.Before(x.End) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func shiftOccurrences(a *models.ShiftAssignment, from, to time.Time) []ShiftOccurrence {
+	if a.Shift == nil {
+		return nil
+	}
+	if a.StartDate.After(from) {
+		from = a.StartDate
+	}
+	if a.EndDate.Before(to) {
+		to = a.EndDate
+	}
+	var occurrences []ShiftOccurrence
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if !a.Shift.Days.Has(day.Weekday()) {
+			continue
+		}
+		start, end := a.Shift.Window(day)
+		occurrences = append(occurrences, ShiftOccurrence{AssignmentID: a.ID, Shift: *a.Shift, Date: day, Start: start, End: end})
+	}
+	return occurrences
+}
+
+func compareOccurrences(a, b ShiftOccurrence) int {
+	if c := a.Start.Compare(b.Start); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.AssignmentID, b.AssignmentID)
+}
+
+var _ IShiftService = (*ShiftService)(nil)
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"zatrano/models"
+)
+
+func TestShiftAssignmentRules(t *testing.T) {
+	s := newTestServices(t)
+	ctx := context.Background()
+	sales := s.mustCreateTeam(t, "Satış")
+	support := s.mustCreateTeam(t, "Destek")
+	manager := s.mustCreateUser(t, models.User{Name: "Yönetici", Account: "manager@x", Password: "secret1", Type: models.Manager, TeamID: &sales.ID})
+	ali := s.mustCreateUser(t, models.User{Name: "Ali", Account: "ali@x", Password: "secret1", Type: models.Agent, TeamID: &sales.ID})
+	veli := s.mustCreateUser(t, models.User{Name: "Veli", Account: "veli@x", Password: "secret1", Type: models.Agent, TeamID: &sales.ID})
+	can := s.mustCreateUser(t, models.User{Name: "Can", Account: "can@x", Password: "secret1", Type: models.Agent, TeamID: &support.ID})
+	inactive := models.User{Name: "Veli", Account: "veli@x", Status: false, Type: models.Agent, TeamID: &sales.ID}
+	if err := s.users.UpdateUser(ctx, veli.ID, &inactive); err != nil {
+		t.Fatalf("UpdateUser(inactive) error = %v", err)
+	}
+
+	weekdays := models.NewWeekdaySet(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday)
+	day := &models.Shift{Name: "Gündüz", StartMinute: 9 * 60, EndMinute: 17 * 60, Days: weekdays}
+	night := &models.Shift{Name: "Gece", StartMinute: 22 * 60, EndMinute: 6 * 60, Days: models.NewWeekdaySet(time.Monday)}
+	early := &models.Shift{Name: "Erken", StartMinute: 5 * 60, EndMinute: 9 * 60, Days: weekdays}
+	for _, shift := range []*models.Shift{day, night, early} {
+		if err := s.shifts.CreateShift(ctx, manager, shift); err != nil {
+			t.Fatalf("CreateShift(%s) error = %v", shift.Name, err)
+		}
+	}
+	if err := s.shifts.CreateShift(ctx, manager, &models.Shift{Name: "Boş", StartMinute: 60, EndMinute: 60, Days: weekdays}); err != ErrShiftInvalidTime {
+		t.Fatalf("CreateShift(zero length) error = %v, want ErrShiftInvalidTime", err)
+	}
+	if err := s.shifts.CreateShift(ctx, ali, &models.Shift{Name: "Ajan", StartMinute: 60, EndMinute: 120, Days: weekdays}); err != ErrShiftForbidden {
+		t.Fatalf("CreateShift(agent) error = %v, want ErrShiftForbidden", err)
+	}
+
+	// 2026-03-02 bir Pazartesidir.
+	date := func(d int) time.Time { return time.Date(2026, time.March, d, 0, 0, 0, 0, time.UTC) }
+	assign := func(shift *models.Shift, user *models.User, from, to int) error {
+		return s.shifts.AssignShift(ctx, manager, &models.ShiftAssignment{ShiftID: shift.ID, UserID: user.ID, StartDate: date(from), EndDate: date(to)})
+	}
+
+	if err := assign(day, ali, 2, 15); err != nil {
+		t.Fatalf("AssignShift(day) error = %v", err)
+	}
+	if err := assign(day, ali, 9, 9); err != ErrShiftAssignmentOverlap {
+		t.Fatalf("AssignShift(same shift) error = %v, want ErrShiftAssignmentOverlap", err)
+	}
+	// Erken vardiya 09:00'da biter, gündüz vardiyası 09:00'da başlar; çakışma yoktur.
+	if err := assign(early, ali, 2, 15); err != nil {
+		t.Fatalf("AssignShift(adjacent) error = %v", err)
+	}
+	// Pazartesi gecesi vardiyası Salı 06:00'ya kadar sürer ve Salı erken vardiyasıyla çakışır.
+	if err := assign(night, ali, 9, 9); err != ErrShiftAssignmentOverlap {
+		t.Fatalf("AssignShift(overnight) error = %v, want ErrShiftAssignmentOverlap", err)
+	}
+	// Haftalar bitince çakışma kalmaz.
+	if err := assign(night, ali, 16, 22); err != nil {
+		t.Fatalf("AssignShift(later week) error = %v", err)
+	}
+	if err := assign(day, veli, 2, 6); err != ErrShiftAgentInactive {
+		t.Fatalf("AssignShift(inactive) error = %v, want ErrShiftAgentInactive", err)
+	}
+	if err := assign(day, can, 2, 6); err != ErrShiftAgentNotInTeam {
+		t.Fatalf("AssignShift(other team) error = %v, want ErrShiftAgentNotInTeam", err)
+	}
+	if err := assign(day, ali, 20, 16); err != ErrShiftInvalidDateRange {
+		t.Fatalf("AssignShift(reversed range) error = %v, want ErrShiftInvalidDateRange", err)
+	}
+
+	// Erken vardiyayı 10:00'a uzatmak Ali'nin gündüz vardiyasıyla çakışır.
+	longer := *early
+	longer.EndMinute = 10 * 60
+	if err := s.shifts.UpdateShift(ctx, manager, early.ID, &longer); err != ErrShiftUpdateOverlap {
+		t.Fatalf("UpdateShift(overlap) error = %v, want ErrShiftUpdateOverlap", err)
+	}
+
+	week, err := s.shifts.WeekSchedule(ctx, manager, date(11))
+	if err != nil {
+		t.Fatalf("WeekSchedule() error = %v", err)
+	}
+	if !week.Start.Equal(date(9)) || len(week.Days) != 7 || len(week.Agents) != 1 || len(week.Rows) != 1 {
+		t.Fatalf("WeekSchedule() = start %v, %d days, %d agents, %d rows", week.Start, len(week.Days), len(week.Agents), len(week.Rows))
+	}
+	monday := week.Rows[0].Days[0]
+	if len(monday) != 2 || monday[0].Shift.Name != "Erken" || monday[1].Shift.Name != "Gündüz" {
+		t.Fatalf("Monday cell = %+v, want Erken then Gündüz", monday)
+	}
+	if len(week.Rows[0].Days[5]) != 0 {
+		t.Fatalf("Saturday cell = %+v, want empty", week.Rows[0].Days[5])
+	}
+
+	upcoming, err := s.shifts.UpcomingShifts(ctx, ali, date(13))
+	if err != nil {
+		t.Fatalf("UpcomingShifts() error = %v", err)
+	}
+	// Cuma (erken, gündüz), sonraki hafta Pazartesi gecesi; toplam 3 vardiya.
+	if len(upcoming) != 3 || upcoming[2].Shift.Name != "Gece" || !upcoming[2].End.Equal(date(17).Add(6*time.Hour)) {
+		t.Fatalf("UpcomingShifts() = %+v", upcoming)
+	}
+
+	if err := s.shifts.DeleteShift(ctx, manager, night.ID); err != nil {
+		t.Fatalf("DeleteShift() error = %v", err)
+	}
+	if err := assign(night, ali, 23, 23); err != ErrShiftNotFound {
+		t.Fatalf("AssignShift(deleted shift) error = %v, want ErrShiftNotFound", err)
+	}
+}
@@ -0,0 +1,426 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"zatrano/i18n"
+	"zatrano/models"
+	"zatrano/repositories"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type TaskServiceError string
+
+func (e TaskServiceError) Error() string {
+	return i18n.T(i18n.DefaultLocale, string(e))
+}
+
+func (e TaskServiceError) Code() string {
+	return string(e)
+}
+
+const (
+	ErrTaskNotFound            TaskServiceError = "errors.task.not_found"
+	ErrTaskForbidden           TaskServiceError = "errors.task.forbidden"
+	ErrTaskTitleRequired       TaskServiceError = "errors.task.title_required"
+	ErrTaskTitleTooLong        TaskServiceError = "errors.task.title_too_long"
+	ErrTaskDescriptionTooLong  TaskServiceError = "errors.task.description_too_long"
+	ErrTaskInvalidPriority     TaskServiceError = "errors.task.invalid_priority"
+	ErrTaskInvalidStatus       TaskServiceError = "errors.task.invalid_status"
+	ErrTaskInvalidTransition   TaskServiceError = "errors.task.invalid_transition"
+	ErrTaskAssigneeNotInTeam   TaskServiceError = "errors.task.assignee_not_in_team"
+	ErrTaskAssigneeInactive    TaskServiceError = "errors.task.assignee_inactive"
+	ErrTaskCommentRequired     TaskServiceError = "errors.task.comment_required"
+	ErrTaskCommentTooLong      TaskServiceError = "errors.task.comment_too_long"
+	ErrTaskCreationFailed      TaskServiceError = "errors.task.creation_failed"
+	ErrTaskUpdateFailed        TaskServiceError = "errors.task.update_failed"
+	ErrTaskDeletionFailed      TaskServiceError = "errors.task.deletion_failed"
+	ErrTaskCommentCreateFailed TaskServiceError = "errors.task.comment_failed"
+)
+
+const (
+	TaskTitleMaxLength       = 200
+	TaskDescriptionMaxLength = 5000
+	TaskCommentMaxLength     = 2000
+)
+
+var TaskListSpec = utils.FilterSpec{
+	"id":                           {Type: utils.FieldInt, Sortable: true},
+	"title":                        {Type: utils.FieldString, Sortable: true},
+	"status":                       {Type: utils.FieldEnum, Ops: []utils.FilterOp{utils.OpEq, utils.OpIn}, Values: taskStatusValues(), Sortable: true},
+	"priority":                     {Type: utils.FieldEnum, Ops: []utils.FilterOp{utils.OpEq, utils.OpIn}, Values: taskPriorityValues()},
+	"assignee_id":                  {Type: utils.FieldInt, Ops: []utils.FilterOp{utils.OpEq}},
+	"due_date":                     {Type: utils.FieldDate, Sortable: true},
+	"created_at":                   {Type: utils.FieldDate, Ops: []utils.FilterOp{utils.OpGte, utils.OpLte}, Sortable: true},
+	repositories.TaskOverdueFilter: {Type: utils.FieldBool, Ops: []utils.FilterOp{utils.OpEq}},
+}
+
+func taskStatusValues() []string {
+	values := make([]string, len(models.TaskStatuses))
+	for i, s := range models.TaskStatuses {
+		values[i] = string(s)
+	}
+	return values
+}
+
+func taskPriorityValues() []string {
+	values := make([]string, len(models.TaskPriorities))
+	for i, p := range models.TaskPriorities {
+		values[i] = string(p)
+	}
+	return values
+}
+
+type TaskDetail struct {
+	Task        *models.Task
+	Comments    []models.TaskComment
+	Transitions []models.TaskStatus
+}
+
+type ITaskService interface {
+	ListTeamTasks(ctx context.Context, actor *models.User, params utils.ListParams, today time.Time) (*utils.PaginatedResult, error)
+	TeamAgents(ctx context.Context, actor *models.User) ([]models.User, error)
+	AgentTasks(ctx context.Context, actor *models.User) ([]models.Task, error)
+	GetTask(ctx context.Context, actor *models.User, id uint) (*TaskDetail, error)
+	CreateTask(ctx context.Context, actor *models.User, task *models.Task) error
+	UpdateTask(ctx context.Context, actor *models.User, id uint, task *models.Task) error
+	DeleteTask(ctx context.Context, actor *models.User, id uint) error
+	ChangeStatus(ctx context.Context, actor *models.User, id uint, status models.TaskStatus) error
+	AddComment(ctx context.Context, actor *models.User, id uint, body string) error
+}
+
+type TaskService struct {
+	repo          repositories.ITaskRepository
+	users         repositories.IUserRepository
+	notifications INotificationService
+	now           func() time.Time
+}
+
+func NewTaskService(repo repositories.ITaskRepository, users repositories.IUserRepository, notifications INotificationService) ITaskService {
+	return &TaskService{repo: repo, users: users, notifications: notifications, now: func() time.Time { return time.Now().UTC() }}
+}
+
+func taskTeam(actor *models.User) (uint, error) {
+	if actor.Type != models.Manager || actor.TeamID == nil {
+		return 0, ErrTaskForbidden
+	}
+	return *actor.TeamID, nil
+}
+
+func canManageTask(actor *models.User, task *models.Task) bool {
+	return actor.Type == models.Manager && actor.TeamID != nil && *actor.TeamID == task.TeamID
+}
+
+func canWorkOnTask(actor *models.User, task *models.Task) bool {
+	return canManageTask(actor, task) || (actor.Type == models.Agent && task.AssigneeID == actor.ID)
+}
+
+func taskLink(user models.UserType, id uint) string {
+	prefix := "/agent/tasks/"
+	if user == models.Manager {
+		prefix = "/manager/tasks/"
+	}
+	return prefix + strconv.FormatUint(uint64(id), 10)
+}
+
+func normalizeTask(task *models.Task) error {
+	task.Title = strings.TrimSpace(task.Title)
+	task.Description = strings.TrimSpace(task.Description)
+	if task.Priority == "" {
+		task.Priority = models.TaskPriorityNormal
+	}
+	if task.DueDate != nil {
+		due := models.CivilDate(*task.DueDate)
+		task.DueDate = &due
+	}
+	switch {
+	case task.Title == "":
+		return ErrTaskTitleRequired
+	case utf8.RuneCountInString(task.Title) > TaskTitleMaxLength:
+		return ErrTaskTitleTooLong
+	case utf8.RuneCountInString(task.Description) > TaskDescriptionMaxLength:
+		return ErrTaskDescriptionTooLong
+	case !task.Priority.IsValid():
+		return ErrTaskInvalidPriority
+	}
+	return nil
+}
+
+func (s *TaskService) ListTeamTasks(ctx context.Context, actor *models.User, params utils.ListParams, today time.Time) (*utils.PaginatedResult, error) {
+	teamID, err := taskTeam(actor)
+	if err != nil {
+		return nil, err
+	}
+	if params.Page <= 0 {
+		params.Page = utils.DefaultPage
+	}
+	if params.PerPage <= 0 || params.PerPage > utils.MaxPerPage {
+		params.PerPage = utils.DefaultPerPage
+	}
+
+	tasks, totalCount, err := s.repo.FindAndPaginate(teamID, models.CivilDate(today), params)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Takım görevleri alınırken hata oluştu", zap.Uint("team_id", teamID), zap.Error(err))
+		return nil, err
+	}
+	return &utils.PaginatedResult{
+		Data: tasks,
+		Meta: utils.PaginationMeta{
+			CurrentPage: params.Page, PerPage: params.PerPage,
+			TotalItems: totalCount, TotalPages: utils.CalculateTotalPages(totalCount, params.PerPage),
+		},
+	}, nil
+}
+
+func (s *TaskService) TeamAgents(ctx context.Context, actor *models.User) ([]models.User, error) {
+	teamID, err := taskTeam(actor)
+	if err != nil {
+		return nil, err
+	}
+	agents, err := s.users.FindAgentsByTeam(teamID)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Takım ajanları alınırken hata oluştu", zap.Uint("team_id", teamID), zap.Error(err))
+		return nil, err
+	}
+	return slices.DeleteFunc(agents, func(u models.User) bool { return !u.Status }), nil
+}
+
+func (s *TaskService) AgentTasks(ctx context.Context, actor *models.User) ([]models.Task, error) {
+	if actor.Type != models.Agent {
+		return nil, ErrTaskForbidden
+	}
+	tasks, err := s.repo.FindByAssignee(actor.ID)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Ajan görevleri alınırken hata oluştu", zap.Uint("user_id", actor.ID), zap.Error(err))
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (s *TaskService) find(ctx context.Context, actor *models.User, id uint) (*models.Task, error) {
+	task, err := s.repo.FindByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrTaskNotFound
+		}
+		utils.LogFrom(ctx).Error("Görev alınırken hata oluştu", zap.Uint("task_id", id), zap.Error(err))
+		return nil, err
+	}
+	if !canWorkOnTask(actor, task) {
+		return nil, ErrTaskNotFound
+	}
+	return task, nil
+}
+
+func (s *TaskService) findManaged(ctx context.Context, actor *models.User, id uint) (*models.Task, error) {
+	if _, err := taskTeam(actor); err != nil {
+		return nil, err
+	}
+	task, err := s.find(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+	if !canManageTask(actor, task) {
+		return nil, ErrTaskNotFound
+	}
+	return task, nil
+}
+
+func (s *TaskService) GetTask(ctx context.Context, actor *models.User, id uint) (*TaskDetail, error) {
+	task, err := s.find(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := s.repo.FindComments(task.ID)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Görev yorumları alınırken hata oluştu", zap.Uint("task_id", id), zap.Error(err))
+		return nil, err
+	}
+	return &TaskDetail{Task: task, Comments: comments, Transitions: task.Status.Transitions()}, nil
+}
+
+func (s *TaskService) checkAssignee(ctx context.Context, teamID, assigneeID uint) error {
+	agents, err := s.users.FindAgentsByTeam(teamID)
+	if err != nil {
+		utils.LogFrom(ctx).Error("Takım ajanları alınırken hata oluştu", zap.Uint("team_id", teamID), zap.Error(err))
+		return err
+	}
+	index := slices.IndexFunc(agents, func(u models.User) bool { return u.ID == assigneeID })
+	if index < 0 {
+		return ErrTaskAssigneeNotInTeam
+	}
+	if !agents[index].Status {
+		return ErrTaskAssigneeInactive
+	}
+	return nil
+}
+
+func (s *TaskService) notifyAssignee(ctx context.Context, task *models.Task) {
+	if err := s.notifications.Notify(ctx, task.AssigneeID, "notifications.task_assigned", taskLink(models.Agent, task.ID), task.Title); err != nil {
+		utils.LogFrom(ctx).Warn("Görev ataması bildirimi gönderilemedi", zap.Uint("task_id", task.ID), zap.Error(err))
+	}
+}
+
+func (s *TaskService) notifyOtherSide(ctx context.Context, actor *models.User, task *models.Task, messageID string, params ...string) {
+	recipient, recipientType := task.AssigneeID, models.Agent
+	if actor.ID == task.AssigneeID {
+		recipient, recipientType = task.CreatedByID, models.Manager
+	}
+	if recipient == actor.ID {
+		return
+	}
+	if err := s.notifications.Notify(ctx, recipient, messageID, taskLink(recipientType, task.ID), params...); err != nil {
+		utils.LogFrom(ctx).Warn("Görev bildirimi gönderilemedi", zap.Uint("task_id", task.ID), zap.String("message_id", messageID), zap.Error(err))
+	}
+}
+
+func (s *TaskService) CreateTask(ctx context.Context, actor *models.User, task *models.Task) error {
+	teamID, err := taskTeam(actor)
+	if err != nil {
+		return err
+	}
+	if err := normalizeTask(task); err != nil {
+		return err
+	}
+	if err := s.checkAssignee(ctx, teamID, task.AssigneeID); err != nil {
+		if _, ok := err.(TaskServiceError); ok {
+			return err
+		}
+		return ErrTaskCreationFailed
+	}
+
+	task.TeamID = teamID
+	task.CreatedByID = actor.ID
+	task.Status = models.TaskOpen
+	task.CompletedAt = nil
+	if err := s.repo.Create(task); err != nil {
+		utils.LogFrom(ctx).Error("Görev oluşturulurken veritabanı hatası", zap.Uint("team_id", teamID), zap.Error(err))
+		return ErrTaskCreationFailed
+	}
+	utils.SLogFrom(ctx).Infof("Görev oluşturuldu: %s (ID: %d, atanan: %d)", task.Title, task.ID, task.AssigneeID)
+
+	s.notifyAssignee(ctx, task)
+	return nil
+}
+
+func (s *TaskService) UpdateTask(ctx context.Context, actor *models.User, id uint, task *models.Task) error {
+	existing, err := s.findManaged(ctx, actor, id)
+	if err != nil {
+		return err
+	}
+	if err := normalizeTask(task); err != nil {
+		return err
+	}
+	reassigned := task.AssigneeID != existing.AssigneeID
+	if reassigned {
+		if err := s.checkAssignee(ctx, existing.TeamID, task.AssigneeID); err != nil {
+			if _, ok := err.(TaskServiceError); ok {
+				return err
+			}
+			return ErrTaskUpdateFailed
+		}
+	}
+
+	err = s.repo.Update(id, map[string]interface{}{
+		"title":       task.Title,
+		"description": task.Description,
+		"due_date":    task.DueDate,
+		"priority":    task.Priority,
+		"assignee_id": task.AssigneeID,
+	})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrTaskNotFound
+		}
+		utils.LogFrom(ctx).Error("Görev güncellenirken veritabanı hatası", zap.Uint("task_id", id), zap.Error(err))
+		return ErrTaskUpdateFailed
+	}
+	utils.SLogFrom(ctx).Infof("Görev güncellendi: ID %d", id)
+
+	if reassigned {
+		task.ID = id
+		s.notifyAssignee(ctx, task)
+	}
+	return nil
+}
+
+func (s *TaskService) DeleteTask(ctx context.Context, actor *models.User, id uint) error {
+	if _, err := s.findManaged(ctx, actor, id); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(id); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrTaskNotFound
+		}
+		utils.LogFrom(ctx).Error("Görev silinirken veritabanı hatası", zap.Uint("task_id", id), zap.Error(err))
+		return ErrTaskDeletionFailed
+	}
+	utils.SLogFrom(ctx).Infof("Görev silindi: ID %d", id)
+	return nil
+}
+
+func (s *TaskService) ChangeStatus(ctx context.Context, actor *models.User, id uint, status models.TaskStatus) error {
+	if !status.IsValid() {
+		return ErrTaskInvalidStatus
+	}
+	task, err := s.find(ctx, actor, id)
+	if err != nil {
+		return err
+	}
+	if task.Status == status {
+		return nil
+	}
+	if !task.Status.CanTransitionTo(status) {
+		return ErrTaskInvalidTransition
+	}
+
+	var completedAt *time.Time
+	if status == models.TaskDone {
+		now := s.now()
+		completedAt = &now
+	}
+	if err := s.repo.Update(id, map[string]interface{}{"status": status, "completed_at": completedAt}); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrTaskNotFound
+		}
+		utils.LogFrom(ctx).Error("Görev durumu güncellenirken veritabanı hatası", zap.Uint("task_id", id), zap.Error(err))
+		return ErrTaskUpdateFailed
+	}
+	utils.SLogFrom(ctx).Infof("Görev durumu değişti: ID %d, %s -> %s (%s)", id, task.Status, status, actor.Account)
+
+	s.notifyOtherSide(ctx, actor, task, "notifications.task_status_changed", actor.Name, task.Title)
+	return nil
+}
+
+func (s *TaskService) AddComment(ctx context.Context, actor *models.User, id uint, body string) error {
+	body = strings.TrimSpace(body)
+	switch {
+	case body == "":
+		return ErrTaskCommentRequired
+	case utf8.RuneCountInString(body) > TaskCommentMaxLength:
+		return ErrTaskCommentTooLong
+	}
+	task, err := s.find(ctx, actor, id)
+	if err != nil {
+		return err
+	}
+
+	comment := models.TaskComment{TaskID: task.ID, AuthorID: actor.ID, Body: body, CreatedAt: s.now()}
+	if err := s.repo.CreateComment(&comment); err != nil {
+		utils.LogFrom(ctx).Error("Görev yorumu eklenirken veritabanı hatası", zap.Uint("task_id", id), zap.Error(err))
+		return ErrTaskCommentCreateFailed
+	}
+
+	s.notifyOtherSide(ctx, actor, task, "notifications.task_commented", actor.Name, task.Title)
+	return nil
+}
+
+var _ ITaskService = (*TaskService)(nil)
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"zatrano/models"
+	"zatrano/utils"
+)
+
+func TestTaskWorkflow(t *testing.T) {
+	s := newTestServices(t)
+	ctx := context.Background()
+	sales := s.mustCreateTeam(t, "Satış")
+	support := s.mustCreateTeam(t, "Destek")
+	manager := s.mustCreateUser(t, models.User{Name: "Yönetici", Account: "manager@x", Password: "secret1", Type: models.Manager, TeamID: &sales.ID})
+	ali := s.mustCreateUser(t, models.User{Name: "Ali", Account: "ali@x", Password: "secret1", Type: models.Agent, TeamID: &sales.ID})
+	veli := s.mustCreateUser(t, models.User{Name: "Veli", Account: "veli@x", Password: "secret1", Type: models.Agent, TeamID: &sales.ID})
+	can := s.mustCreateUser(t, models.User{Name: "Can", Account: "can@x", Password: "secret1", Type: models.Agent, TeamID: &support.ID})
+	inactive := models.User{Name: "Veli", Account: "veli@x", Status: false, Type: models.Agent, TeamID: &sales.ID}
+	if err := s.users.UpdateUser(ctx, veli.ID, &inactive); err != nil {
+		t.Fatalf("UpdateUser(inactive) error = %v", err)
+	}
+
+	due := time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC)
+	invalid := []struct {
+		name string
+		task models.Task
+		want error
+	}{
+		{"title", models.Task{Title: "  ", AssigneeID: ali.ID}, ErrTaskTitleRequired},
+		{"long title", models.Task{Title: strings.Repeat("a", TaskTitleMaxLength+1), AssigneeID: ali.ID}, ErrTaskTitleTooLong},
+		{"priority", models.Task{Title: "Rapor", Priority: "urgent", AssigneeID: ali.ID}, ErrTaskInvalidPriority},
+		{"other team", models.Task{Title: "Rapor", AssigneeID: can.ID}, ErrTaskAssigneeNotInTeam},
+		{"manager", models.Task{Title: "Rapor", AssigneeID: manager.ID}, ErrTaskAssigneeNotInTeam},
+		{"inactive", models.Task{Title: "Rapor", AssigneeID: veli.ID}, ErrTaskAssigneeInactive},
+	}
+	for _, tc := range invalid {
+		if err := s.tasks.CreateTask(ctx, manager, &tc.task); err != tc.want {
+			t.Errorf("CreateTask(%s) error = %v, want %v", tc.name, err, tc.want)
+		}
+	}
+	if err := s.tasks.CreateTask(ctx, ali, &models.Task{Title: "Rapor", AssigneeID: ali.ID}); err != ErrTaskForbidden {
+		t.Fatalf("CreateTask(agent) error = %v, want ErrTaskForbidden", err)
+	}
+
+	task := &models.Task{Title: "  Rapor hazırla ", DueDate: &due, AssigneeID: ali.ID}
+	if err := s.tasks.CreateTask(ctx, manager, task); err != nil {
+		t.Fatalf("CreateTask() error = %v", err)
+	}
+	if task.Title != "Rapor hazırla" || task.Priority != models.TaskPriorityNormal || task.Status != models.TaskOpen || task.TeamID != sales.ID {
+		t.Fatalf("CreateTask() = %+v", task)
+	}
+	if recent, _ := s.notifications.ListRecent(ctx, ali.ID); len(recent) != 1 || recent[0].MessageID != "notifications.task_assigned" || recent[0].Link != "/agent/tasks/1" {
+		t.Fatalf("agent notifications = %+v", recent)
+	}
+
+	today := due.AddDate(0, 0, 5)
+	overdue := utils.ListParams{Filters: []utils.Filter{{Field: "overdue", Op: utils.OpEq, Value: true}}}
+	result, err := s.tasks.ListTeamTasks(ctx, manager, overdue, today)
+	if err != nil || result.Meta.TotalItems != 1 {
+		t.Fatalf("ListTeamTasks(overdue) = %+v, %v", result, err)
+	}
+	if _, err := s.tasks.ListTeamTasks(ctx, ali, utils.ListParams{}, today); err != ErrTaskForbidden {
+		t.Fatalf("ListTeamTasks(agent) error = %v, want ErrTaskForbidden", err)
+	}
+	if agents, err := s.tasks.TeamAgents(ctx, manager); err != nil || len(agents) != 1 || agents[0].ID != ali.ID {
+		t.Fatalf("TeamAgents() = %+v, %v", agents, err)
+	}
+
+	if _, err := s.tasks.GetTask(ctx, can, task.ID); err != ErrTaskNotFound {
+		t.Fatalf("GetTask(other agent) error = %v, want ErrTaskNotFound", err)
+	}
+	if err := s.tasks.ChangeStatus(ctx, ali, task.ID, "archived"); err != ErrTaskInvalidStatus {
+		t.Fatalf("ChangeStatus(invalid) error = %v, want ErrTaskInvalidStatus", err)
+	}
+	if err := s.tasks.ChangeStatus(ctx, ali, task.ID, models.TaskInProgress); err != nil {
+		t.Fatalf("ChangeStatus(in_progress) error = %v", err)
+	}
+	if recent, _ := s.notifications.ListRecent(ctx, manager.ID); len(recent) != 1 || recent[0].MessageID != "notifications.task_status_changed" || recent[0].Link != "/manager/tasks/1" {
+		t.Fatalf("manager notifications = %+v", recent)
+	}
+	if err := s.tasks.AddComment(ctx, ali, task.ID, " "); err != ErrTaskCommentRequired {
+		t.Fatalf("AddComment(empty) error = %v, want ErrTaskCommentRequired", err)
+	}
+	if err := s.tasks.AddComment(ctx, manager, task.ID, "Cuma'ya kadar lütfen"); err != nil {
+		t.Fatalf("AddComment() error = %v", err)
+	}
+	if err := s.tasks.ChangeStatus(ctx, ali, task.ID, models.TaskDone); err != nil {
+		t.Fatalf("ChangeStatus(done) error = %v", err)
+	}
+
+	detail, err := s.tasks.GetTask(ctx, ali, task.ID)
+	if err != nil || detail.Task.CompletedAt == nil || len(detail.Comments) != 1 || detail.Comments[0].Author.ID != manager.ID {
+		t.Fatalf("GetTask() = %+v, %v", detail, err)
+	}
+	if len(detail.Transitions) != 1 || detail.Transitions[0] != models.TaskInProgress {
+		t.Fatalf("transitions = %v, want [in_progress]", detail.Transitions)
+	}
+	if err := s.tasks.ChangeStatus(ctx, manager, task.ID, models.TaskOpen); err != ErrTaskInvalidTransition {
+		t.Fatalf("ChangeStatus(done -> open) error = %v, want ErrTaskInvalidTransition", err)
+	}
+	if mine, err := s.tasks.AgentTasks(ctx, ali); err != nil || len(mine) != 0 {
+		t.Fatalf("AgentTasks() = %+v, %v", mine, err)
+	}
+	if result, _ := s.tasks.ListTeamTasks(ctx, manager, overdue, today); result.Meta.TotalItems != 0 {
+		t.Fatalf("done task still overdue: %+v", result)
+	}
+
+	if err := s.tasks.UpdateTask(ctx, ali, task.ID, &models.Task{Title: "x", AssigneeID: ali.ID}); err != ErrTaskForbidden {
+		t.Fatalf("UpdateTask(agent) error = %v, want ErrTaskForbidden", err)
+	}
+	if err := s.tasks.DeleteTask(ctx, manager, task.ID); err != nil {
+		t.Fatalf("DeleteTask() error = %v", err)
+	}
+	if _, err := s.tasks.GetTask(ctx, manager, task.ID); err != ErrTaskNotFound {
+		t.Fatalf("GetTask(deleted) error = %v, want ErrTaskNotFound", err)
+	}
+}
@@ -1,6 +1,9 @@
 package services
 
 import (
+	"context"
+
+	"zatrano/i18n"
 	"zatrano/models"
 	"zatrano/repositories"
 	"zatrano/utils"
@@ -12,44 +15,56 @@ import (
 type TeamServiceError string
 
 func (e TeamServiceError) Error() string {
+	return i18n.T(i18n.DefaultLocale, string(e))
+}
+
+func (e TeamServiceError) Code() string {
 	return string(e)
 }
 
 const (
-	ErrTeamNotFound       TeamServiceError = "takım bulunamadı"
-	ErrTeamCreationFailed TeamServiceError = "takım oluşturulamadı"
-	ErrTeamUpdateFailed   TeamServiceError = "takım güncellenemedi"
-	ErrTeamDeletionFailed TeamServiceError = "takım silinemedi"
+	ErrTeamNotFound       TeamServiceError = "errors.team.not_found"
+	ErrTeamCreationFailed TeamServiceError = "errors.team.creation_failed"
+	ErrTeamUpdateFailed   TeamServiceError = "errors.team.update_failed"
+	ErrTeamDeletionFailed TeamServiceError = "errors.team.deletion_failed"
 )
 
+var TeamListSpec = utils.FilterSpec{
+	"id":           {Type: utils.FieldInt, Sortable: true},
+	"name":         {Type: utils.FieldString, Sortable: true},
+	"status":       {Type: utils.FieldBool, Ops: []utils.FilterOp{utils.OpEq}, Sortable: true},
+	"member_count": {Type: utils.FieldInt, Ops: []utils.FilterOp{utils.OpEq, utils.OpGte, utils.OpLte}, Sortable: true},
+	"created_at":   {Type: utils.FieldDate, Ops: []utils.FilterOp{utils.OpGte, utils.OpLte}, Sortable: true},
+}
+
 type ITeamService interface {
-	GetAllTeams() ([]models.Team, error)
-	GetAllTeamsPaginated(params utils.ListParams) (*utils.PaginatedResult, error)
-	GetTeamByID(id uint) (*models.Team, error)
-	CreateTeam(team *models.Team) error
-	UpdateTeam(id uint, teamData *models.Team) error
-	DeleteTeam(id uint) error
-	GetTeamCount() (int64, error)
+	GetAllTeams(ctx context.Context) ([]models.Team, error)
+	GetAllTeamsPaginated(ctx context.Context, params utils.ListParams) (*utils.PaginatedResult, error)
+	GetTeamByID(ctx context.Context, id uint) (*models.Team, error)
+	CreateTeam(ctx context.Context, team *models.Team) error
+	UpdateTeam(ctx context.Context, id uint, teamData *models.Team) error
+	DeleteTeam(ctx context.Context, id uint) error
+	GetTeamCount(ctx context.Context) (int64, error)
 }
 
 type TeamService struct {
 	repo repositories.ITeamRepository
 }
 
-func NewTeamService() ITeamService {
-	return &TeamService{repo: repositories.NewTeamRepository()}
+func NewTeamService(repo repositories.ITeamRepository) ITeamService {
+	return &TeamService{repo: repo}
 }
 
-func (s *TeamService) GetAllTeams() ([]models.Team, error) {
+func (s *TeamService) GetAllTeams(ctx context.Context) ([]models.Team, error) {
 	teams, err := s.repo.FindAll()
 	if err != nil {
-		utils.Log.Error("Tüm takımlar alınırken hata oluştu", zap.Error(err))
+		utils.LogFrom(ctx).Error("Tüm takımlar alınırken hata oluştu", zap.Error(err))
 		return nil, err
 	}
 	return teams, nil
 }
 
-func (s *TeamService) GetAllTeamsPaginated(params utils.ListParams) (*utils.PaginatedResult, error) {
+func (s *TeamService) GetAllTeamsPaginated(ctx context.Context, params utils.ListParams) (*utils.PaginatedResult, error) {
 	if params.Page <= 0 {
 		params.Page = utils.DefaultPage
 	}
@@ -82,63 +97,63 @@ func (s *TeamService) GetAllTeamsPaginated(params utils.ListParams) (*utils.Pagi
 	return result, nil
 }
 
-func (s *TeamService) GetTeamByID(id uint) (*models.Team, error) {
+func (s *TeamService) GetTeamByID(ctx context.Context, id uint) (*models.Team, error) {
 	team, err := s.repo.FindByID(id)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, ErrTeamNotFound
 		}
-		utils.Log.Error("Takım alınırken hata oluştu (ID ile arama)", zap.Uint("team_id", id), zap.Error(err))
+		utils.LogFrom(ctx).Error("Takım alınırken hata oluştu (ID ile arama)", zap.Uint("team_id", id), zap.Error(err))
 		return nil, err
 	}
 	return team, nil
 }
-func (s *TeamService) CreateTeam(team *models.Team) error {
+func (s *TeamService) CreateTeam(ctx context.Context, team *models.Team) error {
 	err := s.repo.Create(team)
 	if err != nil {
-		utils.Log.Error("Takım oluşturulurken veritabanı hatası", zap.String("team_name", team.Name), zap.Error(err))
+		utils.LogFrom(ctx).Error("Takım oluşturulurken veritabanı hatası", zap.String("team_name", team.Name), zap.Error(err))
 		return ErrTeamCreationFailed
 	}
-	utils.SLog.Infof("Takım başarıyla oluşturuldu: %s (ID: %d)", team.Name, team.ID)
+	utils.SLogFrom(ctx).Infof("Takım başarıyla oluşturuldu: %s (ID: %d)", team.Name, team.ID)
 	return nil
 }
-func (s *TeamService) UpdateTeam(id uint, teamData *models.Team) error {
+func (s *TeamService) UpdateTeam(ctx context.Context, id uint, teamData *models.Team) error {
 	_, err := s.repo.FindByID(id)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return ErrTeamNotFound
 		}
-		utils.Log.Error("Takım güncellenemedi: Takım aranırken hata (ön kontrol)", zap.Uint("team_id", id), zap.Error(err))
+		utils.LogFrom(ctx).Error("Takım güncellenemedi: Takım aranırken hata (ön kontrol)", zap.Uint("team_id", id), zap.Error(err))
 		return err
 	}
 	updateData := map[string]interface{}{"name": teamData.Name, "status": teamData.Status}
 	err = s.repo.Update(id, updateData)
 	if err != nil {
-		utils.Log.Error("Takım güncellenirken veritabanı hatası", zap.Uint("team_id", id), zap.String("new_name", teamData.Name), zap.Error(err))
+		utils.LogFrom(ctx).Error("Takım güncellenirken veritabanı hatası", zap.Uint("team_id", id), zap.String("new_name", teamData.Name), zap.Error(err))
 		if err == gorm.ErrRecordNotFound {
 			return ErrTeamNotFound
 		}
 		return ErrTeamUpdateFailed
 	}
-	utils.SLog.Infof("Takım başarıyla güncellendi: ID %d, Yeni Ad: %s", id, teamData.Name)
+	utils.SLogFrom(ctx).Infof("Takım başarıyla güncellendi: ID %d, Yeni Ad: %s", id, teamData.Name)
 	return nil
 }
-func (s *TeamService) DeleteTeam(id uint) error {
+func (s *TeamService) DeleteTeam(ctx context.Context, id uint) error {
 	err := s.repo.Delete(id)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return ErrTeamNotFound
 		}
-		utils.Log.Error("Takım silinirken hata oluştu", zap.Uint("team_id", id), zap.Error(err))
+		utils.LogFrom(ctx).Error("Takım silinirken hata oluştu", zap.Uint("team_id", id), zap.Error(err))
 		return ErrTeamDeletionFailed
 	}
-	utils.SLog.Infof("Takım başarıyla silindi: ID %d", id)
+	utils.SLogFrom(ctx).Infof("Takım başarıyla silindi: ID %d", id)
 	return nil
 }
-func (s *TeamService) GetTeamCount() (int64, error) {
+func (s *TeamService) GetTeamCount(ctx context.Context) (int64, error) {
 	count, err := s.repo.Count()
 	if err != nil {
-		utils.Log.Error("Takım sayısı alınırken hata oluştu", zap.Error(err))
+		utils.LogFrom(ctx).Error("Takım sayısı alınırken hata oluştu", zap.Error(err))
 		return 0, err
 	}
 	return count, nil
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"zatrano/models"
+	"zatrano/utils"
+)
+
+func TestTeamServiceCRUD(t *testing.T) {
+	s := newTestServices(t)
+	team := s.mustCreateTeam(t, "Satış")
+
+	if err := s.teams.UpdateTeam(context.Background(), team.ID, &models.Team{Name: "Satış 2", Status: false}); err != nil {
+		t.Fatalf("UpdateTeam() error = %v", err)
+	}
+	got, err := s.teams.GetTeamByID(context.Background(), team.ID)
+	if err != nil || got.Name != "Satış 2" || got.Status {
+		t.Fatalf("GetTeamByID() = %+v, %v", got, err)
+	}
+
+	if err := s.teams.UpdateTeam(context.Background(), 999, &models.Team{Name: "X"}); err != ErrTeamNotFound {
+		t.Fatalf("UpdateTeam(999) error = %v, want %v", err, ErrTeamNotFound)
+	}
+	if _, err := s.teams.GetTeamByID(context.Background(), 999); err != ErrTeamNotFound {
+		t.Fatalf("GetTeamByID(999) error = %v, want %v", err, ErrTeamNotFound)
+	}
+
+	if err := s.teams.DeleteTeam(context.Background(), team.ID); err != nil {
+		t.Fatalf("DeleteTeam() error = %v", err)
+	}
+	if err := s.teams.DeleteTeam(context.Background(), team.ID); err != ErrTeamNotFound {
+		t.Fatalf("DeleteTeam() twice error = %v, want %v", err, ErrTeamNotFound)
+	}
+	if count, _ := s.teams.GetTeamCount(context.Background()); count != 0 {
+		t.Fatalf("GetTeamCount() = %d, want 0", count)
+	}
+}
+
+func TestTeamServicePaginated(t *testing.T) {
+	s := newTestServices(t)
+	for _, name := range []string{"A", "B", "C", "D", "E"} {
+		s.mustCreateTeam(t, name)
+	}
+
+	tests := []struct {
+		name      string
+		params    utils.ListParams
+		wantNames []string
+		wantPages int
+	}{
+		{name: "defaults sort by id desc", params: utils.ListParams{}, wantNames: []string{"E", "D", "C", "B", "A"}, wantPages: 1},
+		{name: "page size two", params: utils.ListParams{Page: 3, PerPage: 2, SortBy: "name", OrderBy: "asc"}, wantNames: []string{"E"}, wantPages: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := s.teams.GetAllTeamsPaginated(context.Background(), tt.params)
+			if err != nil {
+				t.Fatalf("GetAllTeamsPaginated() error = %v", err)
+			}
+			teams := result.Data.([]models.Team)
+			names := make([]string, len(teams))
+			for i, team := range teams {
+				names[i] = team.Name
+			}
+			if len(names) != len(tt.wantNames) {
+				t.Fatalf("names = %v, want %v", names, tt.wantNames)
+			}
+			for i := range names {
+				if names[i] != tt.wantNames[i] {
+					t.Fatalf("names = %v, want %v", names, tt.wantNames)
+				}
+			}
+			if result.Meta.TotalPages != tt.wantPages || result.Meta.TotalItems != 5 {
+				t.Fatalf("meta = %+v", result.Meta)
+			}
+		})
+	}
+}
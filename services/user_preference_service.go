@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"zatrano/i18n"
+	"zatrano/models"
+	"zatrano/repositories"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type PreferenceServiceError string
+
+func (e PreferenceServiceError) Error() string {
+	return i18n.T(i18n.DefaultLocale, string(e))
+}
+
+func (e PreferenceServiceError) Code() string {
+	return string(e)
+}
+
+const (
+	ErrPreferenceInvalidLocale     PreferenceServiceError = "errors.preference.invalid_locale"
+	ErrPreferenceInvalidTimezone   PreferenceServiceError = "errors.preference.invalid_timezone"
+	ErrPreferenceInvalidDateFormat PreferenceServiceError = "errors.preference.invalid_date_format"
+	ErrPreferenceInvalidPerPage    PreferenceServiceError = "errors.preference.invalid_per_page"
+	ErrPreferenceUpdateFailed      PreferenceServiceError = "errors.preference.update_failed"
+)
+
+type IUserPreferenceService interface {
+	GetPreferences(ctx context.Context, userID uint) (*models.UserPreference, error)
+	UpdatePreferences(ctx context.Context, userID uint, pref *models.UserPreference) error
+}
+
+type UserPreferenceService struct {
+	repo repositories.IUserPreferenceRepository
+}
+
+func NewUserPreferenceService(repo repositories.IUserPreferenceRepository) IUserPreferenceService {
+	return &UserPreferenceService{repo: repo}
+}
+
+func DefaultUserPreference(userID uint) *models.UserPreference {
+	return &models.UserPreference{
+		UserID:     userID,
+		Timezone:   utils.DefaultTimezone,
+		DateFormat: utils.DefaultDateFormat,
+		PerPage:    utils.DefaultPerPage,
+	}
+}
+
+func (s *UserPreferenceService) GetPreferences(ctx context.Context, userID uint) (*models.UserPreference, error) {
+	pref, err := s.repo.FindByUserID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return DefaultUserPreference(userID), nil
+		}
+		utils.LogFrom(ctx).Error("Kullanıcı tercihleri alınırken hata", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, err
+	}
+	return pref, nil
+}
+
+func (s *UserPreferenceService) UpdatePreferences(ctx context.Context, userID uint, pref *models.UserPreference) error {
+	if err := validatePreference(pref); err != nil {
+		return err
+	}
+
+	pref.UserID = userID
+	if err := s.repo.Save(pref); err != nil {
+		utils.LogFrom(ctx).Error("Kullanıcı tercihleri kaydedilemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return ErrPreferenceUpdateFailed
+	}
+
+	utils.LogFrom(ctx).Info("Kullanıcı tercihleri güncellendi",
+		zap.Uint("user_id", userID),
+		zap.String("locale", pref.Locale),
+		zap.String("timezone", pref.Timezone),
+	)
+	return nil
+}
+
+func validatePreference(pref *models.UserPreference) error {
+	if pref.Locale != "" && !i18n.IsSupported(pref.Locale) {
+		return ErrPreferenceInvalidLocale
+	}
+	// "Local" sunucunun saat dilimine karşılık geldiği için kabul edilmez.
+	if pref.Timezone == "" || pref.Timezone == "Local" {
+		return ErrPreferenceInvalidTimezone
+	}
+	if _, err := utils.LoadLocation(pref.Timezone); err != nil {
+		return ErrPreferenceInvalidTimezone
+	}
+	if !utils.IsDateFormat(pref.DateFormat) {
+		return ErrPreferenceInvalidDateFormat
+	}
+	if !utils.IsPerPageOption(pref.PerPage) {
+		return ErrPreferenceInvalidPerPage
+	}
+	return nil
+}
+
+var _ IUserPreferenceService = (*UserPreferenceService)(nil)
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"zatrano/models"
+	"zatrano/repositories"
+	"zatrano/utils"
+)
+
+func TestGetPreferencesDefaults(t *testing.T) {
+	svc := NewUserPreferenceService(repositories.NewMemoryUserPreferenceRepository(repositories.NewMemoryStore()))
+
+	pref, err := svc.GetPreferences(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("GetPreferences() error = %v", err)
+	}
+	if pref.UserID != 7 || pref.Locale != "" || pref.Timezone != utils.DefaultTimezone ||
+		pref.DateFormat != utils.DefaultDateFormat || pref.PerPage != utils.DefaultPerPage {
+		t.Fatalf("GetPreferences() = %+v, want defaults", pref)
+	}
+}
+
+func TestUpdatePreferencesValidation(t *testing.T) {
+	valid := models.UserPreference{Locale: "en", Timezone: "Europe/Istanbul", DateFormat: "2006-01-02", PerPage: 50}
+
+	tests := []struct {
+		name    string
+		mutate  func(p *models.UserPreference)
+		wantErr error
+	}{
+		{name: "valid", mutate: func(p *models.UserPreference) {}},
+		{name: "automatic locale", mutate: func(p *models.UserPreference) { p.Locale = "" }},
+		{name: "unsupported locale", mutate: func(p *models.UserPreference) { p.Locale = "de" }, wantErr: ErrPreferenceInvalidLocale},
+		{name: "unknown timezone", mutate: func(p *models.UserPreference) { p.Timezone = "Mars/Olympus" }, wantErr: ErrPreferenceInvalidTimezone},
+		{name: "server local timezone", mutate: func(p *models.UserPreference) { p.Timezone = "Local" }, wantErr: ErrPreferenceInvalidTimezone},
+		{name: "empty timezone", mutate: func(p *models.UserPreference) { p.Timezone = "" }, wantErr: ErrPreferenceInvalidTimezone},
+		{name: "free-form date layout", mutate: func(p *models.UserPreference) { p.DateFormat = "Jan 2" }, wantErr: ErrPreferenceInvalidDateFormat},
+		{name: "page size not offered", mutate: func(p *models.UserPreference) { p.PerPage = 1000 }, wantErr: ErrPreferenceInvalidPerPage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewUserPreferenceService(repositories.NewMemoryUserPreferenceRepository(repositories.NewMemoryStore()))
+			pref := valid
+			tt.mutate(&pref)
+
+			err := svc.UpdatePreferences(context.Background(), 1, &pref)
+			if err != tt.wantErr {
+				t.Fatalf("UpdatePreferences() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			stored, err := svc.GetPreferences(context.Background(), 1)
+			if err != nil {
+				t.Fatalf("GetPreferences() error = %v", err)
+			}
+			if stored.Timezone != pref.Timezone || stored.Locale != pref.Locale || stored.PerPage != pref.PerPage {
+				t.Fatalf("GetPreferences() = %+v, want %+v", stored, pref)
+			}
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package services
 
 import (
+	"context"
+	"errors"
+	"time"
+
+	"zatrano/i18n"
 	"zatrano/models"
 	"zatrano/repositories"
 	"zatrano/utils"
@@ -12,44 +17,61 @@ import (
 type UserServiceError string
 
 func (e UserServiceError) Error() string {
+	return i18n.T(i18n.DefaultLocale, string(e))
+}
+
+func (e UserServiceError) Code() string {
 	return string(e)
 }
 
 const (
-	ErrUserServiceUserNotFound UserServiceError = "kullanıcı bulunamadı"
-	ErrPasswordHashingFailed   UserServiceError = "şifre oluşturulurken bir hata oluştu"
-	ErrPasswordUpdateFailed    UserServiceError = "şifre güncellenirken bir hata oluştu"
-	ErrUserCreationFailed      UserServiceError = "kullanıcı veritabanına kaydedilemedi"
-	ErrUserUpdateFailed        UserServiceError = "kullanıcı veritabanında güncellenemedi"
-	ErrUserDeletionFailed      UserServiceError = "kullanıcı silinirken bir veritabanı hatası oluştu"
-	ErrPasswordRequired        UserServiceError = "şifre alanı boş olamaz"
+	ErrUserServiceUserNotFound UserServiceError = "errors.user.not_found"
+	ErrPasswordHashingFailed   UserServiceError = "errors.user.password_hashing_failed"
+	ErrPasswordUpdateFailed    UserServiceError = "errors.user.password_update_failed"
+	ErrUserCreationFailed      UserServiceError = "errors.user.creation_failed"
+	ErrUserUpdateFailed        UserServiceError = "errors.user.update_failed"
+	ErrUserDeletionFailed      UserServiceError = "errors.user.deletion_failed"
+	ErrPasswordRequired        UserServiceError = "errors.user.password_required"
+	ErrUserInvalidCursor       UserServiceError = "errors.user.invalid_cursor"
 )
 
+var UserListSpec = utils.FilterSpec{
+	"id":         {Type: utils.FieldInt, Sortable: true},
+	"name":       {Type: utils.FieldString, Sortable: true},
+	"account":    {Type: utils.FieldString, Sortable: true},
+	"team_id":    {Type: utils.FieldInt, Ops: []utils.FilterOp{utils.OpEq, utils.OpIn}, Sortable: true},
+	"type":       {Type: utils.FieldEnum, Ops: []utils.FilterOp{utils.OpEq, utils.OpNe, utils.OpIn}, Values: []string{string(models.System), string(models.Manager), string(models.Agent)}, Sortable: true},
+	"status":     {Type: utils.FieldBool, Ops: []utils.FilterOp{utils.OpEq}, Sortable: true},
+	"created_at": {Type: utils.FieldDate, Ops: []utils.FilterOp{utils.OpGte, utils.OpLte}, Sortable: true},
+}
+
 type IUserService interface {
-	GetAllUsersPaginated(params utils.ListParams) (*utils.PaginatedResult, error)
-	GetUserByID(id uint) (*models.User, error)
-	CreateUser(user *models.User) error
-	UpdateUser(id uint, userData *models.User) error
-	DeleteUser(id uint) error
-	GetUserCount() (int64, error)
+	GetAllUsersPaginated(ctx context.Context, params utils.ListParams) (*utils.PaginatedResult, error)
+	GetUsersByCursor(ctx context.Context, params utils.ListParams, cursor utils.CursorParams) (*utils.CursorResult, error)
+	GetUserByID(ctx context.Context, id uint) (*models.User, error)
+	CreateUser(ctx context.Context, user *models.User) error
+	UpdateUser(ctx context.Context, id uint, userData *models.User) error
+	DeleteUser(ctx context.Context, id uint) error
+	GetUserCount(ctx context.Context) (int64, error)
 }
 
 type UserService struct {
-	repo repositories.IUserRepository
+	repo          repositories.IUserRepository
+	notifications INotificationService
 }
 
-func NewUserService() IUserService {
-	return &UserService{repo: repositories.NewUserRepository()}
+func NewUserService(repo repositories.IUserRepository, notifications INotificationService) IUserService {
+	return &UserService{repo: repo, notifications: notifications}
 }
 
-func (s *UserService) GetAllUsersPaginated(params utils.ListParams) (*utils.PaginatedResult, error) {
+func (s *UserService) GetAllUsersPaginated(ctx context.Context, params utils.ListParams) (*utils.PaginatedResult, error) {
 	if params.Page <= 0 {
 		params.Page = utils.DefaultPage
 	}
 	if params.PerPage <= 0 {
 		params.PerPage = utils.DefaultPerPage
 	} else if params.PerPage > utils.MaxPerPage {
-		utils.Log.Warn("Sayfa başına istenen kayıt sayısı limiti aştı, varsayılana çekildi.",
+		utils.LogFrom(ctx).Warn("Sayfa başına istenen kayıt sayısı limiti aştı, varsayılana çekildi.",
 			zap.Int("requested", params.PerPage),
 			zap.Int("max", utils.MaxPerPage),
 			zap.Int("default", utils.DefaultPerPage),
@@ -83,30 +105,46 @@ func (s *UserService) GetAllUsersPaginated(params utils.ListParams) (*utils.Pagi
 	return result, nil
 }
 
-func (s *UserService) GetUserByID(id uint) (*models.User, error) {
+func (s *UserService) GetUsersByCursor(ctx context.Context, params utils.ListParams, cursor utils.CursorParams) (*utils.CursorResult, error) {
+	if params.SortBy == "" {
+		params.SortBy = utils.DefaultSortBy
+	}
+	if params.OrderBy == "" {
+		params.OrderBy = utils.DefaultOrderBy
+	}
+
+	users, meta, err := s.repo.FindByCursor(params, cursor)
+	if err != nil {
+		if errors.Is(err, utils.ErrInvalidCursor) {
+			utils.LogFrom(ctx).Warn("Kullanıcı listesi: Geçersiz imleç", zap.String("cursor", cursor.Cursor))
+			return nil, ErrUserInvalidCursor
+		}
+		return nil, err
+	}
+	return &utils.CursorResult{Data: users, Meta: meta}, nil
+}
+
+func (s *UserService) GetUserByID(ctx context.Context, id uint) (*models.User, error) {
 	user, err := s.repo.FindByID(id)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			utils.Log.Warn("Kullanıcı bulunamadı (ID ile arama)", zap.Uint("user_id", id))
+			utils.LogFrom(ctx).Warn("Kullanıcı bulunamadı (ID ile arama)", zap.Uint("user_id", id))
 			return nil, ErrUserServiceUserNotFound
 		}
-		utils.Log.Error("Kullanıcı alınırken hata oluştu (ID ile arama)", zap.Uint("user_id", id), zap.Error(err))
+		utils.LogFrom(ctx).Error("Kullanıcı alınırken hata oluştu (ID ile arama)", zap.Uint("user_id", id), zap.Error(err))
 		return nil, err
 	}
 	return user, nil
 }
 
-func (s *UserService) CreateUser(user *models.User) error {
+func (s *UserService) CreateUser(ctx context.Context, user *models.User) error {
 	if user.Password == "" {
 		return ErrPasswordRequired
 	}
 
-	if err := user.SetPassword(user.Password); err != nil {
-		utils.Log.Error("Kullanıcı oluşturma: Şifre ayarlanamadı/hashlenemedi", zap.String("account", user.Account), zap.Error(err))
-		return ErrPasswordHashingFailed
-	}
-
-	utils.Log.Info("Kullanıcı oluşturuluyor...",
+	// Şifre, models.User.BeforeCreate hook'unda hashlenir; burada tekrar
+	// hashlemek kullanıcının giriş yapamamasına yol açar.
+	utils.LogFrom(ctx).Info("Kullanıcı oluşturuluyor...",
 		zap.String("account", user.Account),
 		zap.Any("type", user.Type),
 		zap.Any("team_id", user.TeamID),
@@ -114,7 +152,7 @@ func (s *UserService) CreateUser(user *models.User) error {
 
 	err := s.repo.Create(user)
 	if err != nil {
-		utils.Log.Error("Kullanıcı oluşturulurken veritabanı hatası",
+		utils.LogFrom(ctx).Error("Kullanıcı oluşturulurken veritabanı hatası",
 			zap.String("account", user.Account),
 			zap.Error(err),
 		)
@@ -125,18 +163,22 @@ func (s *UserService) CreateUser(user *models.User) error {
 		return ErrUserCreationFailed
 	}
 
-	utils.SLog.Infof("Kullanıcı başarıyla oluşturuldu: %s (ID: %d)", user.Account, user.ID)
+	utils.SLogFrom(ctx).Infof("Kullanıcı başarıyla oluşturuldu: %s (ID: %d)", user.Account, user.ID)
+
+	if user.TeamID != nil {
+		s.recordTeamChange(ctx, user.ID, nil, user.TeamID)
+	}
 	return nil
 }
 
-func (s *UserService) UpdateUser(id uint, userData *models.User) error {
-	_, err := s.repo.FindByID(id)
+func (s *UserService) UpdateUser(ctx context.Context, id uint, userData *models.User) error {
+	existing, err := s.repo.FindByID(id)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			utils.Log.Warn("Kullanıcı güncellenemedi: Kullanıcı bulunamadı (ön kontrol)", zap.Uint("user_id", id))
+			utils.LogFrom(ctx).Warn("Kullanıcı güncellenemedi: Kullanıcı bulunamadı (ön kontrol)", zap.Uint("user_id", id))
 			return ErrUserServiceUserNotFound
 		}
-		utils.Log.Error("Kullanıcı güncellenemedi: Kullanıcı aranırken hata (ön kontrol)", zap.Uint("user_id", id), zap.Error(err))
+		utils.LogFrom(ctx).Error("Kullanıcı güncellenemedi: Kullanıcı aranırken hata (ön kontrol)", zap.Uint("user_id", id), zap.Error(err))
 		return err
 	}
 
@@ -147,19 +189,25 @@ func (s *UserService) UpdateUser(id uint, userData *models.User) error {
 		"type":    userData.Type,
 		"team_id": userData.TeamID,
 	}
+	if existing.Status && !userData.Status {
+		deactivatedAt := time.Now().UTC()
+		updateData["deactivated_at"] = &deactivatedAt
+	} else if !existing.Status && userData.Status {
+		updateData["deactivated_at"] = nil
+	}
 
 	passwordUpdated := false
 	if userData.Password != "" {
 		tempUserForHash := models.User{}
 		if err := tempUserForHash.SetPassword(userData.Password); err != nil {
-			utils.Log.Error("Kullanıcı güncelleme: Şifre ayarlanamadı/hashlenemedi", zap.Uint("user_id", id), zap.Error(err))
+			utils.LogFrom(ctx).Error("Kullanıcı güncelleme: Şifre ayarlanamadı/hashlenemedi", zap.Uint("user_id", id), zap.Error(err))
 			return ErrPasswordUpdateFailed
 		}
 		updateData["password"] = tempUserForHash.Password
 		passwordUpdated = true
 	}
 
-	utils.Log.Info("Kullanıcı güncelleniyor (map ile)...",
+	utils.LogFrom(ctx).Info("Kullanıcı güncelleniyor (map ile)...",
 		zap.Uint("user_id", id),
 		zap.Bool("password_updated", passwordUpdated),
 		zap.Uintp("team_id", userData.TeamID),
@@ -168,7 +216,7 @@ func (s *UserService) UpdateUser(id uint, userData *models.User) error {
 
 	err = s.repo.Update(id, updateData)
 	if err != nil {
-		utils.Log.Error("Kullanıcı güncellenirken veritabanı hatası (Update)",
+		utils.LogFrom(ctx).Error("Kullanıcı güncellenirken veritabanı hatası (Update)",
 			zap.Uint("user_id", id),
 			zap.Error(err),
 		)
@@ -182,30 +230,68 @@ func (s *UserService) UpdateUser(id uint, userData *models.User) error {
 		return ErrUserUpdateFailed
 	}
 
-	utils.SLog.Infof("Kullanıcı başarıyla güncellendi (map ile): ID %d, Hesap: %s", id, userData.Account)
+	utils.SLogFrom(ctx).Infof("Kullanıcı başarıyla güncellendi (map ile): ID %d, Hesap: %s", id, userData.Account)
+
+	if !sameTeam(existing.TeamID, userData.TeamID) {
+		s.recordTeamChange(ctx, id, existing.TeamID, userData.TeamID)
+		if userData.TeamID != nil {
+			s.notifyTeamChanged(ctx, id)
+		}
+	}
 	return nil
 }
 
-func (s *UserService) DeleteUser(id uint) error {
+func sameTeam(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func (s *UserService) recordTeamChange(ctx context.Context, userID uint, from, to *uint) {
+	change := &models.TeamMembershipChange{UserID: userID, FromTeamID: from, ToTeamID: to}
+	if err := s.repo.CreateTeamChange(change); err != nil {
+		utils.LogFrom(ctx).Warn("Takım değişikliği kaydedilemedi", zap.Uint("user_id", userID), zap.Error(err))
+	}
+}
+
+func (s *UserService) notifyTeamChanged(ctx context.Context, id uint) {
+	user, err := s.repo.FindByID(id)
+	if err != nil || user.Team == nil {
+		utils.LogFrom(ctx).Warn("Takım değişikliği bildirimi için kullanıcı okunamadı", zap.Uint("user_id", id), zap.Error(err))
+		return
+	}
+	if err := s.notifications.Notify(ctx, id, "notifications.team_changed", "", user.Team.Name); err != nil {
+		utils.LogFrom(ctx).Warn("Takım değişikliği bildirimi gönderilemedi", zap.Uint("user_id", id), zap.Error(err))
+	}
+}
+
+func (s *UserService) DeleteUser(ctx context.Context, id uint) error {
 	err := s.repo.Delete(id)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			utils.Log.Warn("Kullanıcı silinemedi: Kullanıcı bulunamadı", zap.Uint("user_id", id))
+			utils.LogFrom(ctx).Warn("Kullanıcı silinemedi: Kullanıcı bulunamadı", zap.Uint("user_id", id))
 			return ErrUserServiceUserNotFound
 		}
-		utils.Log.Error("Kullanıcı silinirken hata oluştu (Delete)", zap.Uint("user_id", id), zap.Error(err))
+		utils.LogFrom(ctx).Error("Kullanıcı silinirken hata oluştu (Delete)", zap.Uint("user_id", id), zap.Error(err))
 		return ErrUserDeletionFailed
 	}
-	utils.SLog.Infof("Kullanıcı başarıyla silindi: ID %d", id)
+	utils.SLogFrom(ctx).Infof("Kullanıcı başarıyla silindi: ID %d", id)
 	return nil
 }
 
-func (s *UserService) GetUserCount() (int64, error) {
-	count, err := s.repo.Count()
+func (s *UserService) GetUserCount(ctx context.Context) (int64, error) {
+	rows, err := s.repo.CountByTypeAndStatus()
 	if err != nil {
-		utils.Log.Error("Kullanıcı sayısı alınırken hata oluştu", zap.Error(err))
+		utils.LogFrom(ctx).Error("Kullanıcı sayısı alınırken hata oluştu", zap.Error(err))
 		return 0, err
 	}
+	var count int64
+	for _, row := range rows {
+		if row.Type != models.System {
+			count += row.Count
+		}
+	}
 	return count, nil
 }
 
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"zatrano/models"
+	"zatrano/utils"
+)
+
+func TestUserServiceCreateUser(t *testing.T) {
+	s := newTestServices(t)
+	team := s.mustCreateTeam(t, "Destek")
+
+	tests := []struct {
+		name    string
+		user    models.User
+		wantErr error
+	}{
+		{name: "agent with team", user: models.User{Name: "Ajan", Account: "ajan@x", Password: "secret", Type: models.Agent, TeamID: &team.ID}},
+		{name: "missing password", user: models.User{Name: "Ajan", Account: "ajan2@x", Type: models.Agent, TeamID: &team.ID}, wantErr: ErrPasswordRequired},
+		{name: "agent without team", user: models.User{Name: "Ajan", Account: "ajan3@x", Password: "secret", Type: models.Agent}, wantErr: models.ErrUserMissingTeam},
+		{name: "system user with team", user: models.User{Name: "Sys", Account: "sys@x", Password: "secret", Type: models.System, TeamID: &team.ID}, wantErr: models.ErrSystemUserHasTeam},
+		{name: "invalid type", user: models.User{Name: "X", Account: "x@x", Password: "secret", Type: "admin"}, wantErr: models.ErrInvalidUserType},
+		{name: "duplicate account", user: models.User{Name: "Ajan", Account: "ajan@x", Password: "secret", Type: models.Agent, TeamID: &team.ID}, wantErr: ErrUserCreationFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := tt.user
+			err := s.users.CreateUser(context.Background(), &user)
+			if err != tt.wantErr {
+				t.Fatalf("CreateUser() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if _, err := s.auth.Authenticate(context.Background(), tt.user.Account, tt.user.Password); err != nil {
+				t.Fatalf("created user cannot authenticate: %v", err)
+			}
+		})
+	}
+}
+
+func TestUserServiceUpdateUser(t *testing.T) {
+	s := newTestServices(t)
+	teamA := s.mustCreateTeam(t, "A")
+	teamB := s.mustCreateTeam(t, "B")
+	agent := s.mustCreateUser(t, models.User{Name: "Ajan", Account: "ajan@x", Password: "secret", Type: models.Agent, TeamID: &teamA.ID})
+
+	t.Run("moves team and changes password", func(t *testing.T) {
+		err := s.users.UpdateUser(context.Background(), agent.ID, &models.User{Name: "Ajan 2", Account: "ajan@x", Password: "yenisifre", Status: true, Type: models.Agent, TeamID: &teamB.ID})
+		if err != nil {
+			t.Fatalf("UpdateUser() error = %v", err)
+		}
+		got, _ := s.users.GetUserByID(context.Background(), agent.ID)
+		if got.Name != "Ajan 2" || got.TeamID == nil || *got.TeamID != teamB.ID {
+			t.Fatalf("UpdateUser() not applied: %+v", got)
+		}
+		if got.Team == nil || got.Team.Name != "B" {
+			t.Fatalf("GetUserByID() did not load the team: %+v", got.Team)
+		}
+		if _, err := s.auth.Authenticate(context.Background(), "ajan@x", "yenisifre"); err != nil {
+			t.Fatalf("Authenticate() with new password error = %v", err)
+		}
+	})
+
+	t.Run("keeps password when empty", func(t *testing.T) {
+		err := s.users.UpdateUser(context.Background(), agent.ID, &models.User{Name: "Ajan 2", Account: "ajan@x", Status: true, Type: models.Agent, TeamID: &teamB.ID})
+		if err != nil {
+			t.Fatalf("UpdateUser() error = %v", err)
+		}
+		if _, err := s.auth.Authenticate(context.Background(), "ajan@x", "yenisifre"); err != nil {
+			t.Fatalf("Authenticate() after update without password error = %v", err)
+		}
+	})
+
+	t.Run("rejects system user with team", func(t *testing.T) {
+		err := s.users.UpdateUser(context.Background(), agent.ID, &models.User{Name: "Ajan", Account: "ajan@x", Type: models.System, TeamID: &teamA.ID})
+		if err != models.ErrSystemUserHasTeam {
+			t.Fatalf("UpdateUser() error = %v, want %v", err, models.ErrSystemUserHasTeam)
+		}
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		err := s.users.UpdateUser(context.Background(), 999, &models.User{Name: "X", Account: "x@x", Type: models.Agent, TeamID: &teamA.ID})
+		if err != ErrUserServiceUserNotFound {
+			t.Fatalf("UpdateUser() error = %v, want %v", err, ErrUserServiceUserNotFound)
+		}
+	})
+}
+
+func TestUserServicePaginationDefaults(t *testing.T) {
+	s := newTestServices(t)
+	team := s.mustCreateTeam(t, "Destek")
+	s.mustCreateUser(t, models.User{Name: "System", Account: "system@system", Password: "secret", Type: models.System})
+	for _, account := range []string{"a@x", "b@x", "c@x"} {
+		s.mustCreateUser(t, models.User{Name: account, Account: account, Password: "secret", Type: models.Agent, TeamID: &team.ID})
+	}
+
+	tests := []struct {
+		name        string
+		params      utils.ListParams
+		wantPage    int
+		wantPerPage int
+		wantPages   int
+	}{
+		{name: "zero values use defaults", params: utils.ListParams{}, wantPage: utils.DefaultPage, wantPerPage: utils.DefaultPerPage, wantPages: 1},
+		{name: "per page above max falls back to default", params: utils.ListParams{Page: 1, PerPage: utils.MaxPerPage + 1}, wantPage: 1, wantPerPage: utils.DefaultPerPage, wantPages: 1},
+		{name: "explicit per page", params: utils.ListParams{Page: 2, PerPage: 2}, wantPage: 2, wantPerPage: 2, wantPages: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := s.users.GetAllUsersPaginated(context.Background(), tt.params)
+			if err != nil {
+				t.Fatalf("GetAllUsersPaginated() error = %v", err)
+			}
+			meta := result.Meta
+			if meta.CurrentPage != tt.wantPage || meta.PerPage != tt.wantPerPage || meta.TotalPages != tt.wantPages || meta.TotalItems != 3 {
+				t.Fatalf("meta = %+v", meta)
+			}
+		})
+	}
+}
+
+func TestUserServiceDeleteAndCount(t *testing.T) {
+	s := newTestServices(t)
+	s.mustCreateUser(t, models.User{Name: "System", Account: "system@system", Password: "secret", Type: models.System})
+	team := s.mustCreateTeam(t, "Destek")
+	user := s.mustCreateUser(t, models.User{Name: "Ajan", Account: "ajan@x", Password: "secret", Type: models.Agent, TeamID: &team.ID})
+
+	if count, _ := s.users.GetUserCount(context.Background()); count != 1 {
+		t.Fatalf("GetUserCount() = %d, want 1 (system users are not counted)", count)
+	}
+	if err := s.users.DeleteUser(context.Background(), user.ID); err != nil {
+		t.Fatalf("DeleteUser() error = %v", err)
+	}
+	if err := s.users.DeleteUser(context.Background(), user.ID); err != ErrUserServiceUserNotFound {
+		t.Fatalf("DeleteUser() twice error = %v, want %v", err, ErrUserServiceUserNotFound)
+	}
+	if _, err := s.users.GetUserByID(context.Background(), user.ID); err != ErrUserServiceUserNotFound {
+		t.Fatalf("GetUserByID() after delete error = %v, want %v", err, ErrUserServiceUserNotFound)
+	}
+	if count, _ := s.users.GetUserCount(context.Background()); count != 0 {
+		t.Fatalf("GetUserCount() after delete = %d, want 0", count)
+	}
+}
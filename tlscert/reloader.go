@@ -0,0 +1,136 @@
+package tlscert
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+)
+
+type Reloader struct {
+	certFile string
+	keyFile  string
+
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+	modState fileState
+}
+
+type fileState struct {
+	certMod, keyMod   time.Time
+	certSize, keySize int64
+}
+
+func NewReloader(certFile, keyFile string) (*Reloader, error) {
+	r := &Reloader{certFile: certFile, keyFile: keyFile}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Reloader) Reload() error {
+	state, err := r.stat()
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("sertifika yüklenemedi: %w", err)
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.modState = state
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *Reloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+func (r *Reloader) TLSConfig(minVersion uint16) *tls.Config {
+	return &tls.Config{
+		MinVersion:     minVersion,
+		GetCertificate: r.GetCertificate,
+	}
+}
+
+func (r *Reloader) Watch(ctx context.Context, interval time.Duration) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			r.reloadAndLog("sighup")
+		case <-tick:
+			if r.changed() {
+				r.reloadAndLog("file_change")
+			}
+		}
+	}
+}
+
+func (r *Reloader) reloadAndLog(trigger string) {
+	if err := r.Reload(); err != nil {
+		utils.Log.Error("TLS sertifikası yeniden yüklenemedi, mevcut sertifika kullanılmaya devam ediyor",
+			zap.String("trigger", trigger),
+			zap.String("cert_file", r.certFile),
+			zap.Error(err),
+		)
+		return
+	}
+	utils.Log.Info("TLS sertifikası yeniden yüklendi",
+		zap.String("trigger", trigger),
+		zap.String("cert_file", r.certFile),
+	)
+}
+
+func (r *Reloader) changed() bool {
+	state, err := r.stat()
+	if err != nil {
+		return false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return state != r.modState
+}
+
+func (r *Reloader) stat() (fileState, error) {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return fileState{}, err
+	}
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return fileState{}, err
+	}
+	return fileState{
+		certMod:  certInfo.ModTime(),
+		keyMod:   keyInfo.ModTime(),
+		certSize: certInfo.Size(),
+		keySize:  keyInfo.Size(),
+	}, nil
+}
@@ -0,0 +1,188 @@
+package tlscert
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"zatrano/utils"
+)
+
+func TestMain(m *testing.M) {
+	utils.InitLogger("test", "error")
+	os.Exit(m.Run())
+}
+
+func writeCert(t *testing.T, dir, commonName string) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile, keyFile = filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+func servedCommonName(t *testing.T, r *Reloader) string {
+	t.Helper()
+	cert, err := r.GetCertificate(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return leaf.Subject.CommonName
+}
+
+func TestReloaderReload(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeCert(t, dir, "ilk")
+
+	r, err := NewReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("NewReloader() error = %v", err)
+	}
+	if got := servedCommonName(t, r); got != "ilk" {
+		t.Fatalf("CommonName = %q, want ilk", got)
+	}
+
+	writeCert(t, dir, "yeni")
+	if err := r.Reload(); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if got := servedCommonName(t, r); got != "yeni" {
+		t.Fatalf("CommonName after reload = %q, want yeni", got)
+	}
+
+	if err := os.WriteFile(keyFile, []byte("bozuk"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Reload(); err == nil {
+		t.Fatal("Reload() with a broken key succeeded")
+	}
+	if got := servedCommonName(t, r); got != "yeni" {
+		t.Fatalf("CommonName after failed reload = %q, want the previous certificate", got)
+	}
+}
+
+func TestReloaderWatchPicksUpFileChanges(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeCert(t, dir, "ilk")
+	r, err := NewReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go r.Watch(ctx, 10*time.Millisecond)
+
+	writeCert(t, dir, "yeni")
+	// Bazı dosya sistemlerinde mtime çözünürlüğü düşüktür; boyut da değişmeyebilir.
+	future := time.Now().Add(time.Minute)
+	_ = os.Chtimes(certFile, future, future)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for servedCommonName(t, r) != "yeni" {
+		if time.Now().After(deadline) {
+			t.Fatal("watcher did not reload the changed certificate")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestTLSConfigMinVersion(t *testing.T) {
+	certFile, keyFile := writeCert(t, t.TempDir(), "localhost")
+	r, err := NewReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {}))
+	server.TLS = r.TLSConfig(tls.VersionTLS13)
+	server.StartTLS()
+	defer server.Close()
+
+	for _, tt := range []struct {
+		maxVersion uint16
+		wantErr    bool
+	}{
+		{maxVersion: tls.VersionTLS12, wantErr: true},
+		{maxVersion: tls.VersionTLS13},
+	} {
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: true, // öz imzalı test sertifikası
+			MaxVersion:         tt.maxVersion,
+		}}}
+		resp, err := client.Get(server.URL)
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("max version %x: err = %v, wantErr %v", tt.maxVersion, err, tt.wantErr)
+		}
+	}
+}
+
+func TestRedirectHandler(t *testing.T) {
+	tests := []struct {
+		host      string
+		httpsPort int
+		want      string
+	}{
+		{host: "ornek.com", httpsPort: 443, want: "https://ornek.com/auth/login?next=%2F"},
+		{host: "ornek.com:8080", httpsPort: 8443, want: "https://ornek.com:8443/auth/login?next=%2F"},
+		{host: "[::1]:80", httpsPort: 443, want: "https://[::1]/auth/login?next=%2F"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/auth/login?next=%2F", nil)
+			req.Host = tt.host
+			rec := httptest.NewRecorder()
+			RedirectHandler(tt.httpsPort).ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusPermanentRedirect {
+				t.Fatalf("status = %d, want 308", rec.Code)
+			}
+			if got := rec.Header().Get("Location"); got != tt.want {
+				t.Fatalf("Location = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
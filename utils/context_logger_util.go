@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type contextLoggerKey struct{}
+
+func WithLogger(ctx context.Context, logger *zap.Logger) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, contextLoggerKey{}, logger)
+}
+
+func LogFrom(ctx context.Context) *zap.Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(contextLoggerKey{}).(*zap.Logger); ok && logger != nil {
+			return logger
+		}
+	}
+	return Log
+}
+
+func SLogFrom(ctx context.Context) *zap.SugaredLogger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(contextLoggerKey{}).(*zap.Logger); ok && logger != nil {
+			return logger.Sugar()
+		}
+	}
+	return SLog
+}
@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronNext(t *testing.T) {
+	istanbul, err := time.LoadLocation("Europe/Istanbul")
+	if err != nil {
+		t.Skipf("tzdata yok: %v", err)
+	}
+	// 2026-03-04 bir çarşamba.
+	from := time.Date(2026, time.March, 4, 10, 17, 30, 0, istanbul)
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, time.March, 4, 10, 18, 0, 0, istanbul)},
+		{"*/15 * * * *", time.Date(2026, time.March, 4, 10, 30, 0, 0, istanbul)},
+		{"0 8 * * 1", time.Date(2026, time.March, 9, 8, 0, 0, 0, istanbul)},
+		{"30 9-17/4 * * 1-5", time.Date(2026, time.March, 4, 13, 30, 0, 0, istanbul)},
+		{"0 0 1 * *", time.Date(2026, time.April, 1, 0, 0, 0, 0, istanbul)},
+		{"0 6 15 * 0", time.Date(2026, time.March, 8, 6, 0, 0, 0, istanbul)},
+		{"0 6 * * 7", time.Date(2026, time.March, 8, 6, 0, 0, 0, istanbul)},
+		{"0 0 29 2 *", time.Date(2028, time.February, 29, 0, 0, 0, 0, istanbul)},
+		{"@daily", time.Date(2026, time.March, 5, 0, 0, 0, 0, istanbul)},
+	}
+	for _, tt := range tests {
+		schedule, err := ParseCron(tt.expr)
+		if err != nil {
+			t.Fatalf("ParseCron(%q) error = %v", tt.expr, err)
+		}
+		if got := schedule.Next(from); !got.Equal(tt.want) {
+			t.Errorf("ParseCron(%q).Next() = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+
+	never, _ := ParseCron("0 0 30 2 *")
+	if got := never.Next(from); !got.IsZero() {
+		t.Fatalf("Next() for February 30 = %v, want zero", got)
+	}
+}
+
+func TestCronNextAcrossDST(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("tzdata yok: %v", err)
+	}
+	schedule, _ := ParseCron("30 2 * * *")
+	// 29 Mart 2026'da saat 02:00'dan 03:00'a atlanır; 02:30 o gün yoktur.
+	got := schedule.Next(time.Date(2026, time.March, 28, 12, 0, 0, 0, berlin))
+	if want := time.Date(2026, time.March, 30, 2, 30, 0, 0, berlin); !got.Equal(want) {
+		t.Fatalf("Next() = %v, want %v", got, want)
+	}
+}
+
+func TestParseCronRejectsInvalidInput(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "5-1 * * * *", "*/0 * * * *", "a * * * *", "@yearly"} {
+		if _, err := ParseCron(expr); err != ErrInvalidCron {
+			t.Errorf("ParseCron(%q) error = %v, want ErrInvalidCron", expr, err)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package utils
+
+import "strings"
+
+const csvFormulaPrefixes = "=+-@\t\r"
+
+// CSVSafe, dışa aktarılan bir CSV hücresinin tablolama programında formül
+// olarak çalıştırılmasını (CSV/formula injection) engeller: hücre bu
+// karakterlerden biriyle başlıyorsa başına tek tırnak eklenir.
+func CSVSafe(cell string) string {
+	if cell != "" && strings.IndexByte(csvFormulaPrefixes, cell[0]) >= 0 {
+		return "'" + cell
+	}
+	return cell
+}
+
+func CSVSafeRecord(record []string) []string {
+	for i, cell := range record {
+		record[i] = CSVSafe(cell)
+	}
+	return record
+}
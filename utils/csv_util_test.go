@@ -0,0 +1,26 @@
+package utils
+
+import "testing"
+
+func TestCSVSafe(t *testing.T) {
+	tests := map[string]string{
+		"":                  "",
+		"Ahmet Yılmaz":      "Ahmet Yılmaz",
+		"42":                "42",
+		"a=b":               "a=b",
+		"=HYPERLINK(\"x\")": "'=HYPERLINK(\"x\")",
+		"+90 555":           "'+90 555",
+		"-1+1":              "'-1+1",
+		"@SUM(A1)":          "'@SUM(A1)",
+		"\t=1":              "'\t=1",
+		"\r=1":              "'\r=1",
+	}
+	for in, want := range tests {
+		if got := CSVSafe(in); got != want {
+			t.Errorf("CSVSafe(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if got := CSVSafeRecord([]string{"1", "=cmd"}); got[0] != "1" || got[1] != "'=cmd" {
+		t.Errorf("CSVSafeRecord() = %q", got)
+	}
+}
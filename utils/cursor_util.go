@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultCursorLimit = 100
+	MaxCursorLimit     = 1000
+)
+
+var ErrInvalidCursor = errors.New("geçersiz imleç")
+
+type CursorParams struct {
+	Cursor string `query:"cursor"`
+	Limit  int    `query:"limit"`
+}
+
+type Cursor struct {
+	Field  string
+	Desc   bool
+	Value  interface{}
+	ID     uint
+	Before bool
+}
+
+type cursorPayload struct {
+	Field  string `json:"f"`
+	Desc   bool   `json:"d,omitempty"`
+	Kind   string `json:"k"`
+	Value  string `json:"v"`
+	ID     uint   `json:"i"`
+	Before bool   `json:"b,omitempty"`
+}
+
+// EncodeCursor, imleci istemciye verilecek opak bir metne çevirir. İmleç
+// imzalanmaz; içeriği değiştirilse bile yalnızca farklı bir konumdan
+// başlanmasına yol açar, değerler sorguya her zaman parametre olarak girer.
+func EncodeCursor(c Cursor) string {
+	payload := cursorPayload{Field: c.Field, Desc: c.Desc, ID: c.ID, Before: c.Before}
+	switch v := c.Value.(type) {
+	case int64:
+		payload.Kind, payload.Value = "i", strconv.FormatInt(v, 10)
+	case string:
+		payload.Kind, payload.Value = "s", v
+	case bool:
+		payload.Kind, payload.Value = "b", strconv.FormatBool(v)
+	case time.Time:
+		payload.Kind, payload.Value = "t", v.UTC().Format(time.RFC3339Nano)
+	default:
+		payload.Kind = "n"
+	}
+	data, _ := json.Marshal(payload)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(s string) (Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	var payload cursorPayload
+	if err := json.Unmarshal(data, &payload); err != nil || payload.Field == "" {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	c := Cursor{Field: payload.Field, Desc: payload.Desc, ID: payload.ID, Before: payload.Before}
+	switch payload.Kind {
+	case "i":
+		c.Value, err = strconv.ParseInt(payload.Value, 10, 64)
+	case "s":
+		c.Value = payload.Value
+	case "b":
+		c.Value, err = strconv.ParseBool(payload.Value)
+	case "t":
+		c.Value, err = time.Parse(time.RFC3339Nano, payload.Value)
+	default:
+		return Cursor{}, ErrInvalidCursor
+	}
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+func (p CursorParams) NormalizeLimit() int {
+	if p.Limit <= 0 {
+		return DefaultCursorLimit
+	}
+	if p.Limit > MaxCursorLimit {
+		return MaxCursorLimit
+	}
+	return p.Limit
+}
+
+type CursorMeta struct {
+	Limit          int    `json:"limit"`
+	NextCursor     string `json:"next_cursor,omitempty"`
+	PrevCursor     string `json:"prev_cursor,omitempty"`
+	EstimatedTotal int64  `json:"estimated_total,omitempty"`
+}
+
+type CursorResult struct {
+	Data interface{} `json:"data"`
+	Meta CursorMeta  `json:"meta"`
+}
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	created := time.Date(2024, 3, 1, 9, 30, 0, 123456000, time.FixedZone("TRT", 3*3600))
+	tests := []Cursor{
+		{Field: "id", Desc: true, Value: int64(42), ID: 42},
+		{Field: "name", Value: "Çağla", ID: 7, Before: true},
+		{Field: "status", Desc: true, Value: false, ID: 3},
+		{Field: "created_at", Value: created, ID: 9},
+	}
+	for _, want := range tests {
+		got, err := DecodeCursor(EncodeCursor(want))
+		if err != nil {
+			t.Fatalf("DecodeCursor(%+v) error = %v", want, err)
+		}
+		if tm, ok := want.Value.(time.Time); ok {
+			if !got.Value.(time.Time).Equal(tm) {
+				t.Fatalf("time value = %v, want %v", got.Value, tm)
+			}
+			got.Value, want.Value = nil, nil
+		}
+		if got != want {
+			t.Fatalf("DecodeCursor() = %+v, want %+v", got, want)
+		}
+	}
+}
+
+func TestDecodeCursorRejectsInvalidInput(t *testing.T) {
+	for _, raw := range []string{"", "%%%", "e30", EncodeCursor(Cursor{Field: "id"})} {
+		if _, err := DecodeCursor(raw); err != ErrInvalidCursor {
+			t.Fatalf("DecodeCursor(%q) error = %v, want ErrInvalidCursor", raw, err)
+		}
+	}
+}
+
+func TestCursorParamsNormalizeLimit(t *testing.T) {
+	for limit, want := range map[int]int{0: DefaultCursorLimit, -5: DefaultCursorLimit, 25: 25, MaxCursorLimit + 1: MaxCursorLimit} {
+		if got := (CursorParams{Limit: limit}).NormalizeLimit(); got != want {
+			t.Fatalf("NormalizeLimit(%d) = %d, want %d", limit, got, want)
+		}
+	}
+}
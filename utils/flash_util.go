@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"zatrano/i18n"
+
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 )
@@ -26,13 +28,13 @@ type FlashMessagesData struct {
 	Error   string
 }
 
-func SetFlashMessage(c *fiber.Ctx, key string, message string) error {
+func SetFlashMessage(c *fiber.Ctx, key string, messageID string) error {
 	sess, err := SessionStart(c)
 	if err != nil {
 		Log.Error("Flash mesajı için session başlatılamadı", zap.Error(err))
 		return ErrSessionStartFailed
 	}
-	sess.Set(key, message)
+	sess.Set(key, messageID)
 	if err := sess.Save(); err != nil {
 		Log.Error("Flash mesajı için session kaydedilemedi", zap.Error(err))
 		return ErrSessionSaveFailed
@@ -42,6 +44,7 @@ func SetFlashMessage(c *fiber.Ctx, key string, message string) error {
 
 func GetFlashMessages(c *fiber.Ctx) (FlashMessagesData, error) {
 	messages := FlashMessagesData{}
+	locale := Locale(c)
 	sess, err := SessionStart(c)
 	if err != nil {
 		Log.Error("Flash mesajları alınırken session başlatılamadı", zap.Error(err))
@@ -52,7 +55,7 @@ func GetFlashMessages(c *fiber.Ctx) (FlashMessagesData, error) {
 
 	if success := sess.Get(FlashSuccessKey); success != nil {
 		if msg, ok := success.(string); ok {
-			messages.Success = msg
+			messages.Success = i18n.T(locale, msg)
 			sess.Delete(FlashSuccessKey)
 			sessionNeedsSave = true
 		}
@@ -60,7 +63,7 @@ func GetFlashMessages(c *fiber.Ctx) (FlashMessagesData, error) {
 
 	if errorFlash := sess.Get(FlashErrorKey); errorFlash != nil {
 		if msg, ok := errorFlash.(string); ok {
-			messages.Error = msg
+			messages.Error = i18n.T(locale, msg)
 			sess.Delete(FlashErrorKey)
 			sessionNeedsSave = true
 		}
@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"zatrano/i18n"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	LocaleLocalsKey  = "locale"
+	LocaleCookieName = "lang"
+	SessionLocaleKey = "user_locale"
+)
+
+func Locale(c *fiber.Ctx) string {
+	if locale, ok := c.Locals(LocaleLocalsKey).(string); ok && locale != "" {
+		return locale
+	}
+	return i18n.DefaultLocale
+}
+
+func T(c *fiber.Ctx, id string, args ...interface{}) string {
+	return i18n.T(Locale(c), id, args...)
+}
+
+func TError(c *fiber.Ctx, err error) string {
+	return i18n.Error(Locale(c), err)
+}
@@ -0,0 +1,321 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	FilterQueryPrefix = "filter."
+	SortQueryKey      = "sort"
+	MaxSortFields     = 3
+)
+
+type FilterOp string
+
+const (
+	OpEq  FilterOp = "eq"
+	OpNe  FilterOp = "ne"
+	OpGt  FilterOp = "gt"
+	OpGte FilterOp = "gte"
+	OpLt  FilterOp = "lt"
+	OpLte FilterOp = "lte"
+	OpIn  FilterOp = "in"
+)
+
+type FieldType int
+
+const (
+	FieldString FieldType = iota
+	FieldInt
+	FieldBool
+	FieldEnum
+	FieldDate
+)
+
+type FilterField struct {
+	Type     FieldType
+	Ops      []FilterOp
+	Values   []string
+	Sortable bool
+}
+
+type FilterSpec map[string]FilterField
+
+type Filter struct {
+	Key   string
+	Field string
+	Op    FilterOp
+	Value interface{}
+	Raw   string
+}
+
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+func ParseListQuery(c *fiber.Ctx, params *ListParams, spec FilterSpec) error {
+	values, err := url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return err
+	}
+	return params.ApplyQuery(values, spec, Prefs(c).Location)
+}
+
+func (p *ListParams) ApplyQuery(values url.Values, spec FilterSpec, loc *time.Location) error {
+	if loc == nil {
+		loc = time.UTC
+	}
+	var errs []error
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		if strings.HasPrefix(key, FilterQueryPrefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	p.Filters = nil
+	for _, key := range keys {
+		raw := strings.TrimSpace(values.Get(key))
+		if raw == "" {
+			continue
+		}
+		filter, err := spec.parseFilter(key, raw, loc)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		p.Filters = append(p.Filters, filter)
+	}
+
+	sorts, err := spec.parseSort(values.Get(SortQueryKey))
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if len(sorts) == 0 && p.SortBy != "" {
+		if field, ok := spec[p.SortBy]; ok && field.Sortable {
+			sorts = []SortField{{Field: p.SortBy, Desc: strings.ToLower(p.OrderBy) != "asc"}}
+		}
+	}
+	p.Sort = sorts
+	if len(sorts) > 0 {
+		p.SortBy = sorts[0].Field
+		p.OrderBy = "asc"
+		if sorts[0].Desc {
+			p.OrderBy = "desc"
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s FilterSpec) parseFilter(key, raw string, loc *time.Location) (Filter, error) {
+	name, opName, ok := strings.Cut(strings.TrimPrefix(key, FilterQueryPrefix), ".")
+	field, known := s[name]
+	if !ok || !known {
+		return Filter{}, fmt.Errorf("bilinmeyen filtre alanı: %q", key)
+	}
+	op := FilterOp(opName)
+	if !field.allows(op) {
+		return Filter{}, fmt.Errorf("%q alanı için izin verilmeyen operatör: %q", name, opName)
+	}
+
+	filter := Filter{Key: key, Field: name, Op: op, Raw: raw}
+	if op == OpIn {
+		var list []interface{}
+		for _, part := range strings.Split(raw, ",") {
+			if part = strings.TrimSpace(part); part == "" {
+				continue
+			}
+			v, err := field.parseValue(part, loc)
+			if err != nil {
+				return Filter{}, fmt.Errorf("%q: %w", key, err)
+			}
+			list = append(list, v)
+		}
+		if len(list) == 0 {
+			return Filter{}, fmt.Errorf("%q: boş liste", key)
+		}
+		filter.Value = list
+		return filter, nil
+	}
+
+	v, err := field.parseValue(raw, loc)
+	if err != nil {
+		return Filter{}, fmt.Errorf("%q: %w", key, err)
+	}
+	filter.Value = v
+	// Tarih filtrelerinde "lte" seçilen günü de kapsamalıdır; bu yüzden
+	// ertesi günün başlangıcından küçük olarak, "gt" ise ertesi günden
+	// itibaren olarak uygulanır.
+	if field.Type == FieldDate {
+		switch op {
+		case OpLte:
+			filter.Op, filter.Value = OpLt, v.(time.Time).AddDate(0, 0, 1)
+		case OpGt:
+			filter.Op, filter.Value = OpGte, v.(time.Time).AddDate(0, 0, 1)
+		}
+	}
+	return filter, nil
+}
+
+func (f FilterField) allows(op FilterOp) bool {
+	for _, allowed := range f.Ops {
+		if allowed == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (f FilterField) parseValue(raw string, loc *time.Location) (interface{}, error) {
+	switch f.Type {
+	case FieldInt:
+		return strconv.ParseInt(raw, 10, 64)
+	case FieldBool:
+		return strconv.ParseBool(raw)
+	case FieldEnum:
+		for _, allowed := range f.Values {
+			if allowed == raw {
+				return raw, nil
+			}
+		}
+		return nil, fmt.Errorf("geçersiz değer: %q", raw)
+	case FieldDate:
+		return time.ParseInLocation("2006-01-02", raw, loc)
+	}
+	return nil, fmt.Errorf("alan filtrelenemez")
+}
+
+func (s FilterSpec) parseSort(raw string) ([]SortField, error) {
+	var sorts []SortField
+	seen := map[string]bool{}
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		desc := strings.HasPrefix(part, "-")
+		name := strings.TrimPrefix(part, "-")
+		if field, ok := s[name]; !ok || !field.Sortable {
+			return sorts, fmt.Errorf("sıralanamayan alan: %q", name)
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		sorts = append(sorts, SortField{Field: name, Desc: desc})
+		if len(sorts) == MaxSortFields {
+			break
+		}
+	}
+	return sorts, nil
+}
+
+func (p ListParams) SortFields() []SortField {
+	if len(p.Sort) > 0 {
+		return p.Sort
+	}
+	if p.SortBy == "" {
+		return nil
+	}
+	return []SortField{{Field: p.SortBy, Desc: strings.ToLower(p.OrderBy) != "asc"}}
+}
+
+func (p ListParams) SortValue() string {
+	return EncodeSort(p.SortFields())
+}
+
+func (p ListParams) FilterValue(key string) string {
+	for _, f := range p.Filters {
+		if f.Key == FilterQueryPrefix+key {
+			return f.Raw
+		}
+	}
+	return ""
+}
+
+func (p ListParams) HasFilters() bool {
+	return p.Name != "" || len(p.Filters) > 0
+}
+
+func (p ListParams) PageQuery(page int) template.URL {
+	return p.query(page, p.Sort)
+}
+
+func (p ListParams) SortQuery(field string) template.URL {
+	current := p.SortFields()
+	next := []SortField{{Field: field}}
+	if len(current) > 0 && current[0].Field == field {
+		next[0].Desc = !current[0].Desc
+	}
+	for _, s := range current {
+		if s.Field != field && len(next) < MaxSortFields {
+			next = append(next, s)
+		}
+	}
+	return p.query(1, next)
+}
+
+func (p ListParams) SortDirection(field string) string {
+	for _, s := range p.SortFields() {
+		if s.Field == field {
+			if s.Desc {
+				return "desc"
+			}
+			return "asc"
+		}
+	}
+	return ""
+}
+
+func (p ListParams) SortPosition(field string) int {
+	for i, s := range p.SortFields() {
+		if s.Field == field {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func (p ListParams) query(page int, sorts []SortField) template.URL {
+	values := url.Values{}
+	values.Set("page", strconv.Itoa(page))
+	if p.PerPage > 0 {
+		values.Set("perPage", strconv.Itoa(p.PerPage))
+	}
+	if p.Name != "" {
+		values.Set("name", p.Name)
+	}
+	if encoded := EncodeSort(sorts); encoded != "" {
+		values.Set(SortQueryKey, encoded)
+	}
+	for _, f := range p.Filters {
+		values.Set(f.Key, f.Raw)
+	}
+	// Değerler url.Values ile kodlandığından çıktı güvenlidir; template.URL,
+	// html/template'in "&" ve "=" karakterlerini tekrar kodlamasını önler.
+	return template.URL("?" + values.Encode())
+}
+
+func EncodeSort(sorts []SortField) string {
+	parts := make([]string, 0, len(sorts))
+	for _, s := range sorts {
+		if s.Desc {
+			parts = append(parts, "-"+s.Field)
+		} else {
+			parts = append(parts, s.Field)
+		}
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,101 @@
+package utils
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testSpec = FilterSpec{
+	"id":         {Type: FieldInt, Sortable: true},
+	"name":       {Type: FieldString, Sortable: true},
+	"type":       {Type: FieldEnum, Ops: []FilterOp{OpEq, OpIn}, Values: []string{"manager", "agent"}, Sortable: true},
+	"status":     {Type: FieldBool, Ops: []FilterOp{OpEq}},
+	"created_at": {Type: FieldDate, Ops: []FilterOp{OpGte, OpLte}, Sortable: true},
+}
+
+func TestApplyQueryParsesWhitelistedFilters(t *testing.T) {
+	istanbul, err := time.LoadLocation("Europe/Istanbul")
+	if err != nil {
+		t.Fatal(err)
+	}
+	values, _ := url.ParseQuery("filter.type.in=agent,manager&filter.status.eq=false&filter.created_at.lte=2024-03-01&filter.name.eq=&sort=-created_at,name")
+
+	var p ListParams
+	if err := p.ApplyQuery(values, testSpec, istanbul); err != nil {
+		t.Fatalf("ApplyQuery() error = %v", err)
+	}
+	if len(p.Filters) != 3 {
+		t.Fatalf("Filters = %+v, want 3 filters", p.Filters)
+	}
+
+	byField := map[string]Filter{}
+	for _, f := range p.Filters {
+		byField[f.Field] = f
+	}
+	if list, ok := byField["type"].Value.([]interface{}); !ok || len(list) != 2 {
+		t.Fatalf("type filter value = %#v, want two values", byField["type"].Value)
+	}
+	if byField["status"].Value != false {
+		t.Fatalf("status filter value = %#v, want false", byField["status"].Value)
+	}
+	// "lte" seçilen günü kapsar: ertesi gün başı, kullanıcının saat diliminde.
+	created := byField["created_at"]
+	want := time.Date(2024, 3, 2, 0, 0, 0, 0, istanbul)
+	if created.Op != OpLt || !created.Value.(time.Time).Equal(want) {
+		t.Fatalf("created_at filter = %s %v, want lt %v", created.Op, created.Value, want)
+	}
+
+	if p.SortValue() != "-created_at,name" || p.SortBy != "created_at" || p.OrderBy != "desc" {
+		t.Fatalf("sort = %q (SortBy %q, OrderBy %q)", p.SortValue(), p.SortBy, p.OrderBy)
+	}
+}
+
+func TestApplyQueryRejectsUnknownFieldsAndOperators(t *testing.T) {
+	for _, query := range []string{
+		"filter.password.eq=x",
+		"filter.status.gt=true",
+		"filter.type.eq=system",
+		"filter.created_at.gte=yesterday",
+		"filter.name.eq=Ali",
+		"sort=status",
+		"sort=password",
+	} {
+		values, _ := url.ParseQuery(query)
+		p := ListParams{SortBy: "id", OrderBy: "asc"}
+		if err := p.ApplyQuery(values, testSpec, time.UTC); err == nil {
+			t.Errorf("ApplyQuery(%q) error = nil, want error", query)
+		}
+		if len(p.Filters) != 0 {
+			t.Errorf("ApplyQuery(%q) kept filters %+v", query, p.Filters)
+		}
+		if p.SortValue() != "id" {
+			t.Errorf("ApplyQuery(%q) sort = %q, want fallback to SortBy", query, p.SortValue())
+		}
+	}
+}
+
+func TestSortQueryBuildsMultiColumnSort(t *testing.T) {
+	p := ListParams{Page: 3, PerPage: 20, Name: "ay", Sort: []SortField{{Field: "created_at", Desc: true}}}
+	p.Filters = []Filter{{Key: "filter.status.eq", Field: "status", Op: OpEq, Value: true, Raw: "true"}}
+
+	link := string(p.SortQuery("name"))
+	values, err := url.ParseQuery(strings.TrimPrefix(link, "?"))
+	if err != nil {
+		t.Fatalf("SortQuery() = %q: %v", link, err)
+	}
+	if values.Get("sort") != "name,-created_at" || values.Get("page") != "1" ||
+		values.Get("filter.status.eq") != "true" || values.Get("name") != "ay" {
+		t.Fatalf("SortQuery(name) = %q", link)
+	}
+
+	p.Sort = []SortField{{Field: "name"}, {Field: "created_at", Desc: true}}
+	values, _ = url.ParseQuery(strings.TrimPrefix(string(p.SortQuery("name")), "?"))
+	if values.Get("sort") != "-name,-created_at" {
+		t.Fatalf("SortQuery on primary field = %q, want direction toggled", values.Get("sort"))
+	}
+	if p.SortPosition("created_at") != 2 || p.SortDirection("created_at") != "desc" {
+		t.Fatalf("SortPosition/SortDirection(created_at) = %d/%q", p.SortPosition("created_at"), p.SortDirection("created_at"))
+	}
+}
@@ -1,8 +1,6 @@
 package utils
 
 import (
-	"os"
-
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -10,36 +8,34 @@ import (
 var Log *zap.Logger
 var SLog *zap.SugaredLogger
 
-func InitLogger() {
+func InitLogger(env, level string) {
 	if Log != nil {
 		return
 	}
 
-	env := os.Getenv("APP_ENV")
 	var config zap.Config
 	var err error
-	var level zapcore.Level
+	var zapLevel zapcore.Level
 
 	if env == "production" {
 		config = zap.NewProductionConfig()
 		config.EncoderConfig.TimeKey = "timestamp"
 		config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
-		level = zapcore.InfoLevel
+		zapLevel = zapcore.InfoLevel
 	} else {
 		env = "development"
 		config = zap.NewDevelopmentConfig()
 		config.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
-		level = zapcore.DebugLevel
+		zapLevel = zapcore.DebugLevel
 	}
 
-	logLevelEnv := os.Getenv("LOG_LEVEL")
-	if logLevelEnv != "" {
-		err = level.Set(logLevelEnv)
+	if level != "" {
+		err = zapLevel.Set(level)
 		if err != nil {
-			panic("Geçersiz LOG_LEVEL '" + logLevelEnv + "': " + err.Error())
+			panic("Geçersiz log seviyesi '" + level + "': " + err.Error())
 		}
 	}
-	config.Level = zap.NewAtomicLevelAt(level)
+	config.Level = zap.NewAtomicLevelAt(zapLevel)
 
 	Log, err = config.Build(zap.AddCaller())
 	if err != nil {
@@ -0,0 +1,165 @@
+package utils
+
+import (
+	"sync"
+	"time"
+
+	"zatrano/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/session"
+)
+
+const (
+	PreferencesLocalsKey = "prefs"
+
+	SessionTimezoneKey   = "user_timezone"
+	SessionDateFormatKey = "user_date_format"
+	SessionPerPageKey    = "user_per_page"
+
+	DefaultTimezone   = "UTC"
+	DefaultDateFormat = "02.01.2006"
+)
+
+var DateFormats = []string{"02.01.2006", "2006-01-02", "02/01/2006", "01/02/2006"}
+
+var PerPageOptions = []int{10, 20, 50, 100}
+
+var CommonTimezones = []string{
+	"UTC", "Europe/Istanbul", "Europe/London", "Europe/Berlin", "Europe/Moscow",
+	"Asia/Dubai", "Asia/Baku", "Asia/Tokyo", "America/New_York", "America/Los_Angeles",
+}
+
+type Preferences struct {
+	Location   *time.Location
+	DateFormat string
+	PerPage    int
+}
+
+func DefaultPreferences() Preferences {
+	return Preferences{Location: time.UTC, DateFormat: DefaultDateFormat, PerPage: DefaultPerPage}
+}
+
+func (p Preferences) FormatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.In(p.location()).Format(p.dateFormat())
+}
+
+func (p Preferences) FormatDateTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.In(p.location()).Format(p.dateFormat() + " 15:04")
+}
+
+func (p Preferences) FormatClock(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.In(p.location()).Format("15:04")
+}
+
+func (p Preferences) FormatCivilDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(p.dateFormat())
+}
+
+func (p Preferences) Now() time.Time {
+	return time.Now().In(p.location())
+}
+
+const DateTimeLocalLayout = "2006-01-02T15:04"
+
+const DateInputLayout = "2006-01-02"
+
+func (p Preferences) ParseDateTimeLocal(value string) (time.Time, error) {
+	t, err := time.ParseInLocation(DateTimeLocalLayout, value, p.location())
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.UTC(), nil
+}
+
+func (p Preferences) location() *time.Location {
+	if p.Location == nil {
+		return time.UTC
+	}
+	return p.Location
+}
+
+func (p Preferences) dateFormat() string {
+	if p.DateFormat == "" {
+		return DefaultDateFormat
+	}
+	return p.DateFormat
+}
+
+func IsDateFormat(layout string) bool {
+	for _, f := range DateFormats {
+		if f == layout {
+			return true
+		}
+	}
+	return false
+}
+
+func IsPerPageOption(perPage int) bool {
+	for _, n := range PerPageOptions {
+		if n == perPage {
+			return true
+		}
+	}
+	return false
+}
+
+func StorePreferencesInSession(sess *session.Session, pref *models.UserPreference) {
+	if pref.Locale != "" {
+		sess.Set(SessionLocaleKey, pref.Locale)
+	} else {
+		sess.Delete(SessionLocaleKey)
+	}
+	sess.Set(SessionTimezoneKey, pref.Timezone)
+	sess.Set(SessionDateFormatKey, pref.DateFormat)
+	sess.Set(SessionPerPageKey, pref.PerPage)
+}
+
+func PreferencesFromSession(sess *session.Session) Preferences {
+	prefs := DefaultPreferences()
+	if tz, ok := sess.Get(SessionTimezoneKey).(string); ok && tz != "" {
+		if loc, err := LoadLocation(tz); err == nil {
+			prefs.Location = loc
+		}
+	}
+	if layout, ok := sess.Get(SessionDateFormatKey).(string); ok && IsDateFormat(layout) {
+		prefs.DateFormat = layout
+	}
+	if perPage, ok := sess.Get(SessionPerPageKey).(int); ok && perPage > 0 && perPage <= MaxPerPage {
+		prefs.PerPage = perPage
+	}
+	return prefs
+}
+
+var locations sync.Map
+
+func LoadLocation(name string) (*time.Location, error) {
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(name, loc)
+	return loc, nil
+}
+
+func Prefs(c *fiber.Ctx) Preferences {
+	if prefs, ok := c.Locals(PreferencesLocalsKey).(Preferences); ok {
+		return prefs
+	}
+	return DefaultPreferences()
+}
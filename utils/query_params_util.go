@@ -10,6 +10,9 @@ type ListParams struct {
 
 	Page    int `query:"page"`
 	PerPage int `query:"perPage"`
+
+	Filters []Filter    `query:"-"`
+	Sort    []SortField `query:"-"`
 }
 
 type PaginationMeta struct {
@@ -1,7 +1,7 @@
 package utils
 
 import (
-	"davet.link/models"
+	"zatrano/models"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/session"
@@ -44,3 +44,10 @@ func GetUserStatusFromSession(sess *session.Session) (bool, error) {
 	return userStatus, nil
 
 }
+
+const CurrentUserLocalsKey = "currentUser"
+
+func CurrentUser(c *fiber.Ctx) (*models.User, bool) {
+	user, ok := c.Locals(CurrentUserLocalsKey).(*models.User)
+	return user, ok && user != nil
+}
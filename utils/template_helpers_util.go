@@ -1,14 +1,19 @@
 package utils
 
 import (
+	"fmt"
 	"net/url"
 	"text/template"
 	"time"
+
+	"zatrano/i18n"
 )
 
 func TemplateHelpers() template.FuncMap {
 	fm := template.FuncMap{
 		"CurrentYear": func() int { return time.Now().Year() },
+		"T":           i18n.T,
+		"Locales":     i18n.Supported,
 		"Add":         func(a, b int) int { return a + b },
 		"Subtract":    func(a, b int) int { return a - b },
 		"Mul":         func(a, b int) int { return a * b },
@@ -58,19 +63,41 @@ func TemplateHelpers() template.FuncMap {
 			return t.Format(layout)
 		},
 
-		"FormatDate": func(t time.Time) string {
-			if t.IsZero() {
-				return ""
-			}
-			return t.Format("02.01.2006")
+		// FormatDate ve FormatDateTime, isteğe bağlı ikinci argüman olarak
+		// kullanıcı tercihlerini alır: {{ FormatDate .CreatedAt $.prefs }}.
+		// Tercih verilmezse UTC ve varsayılan tarih düzeni kullanılır.
+		"FormatDate": func(t time.Time, prefs ...interface{}) string {
+			return templatePrefs(prefs).FormatDate(t)
 		},
 
-		"FormatDateTime": func(t time.Time) string {
-			if t.IsZero() {
-				return ""
-			}
-			return t.Format("02.01.2006 15:04")
+		"FormatDateTime": func(t time.Time, prefs ...interface{}) string {
+			return templatePrefs(prefs).FormatDateTime(t)
+		},
+
+		"FormatCivilDate": func(t time.Time, prefs ...interface{}) string {
+			return templatePrefs(prefs).FormatCivilDate(t)
 		},
+
+		"FormatClock": func(t time.Time, prefs ...interface{}) string {
+			return templatePrefs(prefs).FormatClock(t)
+		},
+
+		"FormatDuration": func(d time.Duration) string {
+			minutes := int(d.Round(time.Minute) / time.Minute)
+			return fmt.Sprintf("%d:%02d", minutes/60, minutes%60)
+		},
+
+		"DateFormats":    func() []string { return DateFormats },
+		"PerPageOptions": func() []int { return PerPageOptions },
 	}
 	return fm
 }
+
+func templatePrefs(args []interface{}) Preferences {
+	if len(args) > 0 {
+		if prefs, ok := args[0].(Preferences); ok {
+			return prefs
+		}
+	}
+	return DefaultPreferences()
+}
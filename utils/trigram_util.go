@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+func Trigrams(text string) []string {
+	words := strings.FieldsFunc(FoldSearch(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var trigrams []string
+	for _, word := range words {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			trigrams = append(trigrams, string(padded[i:i+3]))
+		}
+	}
+	return trigrams
+}
+
+func WordSimilarity(query, text string) float64 {
+	q := map[string]bool{}
+	for _, t := range Trigrams(query) {
+		q[t] = true
+	}
+	if len(q) == 0 {
+		return 0
+	}
+
+	t := Trigrams(text)
+	best := 0.0
+	for i := range t {
+		extent := map[string]bool{}
+		shared := 0
+		for j := i; j < len(t); j++ {
+			if !extent[t[j]] {
+				extent[t[j]] = true
+				if q[t[j]] {
+					shared++
+				}
+			}
+			if sim := float64(shared) / float64(len(q)+len(extent)-shared); sim > best {
+				best = sim
+			}
+		}
+	}
+	return best
+}
@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTrigrams(t *testing.T) {
+	got := strings.Join(Trigrams("Şule Ak!"), "|")
+	want := "  s| su|sul|ule|le |  a| ak|ak "
+	if got != want {
+		t.Fatalf("Trigrams() = %q, want %q", got, want)
+	}
+}
+
+func TestWordSimilarity(t *testing.T) {
+	tests := []struct {
+		query, text string
+		min, max    float64
+	}{
+		{"ahmet", "Ahmet Yılmaz", 1, 1},
+		{"yilmaz", "Ahmet Yılmaz", 1, 1},
+		{"ahmt", "Ahmet Yılmaz", SearchSimilarityThreshold, 0.99},
+		{"zeynep", "Ahmet Yılmaz", 0, 0.2},
+		{"", "Ahmet", 0, 0},
+	}
+	for _, tt := range tests {
+		if got := WordSimilarity(tt.query, tt.text); got < tt.min || got > tt.max {
+			t.Errorf("WordSimilarity(%q, %q) = %.2f, want between %.2f and %.2f", tt.query, tt.text, got, tt.min, tt.max)
+		}
+	}
+}
+
+func TestSQLSearch(t *testing.T) {
+	query, params := SQLSearch("50%_İŞ", "name_folded", "account_folded")
+	want := `(name_folded LIKE ? ESCAPE '\' OR account_folded LIKE ? ESCAPE '\')`
+	if query != want {
+		t.Fatalf("query = %q, want %q", query, want)
+	}
+	if len(params) != 2 || params[0] != `%50\%\_is%` {
+		t.Fatalf("params = %v", params)
+	}
+}
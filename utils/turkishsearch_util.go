@@ -3,41 +3,65 @@ package utils
 import (
 	"strings"
 	"unicode"
+
+	"golang.org/x/text/unicode/norm"
 )
 
-func normalize(str string) string {
-	replacements := map[rune]rune{
-		'ç': 'c', 'Ç': 'C',
-		'ğ': 'g', 'Ğ': 'G',
-		'ı': 'i', 'I': 'I',
-		'ö': 'o', 'Ö': 'O',
-		'ş': 's', 'Ş': 'S',
-		'ü': 'u', 'Ü': 'U',
+var turkicLocales = map[string]bool{"tr": true, "az": true}
+
+func CaseFold(locale, s string) string {
+	if turkicLocales[baseLanguage(locale)] {
+		return strings.ToLowerSpecial(unicode.TurkishCase, s)
 	}
+	return strings.ToLower(s)
+}
+
+func baseLanguage(locale string) string {
+	base, _, _ := strings.Cut(strings.ToLower(locale), "-")
+	base, _, _ = strings.Cut(base, "_")
+	return base
+}
 
-	var builder strings.Builder
-	for _, r := range str {
-		if repl, ok := replacements[r]; ok {
-			builder.WriteRune(unicode.ToLower(repl))
-		} else {
-			builder.WriteRune(unicode.ToLower(r))
+// SearchFoldSpecial, NFD ile aksanı ayrılmayan harflerin karşılıklarıdır.
+// search_fold() SQL fonksiyonu da bu tabloyla üretilir; unaccent sözlüğü bu
+// harfleri her kurulumda çevirmez.
+var SearchFoldSpecial = map[rune]string{
+	'ı': "i", 'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'ł': "l", 'đ': "d",
+}
+
+func FoldSearch(s string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(CaseFold("tr", s)) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		if repl, ok := SearchFoldSpecial[r]; ok {
+			b.WriteString(repl)
+			continue
 		}
+		b.WriteRune(unicode.ToLower(r))
 	}
-	return builder.String()
+	return b.String()
 }
 
 func MatchNormalized(text, keyword string) bool {
-	normText := normalize(text)
-	normKeyword := normalize(keyword)
-	return strings.Contains(normText, normKeyword)
+	return strings.Contains(FoldSearch(text), FoldSearch(keyword))
 }
 
-func SQLFilter(columnName, search string) (string, []interface{}) {
-	filterValue := "%" + strings.ToLower(search) + "%"
+const SearchSimilarityThreshold = 0.6
 
-	query := "unaccent(lower(" + columnName + ")) ILIKE unaccent($1)"
+func SQLSearch(search string, columns ...string) (string, []interface{}) {
+	pattern := "%" + EscapeLike(FoldSearch(search)) + "%"
 
-	params := []interface{}{filterValue}
+	parts := make([]string, 0, len(columns))
+	params := make([]interface{}, 0, len(columns))
+	for _, column := range columns {
+		parts = append(parts, column+` LIKE ? ESCAPE '\'`)
+		params = append(params, pattern)
+	}
+	return "(" + strings.Join(parts, " OR ") + ")", params
+}
 
-	return query, params
+func EscapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
 }
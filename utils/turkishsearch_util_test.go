@@ -0,0 +1,61 @@
+package utils
+
+import "testing"
+
+func TestCaseFold(t *testing.T) {
+	tests := []struct {
+		locale, in, want string
+	}{
+		{"tr", "ISPARTA", "ısparta"},
+		{"tr", "İSTANBUL", "istanbul"},
+		{"tr-TR", "IŞIK İnce", "ışık ince"},
+		{"az", "İLHAM", "ilham"},
+		{"en", "ISPARTA", "isparta"},
+		{"en", "İSTANBUL", "istanbul"},
+		{"de", "STRASSE", "strasse"},
+	}
+	for _, tt := range tests {
+		if got := CaseFold(tt.locale, tt.in); got != tt.want {
+			t.Errorf("CaseFold(%q, %q) = %q, want %q", tt.locale, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFoldSearch(t *testing.T) {
+	tests := map[string]string{
+		"ISPARTA":         "isparta",
+		"Isparta":         "isparta",
+		"ısparta":         "isparta",
+		"İSTANBUL":        "istanbul",
+		"i̇stanbul":       "istanbul", // i + birleşik üst nokta
+		"ÇAĞLA ÖZGÜR":     "cagla ozgur",
+		"Şükrü Işıklı":    "sukru isikli",
+		"Hâkim Îmân Ûlu":  "hakim iman ulu",
+		"Åse Straße Łódź": "ase strasse lodz",
+	}
+	for in, want := range tests {
+		if got := FoldSearch(in); got != want {
+			t.Errorf("FoldSearch(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestMatchNormalized(t *testing.T) {
+	tests := []struct {
+		text, keyword string
+		want          bool
+	}{
+		{"IŞIK İNCE", "ışık", true},
+		{"IŞIK İNCE", "isik ince", true},
+		{"ılgaz", "ILGAZ", true},
+		{"İzmir", "izmir", true},
+		{"izmir", "İZMİR", true},
+		{"Çağla", "cag", true},
+		{"Çağla", "caglar", false},
+	}
+	for _, tt := range tests {
+		if got := MatchNormalized(tt.text, tt.keyword); got != tt.want {
+			t.Errorf("MatchNormalized(%q, %q) = %v, want %v", tt.text, tt.keyword, got, tt.want)
+		}
+	}
+}